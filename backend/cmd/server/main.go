@@ -25,11 +25,19 @@ import (
 	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/translations"
 	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/uow"
 	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase/achievement"
 	"github.com/lordmitrii/golang-web-gin/internal/usecase/admin"
 	ai_usecase "github.com/lordmitrii/golang-web-gin/internal/usecase/ai"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase/challenge"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase/coaching"
 	email_usecase "github.com/lordmitrii/golang-web-gin/internal/usecase/email"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase/equipment"
 	"github.com/lordmitrii/golang-web-gin/internal/usecase/exercise"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase/goal"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase/nutrition"
 	"github.com/lordmitrii/golang-web-gin/internal/usecase/rbac"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase/security"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase/social"
 	translations_usecase "github.com/lordmitrii/golang-web-gin/internal/usecase/translations"
 	"github.com/lordmitrii/golang-web-gin/internal/usecase/user"
 	"github.com/lordmitrii/golang-web-gin/internal/usecase/versions"
@@ -59,11 +67,19 @@ func main() {
 	if err := postgres.AddWorkoutIndex(db, true); err != nil {
 		panic(err)
 	}
+	if err := postgres.AddSearchIndexes(db, true); err != nil {
+		panic(err)
+	}
+	if err := postgres.AddAuditLogGuards(db); err != nil {
+		panic(err)
+	}
 	if err := postgres.SeedRBAC(db); err != nil {
 		panic(err)
 	}
 
 	redisLimiter := myredis.NewRedisLimiter(cfg.RedisAddr, cfg.RedisPassword, 0)
+	permissionCache := myredis.NewPermissionCache(cfg.RedisAddr, cfg.RedisPassword, 0, cfg.PermissionCacheTTL)
+	leaderboard := myredis.NewLeaderboard(cfg.RedisAddr, cfg.RedisPassword, 0)
 
 	exerciseRepo := postgres.NewExerciseRepo(db)
 	muscleGroupRepo := postgres.NewMuscleGroupRepo(db)
@@ -88,6 +104,30 @@ func main() {
 	missingTranslationRepo := postgres.NewMissingTranslationsRepo(db)
 
 	versionRepo := postgres.NewVersionRepository(db)
+	auditLogRepo := postgres.NewAuditLogRepo(db)
+	personalTokenRepo := postgres.NewPersonalAccessTokenRepo(db)
+	coachClientRepo := postgres.NewCoachClientRepo(db)
+	workoutCommentRepo := postgres.NewWorkoutCommentRepo(db)
+	followRepo := postgres.NewFollowRepo(db)
+	activityRepo := postgres.NewActivityRepo(db)
+	workoutShareRepo := postgres.NewWorkoutShareRepo(db)
+	challengeRepo := postgres.NewChallengeRepo(db)
+	challengeParticipantRepo := postgres.NewChallengeParticipantRepo(db)
+	achievementRepo := postgres.NewAchievementRepo(db)
+	achievementStatsRepo := postgres.NewAchievementStatsRepo(db)
+	equipmentProfileRepo := postgres.NewEquipmentProfileRepo(db)
+	loadingSettingsRepo := postgres.NewLoadingSettingsRepo(db)
+	goalRepo := postgres.NewGoalRepo(db)
+	goalProgressRepo := postgres.NewGoalProgressRepo(db)
+	foodRepo := postgres.NewFoodRepo(db)
+	mealEntryRepo := postgres.NewMealEntryRepo(db)
+	nutritionGoalRepo := postgres.NewNutritionGoalRepo(db)
+	workoutBurnRepo := postgres.NewWorkoutBurnRepo(db)
+	conversationRepo := postgres.NewConversationRepo(db)
+	aiUsageRepo := postgres.NewAIUsageRepo(db)
+	aiQuotaRepo := postgres.NewAIQuotaRepo(db)
+	aiPromptRepo := postgres.NewAIPromptRepo(db)
+	aiBlockedRequestRepo := postgres.NewAIBlockedRequestRepo(db)
 	// emailSender := email.NewGmailSender(            //not working in digital ocean as port 587 is blocked
 	// 	os.Getenv("NOREPLY_EMAIL"),
 	// 	os.Getenv("NOREPLY_EMAIL_PASSWORD"),
@@ -114,29 +154,52 @@ func main() {
 		cfg.DeepLAPIURL,
 	)
 
-	openai := ai.NewOpenAI(
-		cfg.OpenAIKey,
-		1.0, // temperature
-	)
+	llm, err := ai.NewLLM(ai.Config{
+		Provider:        cfg.LLMProvider,
+		BaseURL:         cfg.LLMBaseURL,
+		APIKey:          cfg.LLMAPIKey,
+		Model:           cfg.LLMModel,
+		Temperature:     cfg.LLMTemperature,
+		ReasoningEffort: cfg.LLMReasoningEffort,
+		Timeout:         cfg.LLMTimeout,
+		FakeFixture:     cfg.LLMFakeFixture,
+	})
+	if err != nil {
+		log.Printf("llm not configured, AI endpoints will fail: %v", err)
+		llm = ai.NewUnavailable(err)
+	}
 
 	bus := eventbus.NewInproc()
 	txManager := uow.NewManager(db)
 	dispatcher := domainevt.NewDispatcher()
 
-	var exerciseService usecase.ExerciseService = exercise.NewExerciseService(exerciseRepo, muscleGroupRepo, translator, translationRepo, versionRepo)
-	var workoutService usecase.WorkoutService = workout_usecase.NewWorkoutService(profileRepo, workoutPlanRepo, workoutCycleRepo, workoutRepo, workoutExerciseRepo, workoutSetRepo, individualExerciseRepo, exerciseRepo, txManager, bus, dispatcher)
-	var userService usecase.UserService = user.NewUserService(userRepo, profileRepo, userConsentRepo, roleRepo, permissionRepo, userSettingsRepo)
-	var aiService usecase.AIService = ai_usecase.NewAIService(workoutService, exerciseService, userService, openai)
-	var emailService usecase.EmailService = email_usecase.NewEmailService(userRepo, roleRepo, emailSender, emailTokenRepo)
-	var rbacService usecase.RBACService = rbac.NewRBACService(roleRepo, permissionRepo, userRepo)
-	var adminService usecase.AdminService = admin.NewAdminService(userRepo, roleRepo, emailService)
-	var translationService usecase.TranslationService = translations_usecase.NewTranslationService(translationRepo, missingTranslationRepo, versionRepo)
+	var exerciseService usecase.ExerciseService = exercise.NewExerciseService(exerciseRepo, muscleGroupRepo, translator, translationRepo, versionRepo, txManager)
+	var loadingService usecase.LoadingService = equipment.NewLoadingService(loadingSettingsRepo, userSettingsRepo, txManager)
+	var workoutService usecase.WorkoutService = workout_usecase.NewWorkoutService(profileRepo, workoutPlanRepo, workoutCycleRepo, workoutRepo, workoutExerciseRepo, workoutSetRepo, individualExerciseRepo, exerciseRepo, loadingService, txManager, bus, dispatcher)
+	var goalService usecase.GoalService = goal.NewGoalService(goalRepo, goalProgressRepo, profileRepo, individualExerciseRepo, txManager, bus)
+	var userService usecase.UserService = user.NewUserService(userRepo, profileRepo, userConsentRepo, roleRepo, permissionRepo, userSettingsRepo, auditLogRepo, conversationRepo, txManager)
 	var versionsService usecase.VersionsService = versions.NewVersionsService(versionRepo)
+	var aiService usecase.AIService = ai_usecase.NewAIService(workoutService, exerciseService, userService, goalService, versionsService, llm, conversationRepo, aiUsageRepo, aiQuotaRepo, roleRepo, cfg.AIDailyTokenQuota, cfg.AIMonthlyTokenQuota, aiPromptRepo, aiBlockedRequestRepo, txManager)
+	var emailService usecase.EmailService = email_usecase.NewEmailService(userRepo, roleRepo, emailSender, emailTokenRepo, auditLogRepo, permissionCache, txManager)
+	var rbacService usecase.RBACService = rbac.NewRBACService(roleRepo, permissionRepo, userRepo, auditLogRepo, permissionCache, txManager)
+	var adminService usecase.AdminService = admin.NewAdminService(userRepo, roleRepo, auditLogRepo, emailService, permissionCache, txManager)
+	var translationService usecase.TranslationService = translations_usecase.NewTranslationService(translationRepo, missingTranslationRepo, versionRepo)
 
-	app.RegisterEvents(context.Background(), db, bus, dispatcher, workoutService)
+	guardPolicy := security.DefaultPolicy()
+	guardPolicy.NotifyOnLockout = cfg.LockoutNotifyEmail
+	var loginGuard usecase.LoginGuard = security.NewLoginGuard(redisLimiter, userRepo, auditLogRepo, emailSender, guardPolicy)
+	var personalTokenService usecase.PersonalTokenService = security.NewPersonalTokenService(personalTokenRepo, permissionRepo, auditLogRepo, txManager)
+	var coachingService usecase.CoachingService = coaching.NewCoachingService(coachClientRepo, workoutCommentRepo, userRepo, auditLogRepo, rbacService, workoutService, txManager)
+	var socialService usecase.SocialService = social.NewSocialService(followRepo, activityRepo, workoutShareRepo, userRepo, userSettingsRepo, workoutRepo, workoutService, txManager)
+	var challengeService usecase.ChallengeService = challenge.NewChallengeService(challengeRepo, challengeParticipantRepo, workoutRepo, auditLogRepo, leaderboard, txManager)
+	var achievementService usecase.AchievementService = achievement.NewAchievementService(achievementRepo, achievementStatsRepo, txManager)
+	var equipmentService usecase.EquipmentService = equipment.NewEquipmentService(equipmentProfileRepo, exerciseRepo, workoutService, txManager)
+	var nutritionService usecase.NutritionService = nutrition.NewNutritionService(foodRepo, mealEntryRepo, nutritionGoalRepo, workoutBurnRepo, profileRepo, translator, translationRepo, versionRepo, txManager)
+
+	app.RegisterEvents(context.Background(), db, bus, dispatcher, workoutService, socialService, challengeService, achievementService, goalService, aiService)
 	app.StartCleanup(cfg, db)
 
-	server := app.NewServer(cfg, exerciseService, workoutService, userService, aiService, emailService, redisLimiter, adminService, rbacService, translationService, versionsService)
+	server := app.NewServer(cfg, exerciseService, workoutService, userService, aiService, emailService, redisLimiter, adminService, rbacService, translationService, versionsService, loginGuard, personalTokenService, coachingService, socialService, challengeService, achievementService, equipmentService, loadingService, nutritionService, goalService)
 
 	server.Run(":" + cfg.Port)
 }
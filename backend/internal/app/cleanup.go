@@ -13,7 +13,7 @@ func StartCleanup(cfg Config, db *gorm.DB) {
 		return
 	}
 
-	cleanupJob := job.NewCleanupJob(db)
+	cleanupJob := job.NewCleanupJob(db, cfg.AuditRetention)
 	ctx, cancel := context.WithCancel(context.Background())
 	go cleanupJob.Run(ctx, cfg.CleanupInterval)
 	// cancellation is tied to process lifetime so we keep cancel in scope for later shutdown hooks
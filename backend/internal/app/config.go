@@ -21,8 +21,26 @@ type Config struct {
 	SendgridAPIKey  string
 	DeepLAuthKey    string
 	DeepLAPIURL     string
-	OpenAIKey       string
 	CleanupInterval time.Duration
+
+	// LLMProvider is openai, openai_compatible (Ollama, vLLM, ...) or fake.
+	LLMProvider        string
+	LLMBaseURL         string
+	LLMAPIKey          string
+	LLMModel           string
+	LLMTemperature     *float64
+	LLMReasoningEffort string
+	LLMTimeout         time.Duration
+	// LLMFakeFixture replays recorded replies when LLMProvider is fake.
+	LLMFakeFixture string
+	// AIDailyTokenQuota and AIMonthlyTokenQuota apply to roles without their own quota.
+	// Zero means unlimited.
+	AIDailyTokenQuota   int64
+	AIMonthlyTokenQuota int64
+
+	LockoutNotifyEmail bool
+	AuditRetention     time.Duration
+	PermissionCacheTTL time.Duration
 }
 
 func LoadConfig() Config {
@@ -51,6 +69,62 @@ func LoadConfig() Config {
 		}
 	}
 
+	auditRetention := 365 * 24 * time.Hour
+	if raw := os.Getenv("AUDIT_RETENTION_DAYS"); raw != "" {
+		if days, err := strconv.Atoi(raw); err == nil && days >= 0 {
+			auditRetention = time.Duration(days) * 24 * time.Hour
+		}
+	}
+
+	permissionCacheTTL := 5 * time.Minute
+	if raw := os.Getenv("PERMISSION_CACHE_TTL_SECONDS"); raw != "" {
+		if secs, err := strconv.Atoi(raw); err == nil && secs > 0 {
+			permissionCacheTTL = time.Duration(secs) * time.Second
+		}
+	}
+
+	llmProvider := os.Getenv("LLM_PROVIDER")
+	if llmProvider == "" {
+		llmProvider = "openai"
+	}
+
+	llmAPIKey := os.Getenv("LLM_API_KEY")
+	if llmAPIKey == "" {
+		llmAPIKey = os.Getenv("OPENAI_API_KEY")
+	}
+
+	var llmTemperature *float64
+	if raw := os.Getenv("LLM_TEMPERATURE"); raw != "" {
+		if t, err := strconv.ParseFloat(raw, 64); err == nil && t >= 0 {
+			llmTemperature = &t
+		}
+	}
+
+	llmReasoningEffort := "low"
+	if raw, ok := os.LookupEnv("LLM_REASONING_EFFORT"); ok {
+		llmReasoningEffort = raw
+	}
+
+	llmTimeout := 60 * time.Second
+	if raw := os.Getenv("LLM_TIMEOUT_SECONDS"); raw != "" {
+		if secs, err := strconv.Atoi(raw); err == nil && secs > 0 {
+			llmTimeout = time.Duration(secs) * time.Second
+		}
+	}
+
+	aiDailyTokenQuota := int64(50_000)
+	if raw := os.Getenv("AI_DAILY_TOKEN_QUOTA"); raw != "" {
+		if n, err := strconv.ParseInt(raw, 10, 64); err == nil && n >= 0 {
+			aiDailyTokenQuota = n
+		}
+	}
+	aiMonthlyTokenQuota := int64(1_000_000)
+	if raw := os.Getenv("AI_MONTHLY_TOKEN_QUOTA"); raw != "" {
+		if n, err := strconv.ParseInt(raw, 10, 64); err == nil && n >= 0 {
+			aiMonthlyTokenQuota = n
+		}
+	}
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
@@ -75,7 +149,22 @@ func LoadConfig() Config {
 		SendgridAPIKey:  os.Getenv("SENDGRID_API_KEY"),
 		DeepLAuthKey:    os.Getenv("DEEPL_AUTH_KEY"),
 		DeepLAPIURL:     os.Getenv("DEEPL_API_URL"),
-		OpenAIKey:       os.Getenv("OPENAI_API_KEY"),
 		CleanupInterval: cleanupInterval,
+
+		LLMProvider:        llmProvider,
+		LLMBaseURL:         os.Getenv("LLM_BASE_URL"),
+		LLMAPIKey:          llmAPIKey,
+		LLMModel:           os.Getenv("LLM_MODEL"),
+		LLMTemperature:     llmTemperature,
+		LLMReasoningEffort: llmReasoningEffort,
+		LLMTimeout:         llmTimeout,
+		LLMFakeFixture:     os.Getenv("LLM_FAKE_FIXTURE"),
+
+		AIDailyTokenQuota:   aiDailyTokenQuota,
+		AIMonthlyTokenQuota: aiMonthlyTokenQuota,
+
+		LockoutNotifyEmail: os.Getenv("LOCKOUT_NOTIFY_EMAIL") == "true",
+		AuditRetention:     auditRetention,
+		PermissionCacheTTL: permissionCacheTTL,
 	}
 }
@@ -11,15 +11,20 @@ import (
 )
 
 // RegisterEvents wires synchronous and asynchronous event handlers for the app.
-func RegisterEvents(ctx context.Context, db *gorm.DB, bus eventbus.Bus, dispatcher *domainevt.Dispatcher, workoutService usecase.WorkoutService) {
+func RegisterEvents(ctx context.Context, db *gorm.DB, bus eventbus.Bus, dispatcher *domainevt.Dispatcher, workoutService usecase.WorkoutService, socialService usecase.SocialService, challengeService usecase.ChallengeService, achievementService usecase.AchievementService, goalService usecase.GoalService, aiService usecase.AIService) {
 	events.RegisterSyncAll(events.SyncDeps{
 		Dispatcher:     dispatcher,
 		WorkoutService: workoutService,
 	})
 
 	events.RegisterAll(ctx, events.Deps{
-		DB:             db,
-		Bus:            bus,
-		WorkoutService: workoutService,
+		DB:                 db,
+		Bus:                bus,
+		WorkoutService:     workoutService,
+		SocialService:      socialService,
+		ChallengeService:   challengeService,
+		AchievementService: achievementService,
+		GoalService:        goalService,
+		AIService:          aiService,
 	})
 }
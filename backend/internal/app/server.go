@@ -11,6 +11,7 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 
 	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
 	graphqlapi "github.com/lordmitrii/golang-web-gin/internal/interface/graphql"
 	"github.com/lordmitrii/golang-web-gin/internal/interface/http/handler"
 	"github.com/lordmitrii/golang-web-gin/internal/interface/http/middleware"
@@ -30,6 +31,16 @@ func NewServer(
 	rbacService usecase.RBACService,
 	translationService usecase.TranslationService,
 	versionsService usecase.VersionsService,
+	loginGuard usecase.LoginGuard,
+	personalTokenService usecase.PersonalTokenService,
+	coachingService usecase.CoachingService,
+	socialService usecase.SocialService,
+	challengeService usecase.ChallengeService,
+	achievementService usecase.AchievementService,
+	equipmentService usecase.EquipmentService,
+	loadingService usecase.LoadingService,
+	nutritionService usecase.NutritionService,
+	goalService usecase.GoalService,
 ) *gin.Engine {
 	if cfg.DevelopmentMode {
 		gin.SetMode(gin.DebugMode)
@@ -56,15 +67,27 @@ func NewServer(
 	r.Use(cors.New(corsConfig))
 
 	api := r.Group("/api")
+	api.Use(middleware.RequestMeta())
+	api.Use(middleware.Units(userService))
 	// api.Use(middleware.DebugHeaders())  // middleware to add debug headers to responses
 
 	// HTTP handlers
 	handler.NewExerciseHandler(api, exerciseService, rbacService)
-	handler.NewWorkoutHandler(api, workoutService)
-	handler.NewUserHandler(api, userService)
-	handler.NewAIHandler(api, aiService, rateLimiter, rbacService)
-	handler.NewEmailHandler(api, emailService, rateLimiter, rbacService)
+	handler.NewWorkoutHandler(api, workoutService, coachingService, personalTokenService, rbacService, rateLimiter)
+	handler.NewCoachingHandler(api, coachingService)
+	handler.NewSocialHandler(api, socialService, rateLimiter)
+	handler.NewChallengeHandler(api, challengeService, rbacService)
+	handler.NewAchievementHandler(api, achievementService)
+	handler.NewEquipmentHandler(api, equipmentService)
+	handler.NewLoadingHandler(api, loadingService)
+	handler.NewNutritionHandler(api, nutritionService, rbacService)
+	handler.NewGoalHandler(api, goalService)
+	handler.NewUserHandler(api, userService, loginGuard, personalTokenService, rbacService, rateLimiter)
+	handler.NewPersonalTokenHandler(api, personalTokenService)
+	handler.NewAIHandler(api, aiService, rateLimiter, rbacService, personalTokenService)
+	handler.NewEmailHandler(api, emailService, rateLimiter, rbacService, loginGuard)
 	handler.NewAdminHandler(api, adminService, rbacService)
+	handler.NewRBACHandler(api, rbacService)
 	handler.NewTranslationHandler(api, translationService)
 	handler.NewVersionsHandler(api, versionsService)
 
@@ -78,9 +101,10 @@ func NewServer(
 	}
 
 	// GraphQL endpoint
-	if gqlHandler, err := graphqlapi.NewHandler(workoutService); err == nil {
+	if gqlHandler, err := graphqlapi.NewHandler(workoutService, challengeService); err == nil {
 		api.POST("/graphql",
-			middleware.JWTMiddleware(),
+			middleware.AuthMiddleware(personalTokenService, rateLimiter),
+			middleware.RequireGraphQLScope(rbacService, rbac.PermWorkoutReadSelf, rbac.PermWorkoutWriteSelf),
 			middleware.RateLimitMiddleware(rateLimiter, 180, "graphql"), // 180 messages per IP
 			gqlHandler.ServeGraphQL,
 		)
@@ -0,0 +1,110 @@
+package achievement
+
+import (
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+)
+
+// Kinds group rules that measure the same statistic; within a kind, rules are ordered by threshold.
+const (
+	KindWorkouts      = "workouts"
+	KindStreak        = "streak"
+	KindTonnage       = "tonnage"
+	KindPerfectCycles = "perfect_cycles"
+)
+
+// Rule awards the achievement Key once the user's statistic for Kind reaches Threshold.
+// Titles and descriptions live in translations under "achievement.<key>".
+type Rule struct {
+	Key       string
+	Kind      string
+	Threshold int64
+}
+
+// Rules is the achievement catalog. Keys are stored per user, so never rename them.
+var Rules = []Rule{
+	{Key: "first_workout", Kind: KindWorkouts, Threshold: 1},
+	{Key: "workouts_10", Kind: KindWorkouts, Threshold: 10},
+	{Key: "workouts_50", Kind: KindWorkouts, Threshold: 50},
+	{Key: "workouts_100", Kind: KindWorkouts, Threshold: 100},
+
+	{Key: "streak_4_weeks", Kind: KindStreak, Threshold: 4},
+	{Key: "streak_12_weeks", Kind: KindStreak, Threshold: 12},
+	{Key: "streak_26_weeks", Kind: KindStreak, Threshold: 26},
+	{Key: "streak_52_weeks", Kind: KindStreak, Threshold: 52},
+
+	{Key: "tonnage_1t", Kind: KindTonnage, Threshold: 1_000},
+	{Key: "tonnage_10t", Kind: KindTonnage, Threshold: 10_000},
+	{Key: "tonnage_100t", Kind: KindTonnage, Threshold: 100_000},
+	{Key: "tonnage_1000t", Kind: KindTonnage, Threshold: 1_000_000},
+
+	{Key: "perfect_cycle", Kind: KindPerfectCycles, Threshold: 1},
+	{Key: "perfect_cycles_5", Kind: KindPerfectCycles, Threshold: 5},
+	{Key: "perfect_cycles_10", Kind: KindPerfectCycles, Threshold: 10},
+}
+
+// Stats are the per-user statistics rules are evaluated against. Tonnage is in kilograms.
+type Stats struct {
+	Workouts      int64
+	TonnageKg     int64
+	Streak        Streak
+	PerfectCycles int64
+}
+
+// Value returns the statistic a rule of the given kind is measured on.
+// Streak rules use the longest streak so a later break never revokes progress.
+func (s Stats) Value(kind string) int64 {
+	switch kind {
+	case KindWorkouts:
+		return s.Workouts
+	case KindStreak:
+		return int64(s.Streak.Longest)
+	case KindTonnage:
+		return s.TonnageKg
+	case KindPerfectCycles:
+		return s.PerfectCycles
+	default:
+		return 0
+	}
+}
+
+type UserAchievement struct {
+	UserID uint      `gorm:"primaryKey"`
+	User   user.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Key    string    `gorm:"primaryKey"`
+
+	// WorkoutID is the workout whose completion unlocked the achievement, if any.
+	WorkoutID *uint
+	AwardedAt time.Time `gorm:"not null"`
+}
+
+// Status is a rule as seen by one user: whether it is earned and how close they are.
+type Status struct {
+	Rule      Rule
+	Current   int64
+	Earned    bool
+	AwardedAt *time.Time
+}
+
+// Progress is the fraction of the threshold reached, capped at 1.
+func (s Status) Progress() float64 {
+	if s.Earned || s.Rule.Threshold <= 0 {
+		return 1
+	}
+	return min(float64(s.Current)/float64(s.Rule.Threshold), 1)
+}
+
+// Next returns the first unearned status of each kind, in catalog order.
+func Next(statuses []Status) []Status {
+	seen := make(map[string]bool)
+	var out []Status
+	for _, s := range statuses {
+		if s.Earned || seen[s.Rule.Kind] {
+			continue
+		}
+		seen[s.Rule.Kind] = true
+		out = append(out, s)
+	}
+	return out
+}
@@ -0,0 +1,23 @@
+package achievement
+
+import (
+	"context"
+	"time"
+)
+
+type AchievementRepository interface {
+	// Award stores the achievement and reports false if the user already had it.
+	Award(ctx context.Context, a *UserAchievement) (bool, error)
+	GetByUserID(ctx context.Context, userID uint) ([]*UserAchievement, error)
+}
+
+// StatsRepository computes the raw statistics achievements are evaluated against.
+type StatsRepository interface {
+	CompletedWorkouts(ctx context.Context, userID uint) (int64, error)
+	TonnageGrams(ctx context.Context, userID uint) (int64, error)
+	PerfectCycles(ctx context.Context, userID uint) (int64, error)
+	CompletionTimes(ctx context.Context, userID uint) ([]time.Time, error)
+	// WeeklyTarget is the number of workouts in the current cycle of the user's active plan,
+	// or 0 when there is no active plan.
+	WeeklyTarget(ctx context.Context, userID uint) (int, error)
+}
@@ -0,0 +1,61 @@
+package achievement
+
+import (
+	"time"
+)
+
+// Streak counts consecutive ISO weeks (Monday to Sunday, UTC) in which the user completed
+// at least WeeklyTarget workouts. The running week never breaks a streak; it only extends it
+// once its target is met.
+type Streak struct {
+	Current      int
+	Longest      int
+	WeeklyTarget int
+	ThisWeek     int
+}
+
+// CalculateStreak derives a Streak from workout completion times. weeklyTarget is the number
+// of workouts the user's plan schedules per week; values below 1 are treated as 1.
+func CalculateStreak(completions []time.Time, weeklyTarget int, now time.Time) Streak {
+	weeklyTarget = max(weeklyTarget, 1)
+
+	perWeek := make(map[time.Time]int, len(completions))
+	var earliest time.Time
+	for _, t := range completions {
+		w := WeekStart(t)
+		perWeek[w]++
+		if earliest.IsZero() || w.Before(earliest) {
+			earliest = w
+		}
+	}
+
+	current := WeekStart(now)
+	s := Streak{WeeklyTarget: weeklyTarget, ThisWeek: perWeek[current]}
+	if len(perWeek) == 0 {
+		return s
+	}
+
+	run := 0
+	for w := earliest; !w.After(current); w = w.AddDate(0, 0, 7) {
+		met := perWeek[w] >= weeklyTarget
+		switch {
+		case met:
+			run++
+		case w.Equal(current):
+			// Week still in progress: keep the run from previous weeks alive.
+		default:
+			run = 0
+		}
+		s.Longest = max(s.Longest, run)
+	}
+	s.Current = run
+	return s
+}
+
+// WeekStart returns the Monday, 00:00 UTC, that starts t's ISO week.
+func WeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7 // Monday = 0
+	return day.AddDate(0, 0, -offset)
+}
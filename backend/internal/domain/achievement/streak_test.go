@@ -0,0 +1,72 @@
+package achievement
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalculateStreak(t *testing.T) {
+	// Wednesday of the "current" week.
+	now := time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC)
+	week := func(back int, day int) time.Time {
+		return WeekStart(now).AddDate(0, 0, -7*back+day).Add(18 * time.Hour)
+	}
+
+	tests := []struct {
+		name        string
+		completions []time.Time
+		target      int
+		wantCurrent int
+		wantLongest int
+	}{
+		{
+			name:   "no workouts",
+			target: 3,
+		},
+		{
+			name:        "current week in progress does not break streak",
+			completions: []time.Time{week(2, 0), week(1, 0)},
+			target:      1,
+			wantCurrent: 2,
+			wantLongest: 2,
+		},
+		{
+			name:        "current week extends streak once target met",
+			completions: []time.Time{week(1, 0), week(1, 2), week(0, 0), week(0, 1)},
+			target:      2,
+			wantCurrent: 2,
+			wantLongest: 2,
+		},
+		{
+			name:        "week under target breaks streak",
+			completions: []time.Time{week(4, 0), week(4, 1), week(3, 0), week(3, 1), week(2, 0), week(1, 0), week(1, 3)},
+			target:      2,
+			wantCurrent: 1,
+			wantLongest: 2,
+		},
+		{
+			name:        "missed week resets current",
+			completions: []time.Time{week(5, 0), week(4, 0), week(3, 0), week(1, 0)},
+			target:      1,
+			wantCurrent: 1,
+			wantLongest: 3,
+		},
+		{
+			name:        "target below one is treated as one",
+			completions: []time.Time{week(0, 0)},
+			target:      0,
+			wantCurrent: 1,
+			wantLongest: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateStreak(tt.completions, tt.target, now)
+			if got.Current != tt.wantCurrent || got.Longest != tt.wantLongest {
+				t.Fatalf("CalculateStreak() = current %d longest %d, want current %d longest %d",
+					got.Current, got.Longest, tt.wantCurrent, tt.wantLongest)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package ai
+
+import (
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+)
+
+const (
+	ConversationGeneral  = "general"
+	ConversationStats    = "stats"
+	ConversationWorkouts = "workouts"
+	ConversationCoach    = "coach"
+)
+
+// MaxTitleLength bounds conversation titles, whether derived from the first question
+// or set by the user.
+const MaxTitleLength = 120
+
+type Conversation struct {
+	ID     uint      `gorm:"primaryKey"`
+	UserID uint      `gorm:"not null;index:idx_ai_conversations_user_updated,priority:1"`
+	User   user.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Kind   string    `gorm:"not null"`
+	Title  string    `gorm:"not null;default:''"`
+
+	Messages []*ConversationMessage `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time `gorm:"index:idx_ai_conversations_user_updated,priority:2"`
+}
+
+// ConversationMessage is one stored turn. Content is what the user typed or the model
+// answered; Prompt is what was actually sent for a user turn, context included, and is
+// what follow-ups are rebuilt from.
+type ConversationMessage struct {
+	ID             uint   `gorm:"primaryKey"`
+	ConversationID uint   `gorm:"not null;index"`
+	Role           Role   `gorm:"not null"`
+	Content        string `gorm:"type:text;not null"`
+	Prompt         string `gorm:"type:text;not null;default:''"`
+
+	Model         string `gorm:"not null;default:''"`
+	InputTokens   int64  `gorm:"not null;default:0"`
+	OutputTokens  int64  `gorm:"not null;default:0"`
+	PromptVersion string `gorm:"not null;default:''"`
+
+	CreatedAt time.Time
+}
+
+// ToMessage turns a stored turn back into what the model saw.
+func (m *ConversationMessage) ToMessage() Message {
+	content := m.Content
+	if m.Role == RoleUser && m.Prompt != "" {
+		content = m.Prompt
+	}
+	return Message{Role: m.Role, Content: content}
+}
+
+// TitleFrom derives a conversation title from the first question.
+func TitleFrom(question string) string {
+	r := []rune(question)
+	if len(r) <= MaxTitleLength {
+		return question
+	}
+	return string(r[:MaxTitleLength-1]) + "…"
+}
@@ -0,0 +1,107 @@
+package ai
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+)
+
+// Input categories the guardrails sort free text into.
+const (
+	InputFitness  = "fitness"
+	InputInjury   = "injury"
+	InputOffTopic = "off_topic"
+	InputUnsafe   = "unsafe"
+)
+
+// InputCategories lists every category, in the order the classifier is given them.
+var InputCategories = []string{InputFitness, InputInjury, InputOffTopic, InputUnsafe}
+
+// Blocked reports whether input of the category is refused rather than answered.
+func Blocked(category string) bool {
+	return category == InputOffTopic || category == InputUnsafe
+}
+
+// BlockedRequest records an input the guardrails refused. Input is stored scrubbed.
+type BlockedRequest struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index"`
+	User      user.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Endpoint  string    `gorm:"not null"`
+	Category  string    `gorm:"not null;index"`
+	Input     string    `gorm:"type:text;not null"`
+	CreatedAt time.Time `gorm:"index"`
+}
+
+// BlockedError carries the refusal, in the user's language, for a blocked input.
+type BlockedError struct {
+	Category string
+	Refusal  string
+}
+
+func (e *BlockedError) Error() string { return e.Refusal }
+
+func (e *BlockedError) Unwrap() error { return custom_err.ErrRequestBlocked }
+
+const (
+	redactedEmail = "[email]"
+	redactedPhone = "[phone]"
+	redactedName  = "[name]"
+)
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	// Phone numbers in international form, grouped like 555-123-4567, or as one long
+	// run of digits. Weights and reps are separated by single spaces far more often
+	// than they are grouped 3-3-4, so workout numbers are left alone.
+	phonePatterns = []*regexp.Regexp{
+		regexp.MustCompile(`\+\d[\d\s().\-]{6,}\d`),
+		regexp.MustCompile(`\(?\b\d{3}\)?[\s.\-]\d{3}[\s.\-]\d{4}\b`),
+		regexp.MustCompile(`\b\d{10,}\b`),
+	}
+	selfIntroPattern = regexp.MustCompile(`(?i)\b(my name is|i am called|call me)\s+\p{Lu}[\p{L}'\-]*(\s+\p{Lu}[\p{L}'\-]*)?`)
+)
+
+// ScrubPII replaces email addresses, phone numbers, self-introductions and the given
+// identifiers, such as the user's username, with placeholders. Identifiers shorter
+// than three characters are ignored, as they would match ordinary words.
+func ScrubPII(text string, identifiers ...string) string {
+	text = emailPattern.ReplaceAllString(text, redactedEmail)
+	for _, p := range phonePatterns {
+		text = p.ReplaceAllString(text, redactedPhone)
+	}
+	text = selfIntroPattern.ReplaceAllString(text, "${1} "+redactedName)
+	for _, id := range identifiers {
+		if id = strings.TrimSpace(id); utf8.RuneCountInString(id) >= 3 {
+			text = redactWord(text, id)
+		}
+	}
+	return text
+}
+
+// redactWord replaces whole-word, case-insensitive occurrences of word.
+func redactWord(text, word string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range regexp.MustCompile(`(?i)`+regexp.QuoteMeta(word)).FindAllStringIndex(text, -1) {
+		before, _ := utf8.DecodeLastRuneInString(text[:m[0]])
+		after, _ := utf8.DecodeRuneInString(text[m[1]:])
+		if isWordRune(before) || isWordRune(after) {
+			continue
+		}
+		b.WriteString(text[last:m[0]])
+		b.WriteString(redactedName)
+		last = m[1]
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
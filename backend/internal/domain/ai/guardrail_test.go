@@ -0,0 +1,24 @@
+package ai
+
+import "testing"
+
+func TestScrubPII(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+		ids            []string
+	}{
+		{"email", "mail me at john.doe+gym@example.com please", "mail me at [email] please", nil},
+		{"international phone", "call +44 20 7946 0958 after", "call [phone] after", nil},
+		{"grouped phone", "text 555-123-4567", "text [phone]", nil},
+		{"workout numbers kept", "I did 100 120 140 kg for 5 5 3 reps", "I did 100 120 140 kg for 5 5 3 reps", nil},
+		{"self introduction", "Hi, my name is Anna Smith and I squat", "Hi, my name is [name] and I squat", nil},
+		{"username", "Is JohnD lifting enough? johnd asks", "Is [name] lifting enough? [name] asks", []string{"johnd"}},
+		{"username inside a word kept", "johndoe is not johnd", "johndoe is not [name]", []string{"johnd"}},
+		{"short identifier ignored", "max reps", "max reps", []string{"ma"}},
+	}
+	for _, tt := range tests {
+		if got := ScrubPII(tt.in, tt.ids...); got != tt.want {
+			t.Errorf("%s: ScrubPII() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type Role string
+
+const (
+	RoleSystem    Role = "system"
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+	RoleTool      Role = "tool"
+)
+
+// Message is one turn of a conversation. Assistant messages may carry tool calls; tool
+// messages answer one of them through ToolCallID.
+type Message struct {
+	Role       Role       `json:"role"`
+	Content    string     `json:"content,omitempty"`
+	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string     `json:"tool_call_id,omitempty"`
+}
+
+type ToolCall struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+// Tool describes a function the model may call. Parameters is a JSON Schema object.
+type Tool struct {
+	Name        string
+	Description string
+	Parameters  map[string]any
+}
+
+// Tool choice values. Any other non-empty value forces the tool with that name.
+const (
+	ToolChoiceAuto     = "auto"
+	ToolChoiceNone     = "none"
+	ToolChoiceRequired = "required"
+)
+
+// Schema asks for a final answer that is JSON valid to Schema.
+type Schema struct {
+	Name   string
+	Schema map[string]any
+	Strict bool
+}
+
+type Request struct {
+	Instructions   string
+	Messages       []Message
+	Tools          []Tool
+	ToolChoice     string
+	ResponseFormat *Schema
+	// MaxTokens caps the completion; 0 leaves it to the provider.
+	MaxTokens int64
+	// PromptVersion names the prompt template versions the request was built from.
+	// Adapters ignore it; it is kept with the usage record.
+	PromptVersion string
+}
+
+type Usage struct {
+	InputTokens  int64
+	OutputTokens int64
+}
+
+type Response struct {
+	Message Message
+	Usage   Usage
+	Model   string
+}
+
+// LLM is a chat model behind some provider. Implementations keep no state between
+// calls: the whole conversation is sent with every request.
+type LLM interface {
+	Chat(ctx context.Context, req Request) (*Response, error)
+	// Stream is Chat with the answer text handed to onDelta as it is generated. The
+	// returned response holds the complete message; an error from onDelta aborts the call.
+	// A call that fails part way may return a response holding only the usage reported
+	// so far, along with the error.
+	Stream(ctx context.Context, req Request, onDelta func(delta string) error) (*Response, error)
+}
+
+func SystemMessage(content string) Message {
+	return Message{Role: RoleSystem, Content: content}
+}
+
+func UserMessage(content string) Message {
+	return Message{Role: RoleUser, Content: content}
+}
+
+func ToolMessage(toolCallID, content string) Message {
+	return Message{Role: RoleTool, ToolCallID: toolCallID, Content: content}
+}
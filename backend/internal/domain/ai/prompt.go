@@ -0,0 +1,86 @@
+package ai
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Prompt template names. Every name has a built-in default, version 0, which is used
+// until an admin activates a stored version.
+const (
+	PromptChatInstructions           = "chat.instructions"
+	PromptCoachInstructions          = "coach.instructions"
+	PromptAskGeneral                 = "ask.general"
+	PromptAskStats                   = "ask.stats"
+	PromptAskWorkouts                = "ask.workouts"
+	PromptAskCoach                   = "ask.coach"
+	PromptFollowUp                   = "ask.follow_up"
+	PromptPlanRequest                = "plan.request"
+	PromptPlanInstructions           = "plan.instructions"
+	PromptPlanToolsInstructions      = "plan.tools_instructions"
+	PromptPlanRepairInstructions     = "plan.repair_instructions"
+	PromptWorkoutSummaryInstructions = "workout_summary.instructions"
+	PromptGuardrailClassify          = "guardrail.classify"
+	PromptGuardrailMedicalPolicy     = "guardrail.medical_policy"
+)
+
+// DefaultLocale is used for languages without a template of their own.
+const DefaultLocale = "en"
+
+// PromptTemplate is one version of a named prompt in one locale, in Go text/template
+// syntax. At most one version per name and locale is active.
+type PromptTemplate struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"not null;uniqueIndex:idx_ai_prompt_templates_version,priority:1"`
+	Locale    string `gorm:"not null;uniqueIndex:idx_ai_prompt_templates_version,priority:2"`
+	Version   int    `gorm:"not null;uniqueIndex:idx_ai_prompt_templates_version,priority:3"`
+	Body      string `gorm:"type:text;not null"`
+	Active    bool   `gorm:"not null;default:false;index"`
+	CreatedAt time.Time
+}
+
+// PromptSet is a prompt with its built-in default and its stored versions.
+type PromptSet struct {
+	Name     string
+	Default  string
+	Versions []*PromptTemplate
+}
+
+// PromptVersion labels a template version, e.g. "ask.stats/en@2".
+func PromptVersion(name, locale string, version int) string {
+	return name + "/" + locale + "@" + strconv.Itoa(version)
+}
+
+// JoinPromptVersions labels a request built from several templates.
+func JoinPromptVersions(versions ...string) string {
+	out := make([]string, 0, len(versions))
+	for _, v := range versions {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return strings.Join(out, "+")
+}
+
+// NormalizeLocale lower-cases a language tag and uses "-" as the separator.
+func NormalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+}
+
+// LocaleFallbacks lists the locales to try for a language tag, most specific first:
+// "pt-br" tries "pt-br", "pt" and then DefaultLocale.
+func LocaleFallbacks(locale string) []string {
+	locale = NormalizeLocale(locale)
+	var out []string
+	if locale != "" {
+		out = append(out, locale)
+		if base, _, ok := strings.Cut(locale, "-"); ok && base != "" {
+			out = append(out, base)
+		}
+	}
+	if len(out) == 0 || out[len(out)-1] != DefaultLocale {
+		out = append(out, DefaultLocale)
+	}
+	return out
+}
@@ -0,0 +1,31 @@
+package ai
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLocaleFallbacks(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   []string
+	}{
+		{"", []string{"en"}},
+		{"en", []string{"en"}},
+		{"de", []string{"de", "en"}},
+		{"pt_BR", []string{"pt-br", "pt", "en"}},
+		{"en-GB", []string{"en-gb", "en"}},
+	}
+	for _, tt := range tests {
+		if got := LocaleFallbacks(tt.locale); !slices.Equal(got, tt.want) {
+			t.Errorf("LocaleFallbacks(%q) = %v, want %v", tt.locale, got, tt.want)
+		}
+	}
+}
+
+func TestJoinPromptVersions(t *testing.T) {
+	got := JoinPromptVersions(PromptVersion(PromptChatInstructions, "de", 2), "", PromptVersion(PromptAskGeneral, "en", 0))
+	if want := "chat.instructions/de@2+ask.general/en@0"; got != want {
+		t.Errorf("JoinPromptVersions() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,61 @@
+package ai
+
+import (
+	"context"
+	"time"
+)
+
+type ConversationRepository interface {
+	Create(ctx context.Context, c *Conversation) error
+	// GetByID returns the conversation without its messages.
+	GetByID(ctx context.Context, userID, id uint) (*Conversation, error)
+	// GetByUserID lists the user's conversations, most recently active first.
+	GetByUserID(ctx context.Context, userID uint, page, pageSize int64) ([]*Conversation, int64, error)
+	// GetAllWithMessages returns every conversation of the user with its messages.
+	GetAllWithMessages(ctx context.Context, userID uint) ([]*Conversation, error)
+	// GetMessages returns a conversation's messages in order.
+	GetMessages(ctx context.Context, conversationID uint) ([]*ConversationMessage, error)
+	// AddMessages appends messages and marks the conversation as active now.
+	AddMessages(ctx context.Context, conversationID uint, msgs ...*ConversationMessage) error
+	Update(ctx context.Context, userID, id uint, updates map[string]any) error
+	Delete(ctx context.Context, userID, id uint) error
+	DeleteByUserID(ctx context.Context, userID uint) error
+}
+
+type UsageRepository interface {
+	Create(ctx context.Context, u *TokenUsage) error
+	// SumTokens returns the user's input plus output tokens since the given time.
+	SumTokens(ctx context.Context, userID uint, since time.Time) (int64, error)
+	// SummarizeByUser sums the user's usage in [from, to) per endpoint and model.
+	SummarizeByUser(ctx context.Context, userID uint, from, to time.Time) ([]*UsageSummary, error)
+	// Summarize sums everyone's usage in [from, to) per endpoint, model and prompt version.
+	Summarize(ctx context.Context, from, to time.Time) ([]*UsageSummary, error)
+	// TopUsers returns the heaviest users in [from, to), by total tokens.
+	TopUsers(ctx context.Context, from, to time.Time, limit int) ([]*UserUsage, error)
+}
+
+type QuotaRepository interface {
+	GetAll(ctx context.Context) ([]*TokenQuota, error)
+	GetByRoleIDs(ctx context.Context, roleIDs []uint) ([]*TokenQuota, error)
+	// Upsert creates or replaces the quota of q.RoleID.
+	Upsert(ctx context.Context, q *TokenQuota) error
+	Delete(ctx context.Context, roleID uint) error
+}
+
+type BlockedRequestRepository interface {
+	Create(ctx context.Context, b *BlockedRequest) error
+	// GetAll lists blocked requests, newest first, of one category or of all when it is empty.
+	GetAll(ctx context.Context, category string, page, pageSize int64) ([]*BlockedRequest, int64, error)
+}
+
+type PromptTemplateRepository interface {
+	// GetActive returns the active version of every name and locale that has one.
+	GetActive(ctx context.Context) ([]*PromptTemplate, error)
+	// GetAll returns every stored version, by name, locale and newest version first.
+	GetAll(ctx context.Context) ([]*PromptTemplate, error)
+	// Create stores t as the next version of its name and locale.
+	Create(ctx context.Context, t *PromptTemplate) error
+	// SetActive makes version the active one of name and locale. Version 0 deactivates
+	// them all, so the built-in default applies again.
+	SetActive(ctx context.Context, name, locale string, version int) error
+}
@@ -0,0 +1,120 @@
+package ai
+
+import (
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+)
+
+// Endpoints usage is recorded under.
+const (
+	EndpointAskGeneral   = "ask_general"
+	EndpointAskStats     = "ask_stats"
+	EndpointAskWorkouts  = "ask_workouts"
+	EndpointAskCoach     = "ask_coach"
+	EndpointGeneratePlan = "generate_plan"
+	// EndpointWorkoutSummary is billed by the post-workout summary, not by a request.
+	EndpointWorkoutSummary = "workout_summary"
+	// EndpointModeration is billed by classifying a request's input before it is answered.
+	EndpointModeration = "moderation"
+)
+
+// EndpointForConversation maps a conversation kind to the endpoint its turns are billed to.
+func EndpointForConversation(kind string) string {
+	return "ask_" + kind
+}
+
+// TokenUsage records one model call.
+type TokenUsage struct {
+	ID           uint      `gorm:"primaryKey"`
+	UserID       uint      `gorm:"not null;index:idx_ai_token_usage_user_created,priority:1"`
+	User         user.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Endpoint     string    `gorm:"not null;index"`
+	Model        string    `gorm:"not null;default:''"`
+	InputTokens  int64     `gorm:"not null;default:0"`
+	OutputTokens int64     `gorm:"not null;default:0"`
+	CreatedAt    time.Time `gorm:"index:idx_ai_token_usage_user_created,priority:2"`
+	// PromptVersion names the template versions the call was built from.
+	PromptVersion string `gorm:"not null;default:''"`
+}
+
+// UsageSummary is usage summed over a period for one endpoint and model, and in the
+// admin report also per prompt version.
+type UsageSummary struct {
+	Endpoint      string
+	Model         string
+	PromptVersion string
+	Calls         int64
+	InputTokens   int64
+	OutputTokens  int64
+}
+
+// UserUsage is a user's usage summed over a period.
+type UserUsage struct {
+	UserID       uint
+	Username     string
+	Calls        int64
+	InputTokens  int64
+	OutputTokens int64
+}
+
+// TokenQuota caps the tokens, input and output together, that holders of a role may use
+// per UTC day and per UTC month. Zero means unlimited. Roles without a row use the
+// configured defaults.
+type TokenQuota struct {
+	RoleID        uint      `gorm:"primaryKey"`
+	Role          rbac.Role `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	DailyTokens   int64     `gorm:"not null;default:0"`
+	MonthlyTokens int64     `gorm:"not null;default:0"`
+	UpdatedAt     time.Time
+}
+
+// Limits are the quotas that apply to one user.
+type Limits struct {
+	DailyTokens   int64
+	MonthlyTokens int64
+}
+
+// MergeLimits returns the most generous of the given limits, each cap taken on its own.
+// Zero (unlimited) wins over any cap.
+func MergeLimits(limits ...Limits) Limits {
+	if len(limits) == 0 {
+		return Limits{}
+	}
+	out := limits[0]
+	for _, l := range limits[1:] {
+		out.DailyTokens = looser(out.DailyTokens, l.DailyTokens)
+		out.MonthlyTokens = looser(out.MonthlyTokens, l.MonthlyTokens)
+	}
+	return out
+}
+
+func looser(a, b int64) int64 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return max(a, b)
+}
+
+// UsageReport is a user's standing against their quotas. ByEndpoint covers the
+// current month.
+type UsageReport struct {
+	Limits        Limits
+	UsedToday     int64
+	UsedThisMonth int64
+	DayResetsAt   time.Time
+	MonthResetsAt time.Time
+	ByEndpoint    []*UsageSummary
+}
+
+// DayStart and MonthStart bound the quota periods, which reset at UTC midnight.
+func DayStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,35 @@
+package ai
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMergeLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits []Limits
+		want   Limits
+	}{
+		{"none", nil, Limits{}},
+		{"single", []Limits{{100, 1000}}, Limits{100, 1000}},
+		{"most generous per cap", []Limits{{100, 5000}, {300, 2000}}, Limits{300, 5000}},
+		{"unlimited wins", []Limits{{100, 1000}, {0, 2000}}, Limits{0, 2000}},
+	}
+	for _, tt := range tests {
+		if got := MergeLimits(tt.limits...); got != tt.want {
+			t.Errorf("%s: MergeLimits() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPeriodStarts(t *testing.T) {
+	at := time.Date(2025, 3, 15, 1, 30, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+
+	if got, want := DayStart(at), time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("DayStart() = %v, want %v", got, want)
+	}
+	if got, want := MonthStart(at), time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("MonthStart() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	ActionAuthLogin                = "auth.login"
+	ActionAuthLoginFailed          = "auth.login_failed"
+	ActionAuthLockout              = "auth.lockout"
+	ActionAuthPasswordResetRequest = "auth.password_reset_requested"
+	ActionAuthPasswordReset        = "auth.password_reset"
+	ActionAuthAccountVerified      = "auth.account_verified"
+
+	ActionUserRegistered     = "user.registered"
+	ActionUserAccountUpdated = "user.account_updated"
+
+	ActionAdminUserRolesSet      = "admin.user_roles_set"
+	ActionAdminPasswordResetSent = "admin.password_reset_triggered"
+	ActionAdminUserDeleted       = "admin.user_deleted"
+
+	ActionTokenCreated = "token.created"
+	ActionTokenRevoked = "token.revoked"
+
+	ActionRoleCreated           = "rbac.role_created"
+	ActionRoleUpdated           = "rbac.role_updated"
+	ActionRoleDeleted           = "rbac.role_deleted"
+	ActionRolePermissionAdded   = "rbac.role_permission_added"
+	ActionRolePermissionRemoved = "rbac.role_permission_removed"
+
+	ActionCoachingInvited  = "coaching.invited"
+	ActionCoachingAccepted = "coaching.accepted"
+	ActionCoachingDeclined = "coaching.declined"
+	ActionCoachingRevoked  = "coaching.revoked"
+
+	ActionChallengeCreated = "challenge.created"
+	ActionChallengeUpdated = "challenge.updated"
+	ActionChallengeDeleted = "challenge.deleted"
+)
+
+const (
+	TargetUser                = "user"
+	TargetPersonalAccessToken = "personal_access_token"
+	TargetRole                = "role"
+	TargetCoachClient         = "coach_client"
+	TargetChallenge           = "challenge"
+)
+
+// AuditLog is append-only: rows are only ever inserted, and removed by the retention job.
+type AuditLog struct {
+	ID uint `gorm:"primaryKey"`
+
+	ActorID    *uint  `gorm:"index"`
+	TargetType string `gorm:"index"`
+	TargetID   *uint  `gorm:"index"`
+	Action     string `gorm:"not null;index"`
+
+	IP        string `gorm:"index"`
+	UserAgent string
+	Diff      string `gorm:"type:jsonb;default:'{}'"`
+	Metadata  string `gorm:"type:jsonb;default:'{}'"`
+
+	CreatedAt time.Time `gorm:"index"`
+}
+
+type Filter struct {
+	ActorID    *uint
+	TargetType string
+	TargetID   *uint
+	Action     string
+	IP         string
+	From       *time.Time
+	To         *time.Time
+}
+
+// NewAuditLog builds an entry for the given action, taking actor, IP and user agent from ctx.
+// before and after are diffed field by field; either may be nil.
+func NewAuditLog(ctx context.Context, action, targetType string, targetID *uint, before, after any) *AuditLog {
+	meta := RequestMetaFrom(ctx)
+	return &AuditLog{
+		ActorID:    meta.ActorID,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Action:     action,
+		IP:         meta.IP,
+		UserAgent:  meta.UserAgent,
+		Diff:       Diff(before, after),
+	}
+}
+
+// WithMetadata attaches free-form details to the entry.
+func (l *AuditLog) WithMetadata(m map[string]any) *AuditLog {
+	if b, err := json.Marshal(m); err == nil {
+		l.Metadata = string(b)
+	}
+	return l
+}
@@ -0,0 +1,28 @@
+package audit
+
+import "context"
+
+// RequestMeta describes who made a request and from where.
+type RequestMeta struct {
+	ActorID   *uint
+	IP        string
+	UserAgent string
+}
+
+type ctxKey struct{}
+
+func WithRequestMeta(ctx context.Context, m RequestMeta) context.Context {
+	return context.WithValue(ctx, ctxKey{}, m)
+}
+
+// WithActor sets the actor while keeping IP and user agent already in ctx.
+func WithActor(ctx context.Context, actorID uint) context.Context {
+	m := RequestMetaFrom(ctx)
+	m.ActorID = &actorID
+	return WithRequestMeta(ctx, m)
+}
+
+func RequestMetaFrom(ctx context.Context) RequestMeta {
+	m, _ := ctx.Value(ctxKey{}).(RequestMeta)
+	return m
+}
@@ -0,0 +1,63 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+const redacted = "[redacted]"
+
+// Diff returns a JSON object of the changed fields as {"field": {"from": x, "to": y}}.
+// Values are compared after a JSON round trip, and password-like fields are redacted.
+func Diff(before, after any) string {
+	b := toMap(before)
+	a := toMap(after)
+
+	out := map[string]map[string]any{}
+	for k, av := range a {
+		bv, ok := b[k]
+		if ok && reflect.DeepEqual(av, bv) {
+			continue
+		}
+		change := map[string]any{"to": redact(k, av)}
+		if ok {
+			change["from"] = redact(k, bv)
+		}
+		out[k] = change
+	}
+	for k, bv := range b {
+		if _, ok := a[k]; !ok {
+			out[k] = map[string]any{"from": redact(k, bv)}
+		}
+	}
+
+	res, err := json.Marshal(out)
+	if err != nil {
+		return "{}"
+	}
+	return string(res)
+}
+
+func toMap(v any) map[string]any {
+	if v == nil {
+		return map[string]any{}
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return map[string]any{}
+	}
+	var m map[string]any
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return map[string]any{"value": string(raw)}
+	}
+	return m
+}
+
+func redact(key string, v any) any {
+	k := strings.ToLower(key)
+	if strings.Contains(k, "password") || strings.Contains(k, "token") || strings.Contains(k, "secret") {
+		return redacted
+	}
+	return v
+}
@@ -0,0 +1,8 @@
+package audit
+
+import "context"
+
+type AuditLogRepository interface {
+	Create(ctx context.Context, l *AuditLog) error
+	List(ctx context.Context, f Filter, page, pageSize int64) ([]*AuditLog, int64, error)
+}
@@ -0,0 +1,8 @@
+package auth
+
+// Scopes used to keep failed-attempt counters of different endpoints apart.
+const (
+	ScopeLogin         = "login"
+	ScopeVerifyAccount = "verify_account"
+	ScopeResetPassword = "reset_password"
+)
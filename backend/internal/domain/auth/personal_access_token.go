@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"strings"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+)
+
+// PersonalTokenPrefix marks bearer tokens that are personal access tokens rather than JWTs.
+const PersonalTokenPrefix = "ftpat_"
+
+// TokenScopes are the permission keys a personal access token may be limited to.
+var TokenScopes = []string{
+	rbac.PermWorkoutReadSelf,
+	rbac.PermWorkoutWriteSelf,
+	rbac.PermUserReadSelf,
+	rbac.PermUserWriteSelf,
+	rbac.PermAiQuestions,
+}
+
+type PersonalAccessToken struct {
+	ID     uint      `gorm:"primaryKey"`
+	UserID uint      `gorm:"not null;index"`
+	User   user.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+
+	Name      string `gorm:"not null"`
+	Prefix    string `gorm:"not null"` // first characters of the token, shown to the user
+	TokenHash string `gorm:"not null;uniqueIndex"`
+	Scopes    string `gorm:"not null"` // comma-separated permission keys
+
+	RateLimitPerMinute int `gorm:"default:60"`
+
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time `gorm:"index"`
+	CreatedAt  time.Time
+}
+
+func (t *PersonalAccessToken) ScopeList() []string {
+	if t.Scopes == "" {
+		return nil
+	}
+	return strings.Split(t.Scopes, ",")
+}
+
+func (t *PersonalAccessToken) HasScope(permKey string) bool {
+	for _, s := range t.ScopeList() {
+		if s == permKey {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *PersonalAccessToken) IsActive(now time.Time) bool {
+	if t.RevokedAt != nil {
+		return false
+	}
+	return t.ExpiresAt == nil || now.Before(*t.ExpiresAt)
+}
+
+func IsPersonalToken(raw string) bool {
+	return strings.HasPrefix(raw, PersonalTokenPrefix)
+}
@@ -0,0 +1,14 @@
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+type PersonalAccessTokenRepository interface {
+	Create(ctx context.Context, t *PersonalAccessToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*PersonalAccessToken, error)
+	GetByUserID(ctx context.Context, userID uint) ([]*PersonalAccessToken, error)
+	Revoke(ctx context.Context, userID, id uint, at time.Time) error
+	TouchLastUsed(ctx context.Context, id uint, at time.Time) error
+}
@@ -0,0 +1,122 @@
+package challenge
+
+import (
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+)
+
+// Metrics a challenge can be scored on. Volume is in kilograms lifted (weight x reps).
+const (
+	MetricWorkouts = "workouts"
+	MetricSets     = "sets"
+	MetricReps     = "reps"
+	MetricVolume   = "volume"
+)
+
+var Metrics = []string{MetricWorkouts, MetricSets, MetricReps, MetricVolume}
+
+const (
+	StatusUpcoming = "upcoming"
+	StatusActive   = "active"
+	StatusPast     = "past"
+)
+
+// MaxDuration caps how long a single challenge may run.
+const MaxDuration = 366 * 24 * time.Hour
+
+// Challenge is a time-boxed competition, e.g. "most volume in October" (metric volume, no target)
+// or "30 workouts in 30 days" (metric workouts, target 30).
+type Challenge struct {
+	ID uint `gorm:"primaryKey"`
+
+	Name        string `gorm:"not null"`
+	Description string `gorm:"type:text"`
+	Metric      string `gorm:"not null"`
+	Target      *int64
+
+	StartsAt time.Time `gorm:"not null;index"`
+	EndsAt   time.Time `gorm:"not null;index"`
+
+	CreatedByID *uint
+	CreatedBy   *user.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (c *Challenge) IsRunning(at time.Time) bool {
+	return !at.Before(c.StartsAt) && at.Before(c.EndsAt)
+}
+
+func (c *Challenge) HasEnded(at time.Time) bool {
+	return !at.Before(c.EndsAt)
+}
+
+// Score computes how much a completed workout contributes under the given metric.
+func Score(metric string, w *workout.Workout) int64 {
+	if metric == MetricWorkouts {
+		return 1
+	}
+
+	var sets, reps, grams int64
+	for _, we := range w.WorkoutExercises {
+		for _, s := range we.WorkoutSets {
+			if !s.Completed {
+				continue
+			}
+			sets++
+			if s.Reps == nil {
+				continue
+			}
+			r := int64(max(*s.Reps, 0))
+			reps += r
+			if s.Weight != nil {
+				grams += int64(max(*s.Weight, 0)) * r
+			}
+		}
+	}
+
+	switch metric {
+	case MetricSets:
+		return sets
+	case MetricReps:
+		return reps
+	case MetricVolume:
+		return grams / 1000
+	default:
+		return 0
+	}
+}
+
+type ChallengeParticipant struct {
+	ChallengeID uint      `gorm:"primaryKey"`
+	Challenge   Challenge `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	UserID      uint      `gorm:"primaryKey;index"`
+	User        user.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	Progress    int64 `gorm:"not null;default:0"`
+	CompletedAt *time.Time
+
+	JoinedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time
+}
+
+// ChallengeContribution records what one workout added to a participant's progress, so a
+// redelivered event never counts twice.
+type ChallengeContribution struct {
+	ChallengeID uint `gorm:"primaryKey"`
+	UserID      uint `gorm:"primaryKey"`
+	WorkoutID   uint `gorm:"primaryKey;index"`
+
+	Value     int64 `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+type LeaderboardEntry struct {
+	Rank     int64
+	UserID   uint
+	Username string
+	Score    int64
+}
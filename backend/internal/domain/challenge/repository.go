@@ -0,0 +1,33 @@
+package challenge
+
+import (
+	"context"
+	"time"
+)
+
+type ChallengeRepository interface {
+	Create(ctx context.Context, c *Challenge) error
+	GetByID(ctx context.Context, id uint) (*Challenge, error)
+	// List returns challenges in the given status (see Status*) relative to at; empty means all.
+	List(ctx context.Context, status string, at time.Time) ([]*Challenge, error)
+	Update(ctx context.Context, c *Challenge) error
+	Delete(ctx context.Context, id uint) error
+	// GetRunningForUser returns challenges the user joined that are running at the given time.
+	GetRunningForUser(ctx context.Context, userID uint, at time.Time) ([]*Challenge, error)
+}
+
+type ParticipantRepository interface {
+	Create(ctx context.Context, p *ChallengeParticipant) error
+	Get(ctx context.Context, challengeID, userID uint) (*ChallengeParticipant, error)
+	Delete(ctx context.Context, challengeID, userID uint) error
+	GetByUserID(ctx context.Context, userID uint) ([]*ChallengeParticipant, error)
+	// Top returns participants ordered by progress, with users preloaded.
+	Top(ctx context.Context, challengeID uint, offset, limit int) ([]*ChallengeParticipant, error)
+	GetByUserIDs(ctx context.Context, challengeID uint, userIDs []uint) ([]*ChallengeParticipant, error)
+	Count(ctx context.Context, challengeID uint) (int64, error)
+
+	// AddContribution stores the contribution and recomputes the participant's progress.
+	// It reports false when the workout was already counted.
+	AddContribution(ctx context.Context, c *ChallengeContribution) (bool, error)
+	SetCompletedAt(ctx context.Context, challengeID, userID uint, at time.Time) error
+}
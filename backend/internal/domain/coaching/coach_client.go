@@ -0,0 +1,41 @@
+package coaching
+
+import (
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+)
+
+const (
+	StatusPending  = "pending"
+	StatusActive   = "active"
+	StatusDeclined = "declined"
+	StatusRevoked  = "revoked"
+)
+
+// CoachClient links a coach to a client. The coach invites, the client accepts or declines,
+// and either side can revoke. Only active relationships grant access to the client's workouts.
+type CoachClient struct {
+	ID uint `gorm:"primaryKey"`
+
+	CoachID  uint      `gorm:"not null;index"`
+	Coach    user.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ClientID uint      `gorm:"not null;index"`
+	Client   user.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	Status  string `gorm:"not null;default:'pending';index"`
+	Message string
+
+	RespondedAt *time.Time
+	RevokedAt   *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (r *CoachClient) IsActive() bool {
+	return r.Status == StatusActive
+}
+
+func (r *CoachClient) IsOpen() bool {
+	return r.Status == StatusPending || r.Status == StatusActive
+}
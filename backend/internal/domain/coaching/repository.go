@@ -0,0 +1,22 @@
+package coaching
+
+import (
+	"context"
+)
+
+type CoachClientRepository interface {
+	Create(ctx context.Context, r *CoachClient) error
+	GetByID(ctx context.Context, id uint) (*CoachClient, error)
+	GetOpen(ctx context.Context, coachID, clientID uint) (*CoachClient, error)
+	GetByCoachID(ctx context.Context, coachID uint) ([]*CoachClient, error)
+	GetByClientID(ctx context.Context, clientID uint) ([]*CoachClient, error)
+	Update(ctx context.Context, id uint, updates map[string]any) error
+	IsActive(ctx context.Context, coachID, clientID uint) (bool, error)
+}
+
+type WorkoutCommentRepository interface {
+	Create(ctx context.Context, c *WorkoutComment) error
+	GetByID(ctx context.Context, id uint) (*WorkoutComment, error)
+	GetByWorkoutID(ctx context.Context, workoutID uint) ([]*WorkoutComment, error)
+	Delete(ctx context.Context, id uint) error
+}
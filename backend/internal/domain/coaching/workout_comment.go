@@ -0,0 +1,22 @@
+package coaching
+
+import (
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+)
+
+type WorkoutComment struct {
+	ID uint `gorm:"primaryKey"`
+
+	WorkoutID uint            `gorm:"not null;index"`
+	Workout   workout.Workout `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	AuthorID  uint            `gorm:"not null;index"`
+	Author    user.User       `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	Body string `gorm:"type:text;not null"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -7,6 +7,7 @@ type EmailToken struct {
 	UserID    uint
 	Token     string
 	Type      string
+	Attempts  int `gorm:"default:0"`
 	ExpiresAt *time.Time
 	CreatedAt *time.Time
 }
@@ -7,6 +7,9 @@ import (
 type EmailTokenRepository interface {
 	Create(ctx context.Context, token *EmailToken) error
 	GetByTokenAndType(ctx context.Context, token, tokenType string) (*EmailToken, error)
+	GetLatestByUserIDAndType(ctx context.Context, userID uint, tokenType string) (*EmailToken, error)
+	IncrementAttempts(ctx context.Context, id uint) (int, error)
 	DeleteExpiredTokens(ctx context.Context) error
 	Delete(ctx context.Context, id uint) error
+	DeleteByUserIDAndType(ctx context.Context, userID uint, tokenType string) error
 }
@@ -0,0 +1,65 @@
+package equipment
+
+import (
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/shared/units"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+)
+
+// LoadingSettings describe what a user can actually put on a bar or pick off a rack.
+// All weights are stored in grams, like the rest of the workout data. BarWeight is used
+// for EZ bars and Smith machines too, as the same plates go on them.
+type LoadingSettings struct {
+	ID                uint      `gorm:"primaryKey"`
+	UserID            uint      `gorm:"not null;uniqueIndex"`
+	User              user.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	BarWeight         int       `gorm:"not null"`
+	DumbbellIncrement int       `gorm:"not null"`
+
+	Plates []LoadingPlate `gorm:"foreignKey:SettingsID;constraint:OnDelete:CASCADE;"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// LoadingPlate is one plate size and how many pairs of it are available.
+type LoadingPlate struct {
+	SettingsID uint `gorm:"primaryKey"`
+	Weight     int  `gorm:"primaryKey;autoIncrement:false"`
+	Pairs      int  `gorm:"not null"`
+}
+
+func kilograms(kg float64) int { return units.Kilograms(kg).Grams() }
+func pounds(lb float64) int    { return units.Pounds(lb).Grams() }
+
+// DefaultLoadingSettings returns a typical commercial gym setup in the given unit system.
+func DefaultLoadingSettings(unitSystem string) *LoadingSettings {
+	if unitSystem == string(units.Imperial) {
+		return &LoadingSettings{
+			BarWeight:         pounds(45),
+			DumbbellIncrement: pounds(5),
+			Plates: []LoadingPlate{
+				{Weight: pounds(45), Pairs: 8},
+				{Weight: pounds(35), Pairs: 2},
+				{Weight: pounds(25), Pairs: 2},
+				{Weight: pounds(10), Pairs: 2},
+				{Weight: pounds(5), Pairs: 2},
+				{Weight: pounds(2.5), Pairs: 2},
+			},
+		}
+	}
+	return &LoadingSettings{
+		BarWeight:         kilograms(20),
+		DumbbellIncrement: kilograms(2),
+		Plates: []LoadingPlate{
+			{Weight: kilograms(25), Pairs: 8},
+			{Weight: kilograms(20), Pairs: 2},
+			{Weight: kilograms(15), Pairs: 2},
+			{Weight: kilograms(10), Pairs: 2},
+			{Weight: kilograms(5), Pairs: 2},
+			{Weight: kilograms(2.5), Pairs: 2},
+			{Weight: kilograms(1.25), Pairs: 2},
+		},
+	}
+}
@@ -0,0 +1,173 @@
+package equipment
+
+import (
+	"math"
+	"slices"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+)
+
+// PlateCount is how many plates of one size go on each side of the bar.
+type PlateCount struct {
+	Weight int
+	Count  int
+}
+
+// Loadout is the loadable weight closest to Target. Bar and PerSide are only set
+// for plate-loaded equipment.
+type Loadout struct {
+	Target  int
+	Weight  int
+	Bar     int
+	PerSide []PlateCount
+}
+
+// PlateLoaded reports whether the equipment is loaded with plates on a bar.
+func PlateLoaded(equipment string) bool {
+	switch equipment {
+	case workout.EquipmentBarbell, workout.EquipmentEZBar, workout.EquipmentSmithMachine:
+		return true
+	}
+	return false
+}
+
+// FixedIncrement reports whether the equipment comes in fixed steps, like a dumbbell rack.
+func FixedIncrement(equipment string) bool {
+	switch equipment {
+	case workout.EquipmentDumbbell, workout.EquipmentKettlebell:
+		return true
+	}
+	return false
+}
+
+// Round returns the loadable weight closest to target for the given equipment. EZ bars
+// and Smith machines are loaded like a barbell and weigh BarWeight.
+// Equipment that is neither plate-loaded nor rack-based is returned unchanged.
+func (s *LoadingSettings) Round(target int, equipment string) Loadout {
+	switch {
+	case PlateLoaded(equipment):
+		return s.RoundBarbell(target)
+	case FixedIncrement(equipment):
+		return Loadout{Target: target, Weight: s.RoundDumbbell(target)}
+	}
+	return Loadout{Target: target, Weight: target}
+}
+
+// RoundDumbbell rounds target to the nearest dumbbell increment. Ties round down.
+func (s *LoadingSettings) RoundDumbbell(target int) int {
+	inc := s.DumbbellIncrement
+	if inc <= 0 {
+		return target
+	}
+	n := target / inc
+	if target-n*inc > (n+1)*inc-target {
+		n++
+	}
+	return max(n, 1) * inc
+}
+
+// RoundBarbell finds the closest total the available plates can build, split evenly
+// across both sides. Among equally close totals it picks the lighter one, so a prefilled
+// set is never heavier than asked, and builds it from the fewest and then heaviest plates.
+func (s *LoadingSettings) RoundBarbell(target int) Loadout {
+	out := Loadout{Target: target, Weight: s.BarWeight, Bar: s.BarWeight}
+	plates := make([]LoadingPlate, 0, len(s.Plates))
+	for _, p := range s.Plates {
+		if p.Weight > 0 && p.Pairs > 0 {
+			plates = append(plates, p)
+		}
+	}
+	if target <= s.BarWeight || len(plates) == 0 {
+		return out
+	}
+	slices.SortFunc(plates, func(a, b LoadingPlate) int { return b.Weight - a.Weight })
+
+	// Per-side weights are counted in units of the plates' greatest common divisor, up to
+	// one extra heaviest plate past the target or everything there is, whichever is less.
+	unit, total := 0, 0
+	for _, p := range plates {
+		unit = gcd(unit, p.Weight)
+		total += p.Weight * p.Pairs
+	}
+	limit := min((target-s.BarWeight)/2+plates[0].Weight, total) / unit
+
+	// fewest[u] is the fewest plates that weigh u units per side, and used[i][u] how many
+	// of plates[i] go into that combination. Lighter plates are added first, so when u is
+	// rebuilt from the heaviest plate down, the heavy plates take as much as they can.
+	fewest := make([]uint16, limit+1)
+	for u := 1; u <= limit; u++ {
+		fewest[u] = unreachable
+	}
+	used := make([][]uint8, len(plates))
+	for i := len(plates) - 1; i >= 0; i-- {
+		fewest, used[i] = addPlates(fewest, plates[i].Weight/unit, min(plates[i].Pairs, math.MaxUint8))
+	}
+
+	best, bestDiff := 0, target-s.BarWeight
+	for u, n := range fewest {
+		if n == unreachable {
+			continue
+		}
+		if diff := abs(s.BarWeight + 2*u*unit - target); diff < bestDiff {
+			best, bestDiff = u, diff
+		}
+	}
+
+	out.Weight = s.BarWeight + 2*best*unit
+	for i, p := range plates {
+		if n := int(used[i][best]); n > 0 {
+			out.PerSide = append(out.PerSide, PlateCount{Weight: p.Weight, Count: n})
+			best -= n * (p.Weight / unit)
+		}
+	}
+	return out
+}
+
+const unreachable = math.MaxUint16
+
+// addPlates adds up to pairs plates of w units to the combinations in prev. For each
+// weight u it takes the minimum of prev[u-n*w]+n over n in 0..pairs, kept in a sliding
+// window per residue of w, and records the largest n that reaches it.
+func addPlates(prev []uint16, w, pairs int) ([]uint16, []uint8) {
+	next := make([]uint16, len(prev))
+	used := make([]uint8, len(prev))
+	window := make([]int, 0, len(prev)/w+1)
+	for r := 0; r < w && r < len(prev); r++ {
+		window = window[:0]
+		head := 0
+		// With cost(j) = prev[r+j*w] - j, next[r+j*w] is the least cost in the window plus j.
+		cost := func(j int) int { return int(prev[r+j*w]) - j }
+		for j := 0; r+j*w < len(prev); j++ {
+			if prev[r+j*w] != unreachable {
+				// Ties keep the earlier index, which uses more of this plate.
+				for len(window) > head && cost(window[len(window)-1]) > cost(j) {
+					window = window[:len(window)-1]
+				}
+				window = append(window, j)
+			}
+			for len(window) > head && window[head] < j-pairs {
+				head++
+			}
+			next[r+j*w] = unreachable
+			if len(window) > head {
+				next[r+j*w] = uint16(cost(window[head]) + j)
+				used[r+j*w] = uint8(j - window[head])
+			}
+		}
+	}
+	return next, used
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,151 @@
+package equipment
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+)
+
+func TestRound(t *testing.T) {
+	metric := DefaultLoadingSettings("metric")
+	imperial := DefaultLoadingSettings("imperial")
+	limited := &LoadingSettings{
+		BarWeight: kilograms(20),
+		Plates:    []LoadingPlate{{Weight: kilograms(20), Pairs: 1}},
+	}
+
+	tests := []struct {
+		name      string
+		settings  *LoadingSettings
+		target    int
+		equipment string
+		want      int
+		perSide   []PlateCount
+	}{
+		{
+			name:      "exact barbell load",
+			settings:  metric,
+			target:    kilograms(100),
+			equipment: workout.EquipmentBarbell,
+			want:      kilograms(100),
+			perSide:   []PlateCount{{kilograms(25), 1}, {kilograms(15), 1}},
+		},
+		{
+			name:      "rounds down to nearest load",
+			settings:  metric,
+			target:    kilograms(101),
+			equipment: workout.EquipmentBarbell,
+			want:      kilograms(100),
+			perSide:   []PlateCount{{kilograms(25), 1}, {kilograms(15), 1}},
+		},
+		{
+			name:      "rounds up to nearest load",
+			settings:  metric,
+			target:    kilograms(101.5),
+			equipment: workout.EquipmentBarbell,
+			want:      kilograms(102.5),
+			perSide:   []PlateCount{{kilograms(25), 1}, {kilograms(15), 1}, {kilograms(1.25), 1}},
+		},
+		{
+			name:      "below the bar is just the bar",
+			settings:  metric,
+			target:    kilograms(12),
+			equipment: workout.EquipmentBarbell,
+			want:      kilograms(20),
+		},
+		{
+			name:      "imperial plates",
+			settings:  imperial,
+			target:    pounds(225),
+			equipment: workout.EquipmentBarbell,
+			want:      pounds(45) + 4*pounds(45),
+			perSide:   []PlateCount{{pounds(45), 2}},
+		},
+		{
+			name:      "limited plates cap the load",
+			settings:  limited,
+			target:    kilograms(150),
+			equipment: workout.EquipmentBarbell,
+			want:      kilograms(60),
+			perSide:   []PlateCount{{kilograms(20), 1}},
+		},
+		{
+			name:      "dumbbell tie rounds down",
+			settings:  metric,
+			target:    kilograms(23),
+			equipment: workout.EquipmentDumbbell,
+			want:      kilograms(22),
+		},
+		{
+			name:      "imperial dumbbell",
+			settings:  imperial,
+			target:    pounds(52),
+			equipment: workout.EquipmentDumbbell,
+			want:      pounds(50),
+		},
+		{
+			name:      "machine weight is untouched",
+			settings:  metric,
+			target:    kilograms(37),
+			equipment: workout.EquipmentMachine,
+			want:      kilograms(37),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.settings.Round(tt.target, tt.equipment)
+			if got.Weight != tt.want {
+				t.Errorf("weight = %d, want %d", got.Weight, tt.want)
+			}
+			if !slices.Equal(got.PerSide, tt.perSide) {
+				t.Errorf("per side = %v, want %v", got.PerSide, tt.perSide)
+			}
+		})
+	}
+}
+
+func TestRoundBarbell_ManyOddPlates(t *testing.T) {
+	// Plate sizes with no common divisor make every gram a reachable weight.
+	s := &LoadingSettings{BarWeight: kilograms(20)}
+	for w := 1000; w < 1008; w++ {
+		s.Plates = append(s.Plates, LoadingPlate{Weight: w, Pairs: 50})
+	}
+
+	start := time.Now()
+	got := s.RoundBarbell(2000000)
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("took %v", d)
+	}
+
+	// Everything on the bar is the closest it gets.
+	want := s.BarWeight
+	for _, p := range s.Plates {
+		want += 2 * p.Weight * p.Pairs
+	}
+	if got.Weight != want {
+		t.Errorf("weight = %d, want %d", got.Weight, want)
+	}
+	if len(got.PerSide) != len(s.Plates) {
+		t.Errorf("per side = %v, want every plate", got.PerSide)
+	}
+}
+
+func TestRoundBarbell_FewestPlates(t *testing.T) {
+	s := &LoadingSettings{
+		BarWeight: kilograms(20),
+		Plates: []LoadingPlate{
+			{Weight: kilograms(10), Pairs: 1},
+			{Weight: kilograms(5), Pairs: 4},
+			{Weight: kilograms(2.5), Pairs: 4},
+		},
+	}
+
+	got := s.RoundBarbell(kilograms(50))
+	want := []PlateCount{{kilograms(10), 1}, {kilograms(5), 1}}
+	if got.Weight != kilograms(50) || !slices.Equal(got.PerSide, want) {
+		t.Errorf("got %d with %v, want %d with %v", got.Weight, got.PerSide, kilograms(50), want)
+	}
+}
@@ -0,0 +1,74 @@
+package equipment
+
+import (
+	"slices"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+)
+
+const (
+	PresetHomeGym       = "home_gym"
+	PresetCommercialGym = "commercial_gym"
+	PresetTravel        = "travel"
+)
+
+// Presets are starting points for a new profile; users can edit the list afterwards.
+var Presets = map[string][]string{
+	PresetHomeGym: {
+		workout.EquipmentDumbbell, workout.EquipmentKettlebell, workout.EquipmentBand,
+	},
+	PresetCommercialGym: {
+		workout.EquipmentBarbell, workout.EquipmentDumbbell, workout.EquipmentEZBar, workout.EquipmentKettlebell,
+		workout.EquipmentCable, workout.EquipmentMachine, workout.EquipmentSmithMachine, workout.EquipmentBand,
+		workout.EquipmentOther,
+	},
+	PresetTravel: {
+		workout.EquipmentBand,
+	},
+}
+
+// EquipmentProfile is a named set of equipment a user has access to in one place.
+type EquipmentProfile struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_equipment_profiles_user_name"`
+	User      user.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Name      string    `gorm:"not null;uniqueIndex:idx_equipment_profiles_user_name"`
+	IsDefault bool      `gorm:"not null;default:false"`
+
+	Items []EquipmentProfileItem `gorm:"foreignKey:ProfileID;constraint:OnDelete:CASCADE;"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type EquipmentProfileItem struct {
+	ProfileID uint   `gorm:"primaryKey"`
+	Equipment string `gorm:"primaryKey"`
+}
+
+// Equipment lists what the profile provides. Bodyweight is always available.
+func (p *EquipmentProfile) Equipment() []string {
+	out := []string{workout.EquipmentBodyweight}
+	for _, it := range p.Items {
+		if it.Equipment != workout.EquipmentBodyweight {
+			out = append(out, it.Equipment)
+		}
+	}
+	return out
+}
+
+func (p *EquipmentProfile) Has(equipment string) bool {
+	return slices.Contains(p.Equipment(), equipment)
+}
+
+// IsKnown reports whether every entry is a catalog equipment value.
+func IsKnown(equipment []string) bool {
+	for _, e := range equipment {
+		if !slices.Contains(workout.Equipment, e) {
+			return false
+		}
+	}
+	return true
+}
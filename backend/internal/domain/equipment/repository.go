@@ -0,0 +1,21 @@
+package equipment
+
+import "context"
+
+type ProfileRepository interface {
+	Create(ctx context.Context, p *EquipmentProfile) error
+	GetByID(ctx context.Context, userID, id uint) (*EquipmentProfile, error)
+	GetByName(ctx context.Context, userID uint, name string) (*EquipmentProfile, error)
+	GetByUserID(ctx context.Context, userID uint) ([]*EquipmentProfile, error)
+	GetDefault(ctx context.Context, userID uint) (*EquipmentProfile, error)
+	Update(ctx context.Context, userID, id uint, updates map[string]any) error
+	ReplaceItems(ctx context.Context, id uint, equipment []string) error
+	ClearDefault(ctx context.Context, userID uint) error
+	Delete(ctx context.Context, userID, id uint) error
+}
+
+type LoadingSettingsRepository interface {
+	GetByUserID(ctx context.Context, userID uint) (*LoadingSettings, error)
+	Upsert(ctx context.Context, s *LoadingSettings) error
+	DeleteByUserID(ctx context.Context, userID uint) error
+}
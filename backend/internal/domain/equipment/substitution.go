@@ -0,0 +1,25 @@
+package equipment
+
+import "github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+
+// Swap records one workout exercise replaced while switching profiles.
+type Swap struct {
+	WorkoutID         uint
+	WorkoutExerciseID uint
+	From              *workout.Exercise
+	To                *workout.Exercise
+}
+
+// Unresolved is a workout exercise that needs equipment the profile lacks and
+// has no equivalent in the catalog; custom exercises always end up here.
+type Unresolved struct {
+	WorkoutID         uint
+	WorkoutExerciseID uint
+	Name              string
+}
+
+type SwitchResult struct {
+	Profile    *EquipmentProfile
+	Swaps      []Swap
+	Unresolved []Unresolved
+}
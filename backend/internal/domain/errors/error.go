@@ -10,5 +10,41 @@ var ErrProfileNotFound = errors.New("profile not found")
 var ErrIndividualExerciseNotFound = errors.New("plan exercise not found")
 var ErrNoConsent = errors.New("no consent provided")
 var ErrTranslationNotFound = errors.New("translation not found")
+var ErrInvalidToken = errors.New("invalid or expired token")
+var ErrTooManyAttempts = errors.New("too many failed attempts")
+var ErrAccountLocked = errors.New("account temporarily locked")
+var ErrInvalidScope = errors.New("invalid token scope")
+var ErrInvalidExpiry = errors.New("token expiry must be in the future")
+var ErrBuiltinRole = errors.New("built-in roles cannot be renamed or deleted")
+var ErrProtectedRole = errors.New("the admin role's permissions cannot be changed")
+var ErrRoleExists = errors.New("role already exists")
+var ErrUnknownPermission = errors.New("unknown permission")
+var ErrNotACoach = errors.New("user is not allowed to coach")
+var ErrCoachingExists = errors.New("coaching relationship already exists")
+var ErrNoCoachingAccess = errors.New("no active coaching relationship")
+var ErrCannotFollowSelf = errors.New("users cannot follow themselves")
+var ErrInvalidReaction = errors.New("invalid reaction")
+var ErrUnknownMuscleGroup = errors.New("unknown muscle group")
+var ErrSharingDisabled = errors.New("workout sharing is disabled")
+var ErrWorkoutNotCompleted = errors.New("workout is not completed")
+var ErrInvalidChallenge = errors.New("invalid challenge rules")
+var ErrChallengeEnded = errors.New("challenge has ended")
+var ErrEquipmentProfileExists = errors.New("equipment profile already exists")
+var ErrUnknownEquipment = errors.New("unknown equipment")
+var ErrInvalidLoadingSettings = errors.New("invalid loading settings")
+var ErrFoodExists = errors.New("food already exists")
+var ErrFoodInUse = errors.New("food is used in logged meals")
+var ErrInvalidGoal = errors.New("invalid goal")
+var ErrBodyWeightRequired = errors.New("body weight must be set in the profile")
+var ErrConversationNotFound = errors.New("conversation not found")
+var ErrInvalidTitle = errors.New("invalid title")
+var ErrQuotaExceeded = errors.New("ai token quota exceeded")
+var ErrInvalidQuota = errors.New("quota must not be negative")
+var ErrNotADraft = errors.New("workout plan is not a draft")
+var ErrDraftPlan = errors.New("workout plan is a draft, accept it first")
+var ErrInvalidGeneratedPlan = errors.New("generated plan failed validation")
+var ErrUnknownPrompt = errors.New("unknown prompt")
+var ErrInvalidPromptTemplate = errors.New("invalid prompt template")
+var ErrRequestBlocked = errors.New("request blocked by ai guardrails")
 
 // more errors can be added here as needed
\ No newline at end of file
@@ -0,0 +1,14 @@
+package goal
+
+import "time"
+
+type GoalAchieved struct {
+	EventID   string
+	GoalID    uint
+	UserID    uint
+	Kind      string
+	WorkoutID *uint
+	At        time.Time
+}
+
+func (e GoalAchieved) EventType() string { return "GoalAchieved" }
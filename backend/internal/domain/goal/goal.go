@@ -0,0 +1,94 @@
+package goal
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/achievement"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/shared/domainevt"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+)
+
+const (
+	KindLift       = "lift"
+	KindBodyweight = "bodyweight"
+	KindFrequency  = "frequency"
+)
+
+// Lift goals are measured either on the estimated one-rep max or on the heaviest
+// weight moved for at least TargetReps reps.
+const (
+	MetricE1RM       = "e1rm"
+	MetricWeightReps = "weight_reps"
+)
+
+const (
+	StatusActive    = "active"
+	StatusAchieved  = "achieved"
+	StatusAbandoned = "abandoned"
+	// StatusExpired is a goal whose deadline passed before it was reached.
+	StatusExpired = "expired"
+)
+
+type Goal struct {
+	ID     uint      `gorm:"primaryKey"`
+	UserID uint      `gorm:"not null;index:idx_goals_user_status,priority:1"`
+	User   user.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Kind   string    `gorm:"not null"`
+	Status string    `gorm:"not null;default:'active';index:idx_goals_user_status,priority:2"`
+
+	IndividualExerciseID *uint                       `gorm:"index"`
+	IndividualExercise   *workout.IndividualExercise `gorm:"foreignKey:IndividualExerciseID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Metric               string                      `gorm:"not null;default:''"`
+
+	// TargetWeight is in grams: the lift for lift goals, the body weight for bodyweight goals.
+	TargetWeight int `gorm:"not null;default:0"`
+	TargetReps   int `gorm:"not null;default:0"`
+	// StartWeight is the body weight when the goal was set; it tells losing from gaining.
+	StartWeight int `gorm:"not null;default:0"`
+
+	// Frequency goals ask for PerWeek workouts in each of Weeks consecutive ISO weeks.
+	PerWeek int `gorm:"not null;default:0"`
+	Weeks   int `gorm:"not null;default:0"`
+
+	Deadline          *time.Time
+	AchievedAt        *time.Time
+	AchievedWorkoutID *uint
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	domainevt.EventsMixin `gorm:"-"`
+}
+
+func (g *Goal) IsActive() bool { return g.Status == StatusActive }
+
+// Overdue reports whether an active goal's deadline has passed.
+func (g *Goal) Overdue(now time.Time) bool {
+	return g.IsActive() && g.Deadline != nil && now.After(*g.Deadline)
+}
+
+// Expire marks an active goal whose deadline has passed as expired.
+func (g *Goal) Expire(now time.Time) {
+	if g.Overdue(now) {
+		g.Status = StatusExpired
+	}
+}
+
+// CountsFrom is when a frequency goal starts counting workouts: the ISO week it was set in.
+func (g *Goal) CountsFrom() time.Time {
+	return achievement.WeekStart(g.CreatedAt)
+}
+
+// Achieve marks an active goal as reached and raises GoalAchieved. workoutID is the
+// workout whose completion reached it, when there is one.
+func (g *Goal) Achieve(now time.Time, workoutID *uint) {
+	if !g.IsActive() {
+		return
+	}
+	g.Status = StatusAchieved
+	g.AchievedAt = &now
+	g.AchievedWorkoutID = workoutID
+	g.Raise(GoalAchieved{EventID: uuid.NewString(), GoalID: g.ID, UserID: g.UserID, Kind: g.Kind, WorkoutID: workoutID, At: now})
+}
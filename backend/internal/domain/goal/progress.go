@@ -0,0 +1,90 @@
+package goal
+
+import (
+	"math"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/achievement"
+)
+
+// Progress is how far a goal has come. Current and Target are grams for lift and
+// bodyweight goals and consecutive weeks for frequency goals.
+type Progress struct {
+	Current int
+	Target  int
+	// ThisWeek is the number of workouts completed in the running week (frequency goals only).
+	ThisWeek int
+	Fraction float64
+	Reached  bool
+}
+
+// Tracked is a goal together with its current progress.
+type Tracked struct {
+	Goal     *Goal
+	Progress Progress
+}
+
+// E1RM estimates the one-rep max with the Epley formula. Singles count as they are.
+func E1RM(weight, reps int) int {
+	if weight <= 0 || reps <= 0 {
+		return 0
+	}
+	if reps == 1 {
+		return weight
+	}
+	return int(math.Round(float64(weight) * (1 + float64(reps)/30)))
+}
+
+// BestLift picks the lift goal's measurement from the heaviest weight done at each rep count.
+func BestLift(g *Goal, maxByReps map[int]int) int {
+	best := 0
+	for reps, weight := range maxByReps {
+		switch g.Metric {
+		case MetricWeightReps:
+			if reps >= g.TargetReps {
+				best = max(best, weight)
+			}
+		default:
+			best = max(best, E1RM(weight, reps))
+		}
+	}
+	return best
+}
+
+func LiftProgress(g *Goal, best int) Progress {
+	p := Progress{Current: best, Target: g.TargetWeight, Reached: best >= g.TargetWeight}
+	p.Fraction = fraction(float64(best), float64(g.TargetWeight), p.Reached)
+	return p
+}
+
+// BodyweightProgress measures the way travelled from StartWeight towards the target,
+// in whichever direction that is.
+func BodyweightProgress(g *Goal, weight int) Progress {
+	p := Progress{Current: weight, Target: g.TargetWeight}
+	if weight <= 0 {
+		return p
+	}
+	if g.StartWeight >= g.TargetWeight {
+		p.Reached = weight <= g.TargetWeight
+		p.Fraction = fraction(float64(g.StartWeight-weight), float64(g.StartWeight-g.TargetWeight), p.Reached)
+	} else {
+		p.Reached = weight >= g.TargetWeight
+		p.Fraction = fraction(float64(weight-g.StartWeight), float64(g.TargetWeight-g.StartWeight), p.Reached)
+	}
+	return p
+}
+
+func FrequencyProgress(g *Goal, s achievement.Streak) Progress {
+	p := Progress{Current: s.Current, Target: g.Weeks, ThisWeek: s.ThisWeek, Reached: s.Current >= g.Weeks}
+	p.Fraction = fraction(float64(s.Current), float64(g.Weeks), p.Reached)
+	return p
+}
+
+func fraction(done, total float64, reached bool) float64 {
+	if reached {
+		return 1
+	}
+	if total <= 0 {
+		return 0
+	}
+	return math.Min(math.Max(done/total, 0), 1)
+}
@@ -0,0 +1,117 @@
+package goal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/achievement"
+)
+
+func TestE1RM(t *testing.T) {
+	tests := []struct {
+		weight, reps, want int
+	}{
+		{100000, 1, 100000},
+		{100000, 5, 116667},
+		{80000, 10, 106667},
+		{0, 5, 0},
+		{100000, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := E1RM(tt.weight, tt.reps); got != tt.want {
+			t.Errorf("E1RM(%d, %d) = %d, want %d", tt.weight, tt.reps, got, tt.want)
+		}
+	}
+}
+
+func TestBestLift(t *testing.T) {
+	byReps := map[int]int{1: 100000, 3: 95000, 5: 90000, 8: 80000}
+
+	e1rm := &Goal{Kind: KindLift, Metric: MetricE1RM}
+	if got := BestLift(e1rm, byReps); got != 105000 {
+		t.Errorf("e1rm best = %d, want 105000", got)
+	}
+
+	fiveRM := &Goal{Kind: KindLift, Metric: MetricWeightReps, TargetReps: 5}
+	if got := BestLift(fiveRM, byReps); got != 90000 {
+		t.Errorf("5RM best = %d, want 90000", got)
+	}
+
+	tenRM := &Goal{Kind: KindLift, Metric: MetricWeightReps, TargetReps: 10}
+	if got := BestLift(tenRM, byReps); got != 0 {
+		t.Errorf("10RM best = %d, want 0", got)
+	}
+}
+
+func TestBodyweightProgress(t *testing.T) {
+	cut := &Goal{Kind: KindBodyweight, StartWeight: 90000, TargetWeight: 80000}
+	if p := BodyweightProgress(cut, 85000); p.Reached || p.Fraction != 0.5 {
+		t.Errorf("cut halfway = %+v", p)
+	}
+	if p := BodyweightProgress(cut, 92000); p.Fraction != 0 {
+		t.Errorf("cut going the wrong way = %+v", p)
+	}
+	if p := BodyweightProgress(cut, 79500); !p.Reached || p.Fraction != 1 {
+		t.Errorf("cut reached = %+v", p)
+	}
+
+	bulk := &Goal{Kind: KindBodyweight, StartWeight: 70000, TargetWeight: 75000}
+	if p := BodyweightProgress(bulk, 71000); p.Reached || p.Fraction != 0.2 {
+		t.Errorf("bulk = %+v", p)
+	}
+	if p := BodyweightProgress(bulk, 0); p.Reached {
+		t.Errorf("unknown weight reached = %+v", p)
+	}
+}
+
+func TestFrequencyProgress(t *testing.T) {
+	g := &Goal{Kind: KindFrequency, PerWeek: 4, Weeks: 4}
+	p := FrequencyProgress(g, achievement.Streak{Current: 1, ThisWeek: 2})
+	if p.Reached || p.Fraction != 0.25 || p.ThisWeek != 2 {
+		t.Errorf("progress = %+v", p)
+	}
+	if p := FrequencyProgress(g, achievement.Streak{Current: 4}); !p.Reached {
+		t.Errorf("progress = %+v", p)
+	}
+}
+
+func TestAchieveRaisesOnce(t *testing.T) {
+	g := &Goal{ID: 1, UserID: 2, Kind: KindLift, Status: StatusActive}
+	g.Achieve(g.CreatedAt, nil)
+	g.Achieve(g.CreatedAt, nil)
+	if g.Status != StatusAchieved || len(g.PendingEvents()) != 1 {
+		t.Errorf("status %q, %d events", g.Status, len(g.PendingEvents()))
+	}
+}
+
+func TestExpire(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		status   string
+		deadline *time.Time
+		want     string
+	}{
+		{"deadline passed", StatusActive, &past, StatusExpired},
+		{"deadline ahead", StatusActive, &future, StatusActive},
+		{"no deadline", StatusActive, nil, StatusActive},
+		{"achieved in time", StatusAchieved, &past, StatusAchieved},
+	}
+	for _, tt := range tests {
+		g := &Goal{Status: tt.status, Deadline: tt.deadline}
+		g.Expire(now)
+		if g.Status != tt.want {
+			t.Errorf("%s: status %q, want %q", tt.name, g.Status, tt.want)
+		}
+	}
+}
+
+func TestCountsFrom(t *testing.T) {
+	// Set on a Wednesday, the goal counts that week's Monday onwards.
+	g := &Goal{Kind: KindFrequency, CreatedAt: time.Date(2026, 3, 4, 18, 30, 0, 0, time.UTC)}
+	if got, want := g.CountsFrom(), time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("counts from %v, want %v", got, want)
+	}
+}
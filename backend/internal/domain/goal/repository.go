@@ -0,0 +1,26 @@
+package goal
+
+import (
+	"context"
+	"time"
+)
+
+type GoalRepository interface {
+	Create(ctx context.Context, g *Goal) error
+	GetByID(ctx context.Context, userID, id uint) (*Goal, error)
+	// GetByUserID lists the user's goals, newest first. An empty status returns all of them.
+	GetByUserID(ctx context.Context, userID uint, status string) ([]*Goal, error)
+	Update(ctx context.Context, userID, id uint, updates map[string]any) error
+	// MarkAchieved stores an achieved goal and reports false if it was no longer active.
+	MarkAchieved(ctx context.Context, g *Goal) (bool, error)
+	// MarkExpired stores an expired goal if it was still active.
+	MarkExpired(ctx context.Context, g *Goal) error
+	Delete(ctx context.Context, userID, id uint) error
+}
+
+// ProgressRepository reads the workout history goals are measured on.
+type ProgressRepository interface {
+	// MaxWeightByReps maps each rep count to the heaviest completed set at that count.
+	MaxWeightByReps(ctx context.Context, userID, individualExerciseID uint) (map[int]int, error)
+	CompletionTimes(ctx context.Context, userID uint) ([]time.Time, error)
+}
@@ -0,0 +1,33 @@
+package nutrition
+
+import "time"
+
+// WorkoutBurn is a completed workout's estimated energy expenditure.
+type WorkoutBurn struct {
+	WorkoutID uint
+	Name      string
+	Kcal      float64
+}
+
+// DailyBalance combines what a user ate on a day with what their workouts burned.
+type DailyBalance struct {
+	Day      time.Time
+	Intake   Macros
+	ByMeal   map[string]Macros
+	Workouts []WorkoutBurn
+	Burned   float64
+	// Targets is nil when the profile is too incomplete to derive them.
+	Targets *Targets
+}
+
+// Net is intake minus training expenditure.
+func (b *DailyBalance) Net() float64 { return b.Intake.Kcal - b.Burned }
+
+// Remaining is how many kcal are left for the day, with training added to the budget.
+func (b *DailyBalance) Remaining() *float64 {
+	if b.Targets == nil {
+		return nil
+	}
+	r := float64(b.Targets.Kcal) + b.Burned - b.Intake.Kcal
+	return &r
+}
@@ -0,0 +1,76 @@
+package nutrition
+
+import (
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+)
+
+// Macros are nutrient totals: energy in kcal, the rest in grams.
+type Macros struct {
+	Kcal    float64
+	Protein float64
+	Carbs   float64
+	Fat     float64
+	Fiber   float64
+}
+
+func (m Macros) Add(o Macros) Macros {
+	return Macros{
+		Kcal:    m.Kcal + o.Kcal,
+		Protein: m.Protein + o.Protein,
+		Carbs:   m.Carbs + o.Carbs,
+		Fat:     m.Fat + o.Fat,
+		Fiber:   m.Fiber + o.Fiber,
+	}
+}
+
+// Scale returns the macros for amount grams when m is given per 100 g.
+func (m Macros) Scale(amount int) Macros {
+	f := float64(amount) / 100
+	return Macros{
+		Kcal:    m.Kcal * f,
+		Protein: m.Protein * f,
+		Carbs:   m.Carbs * f,
+		Fat:     m.Fat * f,
+		Fiber:   m.Fiber * f,
+	}
+}
+
+// Food is either a catalog entry (OwnerID nil, seeded by admins and translated
+// under "food.<slug>") or a user's own food, which is shown by Name as typed.
+// Nutrient values are per 100 g.
+type Food struct {
+	ID      uint       `gorm:"primaryKey"`
+	OwnerID *uint      `gorm:"index"`
+	Owner   *user.User `gorm:"foreignKey:OwnerID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Slug    string     `gorm:"not null;default:''"`
+	Name    string     `gorm:"not null"`
+	Brand   string     `gorm:"not null;default:''"`
+
+	Kcal    float64 `gorm:"not null;default:0"`
+	Protein float64 `gorm:"not null;default:0"`
+	Carbs   float64 `gorm:"not null;default:0"`
+	Fat     float64 `gorm:"not null;default:0"`
+	Fiber   float64 `gorm:"not null;default:0"`
+
+	// ServingSize is the default amount in grams suggested when logging the food.
+	ServingSize int `gorm:"not null;default:100"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (f *Food) IsCatalog() bool { return f.OwnerID == nil }
+
+// TranslationKey is where catalog food names live; custom foods have none.
+func (f *Food) TranslationKey() string {
+	if !f.IsCatalog() {
+		return ""
+	}
+	return "food." + f.Slug
+}
+
+func (f *Food) Per100g() Macros {
+	return Macros{Kcal: f.Kcal, Protein: f.Protein, Carbs: f.Carbs, Fat: f.Fat, Fiber: f.Fiber}
+}
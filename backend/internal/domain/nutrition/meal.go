@@ -0,0 +1,40 @@
+package nutrition
+
+import (
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+)
+
+const (
+	MealBreakfast = "breakfast"
+	MealLunch     = "lunch"
+	MealDinner    = "dinner"
+	MealSnack     = "snack"
+)
+
+var Meals = []string{MealBreakfast, MealLunch, MealDinner, MealSnack}
+
+// MealEntry is one logged portion of a food.
+type MealEntry struct {
+	ID     uint      `gorm:"primaryKey"`
+	UserID uint      `gorm:"not null;index:idx_meal_entries_user_eaten,priority:1"`
+	User   user.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	FoodID uint      `gorm:"not null;index"`
+	Food   *Food     `gorm:"constraint:OnUpdate:CASCADE,OnDelete:NO ACTION;"`
+	Meal   string    `gorm:"not null;default:'snack'"`
+	// Amount is in grams.
+	Amount  int       `gorm:"not null"`
+	EatenAt time.Time `gorm:"not null;index:idx_meal_entries_user_eaten,priority:2"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Macros needs Food to be loaded.
+func (e *MealEntry) Macros() Macros {
+	if e.Food == nil {
+		return Macros{}
+	}
+	return e.Food.Per100g().Scale(e.Amount)
+}
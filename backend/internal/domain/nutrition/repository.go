@@ -0,0 +1,38 @@
+package nutrition
+
+import (
+	"context"
+	"time"
+)
+
+type FoodRepository interface {
+	Create(ctx context.Context, f *Food) error
+	// GetByID returns a catalog food or one owned by userID.
+	GetByID(ctx context.Context, userID, id uint) (*Food, error)
+	GetCatalogBySlug(ctx context.Context, slug string) (*Food, error)
+	// Search matches names in any locale and lists the user's own foods before the catalog.
+	Search(ctx context.Context, userID uint, query string, page, pageSize int) ([]*Food, int64, error)
+	// Update and Delete act on the user's own foods, or on the catalog when ownerID is nil.
+	Update(ctx context.Context, ownerID *uint, id uint, updates map[string]any) error
+	Delete(ctx context.Context, ownerID *uint, id uint) error
+}
+
+type MealEntryRepository interface {
+	Create(ctx context.Context, e *MealEntry) error
+	GetByID(ctx context.Context, userID, id uint) (*MealEntry, error)
+	// GetByRange returns entries with EatenAt in [from, to), food preloaded.
+	GetByRange(ctx context.Context, userID uint, from, to time.Time) ([]*MealEntry, error)
+	CountByFoodID(ctx context.Context, foodID uint) (int64, error)
+	Update(ctx context.Context, userID, id uint, updates map[string]any) error
+	Delete(ctx context.Context, userID, id uint) error
+}
+
+type GoalRepository interface {
+	GetByUserID(ctx context.Context, userID uint) (*NutritionGoal, error)
+	Upsert(ctx context.Context, g *NutritionGoal) error
+}
+
+// BurnRepository reads training expenditure from completed workouts.
+type BurnRepository interface {
+	WorkoutCalories(ctx context.Context, userID uint, from, to time.Time) ([]WorkoutBurn, error)
+}
@@ -0,0 +1,108 @@
+package nutrition
+
+import (
+	"math"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+)
+
+const (
+	GoalLose     = "lose"
+	GoalMaintain = "maintain"
+	GoalGain     = "gain"
+)
+
+const (
+	// Training is added from Workout.EstimatedCalories, so the baseline assumes a
+	// sedentary day to avoid counting it twice.
+	baselineActivity = 1.2
+	loseDeficit      = 500
+	gainSurplus      = 300
+	proteinPerKg     = 1.8
+	fatShare         = 0.25
+)
+
+// Targets are daily goals: energy in kcal, macros in grams.
+type Targets struct {
+	Kcal    int
+	Protein int
+	Carbs   int
+	Fat     int
+}
+
+// NutritionGoal is the user's direction plus optional manual overrides of the derived targets.
+type NutritionGoal struct {
+	ID     uint      `gorm:"primaryKey"`
+	UserID uint      `gorm:"not null;uniqueIndex"`
+	User   user.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Goal   string    `gorm:"not null;default:'maintain'"`
+
+	Kcal    *int
+	Protein *int
+	Carbs   *int
+	Fat     *int
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Apply replaces derived values with the user's overrides.
+func (g *NutritionGoal) Apply(t Targets) Targets {
+	if g == nil {
+		return t
+	}
+	if g.Kcal != nil {
+		t.Kcal = *g.Kcal
+	}
+	if g.Protein != nil {
+		t.Protein = *g.Protein
+	}
+	if g.Carbs != nil {
+		t.Carbs = *g.Carbs
+	}
+	if g.Fat != nil {
+		t.Fat = *g.Fat
+	}
+	return t
+}
+
+// DeriveTargets estimates daily targets from the profile using the Mifflin-St Jeor
+// equation. It reports false when the profile lacks the numbers to do so.
+func DeriveTargets(p *user.Profile, goal string) (Targets, bool) {
+	if p == nil || p.Weight <= 0 || p.Height <= 0 || p.Age <= 0 {
+		return Targets{}, false
+	}
+	kg := float64(p.Weight) / 1000
+	cm := float64(p.Height) / 10
+
+	bmr := 10*kg + 6.25*cm - 5*float64(p.Age)
+	switch p.Sex {
+	case user.SexMale:
+		bmr += 5
+	case user.SexFemale:
+		bmr -= 161
+	default:
+		bmr -= 78
+	}
+
+	kcal := bmr * baselineActivity
+	switch goal {
+	case GoalLose:
+		// Never suggest eating below the resting rate.
+		kcal = math.Max(kcal-loseDeficit, bmr)
+	case GoalGain:
+		kcal += gainSurplus
+	}
+
+	protein := proteinPerKg * kg
+	fat := kcal * fatShare / 9
+	carbs := math.Max((kcal-protein*4-fat*9)/4, 0)
+
+	return Targets{
+		Kcal:    int(math.Round(kcal)),
+		Protein: int(math.Round(protein)),
+		Carbs:   int(math.Round(carbs)),
+		Fat:     int(math.Round(fat)),
+	}, true
+}
@@ -0,0 +1,45 @@
+package nutrition
+
+import (
+	"testing"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+)
+
+func TestDeriveTargets(t *testing.T) {
+	male := &user.Profile{Age: 30, Height: 1800, Weight: 80000, Sex: user.SexMale}
+	female := &user.Profile{Age: 25, Height: 1650, Weight: 60000, Sex: user.SexFemale}
+
+	tests := []struct {
+		name    string
+		profile *user.Profile
+		goal    string
+		want    Targets
+		ok      bool
+	}{
+		{name: "maintain", profile: male, goal: GoalMaintain, want: Targets{Kcal: 2136, Protein: 144, Carbs: 257, Fat: 59}, ok: true},
+		{name: "deficit floored at resting rate", profile: male, goal: GoalLose, want: Targets{Kcal: 1780, Protein: 144, Carbs: 190, Fat: 49}, ok: true},
+		{name: "surplus", profile: female, goal: GoalGain, want: Targets{Kcal: 1914, Protein: 108, Carbs: 251, Fat: 53}, ok: true},
+		{name: "incomplete profile", profile: &user.Profile{Age: 30, Weight: 80000}, goal: GoalMaintain},
+		{name: "no profile", goal: GoalMaintain},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DeriveTargets(tt.profile, tt.goal)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("DeriveTargets() = %+v, %v; want %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestNutritionGoalApply(t *testing.T) {
+	kcal := 2500
+	g := &NutritionGoal{Kcal: &kcal}
+	got := g.Apply(Targets{Kcal: 2000, Protein: 150, Carbs: 200, Fat: 60})
+	want := Targets{Kcal: 2500, Protein: 150, Carbs: 200, Fat: 60}
+	if got != want {
+		t.Errorf("Apply() = %+v, want %+v", got, want)
+	}
+}
@@ -6,8 +6,27 @@ const (
 	RoleMember     = "member"
 	RoleVerified   = "verified"
 	RoleRestricted = "restricted"
+	RoleCoach      = "coach"
 )
 
+// BuiltinRoles are seeded on startup and cannot be renamed or deleted through the API.
+// Their permissions can be edited, except admin's; see IsProtectedRole.
+var BuiltinRoles = []string{RoleAdmin, RoleTester, RoleMember, RoleVerified, RoleRestricted, RoleCoach}
+
+func IsBuiltinRole(name string) bool {
+	for _, r := range BuiltinRoles {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsProtectedRole reports roles whose permissions are fixed, so admins cannot lock themselves out.
+func IsProtectedRole(name string) bool {
+	return name == RoleAdmin
+}
+
 type Role struct {
 	ID          uint         `gorm:"primaryKey"`
 	Name        string       `gorm:"not null;uniqueIndex:idx_role_name"`
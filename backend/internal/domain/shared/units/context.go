@@ -0,0 +1,23 @@
+package units
+
+import "context"
+
+type ctxKey struct{}
+
+// WithResolver stores how to find the request's unit system. Resolution is lazy
+// because the user's own preference is only known once they are authenticated.
+func WithResolver(ctx context.Context, resolve func() System) context.Context {
+	return context.WithValue(ctx, ctxKey{}, resolve)
+}
+
+// FromContext returns the unit system for the request, Canonical when none was asked for.
+func FromContext(ctx context.Context) System {
+	resolve, ok := ctx.Value(ctxKey{}).(func() System)
+	if !ok || resolve == nil {
+		return Canonical
+	}
+	if s := resolve(); s != "" {
+		return s
+	}
+	return Canonical
+}
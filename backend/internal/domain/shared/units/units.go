@@ -0,0 +1,115 @@
+// Package units converts the canonical storage units (grams, millimetres) to and
+// from what people read: kilograms and centimetres, or pounds and feet/inches.
+package units
+
+import "math"
+
+const (
+	GramsPerPound    = 453.59237
+	MillimetresPerIn = 25.4
+	InchesPerFoot    = 12
+)
+
+// System is how quantities are presented to a client.
+type System string
+
+const (
+	// Canonical is the storage representation: integer grams and millimetres.
+	Canonical System = "canonical"
+	Metric    System = "metric"
+	Imperial  System = "imperial"
+)
+
+// Parse accepts the values used by UserSettings.UnitSystem and the Accept-Units header.
+func Parse(s string) (System, bool) {
+	switch System(s) {
+	case Canonical, Metric, Imperial:
+		return System(s), true
+	}
+	return "", false
+}
+
+func (s System) IsDisplay() bool { return s == Metric || s == Imperial }
+
+// Mass is a weight in grams.
+type Mass int
+
+func Kilograms(kg float64) Mass { return Mass(math.Round(kg * 1000)) }
+func Pounds(lb float64) Mass    { return Mass(math.Round(lb * GramsPerPound)) }
+
+func (m Mass) Grams() int         { return int(m) }
+func (m Mass) Kilograms() float64 { return float64(m) / 1000 }
+func (m Mass) Pounds() float64    { return float64(m) / GramsPerPound }
+
+// In returns the mass in the system's display unit, rounded to two decimals.
+// Canonical returns grams.
+func (m Mass) In(s System) float64 {
+	switch s {
+	case Metric:
+		return Round(m.Kilograms(), 2)
+	case Imperial:
+		return Round(m.Pounds(), 2)
+	}
+	return float64(m)
+}
+
+// MassIn reads a value expressed in the system's display unit.
+func MassIn(s System, v float64) Mass {
+	switch s {
+	case Metric:
+		return Kilograms(v)
+	case Imperial:
+		return Pounds(v)
+	}
+	return Mass(math.Round(v))
+}
+
+// Length is a distance in millimetres.
+type Length int
+
+func Centimetres(cm float64) Length { return Length(math.Round(cm * 10)) }
+func Inches(in float64) Length      { return Length(math.Round(in * MillimetresPerIn)) }
+
+// FeetInches builds a length from the usual 5'8" notation.
+func FeetInches(ft, in float64) Length { return Inches(ft*InchesPerFoot + in) }
+
+func (l Length) Millimetres() int     { return int(l) }
+func (l Length) Centimetres() float64 { return float64(l) / 10 }
+func (l Length) Inches() float64      { return float64(l) / MillimetresPerIn }
+func (l Length) Feet() float64        { return l.Inches() / InchesPerFoot }
+
+// In returns the length as centimetres (one decimal) for Metric or decimal feet
+// (two decimals) for Imperial. Canonical returns millimetres.
+func (l Length) In(s System) float64 {
+	switch s {
+	case Metric:
+		return Round(l.Centimetres(), 1)
+	case Imperial:
+		return Round(l.Feet(), 2)
+	}
+	return float64(l)
+}
+
+// LengthIn reads a value expressed in centimetres for Metric or inches for Imperial.
+func LengthIn(s System, v float64) Length {
+	switch s {
+	case Metric:
+		return Centimetres(v)
+	case Imperial:
+		return Inches(v)
+	}
+	return Length(math.Round(v))
+}
+
+// SplitFeetInches returns whole feet and the remaining inches rounded to one decimal.
+func (l Length) SplitFeetInches() (int, float64) {
+	in := Round(l.Inches(), 1)
+	ft := int(in / InchesPerFoot)
+	return ft, Round(in-float64(ft*InchesPerFoot), 1)
+}
+
+// Round rounds v to the given number of decimal places.
+func Round(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,50 @@
+package units
+
+import "testing"
+
+func TestMassRoundTrip(t *testing.T) {
+	tests := []struct {
+		system System
+		grams  Mass
+		want   float64
+	}{
+		{Canonical, 60000, 60000},
+		{Metric, 62500, 62.5},
+		{Metric, 101250, 101.25},
+		{Imperial, Pounds(225), 225},
+		{Imperial, 100000, 220.46},
+	}
+	for _, tt := range tests {
+		got := tt.grams.In(tt.system)
+		if got != tt.want {
+			t.Errorf("%s: In(%d) = %v, want %v", tt.system, tt.grams, got, tt.want)
+		}
+		if back := MassIn(tt.system, got); abs(int(back-tt.grams)) > 5 {
+			t.Errorf("%s: MassIn(%v) = %d, want ~%d", tt.system, got, back, tt.grams)
+		}
+	}
+}
+
+func TestLength(t *testing.T) {
+	if got := Length(1720).In(Metric); got != 172 {
+		t.Errorf("metric height = %v, want 172", got)
+	}
+
+	ft, in := FeetInches(5, 8).SplitFeetInches()
+	if ft != 5 || in != 8 {
+		t.Errorf("5'8\" = %d'%v\", want 5'8\"", ft, in)
+	}
+
+	// 1828 mm is 71.97 in, which must not render as 5'12".
+	ft, in = Length(1828).SplitFeetInches()
+	if ft != 6 || in != 0 {
+		t.Errorf("1828mm = %d'%v\", want 6'0\"", ft, in)
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,73 @@
+package social
+
+import (
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+)
+
+const (
+	ActivityWorkoutCompleted = "workout_completed"
+	ActivityPersonalRecord   = "personal_record"
+)
+
+var ReactionKinds = []string{"like", "fire", "strong", "clap"}
+
+// Activity is something a user did that shows up in their followers' feeds.
+// Visibility is captured from the actor's settings when the activity is created; reads also
+// apply the actor's current setting, so it can only narrow what is shown.
+type Activity struct {
+	ID uint `gorm:"primaryKey"`
+
+	ActorID uint      `gorm:"not null;index"`
+	Actor   user.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	Type       string `gorm:"not null"`
+	WorkoutID  *uint  `gorm:"index"`
+	Title      string
+	Payload    string `gorm:"type:jsonb;default:'{}'"`
+	Visibility string `gorm:"not null;default:'followers'"`
+
+	CreatedAt time.Time `gorm:"index"`
+}
+
+// FeedEntry is a fanned-out copy of an activity in one user's feed.
+type FeedEntry struct {
+	UserID     uint     `gorm:"primaryKey"`
+	ActivityID uint     `gorm:"primaryKey;index"`
+	Activity   Activity `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	CreatedAt time.Time
+}
+
+type ActivityReaction struct {
+	ActivityID uint      `gorm:"primaryKey"`
+	Activity   Activity  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	UserID     uint      `gorm:"primaryKey;index"`
+	User       user.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	Kind string `gorm:"not null"`
+
+	CreatedAt time.Time
+}
+
+type ActivityComment struct {
+	ID uint `gorm:"primaryKey"`
+
+	ActivityID uint      `gorm:"not null;index"`
+	Activity   Activity  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	AuthorID   uint      `gorm:"not null;index"`
+	Author     user.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	Body string `gorm:"type:text;not null"`
+
+	CreatedAt time.Time
+}
+
+// FeedItem is an activity decorated with reaction and comment counts for a viewer.
+type FeedItem struct {
+	Activity     *Activity
+	Reactions    map[string]int64
+	MyReaction   string
+	CommentCount int64
+}
@@ -0,0 +1,20 @@
+package social
+
+import (
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+)
+
+// Follow is a directed edge in the follow graph: FollowerID sees FolloweeID's activity.
+// Following a user who is not public makes a pending request the followee has to accept.
+type Follow struct {
+	FollowerID uint      `gorm:"primaryKey"`
+	Follower   user.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	FolloweeID uint      `gorm:"primaryKey;index"`
+	Followee   user.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	Pending bool `gorm:"not null;default:false"`
+
+	CreatedAt time.Time
+}
@@ -0,0 +1,56 @@
+package social
+
+import (
+	"context"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+)
+
+type FollowRepository interface {
+	Create(ctx context.Context, f *Follow) error
+	Get(ctx context.Context, followerID, followeeID uint) (*Follow, error)
+	Delete(ctx context.Context, followerID, followeeID uint) error
+	// Accept turns a pending request into a follow; ErrNotFound if there is no pending request.
+	Accept(ctx context.Context, followerID, followeeID uint) error
+	// Exists reports an accepted follow; pending requests do not count.
+	Exists(ctx context.Context, followerID, followeeID uint) (bool, error)
+	GetFollowers(ctx context.Context, userID uint, page, pageSize int64) ([]*user.User, int64, error)
+	GetFollowing(ctx context.Context, userID uint, page, pageSize int64) ([]*user.User, int64, error)
+	// GetRequests lists the users waiting for userID to accept their follow.
+	GetRequests(ctx context.Context, userID uint, page, pageSize int64) ([]*user.User, int64, error)
+}
+
+type ActivityRepository interface {
+	Create(ctx context.Context, a *Activity) error
+	GetByID(ctx context.Context, id uint) (*Activity, error)
+	// FanOut copies the activity into the feeds of the actor and all of their accepted followers.
+	FanOut(ctx context.Context, a *Activity) error
+	// GetFeed returns the user's feed newest first; beforeID > 0 pages past that activity.
+	// Entries are filtered against the actors' current visibility and follows, not just the fan-out.
+	GetFeed(ctx context.Context, userID, beforeID uint, limit int) ([]*Activity, error)
+	GetByActor(ctx context.Context, actorID uint, visibilities []string, beforeID uint, limit int) ([]*Activity, error)
+	ExistsForWorkout(ctx context.Context, actorID, workoutID uint, activityType string) (bool, error)
+
+	UpsertReaction(ctx context.Context, r *ActivityReaction) error
+	DeleteReaction(ctx context.Context, activityID, userID uint) error
+	ReactionCounts(ctx context.Context, activityIDs []uint) (map[uint]map[string]int64, error)
+	UserReactions(ctx context.Context, userID uint, activityIDs []uint) (map[uint]string, error)
+
+	CreateComment(ctx context.Context, c *ActivityComment) error
+	GetCommentByID(ctx context.Context, id uint) (*ActivityComment, error)
+	GetComments(ctx context.Context, activityID uint) ([]*ActivityComment, error)
+	CommentCounts(ctx context.Context, activityIDs []uint) (map[uint]int64, error)
+	DeleteComment(ctx context.Context, id uint) error
+}
+
+type WorkoutShareRepository interface {
+	Create(ctx context.Context, s *WorkoutShare) error
+	GetByToken(ctx context.Context, token string) (*WorkoutShare, error)
+	GetActiveByWorkoutID(ctx context.Context, userID, workoutID uint) (*WorkoutShare, error)
+	GetByUserID(ctx context.Context, userID uint) ([]*WorkoutShare, error)
+	Revoke(ctx context.Context, userID, id uint, at time.Time) error
+	// GetWorkout loads the shared workout with exercises and sets, without an owner check.
+	GetWorkout(ctx context.Context, workoutID uint) (*workout.Workout, error)
+}
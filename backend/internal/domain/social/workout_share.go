@@ -0,0 +1,28 @@
+package social
+
+import (
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+)
+
+// WorkoutShare is a public read-only link to a completed workout. Only the token is needed
+// to view it; the owner's AllowWorkoutSharing setting is re-checked on every read.
+type WorkoutShare struct {
+	ID uint `gorm:"primaryKey"`
+
+	WorkoutID uint            `gorm:"not null;index"`
+	Workout   workout.Workout `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	UserID    uint            `gorm:"not null;index"`
+	User      user.User       `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	Token string `gorm:"not null;uniqueIndex"`
+
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+func (s *WorkoutShare) IsActive() bool {
+	return s.RevokedAt == nil
+}
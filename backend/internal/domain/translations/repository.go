@@ -6,6 +6,7 @@ import (
 
 type TranslationRepository interface {
 	Create(ctx context.Context, translation *Translation) error
+	Upsert(ctx context.Context, translation *Translation) error
 	GetByID(ctx context.Context, id uint) (*Translation, error)
 	Update(ctx context.Context, id uint, updates map[string]any) error
 	Delete(ctx context.Context, id uint) error
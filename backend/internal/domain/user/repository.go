@@ -15,6 +15,7 @@ type UserRepository interface {
 	Delete(ctx context.Context, id uint) error
 	CheckEmail(ctx context.Context, email string) (bool, error)
 	GetUsers(ctx context.Context, q string, page, pageSize int64, sortBy, sortDir string) ([]*User, int64, error)
+	GetByRole(ctx context.Context, roleName string, page, pageSize int64) ([]*User, int64, error)
 }
 
 type ProfileRepository interface {
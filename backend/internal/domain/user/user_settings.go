@@ -1,5 +1,12 @@
 package user
 
+// Activity visibility levels control who sees a user's feed activity.
+const (
+	VisibilityPrivate   = "private"
+	VisibilityFollowers = "followers"
+	VisibilityPublic    = "public"
+)
+
 type UserSettings struct {
 	ID     uint `gorm:"primaryKey"`
 	UserID uint `gorm:"uniqueIndex;not null"`
@@ -8,4 +15,9 @@ type UserSettings struct {
 	BetaOptIn          bool   `gorm:"default:false"`
 	EmailNotifications bool   `gorm:"default:false"`
 	CalculateCalories  bool   `gorm:"default:true"`
+
+	ActivityVisibility  string `gorm:"default:'followers'"`
+	AllowWorkoutSharing bool   `gorm:"default:false"`
+
+	AIWorkoutSummaries bool `gorm:"default:true"`
 }
@@ -1,25 +1,103 @@
 package workout
 
 import (
+	"fmt"
+
 	"github.com/gosimple/slug"
 	"gorm.io/gorm"
 )
 
+const (
+	EquipmentBarbell      = "barbell"
+	EquipmentDumbbell     = "dumbbell"
+	EquipmentEZBar        = "ez_bar"
+	EquipmentKettlebell   = "kettlebell"
+	EquipmentCable        = "cable"
+	EquipmentMachine      = "machine"
+	EquipmentSmithMachine = "smith_machine"
+	EquipmentBand         = "band"
+	EquipmentBodyweight   = "bodyweight"
+	EquipmentOther        = "other"
+)
+
+var Equipment = []string{
+	EquipmentBarbell, EquipmentDumbbell, EquipmentEZBar, EquipmentKettlebell, EquipmentCable,
+	EquipmentMachine, EquipmentSmithMachine, EquipmentBand, EquipmentBodyweight, EquipmentOther,
+}
+
+const (
+	DifficultyBeginner     = "beginner"
+	DifficultyIntermediate = "intermediate"
+	DifficultyAdvanced     = "advanced"
+)
+
+const (
+	MovementPush      = "push"
+	MovementPull      = "pull"
+	MovementHinge     = "hinge"
+	MovementSquat     = "squat"
+	MovementLunge     = "lunge"
+	MovementCarry     = "carry"
+	MovementCore      = "core"
+	MovementIsolation = "isolation"
+)
+
+const (
+	MediaImage = "image"
+	MediaVideo = "video"
+)
+
 type Exercise struct {
 	ID           uint   `gorm:"primaryKey"`
 	Name         string `gorm:"uniqueIndex;not null"`
 	IsBodyweight bool   `gorm:"default:false"`
 	IsTimeBased  bool   `gorm:"default:false"`
 
-	MuscleGroupID *uint
-	MuscleGroup   *MuscleGroup `gorm:"foreignKey:MuscleGroupID;constraint:OnDelete:SET NULL,OnUpdate:CASCADE;"`
+	// MuscleGroup is the primary target; SecondaryMuscleGroups are worked
+	// as synergists and are only used for catalog browsing and search.
+	MuscleGroupID         *uint
+	MuscleGroup           *MuscleGroup  `gorm:"foreignKey:MuscleGroupID;constraint:OnDelete:SET NULL,OnUpdate:CASCADE;"`
+	SecondaryMuscleGroups []MuscleGroup `gorm:"many2many:exercise_secondary_muscle_groups;constraint:OnDelete:CASCADE;"`
+
+	Equipment       string `gorm:"not null;default:'other'"`
+	Difficulty      string `gorm:"not null;default:'beginner'"`
+	MovementPattern string `gorm:"not null;default:'isolation'"`
+
+	Media []ExerciseMedia `gorm:"foreignKey:ExerciseID;constraint:OnDelete:CASCADE;"`
 
 	Slug string `gorm:"uniqueIndex;not null"`
 }
 
+// ExerciseMedia is an image or video demonstrating an exercise, shown in
+// ascending Position order.
+type ExerciseMedia struct {
+	ID         uint   `gorm:"primaryKey"`
+	ExerciseID uint   `gorm:"not null;index"`
+	Kind       string `gorm:"not null"`
+	URL        string `gorm:"not null"`
+	Position   int    `gorm:"not null;default:0"`
+}
+
+// ExerciseFilter narrows an exercise search. Query matches the English name
+// and every translated "exercise.<slug>" value; empty fields are ignored.
+type ExerciseFilter struct {
+	Query         string
+	MuscleGroupID *uint
+	MuscleGroup   string // primary muscle group name or slug
+	Equipment     []string
+	IsBodyweight  *bool
+	IsTimeBased   *bool
+}
+
 func (e *Exercise) BeforeCreate(tx *gorm.DB) (err error) {
 	if e.Slug == "" {
 		e.Slug = slug.Make(e.Name)
 	}
 	return nil
 }
+
+// InstructionsKey is the translation key holding the how-to text for the
+// exercise, next to the "exercise.<slug>" key used for its name.
+func (e *Exercise) InstructionsKey() string {
+	return fmt.Sprintf("exercise_instructions.%s", e.Slug)
+}
@@ -63,6 +63,9 @@ type WorkoutExerciseRepository interface {
 	GetSkippedExercisesCount(ctx context.Context, userId, planId, cycleId, workoutId uint) (int64, error)
 	GetBestPerformanceByIndividualExerciseIDs(ctx context.Context, userId uint, individualExerciseIDs []uint) (map[uint]*ExercisePerformance, error)
 	GetSessionPerformancesByIndividualExerciseID(ctx context.Context, userId, individualExerciseID uint) ([]*ExercisePerformance, error)
+	// GetCompletedSessionPerformances returns the top completed set of each session in a completed
+	// workout other than excludeWorkoutID, with CompletedAt set to when that workout was completed.
+	GetCompletedSessionPerformances(ctx context.Context, userId, individualExerciseID, excludeWorkoutID uint) ([]*ExercisePerformance, error)
 	GetMaxIndexByWorkoutID(ctx context.Context, userId, planId, cycleId, workoutId uint) (int, error)
 	DecrementIndexesAfter(ctx context.Context, userId, planId, cycleId, workoutId uint, deletedIndex int) error
 	IncrementIndexesAfter(ctx context.Context, userId, planId, cycleId, workoutId uint, index int) error
@@ -106,12 +109,17 @@ type ExerciseRepository interface {
 	Update(ctx context.Context, id uint, updates map[string]any) error
 	UpdateReturning(ctx context.Context, id uint, updates map[string]any) (*Exercise, error)
 	Delete(ctx context.Context, id uint) error
-	GetExerciseNamesByMuscleName(ctx context.Context, muscleName string, limit, offset int) ([]*Exercise, error)
+	Search(ctx context.Context, f ExerciseFilter, page, pageSize int64) ([]*Exercise, int64, error)
+	FindSubstitutes(ctx context.Context, e *Exercise, equipment []string, limit int) ([]*Exercise, error)
+	ReplaceSecondaryMuscleGroups(ctx context.Context, id uint, muscleGroupIDs []uint) error
+	ReplaceMedia(ctx context.Context, id uint, media []*ExerciseMedia) error
 }
 
 type MuscleGroupRepository interface {
 	Create(ctx context.Context, mg *MuscleGroup) error
 	GetByID(ctx context.Context, id uint) (*MuscleGroup, error)
+	// CountByIDs counts how many of the given distinct IDs exist.
+	CountByIDs(ctx context.Context, ids []uint) (int64, error)
 	GetByName(ctx context.Context, name string) (*MuscleGroup, error)
 	GetAll(ctx context.Context) ([]*MuscleGroup, error)
 	Update(ctx context.Context, id uint, updates map[string]any) error
@@ -0,0 +1,98 @@
+package workout
+
+import "time"
+
+// Trends of an exercise against its earlier sessions.
+const (
+	TrendNew  = "new"  // no earlier session to compare with
+	TrendPR   = "pr"   // top set beats every earlier set
+	TrendUp   = "up"   // top set beats the previous session's
+	TrendSame = "same" // top set matches the previous session's
+	TrendDown = "down" // top set falls short of the previous session's
+)
+
+// ExerciseReview compares an exercise of a completed workout with the session before it,
+// found through PreviousExerciseID, and with the best set done before.
+type ExerciseReview struct {
+	WorkoutExerciseID uint
+	Name              string
+	IsBodyweight      bool
+	IsTimeBased       bool
+
+	Sets         []*WorkoutSet // completed sets of this session
+	PreviousSets []*WorkoutSet // completed sets of the previous session, if any
+	BestBefore   *ExercisePerformance
+}
+
+// Trend rates the session's top set. Sets are ranked like the best-performance queries,
+// by weight times reps and then weight, with reps last so bodyweight sets compare too.
+func (r *ExerciseReview) Trend() string {
+	top := TopSet(r.Sets)
+	if top == nil {
+		return TrendNew
+	}
+	if r.BestBefore != nil && r.BestBefore.Weight != nil && r.BestBefore.Reps != nil &&
+		compareSets(*top.Weight, *top.Reps, *r.BestBefore.Weight, *r.BestBefore.Reps) > 0 {
+		return TrendPR
+	}
+
+	prev := TopSet(r.PreviousSets)
+	if prev == nil {
+		return TrendNew
+	}
+	switch c := compareSets(*top.Weight, *top.Reps, *prev.Weight, *prev.Reps); {
+	case c > 0:
+		return TrendUp
+	case c < 0:
+		return TrendDown
+	}
+	return TrendSame
+}
+
+// TopSet returns the best completed set with both weight and reps, or nil.
+func TopSet(sets []*WorkoutSet) *WorkoutSet {
+	var top *WorkoutSet
+	for _, s := range sets {
+		if s == nil || !s.Completed || s.Weight == nil || s.Reps == nil {
+			continue
+		}
+		if top == nil || compareSets(*s.Weight, *s.Reps, *top.Weight, *top.Reps) > 0 {
+			top = s
+		}
+	}
+	return top
+}
+
+// BestBefore returns the best of the session performances completed before t, or nil.
+func BestBefore(perfs []*ExercisePerformance, t time.Time) *ExercisePerformance {
+	var best *ExercisePerformance
+	for _, p := range perfs {
+		if p == nil || p.Weight == nil || p.Reps == nil || p.CompletedAt == nil || !p.CompletedAt.Before(t) {
+			continue
+		}
+		if best == nil || compareSets(*p.Weight, *p.Reps, *best.Weight, *best.Reps) > 0 {
+			best = p
+		}
+	}
+	return best
+}
+
+func compareSets(weightA, repsA, weightB, repsB int) int {
+	if a, b := weightA*repsA, weightB*repsB; a != b {
+		if a > b {
+			return 1
+		}
+		return -1
+	}
+	switch {
+	case weightA > weightB:
+		return 1
+	case weightA < weightB:
+		return -1
+	case repsA > repsB:
+		return 1
+	case repsA < repsB:
+		return -1
+	}
+	return 0
+}
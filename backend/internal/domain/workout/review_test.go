@@ -0,0 +1,67 @@
+package workout
+
+import (
+	"testing"
+	"time"
+)
+
+func set(weight, reps int) *WorkoutSet {
+	return &WorkoutSet{Completed: true, Weight: &weight, Reps: &reps}
+}
+
+func perf(at time.Time, weight, reps int) *ExercisePerformance {
+	return &ExercisePerformance{CompletedAt: &at, Weight: &weight, Reps: &reps}
+}
+
+func TestExerciseReviewTrend(t *testing.T) {
+	tests := []struct {
+		name   string
+		review ExerciseReview
+		want   string
+	}{
+		{"first session", ExerciseReview{Sets: []*WorkoutSet{set(60000, 8)}}, TrendNew},
+		{"pr", ExerciseReview{
+			Sets:         []*WorkoutSet{set(80000, 6), set(82500, 5)},
+			PreviousSets: []*WorkoutSet{set(80000, 5)},
+			BestBefore:   &ExercisePerformance{Weight: ptr(80000), Reps: ptr(5)},
+		}, TrendPR},
+		{"up", ExerciseReview{
+			Sets:         []*WorkoutSet{set(80000, 6)},
+			PreviousSets: []*WorkoutSet{set(80000, 5)},
+			BestBefore:   &ExercisePerformance{Weight: ptr(90000), Reps: ptr(6)},
+		}, TrendUp},
+		{"down", ExerciseReview{
+			Sets:         []*WorkoutSet{set(80000, 4)},
+			PreviousSets: []*WorkoutSet{set(80000, 5)},
+			BestBefore:   &ExercisePerformance{Weight: ptr(80000), Reps: ptr(5)},
+		}, TrendDown},
+		{"bodyweight reps", ExerciseReview{
+			Sets:         []*WorkoutSet{set(0, 12)},
+			PreviousSets: []*WorkoutSet{set(0, 10)},
+			BestBefore:   &ExercisePerformance{Weight: ptr(0), Reps: ptr(12)},
+		}, TrendUp},
+	}
+	for _, tt := range tests {
+		if got := tt.review.Trend(); got != tt.want {
+			t.Errorf("%s: Trend() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBestBefore(t *testing.T) {
+	now := time.Date(2025, 3, 15, 10, 0, 0, 0, time.UTC)
+	perfs := []*ExercisePerformance{
+		perf(now.AddDate(0, 0, -14), 70000, 8),
+		perf(now.AddDate(0, 0, -7), 75000, 8),
+		perf(now, 90000, 8),
+	}
+	got := BestBefore(perfs, now)
+	if got == nil || *got.Weight != 75000 {
+		t.Fatalf("BestBefore() = %+v, want the 75 kg session", got)
+	}
+	if BestBefore(perfs, now.AddDate(0, 0, -30)) != nil {
+		t.Errorf("BestBefore() with no earlier session should be nil")
+	}
+}
+
+func ptr(n int) *int { return &n }
@@ -18,6 +18,9 @@ type Workout struct {
 	Skipped           bool               `gorm:"default:false"`
 	PreviousWorkoutID *uint              `gorm:"index"`
 
+	// CompletedAt is when the workout was last marked completed; nil before it ever was.
+	CompletedAt *time.Time
+
 	CreatedAt *time.Time
 	UpdatedAt *time.Time
 
@@ -25,6 +28,10 @@ type Workout struct {
 	EstimatedActiveMin float64 `gorm:"default:0"` // in minutes
 	EstimatedRestMin  float64  `gorm:"default:0"` // in minutes
 
+	// AISummary reviews the session once it is completed, unless the user opted out.
+	AISummary   string `gorm:"type:text;not null;default:''"`
+	AISummaryAt *time.Time
+
 	domainevt.EventsMixin `gorm:"-"`
 }
 
@@ -33,6 +40,7 @@ func (w *Workout) Complete(now time.Time, userId uint) {
 	if first {
 		w.Completed = true
 		w.Skipped = false
+		w.CompletedAt = &now
 	}
 	w.Raise(WorkoutCompleted{EventID: uuid.NewString(), UserID: userId, WorkoutID: w.ID, At: now, First: first})
 }
@@ -11,6 +11,8 @@ type WorkoutPlan struct {
 	ID     uint   `gorm:"primaryKey"`
 	Name   string `gorm:"not null"`
 	Active bool   `gorm:"default:false"`
+	// Draft plans were generated for the user and await their accept or discard.
+	Draft bool `gorm:"default:false"`
 
 	UserID uint      `gorm:"not null;index"` 
 	User   user.User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
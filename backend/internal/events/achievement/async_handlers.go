@@ -0,0 +1,43 @@
+package achievement
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+	"github.com/lordmitrii/golang-web-gin/internal/events/idem"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/eventbus"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type Handlers struct {
+	db          *gorm.DB
+	achievement usecase.AchievementService
+}
+
+func New(db *gorm.DB, achievement usecase.AchievementService) *Handlers {
+	return &Handlers{db: db, achievement: achievement}
+}
+
+func (h *Handlers) Register(bus eventbus.Bus) {
+	bus.Subscribe("WorkoutCompleted", h.onWorkoutCompleted)
+}
+
+// onWorkoutCompleted re-evaluates the user's achievements. Awards are keyed per user and rule,
+// and the handler log makes each event count once.
+func (h *Handlers) onWorkoutCompleted(ctx context.Context, e any) error {
+	ev := e.(workout.WorkoutCompleted)
+	if !ev.First {
+		return nil
+	}
+
+	return idem.TryProcess(ctx, h.db, "achievement.award", "WorkoutCompleted", ev.EventID,
+		func(ctx context.Context) error {
+			if h.achievement == nil {
+				return nil
+			}
+			_, err := h.achievement.EvaluateWorkout(ctx, ev.UserID, ev.WorkoutID)
+			return err
+		})
+}
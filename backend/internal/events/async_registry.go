@@ -4,21 +4,34 @@ import (
 	"context"
 	"gorm.io/gorm"
 
+	"github.com/lordmitrii/golang-web-gin/internal/events/achievement"
+	"github.com/lordmitrii/golang-web-gin/internal/events/challenge"
+	"github.com/lordmitrii/golang-web-gin/internal/events/goal"
+	"github.com/lordmitrii/golang-web-gin/internal/events/social"
 	"github.com/lordmitrii/golang-web-gin/internal/events/workout"
 	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/eventbus"
 	"github.com/lordmitrii/golang-web-gin/internal/usecase"
 )
 
 type Deps struct {
-	DB             *gorm.DB
-	Bus            eventbus.Bus
-	WorkoutService usecase.WorkoutService
+	DB                 *gorm.DB
+	Bus                eventbus.Bus
+	WorkoutService     usecase.WorkoutService
+	SocialService      usecase.SocialService
+	ChallengeService   usecase.ChallengeService
+	AchievementService usecase.AchievementService
+	GoalService        usecase.GoalService
+	AIService          usecase.AIService
 	// Add other concrete services only as needed per module
 }
 
 func RegisterAll(_ context.Context, d Deps) {
 	// Each domain registers itself
-	workout.New(d.DB, d.WorkoutService).Register(d.Bus)
+	workout.New(d.DB, d.WorkoutService, d.AIService).Register(d.Bus)
+	social.New(d.DB, d.SocialService).Register(d.Bus)
+	challenge.New(d.DB, d.ChallengeService).Register(d.Bus)
+	achievement.New(d.DB, d.AchievementService).Register(d.Bus)
+	goal.New(d.DB, d.GoalService).Register(d.Bus)
 
 	// userevents.New(d.DB, d.EmailSvc, d.Analytics).Register(d.Bus)
 	// rbacEvents.New(d.DB, d.AuditSvc).Register(d.Bus)
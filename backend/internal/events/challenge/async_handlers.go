@@ -0,0 +1,41 @@
+package challenge
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+	"github.com/lordmitrii/golang-web-gin/internal/events/idem"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/eventbus"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type Handlers struct {
+	db        *gorm.DB
+	challenge usecase.ChallengeService
+}
+
+func New(db *gorm.DB, challenge usecase.ChallengeService) *Handlers {
+	return &Handlers{db: db, challenge: challenge}
+}
+
+func (h *Handlers) Register(bus eventbus.Bus) {
+	bus.Subscribe("WorkoutCompleted", h.onWorkoutCompleted)
+}
+
+// onWorkoutCompleted scores the workout against the user's running challenges.
+func (h *Handlers) onWorkoutCompleted(ctx context.Context, e any) error {
+	ev := e.(workout.WorkoutCompleted)
+	if !ev.First {
+		return nil
+	}
+
+	return idem.TryProcess(ctx, h.db, "challenge.progress", "WorkoutCompleted", ev.EventID,
+		func(ctx context.Context) error {
+			if h.challenge == nil {
+				return nil
+			}
+			return h.challenge.RecordWorkout(ctx, ev.UserID, ev.WorkoutID, ev.At)
+		})
+}
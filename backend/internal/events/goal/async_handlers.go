@@ -0,0 +1,43 @@
+package goal
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+	"github.com/lordmitrii/golang-web-gin/internal/events/idem"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/eventbus"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type Handlers struct {
+	db   *gorm.DB
+	goal usecase.GoalService
+}
+
+func New(db *gorm.DB, goal usecase.GoalService) *Handlers {
+	return &Handlers{db: db, goal: goal}
+}
+
+func (h *Handlers) Register(bus eventbus.Bus) {
+	bus.Subscribe("WorkoutCompleted", h.onWorkoutCompleted)
+}
+
+// onWorkoutCompleted checks the user's active goals against the new workout. Goals are
+// only marked achieved once, so re-delivery raises no second GoalAchieved.
+func (h *Handlers) onWorkoutCompleted(ctx context.Context, e any) error {
+	ev := e.(workout.WorkoutCompleted)
+	if !ev.First {
+		return nil
+	}
+
+	return idem.TryProcess(ctx, h.db, "goal.progress", "WorkoutCompleted", ev.EventID,
+		func(ctx context.Context) error {
+			if h.goal == nil {
+				return nil
+			}
+			_, err := h.goal.EvaluateWorkout(ctx, ev.UserID, ev.WorkoutID)
+			return err
+		})
+}
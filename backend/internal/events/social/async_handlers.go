@@ -0,0 +1,42 @@
+package social
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+	"github.com/lordmitrii/golang-web-gin/internal/events/idem"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/eventbus"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type Handlers struct {
+	db     *gorm.DB
+	social usecase.SocialService
+}
+
+func New(db *gorm.DB, social usecase.SocialService) *Handlers {
+	return &Handlers{db: db, social: social}
+}
+
+func (h *Handlers) Register(bus eventbus.Bus) {
+	bus.Subscribe("WorkoutCompleted", h.onWorkoutCompleted)
+}
+
+// onWorkoutCompleted fans the workout out to followers' feeds. Re-completing a workout
+// does not post it again.
+func (h *Handlers) onWorkoutCompleted(ctx context.Context, e any) error {
+	ev := e.(workout.WorkoutCompleted)
+	if !ev.First {
+		return nil
+	}
+
+	return idem.TryProcess(ctx, h.db, "social.feed", "WorkoutCompleted", ev.EventID,
+		func(ctx context.Context) error {
+			if h.social == nil {
+				return nil
+			}
+			return h.social.PublishWorkoutCompleted(ctx, ev.UserID, ev.WorkoutID, ev.At)
+		})
+}
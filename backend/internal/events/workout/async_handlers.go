@@ -15,33 +15,34 @@ import (
 type Handlers struct {
 	db      *gorm.DB
 	workout usecase.WorkoutService
+	ai      usecase.AIService
 }
 
-func New(db *gorm.DB, workout usecase.WorkoutService) *Handlers {
-	return &Handlers{db: db, workout: workout}
+func New(db *gorm.DB, workout usecase.WorkoutService, ai usecase.AIService) *Handlers {
+	return &Handlers{db: db, workout: workout, ai: ai}
 }
 
 func (h *Handlers) Register(bus eventbus.Bus) {
 	bus.Subscribe("WorkoutCompleted", h.onWorkoutCompleted)
 }
 
+// onWorkoutCompleted writes the AI summary of the workout. Every first completion gets
+// its own, so a workout reopened and completed again is reviewed with its new sets, while
+// edits to a workout that stays completed do not pay for another summary.
 func (h *Handlers) onWorkoutCompleted(ctx context.Context, e any) error {
 	ev := e.(workout.WorkoutCompleted)
+	if !ev.First {
+		return nil
+	}
 	// key := strconv.FormatUint(uint64(ev.WorkoutID), 10)
 
 	key := ev.EventID
 
 	return idem.TryProcess(ctx, h.db, "workout.summary", "WorkoutCompleted", key,
 		func(ctx context.Context) error {
-			if h.workout == nil {
+			if h.ai == nil {
 				return nil
 			}
-			// Do something here
-			// E.g.
-			// err := h.workout.CalculateWorkoutSummary(ctx, ev.UserID, ev.WorkoutID)
-			// if err != nil {
-			// 	return err
-			// }
-			return nil
+			return h.ai.SummarizeWorkout(ctx, ev.UserID, ev.WorkoutID)
 		})
 }
@@ -0,0 +1,124 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+)
+
+// Fake is a deterministic LLM for tests and local development. It answers with the
+// queued replies in order and, once they run out, echoes the last user message.
+// Every request is recorded.
+type Fake struct {
+	mu       sync.Mutex
+	replies  []ai.Message
+	requests []ai.Request
+}
+
+func NewFake(replies ...ai.Message) *Fake {
+	return &Fake{replies: replies}
+}
+
+// LoadFixture reads replies recorded as a JSON array of messages, in the order they are
+// to be returned. Tool call arguments are JSON objects:
+//
+//	[{"tool_calls": [{"id": "call_1", "name": "get_best_sets", "arguments": {}}]},
+//	 {"content": "Your squat is up 10 kg this month."}]
+func LoadFixture(path string) ([]ai.Message, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var replies []ai.Message
+	if err := json.Unmarshal(raw, &replies); err != nil {
+		return nil, fmt.Errorf("fake llm fixture %s: %w", path, err)
+	}
+	return replies, nil
+}
+
+// Queue appends replies to be returned by the next calls.
+func (f *Fake) Queue(replies ...ai.Message) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.replies = append(f.replies, replies...)
+}
+
+// Requests returns the requests received so far.
+func (f *Fake) Requests() []ai.Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]ai.Request(nil), f.requests...)
+}
+
+func (f *Fake) Chat(ctx context.Context, req ai.Request) (*ai.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	req.Messages = append([]ai.Message(nil), req.Messages...)
+	f.requests = append(f.requests, req)
+
+	var msg ai.Message
+	switch {
+	case len(f.replies) > 0:
+		msg = f.replies[0]
+		f.replies = f.replies[1:]
+		msg.Role = ai.RoleAssistant
+	case req.ResponseFormat != nil:
+		return nil, errors.New("fake llm: no queued reply for structured output")
+	default:
+		msg = ai.Message{Role: ai.RoleAssistant, Content: "echo: " + lastUserContent(req.Messages)}
+	}
+
+	return &ai.Response{
+		Message: msg,
+		Usage:   ai.Usage{InputTokens: countWords(req), OutputTokens: int64(len(strings.Fields(msg.Content)))},
+		Model:   ProviderFake,
+	}, nil
+}
+
+// Stream replies like Chat and hands the content over one word at a time.
+func (f *Fake) Stream(ctx context.Context, req ai.Request, onDelta func(delta string) error) (*ai.Response, error) {
+	resp, err := f.Chat(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, part := range strings.SplitAfter(resp.Message.Content, " ") {
+		if part == "" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := onDelta(part); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+func lastUserContent(msgs []ai.Message) string {
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if msgs[i].Role == ai.RoleUser {
+			return msgs[i].Content
+		}
+	}
+	return ""
+}
+
+// countWords stands in for a tokenizer so usage stays deterministic.
+func countWords(req ai.Request) int64 {
+	n := len(strings.Fields(req.Instructions))
+	for _, m := range req.Messages {
+		n += len(strings.Fields(m.Content))
+	}
+	return int64(n)
+}
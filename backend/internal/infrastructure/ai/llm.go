@@ -0,0 +1,78 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+)
+
+const (
+	ProviderOpenAI           = "openai"
+	ProviderOpenAICompatible = "openai_compatible"
+	ProviderFake             = "fake"
+)
+
+type Config struct {
+	Provider string
+	// BaseURL points an OpenAI-compatible provider at e.g. http://localhost:11434/v1 (Ollama).
+	BaseURL string
+	APIKey  string
+	Model   string
+	// Temperature is only sent when set; reasoning models reject anything but the default.
+	Temperature *float64
+	// ReasoningEffort is sent to OpenAI only.
+	ReasoningEffort string
+	Timeout         time.Duration
+	// FakeFixture is a file of replies for the fake provider to return, see LoadFixture.
+	FakeFixture string
+}
+
+// NewLLM builds the adapter selected by cfg.Provider.
+func NewLLM(cfg Config) (ai.LLM, error) {
+	switch cfg.Provider {
+	case ProviderOpenAI, "":
+		if cfg.APIKey == "" {
+			return nil, errors.New("api key not set for OpenAI")
+		}
+		return newOpenAI(cfg, false), nil
+	case ProviderOpenAICompatible:
+		if cfg.BaseURL == "" {
+			return nil, errors.New("base url not set for OpenAI-compatible provider")
+		}
+		if cfg.Model == "" {
+			return nil, errors.New("model not set for OpenAI-compatible provider")
+		}
+		return newOpenAI(cfg, true), nil
+	case ProviderFake:
+		if cfg.FakeFixture == "" {
+			return NewFake(), nil
+		}
+		replies, err := LoadFixture(cfg.FakeFixture)
+		if err != nil {
+			return nil, err
+		}
+		return NewFake(replies...), nil
+	}
+	return nil, fmt.Errorf("unknown llm provider %q", cfg.Provider)
+}
+
+// Unavailable stands in when no provider is configured, so the server still starts and
+// only the AI endpoints fail.
+type Unavailable struct {
+	err error
+}
+
+func NewUnavailable(err error) ai.LLM {
+	return &Unavailable{err: err}
+}
+
+func (u *Unavailable) Chat(ctx context.Context, req ai.Request) (*ai.Response, error) {
+	return nil, u.err
+}
+
+func (u *Unavailable) Stream(ctx context.Context, req ai.Request, onDelta func(delta string) error) (*ai.Response, error) {
+	return nil, u.err
+}
@@ -3,707 +3,208 @@ package ai
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"slices"
-	"strings"
 
 	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
-	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
 	"github.com/openai/openai-go/v3"
 	"github.com/openai/openai-go/v3/option"
-	"github.com/openai/openai-go/v3/responses"
 	"github.com/openai/openai-go/v3/shared"
 )
 
+// OpenAI talks to the Chat Completions API, which OpenAI-compatible servers such as
+// Ollama and vLLM implement as well.
 type OpenAI struct {
-	APIKey      string
-	Temperature float64
+	client      openai.Client
+	model       string
+	temperature *float64
+	effort      string
+	// compatible servers commonly only understand max_tokens.
+	compatible bool
 }
 
-func NewOpenAI(apiKey string, temperature float64) ai.OpenAI {
-	return &OpenAI{
-		APIKey:      apiKey,
-		Temperature: temperature,
+func newOpenAI(cfg Config, compatible bool) *OpenAI {
+	opts := []option.RequestOption{}
+	if cfg.APIKey != "" {
+		opts = append(opts, option.WithAPIKey(cfg.APIKey))
 	}
-}
-
-func (o *OpenAI) CallOpenAIChat(ctx context.Context, input string, previousResponseID string, maxTokens int64) (string, string, error) {
-	if o.APIKey == "" {
-		return "", "", fmt.Errorf("api key not set for OpenAI")
-	}
-
-	client := openai.NewClient(option.WithAPIKey(o.APIKey))
-
-	params := responses.ResponseNewParams{
-		Model: openai.ChatModelGPT5Nano,
-		// Temperature:     openai.Float(o.Temperature),
-		MaxOutputTokens: openai.Int(maxTokens),
-		Input: responses.ResponseNewParamsInputUnion{
-			OfString: openai.String(input),
-		},
-		Instructions: openai.String("You are a pro fitness assistant who answers user questions. Your answers should be concise, relevant, and not emotional."),
-		Store:        openai.Bool(true),
-		Reasoning: shared.ReasoningParam{
-			Effort: shared.ReasoningEffortLow,
-		},
-		Text: responses.ResponseTextConfigParam{
-			Verbosity: responses.ResponseTextConfigVerbosityMedium,
-		},
+	if cfg.BaseURL != "" {
+		opts = append(opts, option.WithBaseURL(cfg.BaseURL))
 	}
-
-	if previousResponseID != "" {
-		params.PreviousResponseID = openai.String(previousResponseID)
-	}
-
-	resp, err := client.Responses.New(ctx, params)
-	if err != nil {
-		return "", "", fmt.Errorf("failed to call OpenAI API: %w", err)
-	}
-
-	return resp.OutputText(), resp.ID, nil
-}
-
-type exerciseWithMuscleGroupDto struct {
-	// ID              uint   `json:"id"`
-	Name            string `json:"name"`
-	MuscleGroupName string `json:"muscle_group_name"`
-	Slug            string `json:"slug"`
-}
-
-func (o *OpenAI) GenerateWorkoutPlan(
-	ctx context.Context,
-	input string,
-	exercises []*workout.Exercise,
-	maxTokens int64,
-) (*workout.WorkoutPlan, error) {
-	if o.APIKey == "" {
-		return nil, fmt.Errorf("api key not set for OpenAI")
+	if cfg.Timeout > 0 {
+		opts = append(opts, option.WithRequestTimeout(cfg.Timeout))
 	}
-	client := openai.NewClient(option.WithAPIKey(o.APIKey))
 
-	exercisesClean := make([]*exerciseWithMuscleGroupDto, 0, len(exercises))
-	for _, ex := range exercises {
-		exercisesClean = append(exercisesClean, &exerciseWithMuscleGroupDto{
-			// ID:              ex.ID,
-			Name:            ex.Name,
-			MuscleGroupName: ex.MuscleGroup.Name,
-			Slug:            ex.Slug,
-		})
+	model := cfg.Model
+	if model == "" {
+		model = openai.ChatModelGPT5Nano
 	}
-
-	exerciseListBytes, err := json.MarshalIndent(exercisesClean, "", "  ")
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal exercise list: %w", err)
+	effort := cfg.ReasoningEffort
+	if compatible {
+		effort = ""
 	}
-	exerciseList := strings.Builder{}
-	exerciseList.Write(exerciseListBytes)
-
-	params := responses.ResponseNewParams{
-		Model: openai.ChatModelGPT5Nano,
-		// Temperature:     openai.Float(0.55),
-		// MaxOutputTokens: openai.Int(maxTokens),
-		Input: responses.ResponseNewParamsInputUnion{
-			OfString: openai.String(input),
-		},
-		Instructions: openai.String("You are a certified strength coach. Output ONLY JSON valid to the provided JSON Schema. " +
-			"Plan sessions ~45–75 minutes, respect user equipment and limitations, and use realistic rest_seconds and rep ranges. " +
-			"When selecting exercises, ALWAYS pick from the provided list and use Slugs for exercises. NEVER make up exercises or exercise slugs." +
-			"Exercise list: " + exerciseList.String()),
-		Reasoning: shared.ReasoningParam{
-			Effort: shared.ReasoningEffortLow,
-		},
-		Text: responses.ResponseTextConfigParam{
-			Verbosity: responses.ResponseTextConfigVerbosityMedium,
-		},
-		Store: openai.Bool(true),
+	return &OpenAI{
+		client:      openai.NewClient(opts...),
+		model:       model,
+		temperature: cfg.Temperature,
+		effort:      effort,
+		compatible:  compatible,
 	}
+}
 
-	resp, err := client.Responses.New(
-		ctx,
-		params,
-		option.WithJSONSet("text.format.type", "json_schema"),
-		option.WithJSONSet("text.format.name", "WorkoutPlan"),
-		option.WithJSONSet("text.format.schema", WorkoutPlanJSONSchema()),
-		option.WithJSONSet("text.format.strict", true),
-	)
+func (o *OpenAI) Chat(ctx context.Context, req ai.Request) (*ai.Response, error) {
+	resp, err := o.client.Chat.Completions.New(ctx, o.params(req))
 	if err != nil {
-		return nil, fmt.Errorf("openai error: %w", err)
+		return nil, fmt.Errorf("llm request failed: %w", err)
 	}
-
-	raw := resp.OutputText()
-	if raw == "" {
-		return nil, fmt.Errorf("empty model output")
+	if len(resp.Choices) == 0 {
+		return nil, errors.New("llm returned no choices")
 	}
-
-	var plan *workout.WorkoutPlan
-	if err := json.Unmarshal([]byte(raw), &plan); err != nil {
-		return nil, fmt.Errorf("invalid JSON: %w; raw=%s", err, raw)
-	}
-
-	return plan, nil
-}
-
-type exerciseWithSlugDto struct {
-	Name string `json:"name"`
-	Slug string `json:"slug"`
+	return toResponse(resp.Choices[0].Message, resp.Usage, resp.Model), nil
 }
 
-func (o *OpenAI) GenerateWorkoutPlanWithDB(
-	ctx context.Context,
-	input string,
-	maxTokens int64,
-	listMuscleGroups func(ctx context.Context, limit int) ([]string, error),
-	searchExercises func(ctx context.Context, groupQuery string, limit, offset int) ([]map[string]any, error),
-) (*workout.WorkoutPlan, error) {
-	if o.APIKey == "" {
-		return nil, fmt.Errorf("api key not set for OpenAI")
-	}
-	client := openai.NewClient(option.WithAPIKey(o.APIKey))
-
-	tools := []any{
-		map[string]any{
-			"type":        "function",
-			"name":        "list_muscle_groups",
-			"description": "Return a list of available muscle-group names.",
-			"parameters": map[string]any{
-				"type":                 "object",
-				"additionalProperties": false,
-				"properties": map[string]any{
-					"limit": map[string]any{"type": "integer", "minimum": 1, "maximum": 100},
-				},
-			},
-		},
-		map[string]any{
-			"type":        "function",
-			"name":        "search_exercises_by_muscle_group",
-			"description": "Find exercises by muscle-group name filter (e.g., 'chest'). Returns a small list.",
-			"parameters": map[string]any{
-				"type":                 "object",
-				"additionalProperties": false,
-				"required":             []any{"group_query"},
-				"properties": map[string]any{
-					"group_query": map[string]any{"type": "string"},
-					"limit":       map[string]any{"type": "integer", "minimum": 1, "maximum": 20},
-					"offset":      map[string]any{"type": "integer", "minimum": 0},
-				},
-			},
-		},
-	}
-
-	params := responses.ResponseNewParams{
-		Model: openai.ChatModelGPT5Nano,
-		// Temperature:     openai.Float(0.55),
-		// MaxOutputTokens: openai.Int(maxTokens),
-		Input: responses.ResponseNewParamsInputUnion{OfString: openai.String(input)},
-		Instructions: openai.String(
-			"You are a certified strength coach. " +
-				"You need to create a workout plan using a mix of exercises for different muscle groups (depending on user prompt) from a database. " +
-				"First, call list_muscle_groups (limit 100) to see available groups (returns { muscle_groups: string[] }). " +
-				"When selecting exercises, ALWAYS call search_exercises_by_muscle_group (limit 20) (returns { exercises: {name:string,slug:string}[] }). " +
-				"Finally, output ONLY JSON valid to the provided JSON Schema.",
-		),
-		// Reasoning: shared.ReasoningParam{
-		// 	Effort: shared.ReasoningEffortLow,
-		// },
-		// Text: responses.ResponseTextConfigParam{
-		// 	Verbosity: responses.ResponseTextConfigVerbosityMedium,
-		// },
-		Store: openai.Bool(true),
-	}
-
-	resp, err := client.Responses.New(
-		ctx, params,
-		option.WithJSONSet("tools", tools),
-		option.WithJSONSet("tool_choice", "auto"),
-		option.WithJSONSet("parallel_tool_calls", false),
-		option.WithJSONSet("text.format.type", "json_schema"),
-		option.WithJSONSet("text.format.name", "WorkoutPlan"),
-		option.WithJSONSet("text.format.schema", WorkoutPlanJSONSchema()),
-		option.WithJSONSet("text.format.strict", true),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("openai error: %w", err)
-	}
-
-	var (
-		lastMuscleGroups   []string
-		lastMuscleGroupsLC []string
-		hadSearchError     bool
-		sawValidSearch     bool
-
-		selectedGroups    = map[string]struct{}{}
-		minDistinctGroups = 4
-	)
-
-	for range 15 { // max hops
-		out, _ := extractLastAssistantJSON(resp)
-		if out != "" {
-			var plan *workout.WorkoutPlan
-			if err := json.Unmarshal([]byte(out), &plan); err != nil {
-				return nil, fmt.Errorf("invalid JSON: %w; raw=%s", err, out)
-			}
-
-			if !sawValidSearch {
-				resp, err = client.Responses.New(
-					ctx,
-					responses.ResponseNewParams{
-						Model:              openai.ChatModelGPT5Nano,
-						PreviousResponseID: openai.String(resp.ID),
-						// Reasoning: shared.ReasoningParam{
-						// 	Effort: shared.ReasoningEffortLow,
-						// },
-						// Text: responses.ResponseTextConfigParam{
-						// 	Verbosity: responses.ResponseTextConfigVerbosityLow,
-						// },
-					},
-					option.WithJSONSet("input", []any{
-						map[string]any{
-							"type": "message", "role": "system",
-							"content": "Before producing the final JSON, call search_exercises_by_muscle_group at least once.",
-						},
-					}),
-					option.WithJSONSet("tools", tools),
-					option.WithJSONSet("tool_choice", map[string]any{"type": "function", "name": "search_exercises_by_muscle_group"}),
-					option.WithJSONSet("text.format.type", "json_schema"),
-					option.WithJSONSet("text.format.name", "WorkoutPlan"),
-					option.WithJSONSet("text.format.schema", WorkoutPlanJSONSchema()),
-					option.WithJSONSet("text.format.strict", true),
-				)
-				if err != nil {
-					return nil, fmt.Errorf("openai error (retry enforce search): %w", err)
-				}
-				continue
-			}
-
-			needed := minInt(minDistinctGroups, len(lastMuscleGroups))
-			if needed == 0 {
-				resp, err = client.Responses.New(
-					ctx,
-					responses.ResponseNewParams{
-						Model:              openai.ChatModelGPT5Nano,
-						PreviousResponseID: openai.String(resp.ID),
-						// Reasoning: shared.ReasoningParam{
-						// 	Effort: shared.ReasoningEffortLow,
-						// },
-						// Text: responses.ResponseTextConfigParam{
-						// 	Verbosity: responses.ResponseTextConfigVerbosityLow,
-						// },
-					},
-					option.WithJSONSet("input", []any{
-						map[string]any{
-							"type": "message", "role": "system",
-							"content": "Call list_muscle_groups first, then iteratively search distinct groups.",
-						},
-					}),
-					option.WithJSONSet("tools", []any{tools[0]}),
-					option.WithJSONSet("tool_choice", map[string]any{"type": "function", "name": "list_muscle_groups"}),
-					option.WithJSONSet("text.format.type", "json_schema"),
-					option.WithJSONSet("text.format.name", "WorkoutPlan"),
-					option.WithJSONSet("text.format.schema", WorkoutPlanJSONSchema()),
-					option.WithJSONSet("text.format.strict", true),
-				)
-				if err != nil {
-					return nil, fmt.Errorf("openai error (enforce list first): %w", err)
-				}
-				continue
-			}
-
-			if len(selectedGroups) < needed {
-				done := make([]string, 0, len(selectedGroups))
-				doneSet := map[string]struct{}{}
-				for g := range selectedGroups {
-					done = append(done, g)
-					doneSet[g] = struct{}{}
-				}
-
-				var remaining []string
-				for _, g := range lastMuscleGroups {
-					if _, ok := doneSet[strings.ToLower(g)]; !ok {
-						remaining = append(remaining, g)
-					}
-				}
-				resp, err = client.Responses.New(
-					ctx,
-					responses.ResponseNewParams{
-						Model:              openai.ChatModelGPT5Nano,
-						PreviousResponseID: openai.String(resp.ID),
-						// Reasoning: shared.ReasoningParam{
-						// 	Effort: shared.ReasoningEffortLow,
-						// },
-						// Text: responses.ResponseTextConfigParam{
-						// 	Verbosity: responses.ResponseTextConfigVerbosityLow,
-						// },
-					},
-					option.WithJSONSet("input", []any{
-						map[string]any{
-							"type": "message", "role": "system",
-							"content": fmt.Sprintf(
-								"You have searched %d distinct muscle groups: %v. "+
-									"Search more DISTINCT groups until you reach at least %d in total. "+
-									"Pick your next group from: %v",
-								len(done), done, needed, remaining),
-						},
-					}),
-					option.WithJSONSet("tools", []any{
-						map[string]any{
-							"type":        "function",
-							"name":        "search_exercises_by_muscle_group",
-							"description": "Find exercises by muscle-group name filter (e.g., 'chest'). Returns a small list.",
-							"parameters": map[string]any{
-								"type":                 "object",
-								"additionalProperties": false,
-								"required":             []any{"group_query"},
-								"properties": map[string]any{
-									"group_query": map[string]any{"type": "string", "enum": toAnySlice(remaining)},
-									"limit":       map[string]any{"type": "integer", "minimum": 1, "maximum": 20},
-									"offset":      map[string]any{"type": "integer", "minimum": 0},
-								},
-							},
-						},
-					}),
-					option.WithJSONSet("tool_choice", map[string]any{"type": "function", "name": "search_exercises_by_muscle_group"}),
-					option.WithJSONSet("text.format.type", "json_schema"),
-					option.WithJSONSet("text.format.name", "WorkoutPlan"),
-					option.WithJSONSet("text.format.schema", WorkoutPlanJSONSchema()),
-					option.WithJSONSet("text.format.strict", true),
-				)
-				if err != nil {
-					return nil, fmt.Errorf("openai error (enforce multi-group search): %w", err)
-				}
-				continue
-			}
-
-			if len(selectedGroups) < minDistinctGroups {
-				continue
-			}
-
-			return plan, nil
-		}
-
-		var raw map[string]any
-		b, _ := json.Marshal(resp)
-		_ = json.Unmarshal(b, &raw)
-		toolCalls := collectToolCalls(raw)
-		if len(toolCalls) == 0 {
-			return nil, fmt.Errorf("no output and no tool calls in response")
-		}
-
-		var inputItems []map[string]any
-		hadList := false
-		hadSearchError = false
-
-		for _, tc := range toolCalls {
-			name := tc["name"].(string)
-			args := tc["arguments"].(map[string]any)
-			callID := firstNonEmpty(tc["call_id"], tc["id"])
-
-			switch name {
-			case "list_muscle_groups":
-				hadList = true
-				limit := intFrom(args["limit"], 100)
-				names, err := listMuscleGroups(ctx, limit)
-				if err != nil {
-					return nil, err
-				}
-				lastMuscleGroups = names
-				lastMuscleGroupsLC = toLowerSlice(names)
+func (o *OpenAI) Stream(ctx context.Context, req ai.Request, onDelta func(delta string) error) (*ai.Response, error) {
+	params := o.params(req)
+	params.StreamOptions = openai.ChatCompletionStreamOptionsParam{IncludeUsage: openai.Bool(true)}
 
-				payload := map[string]any{"muscle_groups": names}
-				inputItems = append(inputItems, map[string]any{
-					"type": "function_call_output", "call_id": callID, "output": string(mustJSON(payload)),
-				})
-				inputItems = append(inputItems, map[string]any{
-					"type": "message", "role": "system",
-					"content": fmt.Sprintf(
-						"Iteratively call search_exercises_by_muscle_group for DISTINCT muscle groups from this list. "+
-							"Gather enough exercises to assemble a complete multi-day plan. Pick one group at a time, then repeat. "+
-							"Available groups: %v", lastMuscleGroups),
-				})
-
-			case "search_exercises_by_muscle_group":
-				q := strings.TrimSpace(strings.ToLower(stringFrom(args["group_query"], "")))
-				limit := intFrom(args["limit"], 10)
-				offset := intFrom(args["offset"], 0)
-
-				if !containsLower(lastMuscleGroupsLC, q) {
-					hadSearchError = true
-					errPayload := map[string]any{
-						"error":                 "unknown_muscle_group",
-						"message":               "Pick a muscle_group from the provided list.",
-						"allowed_muscle_groups": lastMuscleGroups,
-						"received":              stringFrom(args["group_query"], ""),
-					}
-					inputItems = append(inputItems, map[string]any{
-						"type": "function_call_output", "call_id": callID, "output": string(mustJSON(errPayload)),
-					})
-					continue
-				}
-
-				rows, err := searchExercises(ctx, q, limit, offset)
-				rowsClean := make([]*exerciseWithSlugDto, 0, len(rows))
-				for _, r := range rows {
-					rowsClean = append(rowsClean, &exerciseWithSlugDto{
-						Name: r["name"].(string),
-						Slug: r["slug"].(string),
-					})
-				}
+	stream := o.client.Chat.Completions.NewStreaming(ctx, params)
+	defer stream.Close()
 
-				if err != nil {
-					return nil, err
-				}
-				sawValidSearch = true
-				selectedGroups[q] = struct{}{}
-
-				payload := map[string]any{"exercises": rowsClean}
-				inputItems = append(inputItems, map[string]any{
-					"type": "function_call_output", "call_id": callID, "output": string(mustJSON(payload)),
-				})
-
-			default:
-				// noop
-			}
-		}
-
-		followupOpts := []option.RequestOption{
-			option.WithJSONSet("input", inputItems),
-			option.WithJSONSet("text.format.type", "json_schema"),
-			option.WithJSONSet("text.format.name", "WorkoutPlan"),
-			option.WithJSONSet("text.format.schema", WorkoutPlanJSONSchema()),
-			option.WithJSONSet("text.format.strict", true),
-		}
-
-		needed := minInt(minDistinctGroups, len(lastMuscleGroups))
-		if hadSearchError && len(lastMuscleGroups) > 0 {
-			restrictedSearchTool := []any{
-				map[string]any{
-					"type":        "function",
-					"name":        "search_exercises_by_muscle_group",
-					"description": "Find exercises by muscle-group name filter (e.g., 'chest'). Returns a small list.",
-					"parameters": map[string]any{
-						"type":                 "object",
-						"additionalProperties": false,
-						"required":             []any{"group_query"},
-						"properties": map[string]any{
-							"group_query": map[string]any{"type": "string", "enum": toAnySlice(lastMuscleGroups)},
-							"limit":       map[string]any{"type": "integer", "minimum": 1, "maximum": 20},
-							"offset":      map[string]any{"type": "integer", "minimum": 0},
-						},
-					},
-				},
-			}
-			followupOpts = append(
-				followupOpts,
-				option.WithJSONSet("tools", restrictedSearchTool),
-				option.WithJSONSet("tool_choice", map[string]any{"type": "function", "name": "search_exercises_by_muscle_group"}),
-			)
-		} else if hadList && len(selectedGroups) < needed {
-			var remaining []string
-			for _, g := range lastMuscleGroups {
-				if _, ok := selectedGroups[strings.ToLower(g)]; !ok {
-					remaining = append(remaining, g)
-				}
-			}
-			restrictedSearchTool := []any{
-				map[string]any{
-					"type":        "function",
-					"name":        "search_exercises_by_muscle_group",
-					"description": "Find exercises by muscle-group name filter (e.g., 'chest'). Returns a small list.",
-					"parameters": map[string]any{
-						"type":                 "object",
-						"additionalProperties": false,
-						"required":             []any{"group_query"},
-						"properties": map[string]any{
-							"group_query": map[string]any{"type": "string", "enum": toAnySlice(remaining)},
-							"limit":       map[string]any{"type": "integer", "minimum": 1, "maximum": 20},
-							"offset":      map[string]any{"type": "integer", "minimum": 0},
-						},
-					},
-				},
+	acc := openai.ChatCompletionAccumulator{}
+	for stream.Next() {
+		chunk := stream.Current()
+		acc.AddChunk(chunk)
+		if len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Content != "" {
+			if err := onDelta(chunk.Choices[0].Delta.Content); err != nil {
+				return partialResponse(acc), err
 			}
-			followupOpts = append(
-				followupOpts,
-				option.WithJSONSet("tools", restrictedSearchTool),
-				option.WithJSONSet("tool_choice", map[string]any{"type": "function", "name": "search_exercises_by_muscle_group"}),
-			)
-		} else {
-			followupOpts = append(followupOpts, option.WithJSONSet("tool_choice", "auto"))
-		}
-
-		resp, err = client.Responses.New(
-			ctx,
-			responses.ResponseNewParams{
-				Model:              openai.ChatModelGPT5Nano,
-				PreviousResponseID: openai.String(resp.ID),
-				Reasoning: shared.ReasoningParam{
-					Effort: shared.ReasoningEffortLow,
-				},
-				Text: responses.ResponseTextConfigParam{
-					Verbosity: responses.ResponseTextConfigVerbosityLow,
-				},
-			},
-			followupOpts...,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("openai error (input): %w", err)
 		}
 	}
-
-	return nil, fmt.Errorf("too many tool-call hops without final output")
-}
-
-func toAnySlice(ss []string) []any {
-	out := make([]any, len(ss))
-	for i, s := range ss {
-		out[i] = s
+	if err := stream.Err(); err != nil {
+		return partialResponse(acc), fmt.Errorf("llm stream failed: %w", err)
 	}
-	return out
-}
-
-func toLowerSlice(ss []string) []string {
-	out := make([]string, len(ss))
-	for i, s := range ss {
-		out[i] = strings.ToLower(s)
+	if len(acc.Choices) == 0 {
+		return nil, errors.New("llm returned no choices")
 	}
-	return out
+	return toResponse(acc.Choices[0].Message, acc.Usage, acc.Model), nil
 }
 
-func containsLower(ssLC []string, sLC string) bool {
-	return slices.Contains(ssLC, sLC)
-}
-
-func minInt(a, b int) int {
-	if a < b {
-		return a
+func (o *OpenAI) params(req ai.Request) openai.ChatCompletionNewParams {
+	params := openai.ChatCompletionNewParams{
+		Model:    o.model,
+		Messages: toChatMessages(req.Instructions, req.Messages),
 	}
-	return b
-}
-
-func collectToolCalls(raw map[string]any) (calls []map[string]any) {
-	out, _ := raw["output"].([]any)
-	for _, item := range out {
-		im, _ := item.(map[string]any)
-
-		if im["type"] == "function_call" && im["name"] != nil {
-			name, _ := im["name"].(string)
-			callID, _ := im["call_id"].(string)
-			args := im["arguments"]
-
-			var argMap map[string]any
-			switch a := args.(type) {
-			case string:
-				_ = json.Unmarshal([]byte(a), &argMap)
-			case map[string]any:
-				argMap = a
-			case nil:
-				argMap = map[string]any{}
-			}
-
-			calls = append(calls, map[string]any{
-				"name":      name,
-				"id":        callID,
-				"call_id":   callID,
-				"arguments": argMap,
-			})
-			continue
-		}
-
-		if content, ok := im["content"].([]any); ok {
-			for _, p := range content {
-				pm, _ := p.(map[string]any)
-				if pm["type"] == "tool_call" {
-					body, _ := pm["tool_call"].(map[string]any)
-					if body != nil && body["name"] != nil {
-						switch a := body["arguments"].(type) {
-						case string:
-							var obj map[string]any
-							_ = json.Unmarshal([]byte(a), &obj)
-							body["arguments"] = obj
-						case nil:
-							body["arguments"] = map[string]any{}
-						}
-						calls = append(calls, body)
-					}
-				}
-			}
+	if o.temperature != nil {
+		params.Temperature = openai.Float(*o.temperature)
+	}
+	if o.effort != "" {
+		params.ReasoningEffort = shared.ReasoningEffort(o.effort)
+	}
+	if req.MaxTokens > 0 {
+		if o.compatible {
+			params.MaxTokens = openai.Int(req.MaxTokens)
+		} else {
+			params.MaxCompletionTokens = openai.Int(req.MaxTokens)
 		}
 	}
-	return
-}
 
-func intFrom(v any, def int) int {
-	switch t := v.(type) {
-	case float64:
-		return int(t)
-	case int:
-		return t
-	default:
-		return def
+	for _, t := range req.Tools {
+		params.Tools = append(params.Tools, openai.ChatCompletionFunctionTool(shared.FunctionDefinitionParam{
+			Name:        t.Name,
+			Description: openai.String(t.Description),
+			Parameters:  shared.FunctionParameters(t.Parameters),
+		}))
 	}
-}
-
-func stringFrom(v any, def string) string {
-	if s, ok := v.(string); ok {
-		return s
+	if len(req.Tools) > 0 && req.ToolChoice != "" {
+		switch req.ToolChoice {
+		case ai.ToolChoiceAuto, ai.ToolChoiceNone, ai.ToolChoiceRequired:
+			params.ToolChoice = openai.ChatCompletionToolChoiceOptionUnionParam{OfAuto: openai.String(req.ToolChoice)}
+		default:
+			params.ToolChoice = openai.ToolChoiceOptionFunctionToolChoice(openai.ChatCompletionNamedToolChoiceFunctionParam{Name: req.ToolChoice})
+		}
 	}
-	return def
-}
 
-func firstNonEmpty(vals ...any) string {
-	for _, v := range vals {
-		if s, ok := v.(string); ok && s != "" {
-			return s
+	if f := req.ResponseFormat; f != nil {
+		params.ResponseFormat = openai.ChatCompletionNewParamsResponseFormatUnion{
+			OfJSONSchema: &shared.ResponseFormatJSONSchemaParam{
+				JSONSchema: shared.ResponseFormatJSONSchemaJSONSchemaParam{
+					Name:   f.Name,
+					Schema: f.Schema,
+					Strict: openai.Bool(f.Strict),
+				},
+			},
 		}
 	}
-	return ""
-}
 
-func mustJSON(v any) []byte {
-	b, _ := json.Marshal(v)
-	return b
+	return params
 }
 
-// extractLastAssistantJSON returns the last assistant JSON text block, if any.
-func extractLastAssistantJSON(resp *responses.Response) (string, error) {
-	if resp == nil {
-		return "", fmt.Errorf("nil response")
-	}
-
-	// Shortcut: OpenAI SDK usually gives the final assistant text here
-	if txt := resp.OutputText(); txt != "" {
-		return strings.TrimSpace(txt), nil
+// partialResponse keeps the usage of a stream that failed, if it got far enough to be sent.
+func partialResponse(acc openai.ChatCompletionAccumulator) *ai.Response {
+	return &ai.Response{
+		Usage: ai.Usage{
+			InputTokens:  acc.Usage.PromptTokens,
+			OutputTokens: acc.Usage.CompletionTokens,
+		},
+		Model: acc.Model,
 	}
+}
 
-	// Fallback: walk the raw "output" array
-	var raw map[string]any
-	b, _ := json.Marshal(resp)
-	_ = json.Unmarshal(b, &raw)
-
-	outs, _ := raw["output"].([]any)
-	var last string
-	for _, it := range outs {
-		m, _ := it.(map[string]any)
-		if m["type"] != "message" {
-			continue
-		}
-
-		if role, _ := m["role"].(string); role != "assistant" {
+func toResponse(msg openai.ChatCompletionMessage, usage openai.CompletionUsage, model string) *ai.Response {
+	out := ai.Message{Role: ai.RoleAssistant, Content: msg.Content}
+	for _, tc := range msg.ToolCalls {
+		fn := tc.AsFunction()
+		if fn.Function.Name == "" {
 			continue
 		}
+		out.ToolCalls = append(out.ToolCalls, ai.ToolCall{
+			ID:        fn.ID,
+			Name:      fn.Function.Name,
+			Arguments: json.RawMessage(fn.Function.Arguments),
+		})
+	}
 
-		// Each assistant message can have several "content" parts
-		content, _ := m["content"].([]any)
-		for _, c := range content {
-			cm, _ := c.(map[string]any)
-			if cm["type"] == "output_text" || cm["type"] == "text" {
-				if s, ok := cm["text"].(string); ok {
-					last = s
-				}
+	return &ai.Response{
+		Message: out,
+		Usage: ai.Usage{
+			InputTokens:  usage.PromptTokens,
+			OutputTokens: usage.CompletionTokens,
+		},
+		Model: model,
+	}
+}
+
+func toChatMessages(instructions string, msgs []ai.Message) []openai.ChatCompletionMessageParamUnion {
+	out := make([]openai.ChatCompletionMessageParamUnion, 0, len(msgs)+1)
+	if instructions != "" {
+		out = append(out, openai.SystemMessage(instructions))
+	}
+	for _, m := range msgs {
+		switch m.Role {
+		case ai.RoleSystem:
+			out = append(out, openai.SystemMessage(m.Content))
+		case ai.RoleUser:
+			out = append(out, openai.UserMessage(m.Content))
+		case ai.RoleTool:
+			out = append(out, openai.ToolMessage(m.Content, m.ToolCallID))
+		case ai.RoleAssistant:
+			am := openai.ChatCompletionAssistantMessageParam{}
+			if m.Content != "" {
+				am.Content.OfString = openai.String(m.Content)
 			}
+			for _, tc := range m.ToolCalls {
+				am.ToolCalls = append(am.ToolCalls, openai.ChatCompletionMessageToolCallUnionParam{
+					OfFunction: &openai.ChatCompletionMessageFunctionToolCallParam{
+						ID: tc.ID,
+						Function: openai.ChatCompletionMessageFunctionToolCallFunctionParam{
+							Name:      tc.Name,
+							Arguments: string(tc.Arguments),
+						},
+					},
+				})
+			}
+			out = append(out, openai.ChatCompletionMessageParamUnion{OfAssistant: &am})
 		}
 	}
-
-	if last == "" {
-		return "", fmt.Errorf("no assistant JSON text found")
-	}
-	return strings.TrimSpace(last), nil
+	return out
 }
@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/achievement"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AchievementRepo struct {
+	db *gorm.DB
+}
+
+func NewAchievementRepo(db *gorm.DB) achievement.AchievementRepository {
+	return &AchievementRepo{db: db}
+}
+
+func (r *AchievementRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *AchievementRepo) Award(ctx context.Context, a *achievement.UserAchievement) (bool, error) {
+	res := r.dbFrom(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(a)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *AchievementRepo) GetByUserID(ctx context.Context, userID uint) ([]*achievement.UserAchievement, error) {
+	var out []*achievement.UserAchievement
+	err := r.dbFrom(ctx).
+		Where("user_id = ?", userID).
+		Order("awarded_at ASC").
+		Find(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AchievementStatsRepo aggregates over the user's plans that are not deleted. Achievements
+// already awarded are stored, so deleting an old plan only slows progress towards new ones.
+type AchievementStatsRepo struct {
+	db *gorm.DB
+}
+
+func NewAchievementStatsRepo(db *gorm.DB) achievement.StatsRepository {
+	return &AchievementStatsRepo{db: db}
+}
+
+func (r *AchievementStatsRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *AchievementStatsRepo) CompletedWorkouts(ctx context.Context, userID uint) (int64, error) {
+	var n int64
+	err := r.dbFrom(ctx).Raw(`
+		SELECT COUNT(*) FROM workouts w
+		JOIN workout_cycles wc ON wc.id = w.workout_cycle_id
+		JOIN workout_plans wp ON wp.id = wc.workout_plan_id
+		WHERE wp.user_id = ? AND wp.deleted_at IS NULL AND w.completed`, userID).Scan(&n).Error
+	return n, err
+}
+
+func (r *AchievementStatsRepo) TonnageGrams(ctx context.Context, userID uint) (int64, error) {
+	var n int64
+	err := r.dbFrom(ctx).Raw(`
+		SELECT COALESCE(SUM(GREATEST(ws.weight, 0)::bigint * GREATEST(ws.reps, 0)), 0)
+		FROM workout_sets ws
+		JOIN workout_exercises we ON we.id = ws.workout_exercise_id
+		JOIN workouts w ON w.id = we.workout_id
+		JOIN workout_cycles wc ON wc.id = w.workout_cycle_id
+		JOIN workout_plans wp ON wp.id = wc.workout_plan_id
+		WHERE wp.user_id = ? AND wp.deleted_at IS NULL AND ws.completed
+			AND ws.weight IS NOT NULL AND ws.reps IS NOT NULL`, userID).Scan(&n).Error
+	return n, err
+}
+
+// PerfectCycles counts cycles where every workout was completed and none were skipped.
+func (r *AchievementStatsRepo) PerfectCycles(ctx context.Context, userID uint) (int64, error) {
+	var n int64
+	err := r.dbFrom(ctx).Raw(`
+		SELECT COUNT(*) FROM (
+			SELECT wc.id FROM workout_cycles wc
+			JOIN workout_plans wp ON wp.id = wc.workout_plan_id
+			JOIN workouts w ON w.workout_cycle_id = wc.id
+			WHERE wp.user_id = ? AND wp.deleted_at IS NULL
+			GROUP BY wc.id
+			HAVING BOOL_AND(w.completed AND NOT w.skipped)
+		) perfect`, userID).Scan(&n).Error
+	return n, err
+}
+
+func (r *AchievementStatsRepo) CompletionTimes(ctx context.Context, userID uint) ([]time.Time, error) {
+	return completionTimes(r.dbFrom(ctx), userID)
+}
+
+func (r *AchievementStatsRepo) WeeklyTarget(ctx context.Context, userID uint) (int, error) {
+	var n int
+	err := r.dbFrom(ctx).Raw(`
+		SELECT COUNT(w.id) FROM workout_plans wp
+		JOIN workout_cycles wc ON wc.id = COALESCE(wp.current_cycle_id, (
+			SELECT c.id FROM workout_cycles c
+			WHERE c.workout_plan_id = wp.id
+			ORDER BY c.week_number ASC LIMIT 1))
+		JOIN workouts w ON w.workout_cycle_id = wc.id
+		WHERE wp.user_id = ? AND wp.active AND wp.deleted_at IS NULL`, userID).Scan(&n).Error
+	return n, err
+}
@@ -0,0 +1,242 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/social"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ActivityRepo struct {
+	db *gorm.DB
+}
+
+func NewActivityRepo(db *gorm.DB) social.ActivityRepository {
+	return &ActivityRepo{db: db}
+}
+
+func (r *ActivityRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *ActivityRepo) Create(ctx context.Context, a *social.Activity) error {
+	return r.dbFrom(ctx).Create(a).Error
+}
+
+func (r *ActivityRepo) GetByID(ctx context.Context, id uint) (*social.Activity, error) {
+	var a social.Activity
+	err := r.dbFrom(ctx).Preload("Actor").First(&a, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+// FanOut writes one feed entry per accepted follower in a single statement, plus one for the actor.
+func (r *ActivityRepo) FanOut(ctx context.Context, a *social.Activity) error {
+	return r.dbFrom(ctx).Exec(`
+		INSERT INTO feed_entries (user_id, activity_id, created_at)
+		SELECT f.follower_id, ?::bigint, ?::timestamptz FROM follows f WHERE f.followee_id = ? AND NOT f.pending
+		UNION
+		SELECT ?::bigint, ?::bigint, ?::timestamptz
+		ON CONFLICT DO NOTHING`,
+		a.ID, a.CreatedAt, a.ActorID,
+		a.ActorID, a.ID, a.CreatedAt,
+	).Error
+}
+
+// GetFeed re-checks every entry: the actor may have gone private, narrowed their visibility
+// or lost this follower since the entry was fanned out. Users without settings default to followers.
+func (r *ActivityRepo) GetFeed(ctx context.Context, userID, beforeID uint, limit int) ([]*social.Activity, error) {
+	var out []*social.Activity
+	db := r.dbFrom(ctx).Preload("Actor").
+		Joins("JOIN feed_entries fe ON fe.activity_id = activities.id").
+		Joins("LEFT JOIN user_settings us ON us.user_id = activities.actor_id").
+		Where("fe.user_id = ?", userID).
+		Where(`(activities.actor_id = ? OR (
+			activities.visibility <> ? AND COALESCE(NULLIF(us.activity_visibility, ''), ?) <> ? AND (
+				EXISTS (SELECT 1 FROM follows f WHERE f.follower_id = ? AND f.followee_id = activities.actor_id AND NOT f.pending)
+				OR (activities.visibility = ? AND us.activity_visibility = ?))))`,
+			userID,
+			user.VisibilityPrivate, user.VisibilityFollowers, user.VisibilityPrivate,
+			userID,
+			user.VisibilityPublic, user.VisibilityPublic,
+		)
+	if beforeID > 0 {
+		db = db.Where("activities.id < ?", beforeID)
+	}
+	err := db.Order("activities.id DESC").Limit(limit).Find(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *ActivityRepo) GetByActor(ctx context.Context, actorID uint, visibilities []string, beforeID uint, limit int) ([]*social.Activity, error) {
+	var out []*social.Activity
+	db := r.dbFrom(ctx).Preload("Actor").
+		Where("actor_id = ? AND visibility IN ?", actorID, visibilities)
+	if beforeID > 0 {
+		db = db.Where("id < ?", beforeID)
+	}
+	err := db.Order("id DESC").Limit(limit).Find(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *ActivityRepo) ExistsForWorkout(ctx context.Context, actorID, workoutID uint, activityType string) (bool, error) {
+	var n int64
+	err := r.dbFrom(ctx).Model(&social.Activity{}).
+		Where("actor_id = ? AND workout_id = ? AND type = ?", actorID, workoutID, activityType).
+		Count(&n).Error
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *ActivityRepo) UpsertReaction(ctx context.Context, re *social.ActivityReaction) error {
+	return r.dbFrom(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "activity_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"kind"}),
+	}).Create(re).Error
+}
+
+func (r *ActivityRepo) DeleteReaction(ctx context.Context, activityID, userID uint) error {
+	res := r.dbFrom(ctx).
+		Where("activity_id = ? AND user_id = ?", activityID, userID).
+		Delete(&social.ActivityReaction{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ActivityRepo) ReactionCounts(ctx context.Context, activityIDs []uint) (map[uint]map[string]int64, error) {
+	out := make(map[uint]map[string]int64)
+	if len(activityIDs) == 0 {
+		return out, nil
+	}
+
+	var rows []struct {
+		ActivityID uint
+		Kind       string
+		N          int64
+	}
+	err := r.dbFrom(ctx).Model(&social.ActivityReaction{}).
+		Select("activity_id, kind, COUNT(*) AS n").
+		Where("activity_id IN ?", activityIDs).
+		Group("activity_id, kind").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if out[row.ActivityID] == nil {
+			out[row.ActivityID] = make(map[string]int64)
+		}
+		out[row.ActivityID][row.Kind] = row.N
+	}
+	return out, nil
+}
+
+func (r *ActivityRepo) UserReactions(ctx context.Context, userID uint, activityIDs []uint) (map[uint]string, error) {
+	out := make(map[uint]string)
+	if len(activityIDs) == 0 {
+		return out, nil
+	}
+
+	var rows []*social.ActivityReaction
+	err := r.dbFrom(ctx).
+		Where("user_id = ? AND activity_id IN ?", userID, activityIDs).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		out[row.ActivityID] = row.Kind
+	}
+	return out, nil
+}
+
+func (r *ActivityRepo) CreateComment(ctx context.Context, c *social.ActivityComment) error {
+	if err := r.dbFrom(ctx).Create(c).Error; err != nil {
+		return err
+	}
+	return r.dbFrom(ctx).Preload("Author").First(c, c.ID).Error
+}
+
+func (r *ActivityRepo) GetCommentByID(ctx context.Context, id uint) (*social.ActivityComment, error) {
+	var c social.ActivityComment
+	err := r.dbFrom(ctx).First(&c, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *ActivityRepo) GetComments(ctx context.Context, activityID uint) ([]*social.ActivityComment, error) {
+	var out []*social.ActivityComment
+	err := r.dbFrom(ctx).Preload("Author").
+		Where("activity_id = ?", activityID).
+		Order("created_at ASC").
+		Find(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *ActivityRepo) CommentCounts(ctx context.Context, activityIDs []uint) (map[uint]int64, error) {
+	out := make(map[uint]int64)
+	if len(activityIDs) == 0 {
+		return out, nil
+	}
+
+	var rows []struct {
+		ActivityID uint
+		N          int64
+	}
+	err := r.dbFrom(ctx).Model(&social.ActivityComment{}).
+		Select("activity_id, COUNT(*) AS n").
+		Where("activity_id IN ?", activityIDs).
+		Group("activity_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		out[row.ActivityID] = row.N
+	}
+	return out, nil
+}
+
+func (r *ActivityRepo) DeleteComment(ctx context.Context, id uint) error {
+	res := r.dbFrom(ctx).Delete(&social.ActivityComment{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+)
+
+type AIBlockedRequestRepo struct {
+	db *gorm.DB
+}
+
+func NewAIBlockedRequestRepo(db *gorm.DB) ai.BlockedRequestRepository {
+	return &AIBlockedRequestRepo{db: db}
+}
+
+func (r *AIBlockedRequestRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *AIBlockedRequestRepo) Create(ctx context.Context, b *ai.BlockedRequest) error {
+	return r.dbFrom(ctx).Omit("User").Create(b).Error
+}
+
+func (r *AIBlockedRequestRepo) GetAll(ctx context.Context, category string, page, pageSize int64) ([]*ai.BlockedRequest, int64, error) {
+	var out []*ai.BlockedRequest
+	var total int64
+
+	db := r.dbFrom(ctx).Model(&ai.BlockedRequest{})
+	if category != "" {
+		db = db.Where("category = ?", category)
+	}
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := db.Preload("User").
+		Order("created_at DESC, id DESC").
+		Offset(int((page - 1) * pageSize)).Limit(int(pageSize)).
+		Find(&out).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return out, total, nil
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+)
+
+type AIPromptRepo struct {
+	db *gorm.DB
+}
+
+func NewAIPromptRepo(db *gorm.DB) ai.PromptTemplateRepository {
+	return &AIPromptRepo{db: db}
+}
+
+func (r *AIPromptRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *AIPromptRepo) GetActive(ctx context.Context) ([]*ai.PromptTemplate, error) {
+	var out []*ai.PromptTemplate
+	if err := r.dbFrom(ctx).Where("active").Find(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *AIPromptRepo) GetAll(ctx context.Context) ([]*ai.PromptTemplate, error) {
+	var out []*ai.PromptTemplate
+	if err := r.dbFrom(ctx).Order("name, locale, version DESC").Find(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Create numbers the version itself; two concurrent creates fail on the unique index
+// rather than sharing a number.
+func (r *AIPromptRepo) Create(ctx context.Context, t *ai.PromptTemplate) error {
+	db := r.dbFrom(ctx)
+	var last int
+	err := db.Model(&ai.PromptTemplate{}).
+		Select("COALESCE(MAX(version), 0)").
+		Where("name = ? AND locale = ?", t.Name, t.Locale).
+		Scan(&last).Error
+	if err != nil {
+		return err
+	}
+	t.Version = last + 1
+	return db.Create(t).Error
+}
+
+func (r *AIPromptRepo) SetActive(ctx context.Context, name, locale string, version int) error {
+	db := r.dbFrom(ctx)
+	err := db.Model(&ai.PromptTemplate{}).
+		Where("name = ? AND locale = ? AND active", name, locale).
+		Update("active", false).Error
+	if err != nil {
+		return err
+	}
+	if version == 0 {
+		return nil
+	}
+
+	res := db.Model(&ai.PromptTemplate{}).
+		Where("name = ? AND locale = ? AND version = ?", name, locale, version).
+		Update("active", true)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
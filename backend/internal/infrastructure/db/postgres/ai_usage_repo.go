@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AIUsageRepo struct {
+	db *gorm.DB
+}
+
+func NewAIUsageRepo(db *gorm.DB) ai.UsageRepository {
+	return &AIUsageRepo{db: db}
+}
+
+func (r *AIUsageRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *AIUsageRepo) Create(ctx context.Context, u *ai.TokenUsage) error {
+	return r.dbFrom(ctx).Omit("User").Create(u).Error
+}
+
+func (r *AIUsageRepo) SumTokens(ctx context.Context, userID uint, since time.Time) (int64, error) {
+	var total int64
+	err := r.dbFrom(ctx).Model(&ai.TokenUsage{}).
+		Select("COALESCE(SUM(input_tokens + output_tokens), 0)").
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Scan(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *AIUsageRepo) SummarizeByUser(ctx context.Context, userID uint, from, to time.Time) ([]*ai.UsageSummary, error) {
+	return r.summarize(r.dbFrom(ctx).Where("user_id = ?", userID), "endpoint, model", from, to)
+}
+
+func (r *AIUsageRepo) Summarize(ctx context.Context, from, to time.Time) ([]*ai.UsageSummary, error) {
+	return r.summarize(r.dbFrom(ctx), "endpoint, model, prompt_version", from, to)
+}
+
+func (r *AIUsageRepo) summarize(db *gorm.DB, groupBy string, from, to time.Time) ([]*ai.UsageSummary, error) {
+	var out []*ai.UsageSummary
+	err := db.Model(&ai.TokenUsage{}).
+		Select(groupBy+", COUNT(*) AS calls, SUM(input_tokens) AS input_tokens, SUM(output_tokens) AS output_tokens").
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Group(groupBy).
+		Order(groupBy).
+		Scan(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *AIUsageRepo) TopUsers(ctx context.Context, from, to time.Time, limit int) ([]*ai.UserUsage, error) {
+	var out []*ai.UserUsage
+	err := r.dbFrom(ctx).Model(&ai.TokenUsage{}).
+		Select("ai_token_usages.user_id, users.username, COUNT(*) AS calls, SUM(input_tokens) AS input_tokens, SUM(output_tokens) AS output_tokens").
+		Joins("JOIN users ON users.id = ai_token_usages.user_id").
+		Where("ai_token_usages.created_at >= ? AND ai_token_usages.created_at < ?", from, to).
+		Group("ai_token_usages.user_id, users.username").
+		Order("SUM(input_tokens + output_tokens) DESC").
+		Limit(limit).
+		Scan(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type AIQuotaRepo struct {
+	db *gorm.DB
+}
+
+func NewAIQuotaRepo(db *gorm.DB) ai.QuotaRepository {
+	return &AIQuotaRepo{db: db}
+}
+
+func (r *AIQuotaRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *AIQuotaRepo) GetAll(ctx context.Context) ([]*ai.TokenQuota, error) {
+	var out []*ai.TokenQuota
+	if err := r.dbFrom(ctx).Preload("Role").Order("role_id").Find(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *AIQuotaRepo) GetByRoleIDs(ctx context.Context, roleIDs []uint) ([]*ai.TokenQuota, error) {
+	var out []*ai.TokenQuota
+	if len(roleIDs) == 0 {
+		return out, nil
+	}
+	if err := r.dbFrom(ctx).Where("role_id IN ?", roleIDs).Find(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *AIQuotaRepo) Upsert(ctx context.Context, q *ai.TokenQuota) error {
+	return r.dbFrom(ctx).Omit("Role").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "role_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"daily_tokens", "monthly_tokens", "updated_at"}),
+		}).
+		Create(q).Error
+}
+
+func (r *AIQuotaRepo) Delete(ctx context.Context, roleID uint) error {
+	res := r.dbFrom(ctx).Delete(&ai.TokenQuota{}, "role_id = ?", roleID)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
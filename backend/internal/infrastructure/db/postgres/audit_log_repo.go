@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepo struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepo(db *gorm.DB) audit.AuditLogRepository {
+	return &AuditLogRepo{db: db}
+}
+
+func (r *AuditLogRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *AuditLogRepo) Create(ctx context.Context, l *audit.AuditLog) error {
+	if l.Diff == "" {
+		l.Diff = "{}"
+	}
+	if l.Metadata == "" {
+		l.Metadata = "{}"
+	}
+	return r.dbFrom(ctx).Create(l).Error
+}
+
+func (r *AuditLogRepo) List(ctx context.Context, f audit.Filter, page, pageSize int64) ([]*audit.AuditLog, int64, error) {
+	var logs []*audit.AuditLog
+	var total int64
+
+	db := r.dbFrom(ctx).Model(&audit.AuditLog{})
+	if f.ActorID != nil {
+		db = db.Where("actor_id = ?", *f.ActorID)
+	}
+	if f.TargetType != "" {
+		db = db.Where("target_type = ?", f.TargetType)
+	}
+	if f.TargetID != nil {
+		db = db.Where("target_id = ?", *f.TargetID)
+	}
+	if f.Action != "" {
+		db = db.Where("action = ?", f.Action)
+	}
+	if f.IP != "" {
+		db = db.Where("ip = ?", f.IP)
+	}
+	if f.From != nil {
+		db = db.Where("created_at >= ?", *f.From)
+	}
+	if f.To != nil {
+		db = db.Where("created_at < ?", *f.To)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := db.Order("created_at DESC").Order("id DESC").
+		Offset(int((page - 1) * pageSize)).Limit(int(pageSize)).
+		Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/challenge"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ChallengeParticipantRepo struct {
+	db *gorm.DB
+}
+
+func NewChallengeParticipantRepo(db *gorm.DB) challenge.ParticipantRepository {
+	return &ChallengeParticipantRepo{db: db}
+}
+
+func (r *ChallengeParticipantRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Create is idempotent: joining twice keeps the original progress.
+func (r *ChallengeParticipantRepo) Create(ctx context.Context, p *challenge.ChallengeParticipant) error {
+	return r.dbFrom(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(p).Error
+}
+
+func (r *ChallengeParticipantRepo) Get(ctx context.Context, challengeID, userID uint) (*challenge.ChallengeParticipant, error) {
+	var p challenge.ChallengeParticipant
+	err := r.dbFrom(ctx).
+		Where("challenge_id = ? AND user_id = ?", challengeID, userID).
+		First(&p).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Delete removes the participant together with their challenge_contributions.
+func (r *ChallengeParticipantRepo) Delete(ctx context.Context, challengeID, userID uint) error {
+	res := r.dbFrom(ctx).
+		Where("challenge_id = ? AND user_id = ?", challengeID, userID).
+		Delete(&challenge.ChallengeParticipant{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return r.dbFrom(ctx).
+		Where("challenge_id = ? AND user_id = ?", challengeID, userID).
+		Delete(&challenge.ChallengeContribution{}).Error
+}
+
+func (r *ChallengeParticipantRepo) GetByUserID(ctx context.Context, userID uint) ([]*challenge.ChallengeParticipant, error) {
+	var out []*challenge.ChallengeParticipant
+	err := r.dbFrom(ctx).Preload("Challenge").
+		Where("user_id = ?", userID).
+		Order("joined_at DESC").
+		Find(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *ChallengeParticipantRepo) Top(ctx context.Context, challengeID uint, offset, limit int) ([]*challenge.ChallengeParticipant, error) {
+	var out []*challenge.ChallengeParticipant
+	err := r.dbFrom(ctx).Preload("User").
+		Where("challenge_id = ?", challengeID).
+		Order("progress DESC, updated_at ASC").
+		Offset(offset).Limit(limit).
+		Find(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *ChallengeParticipantRepo) GetByUserIDs(ctx context.Context, challengeID uint, userIDs []uint) ([]*challenge.ChallengeParticipant, error) {
+	var out []*challenge.ChallengeParticipant
+	if len(userIDs) == 0 {
+		return out, nil
+	}
+	err := r.dbFrom(ctx).Preload("User").
+		Where("challenge_id = ? AND user_id IN ?", challengeID, userIDs).
+		Find(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *ChallengeParticipantRepo) Count(ctx context.Context, challengeID uint) (int64, error) {
+	var n int64
+	err := r.dbFrom(ctx).Model(&challenge.ChallengeParticipant{}).
+		Where("challenge_id = ?", challengeID).
+		Count(&n).Error
+	return n, err
+}
+
+func (r *ChallengeParticipantRepo) AddContribution(ctx context.Context, c *challenge.ChallengeContribution) (bool, error) {
+	res := r.dbFrom(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(c)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return false, nil
+	}
+
+	err := r.dbFrom(ctx).Exec(`
+		UPDATE challenge_participants SET
+			progress = (SELECT COALESCE(SUM(value), 0) FROM challenge_contributions
+				WHERE challenge_id = ? AND user_id = ?),
+			updated_at = ?
+		WHERE challenge_id = ? AND user_id = ?`,
+		c.ChallengeID, c.UserID, time.Now(), c.ChallengeID, c.UserID,
+	).Error
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *ChallengeParticipantRepo) SetCompletedAt(ctx context.Context, challengeID, userID uint, at time.Time) error {
+	return r.dbFrom(ctx).Model(&challenge.ChallengeParticipant{}).
+		Where("challenge_id = ? AND user_id = ? AND completed_at IS NULL", challengeID, userID).
+		Update("completed_at", at).Error
+}
@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/challenge"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+)
+
+type ChallengeRepo struct {
+	db *gorm.DB
+}
+
+func NewChallengeRepo(db *gorm.DB) challenge.ChallengeRepository {
+	return &ChallengeRepo{db: db}
+}
+
+func (r *ChallengeRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *ChallengeRepo) Create(ctx context.Context, c *challenge.Challenge) error {
+	return r.dbFrom(ctx).Create(c).Error
+}
+
+func (r *ChallengeRepo) GetByID(ctx context.Context, id uint) (*challenge.Challenge, error) {
+	var c challenge.Challenge
+	err := r.dbFrom(ctx).First(&c, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *ChallengeRepo) List(ctx context.Context, status string, at time.Time) ([]*challenge.Challenge, error) {
+	var out []*challenge.Challenge
+	db := r.dbFrom(ctx)
+	switch status {
+	case challenge.StatusUpcoming:
+		db = db.Where("starts_at > ?", at).Order("starts_at ASC")
+	case challenge.StatusActive:
+		db = db.Where("starts_at <= ? AND ends_at > ?", at, at).Order("ends_at ASC")
+	case challenge.StatusPast:
+		db = db.Where("ends_at <= ?", at).Order("ends_at DESC")
+	default:
+		db = db.Order("starts_at DESC")
+	}
+	if err := db.Order("id ASC").Find(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *ChallengeRepo) Update(ctx context.Context, c *challenge.Challenge) error {
+	res := r.dbFrom(ctx).Model(c).
+		Select("name", "description", "metric", "target", "starts_at", "ends_at").
+		Updates(c)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ChallengeRepo) Delete(ctx context.Context, id uint) error {
+	res := r.dbFrom(ctx).Delete(&challenge.Challenge{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ChallengeRepo) GetRunningForUser(ctx context.Context, userID uint, at time.Time) ([]*challenge.Challenge, error) {
+	var out []*challenge.Challenge
+	err := r.dbFrom(ctx).
+		Joins("JOIN challenge_participants p ON p.challenge_id = challenges.id").
+		Where("p.user_id = ? AND challenges.starts_at <= ? AND challenges.ends_at > ?", userID, at, at).
+		Find(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
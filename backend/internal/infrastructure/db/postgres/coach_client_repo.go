@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/coaching"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+)
+
+type CoachClientRepo struct {
+	db *gorm.DB
+}
+
+func NewCoachClientRepo(db *gorm.DB) coaching.CoachClientRepository {
+	return &CoachClientRepo{db: db}
+}
+
+func (r *CoachClientRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *CoachClientRepo) Create(ctx context.Context, cc *coaching.CoachClient) error {
+	return r.dbFrom(ctx).Create(cc).Error
+}
+
+func (r *CoachClientRepo) GetByID(ctx context.Context, id uint) (*coaching.CoachClient, error) {
+	var cc coaching.CoachClient
+	err := r.dbFrom(ctx).Preload("Coach").Preload("Client").First(&cc, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
+		return nil, err
+	}
+	return &cc, nil
+}
+
+// GetOpen returns the pending or active relationship between the pair, if any.
+func (r *CoachClientRepo) GetOpen(ctx context.Context, coachID, clientID uint) (*coaching.CoachClient, error) {
+	var cc coaching.CoachClient
+	err := r.dbFrom(ctx).
+		Where("coach_id = ? AND client_id = ? AND status IN ?", coachID, clientID,
+			[]string{coaching.StatusPending, coaching.StatusActive}).
+		First(&cc).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
+		return nil, err
+	}
+	return &cc, nil
+}
+
+func (r *CoachClientRepo) GetByCoachID(ctx context.Context, coachID uint) ([]*coaching.CoachClient, error) {
+	var out []*coaching.CoachClient
+	err := r.dbFrom(ctx).Preload("Client").
+		Where("coach_id = ?", coachID).
+		Order("created_at DESC").
+		Find(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *CoachClientRepo) GetByClientID(ctx context.Context, clientID uint) ([]*coaching.CoachClient, error) {
+	var out []*coaching.CoachClient
+	err := r.dbFrom(ctx).Preload("Coach").
+		Where("client_id = ?", clientID).
+		Order("created_at DESC").
+		Find(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *CoachClientRepo) Update(ctx context.Context, id uint, updates map[string]any) error {
+	res := r.dbFrom(ctx).Model(&coaching.CoachClient{}).Where("id = ?", id).Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
+
+func (r *CoachClientRepo) IsActive(ctx context.Context, coachID, clientID uint) (bool, error) {
+	var n int64
+	err := r.dbFrom(ctx).Model(&coaching.CoachClient{}).
+		Where("coach_id = ? AND client_id = ? AND status = ?", coachID, clientID, coaching.StatusActive).
+		Count(&n).Error
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
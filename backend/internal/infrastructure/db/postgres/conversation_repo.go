@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+)
+
+type ConversationRepo struct {
+	db *gorm.DB
+}
+
+func NewConversationRepo(db *gorm.DB) ai.ConversationRepository {
+	return &ConversationRepo{db: db}
+}
+
+func (r *ConversationRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *ConversationRepo) Create(ctx context.Context, c *ai.Conversation) error {
+	return r.dbFrom(ctx).Omit("User").Create(c).Error
+}
+
+func (r *ConversationRepo) GetByID(ctx context.Context, userID, id uint) (*ai.Conversation, error) {
+	var c ai.Conversation
+	err := r.dbFrom(ctx).Where("user_id = ? AND id = ?", userID, id).First(&c).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *ConversationRepo) GetByUserID(ctx context.Context, userID uint, page, pageSize int64) ([]*ai.Conversation, int64, error) {
+	var out []*ai.Conversation
+	var total int64
+
+	db := r.dbFrom(ctx).Model(&ai.Conversation{}).Where("user_id = ?", userID)
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := db.Order("updated_at DESC, id DESC").
+		Offset(int((page - 1) * pageSize)).Limit(int(pageSize)).
+		Find(&out).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return out, total, nil
+}
+
+func (r *ConversationRepo) GetAllWithMessages(ctx context.Context, userID uint) ([]*ai.Conversation, error) {
+	var out []*ai.Conversation
+	err := r.dbFrom(ctx).
+		Preload("Messages", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Where("user_id = ?", userID).
+		Order("created_at ASC, id ASC").
+		Find(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *ConversationRepo) GetMessages(ctx context.Context, conversationID uint) ([]*ai.ConversationMessage, error) {
+	var out []*ai.ConversationMessage
+	err := r.dbFrom(ctx).Where("conversation_id = ?", conversationID).
+		Order("id ASC").
+		Find(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *ConversationRepo) AddMessages(ctx context.Context, conversationID uint, msgs ...*ai.ConversationMessage) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	for _, m := range msgs {
+		m.ConversationID = conversationID
+	}
+	db := r.dbFrom(ctx)
+	if err := db.Create(msgs).Error; err != nil {
+		return err
+	}
+	return db.Model(&ai.Conversation{}).Where("id = ?", conversationID).
+		Update("updated_at", time.Now()).Error
+}
+
+func (r *ConversationRepo) Update(ctx context.Context, userID, id uint, updates map[string]any) error {
+	res := r.dbFrom(ctx).Model(&ai.Conversation{}).
+		Where("user_id = ? AND id = ?", userID, id).
+		Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ConversationRepo) Delete(ctx context.Context, userID, id uint) error {
+	res := r.dbFrom(ctx).Where("user_id = ? AND id = ?", userID, id).Delete(&ai.Conversation{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ConversationRepo) DeleteByUserID(ctx context.Context, userID uint) error {
+	return r.dbFrom(ctx).Where("user_id = ?", userID).Delete(&ai.Conversation{}).Error
+}
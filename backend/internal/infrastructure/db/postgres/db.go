@@ -4,9 +4,19 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/lordmitrii/golang-web-gin/internal/domain/achievement"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/auth"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/challenge"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/coaching"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/email"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/equipment"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/events"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/goal"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/nutrition"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/social"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/translations"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/versions"
@@ -53,9 +63,12 @@ func AutoMigrate(db *gorm.DB) error {
 		&rbac.Permission{}, &rbac.RolePermission{},
 
 		&email.EmailToken{},
+		&audit.AuditLog{},
+		&auth.PersonalAccessToken{},
 
 		&workout.MuscleGroup{},
 		&workout.Exercise{},
+		&workout.ExerciseMedia{},
 		&workout.IndividualExercise{},
 
 		&workout.WorkoutPlan{},
@@ -63,6 +76,40 @@ func AutoMigrate(db *gorm.DB) error {
 		&workout.Workout{},
 		&workout.WorkoutExercise{},
 		&workout.WorkoutSet{},
+
+		&equipment.EquipmentProfile{},
+		&equipment.EquipmentProfileItem{},
+		&equipment.LoadingSettings{},
+		&equipment.LoadingPlate{},
+
+		&coaching.CoachClient{},
+		&coaching.WorkoutComment{},
+
+		&social.Follow{},
+		&social.Activity{},
+		&social.FeedEntry{},
+		&social.ActivityReaction{},
+		&social.ActivityComment{},
+		&social.WorkoutShare{},
+
+		&challenge.Challenge{},
+		&challenge.ChallengeParticipant{},
+		&challenge.ChallengeContribution{},
+
+		&achievement.UserAchievement{},
+
+		&nutrition.Food{},
+		&nutrition.MealEntry{},
+		&nutrition.NutritionGoal{},
+
+		&goal.Goal{},
+
+		&ai.Conversation{},
+		&ai.ConversationMessage{},
+		&ai.TokenUsage{},
+		&ai.TokenQuota{},
+		&ai.PromptTemplate{},
+		&ai.BlockedRequest{},
 	)
 
 }
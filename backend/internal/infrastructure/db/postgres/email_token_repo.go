@@ -2,10 +2,14 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/lordmitrii/golang-web-gin/internal/domain/email"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type EmailTokenRepo struct {
@@ -16,23 +20,68 @@ func NewEmailTokenRepo(db *gorm.DB) *EmailTokenRepo {
 	return &EmailTokenRepo{db: db}
 }
 
+func (r *EmailTokenRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
 func (r *EmailTokenRepo) Create(ctx context.Context, token *email.EmailToken) error {
-	return r.db.WithContext(ctx).Create(token).Error
+	return r.dbFrom(ctx).Create(token).Error
 }
 
 func (r *EmailTokenRepo) GetByTokenAndType(ctx context.Context, token, tokenType string) (*email.EmailToken, error) {
 	var emailToken email.EmailToken
-	err := r.db.WithContext(ctx).Where("token = ? AND type = ?", token, tokenType).First(&emailToken).Error
+	err := r.dbFrom(ctx).Where("token = ? AND type = ?", token, tokenType).First(&emailToken).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrInvalidToken
+		}
 		return nil, err
 	}
 	return &emailToken, nil
 }
 
+func (r *EmailTokenRepo) GetLatestByUserIDAndType(ctx context.Context, userID uint, tokenType string) (*email.EmailToken, error) {
+	var emailToken email.EmailToken
+	err := r.dbFrom(ctx).
+		Where("user_id = ? AND type = ? AND expires_at > ?", userID, tokenType, time.Now()).
+		Order("created_at DESC").
+		First(&emailToken).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrInvalidToken
+		}
+		return nil, err
+	}
+	return &emailToken, nil
+}
+
+func (r *EmailTokenRepo) IncrementAttempts(ctx context.Context, id uint) (int, error) {
+	var emailToken email.EmailToken
+	res := r.dbFrom(ctx).
+		Model(&emailToken).
+		Where("id = ?", id).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "attempts"}}}).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return 0, custom_err.ErrInvalidToken
+	}
+	return emailToken.Attempts, nil
+}
+
 func (r *EmailTokenRepo) DeleteExpiredTokens(ctx context.Context) error {
-	return r.db.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&email.EmailToken{}).Error
+	return r.dbFrom(ctx).Where("expires_at < ?", time.Now()).Delete(&email.EmailToken{}).Error
 }
 
 func (r *EmailTokenRepo) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&email.EmailToken{}).Error
+	return r.dbFrom(ctx).Where("id = ?", id).Delete(&email.EmailToken{}).Error
+}
+
+func (r *EmailTokenRepo) DeleteByUserIDAndType(ctx context.Context, userID uint, tokenType string) error {
+	return r.dbFrom(ctx).Where("user_id = ? AND type = ?", userID, tokenType).Delete(&email.EmailToken{}).Error
 }
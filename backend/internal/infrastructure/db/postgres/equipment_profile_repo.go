@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/equipment"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+)
+
+type EquipmentProfileRepo struct {
+	db *gorm.DB
+}
+
+func NewEquipmentProfileRepo(db *gorm.DB) equipment.ProfileRepository {
+	return &EquipmentProfileRepo{db: db}
+}
+
+func (r *EquipmentProfileRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *EquipmentProfileRepo) Create(ctx context.Context, p *equipment.EquipmentProfile) error {
+	return r.dbFrom(ctx).Create(p).Error
+}
+
+func (r *EquipmentProfileRepo) GetByID(ctx context.Context, userID, id uint) (*equipment.EquipmentProfile, error) {
+	return r.first(r.dbFrom(ctx).Where("user_id = ? AND id = ?", userID, id))
+}
+
+func (r *EquipmentProfileRepo) GetByName(ctx context.Context, userID uint, name string) (*equipment.EquipmentProfile, error) {
+	return r.first(r.dbFrom(ctx).Where("user_id = ? AND name = ?", userID, name))
+}
+
+func (r *EquipmentProfileRepo) GetDefault(ctx context.Context, userID uint) (*equipment.EquipmentProfile, error) {
+	return r.first(r.dbFrom(ctx).Where("user_id = ? AND is_default = TRUE", userID))
+}
+
+func (r *EquipmentProfileRepo) first(db *gorm.DB) (*equipment.EquipmentProfile, error) {
+	var p equipment.EquipmentProfile
+	if err := db.Preload("Items").First(&p).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *EquipmentProfileRepo) GetByUserID(ctx context.Context, userID uint) ([]*equipment.EquipmentProfile, error) {
+	var profiles []*equipment.EquipmentProfile
+	err := r.dbFrom(ctx).Preload("Items").
+		Where("user_id = ?", userID).
+		Order("is_default DESC").Order("name ASC").
+		Find(&profiles).Error
+	if err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+func (r *EquipmentProfileRepo) Update(ctx context.Context, userID, id uint, updates map[string]any) error {
+	res := r.dbFrom(ctx).Model(&equipment.EquipmentProfile{}).
+		Where("user_id = ? AND id = ?", userID, id).
+		Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
+
+func (r *EquipmentProfileRepo) ReplaceItems(ctx context.Context, id uint, items []string) error {
+	db := r.dbFrom(ctx)
+	if err := db.Where("profile_id = ?", id).Delete(&equipment.EquipmentProfileItem{}).Error; err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	rows := make([]equipment.EquipmentProfileItem, 0, len(items))
+	for _, e := range items {
+		rows = append(rows, equipment.EquipmentProfileItem{ProfileID: id, Equipment: e})
+	}
+	return db.Create(&rows).Error
+}
+
+func (r *EquipmentProfileRepo) ClearDefault(ctx context.Context, userID uint) error {
+	return r.dbFrom(ctx).Model(&equipment.EquipmentProfile{}).
+		Where("user_id = ? AND is_default = TRUE", userID).
+		Update("is_default", false).Error
+}
+
+func (r *EquipmentProfileRepo) Delete(ctx context.Context, userID, id uint) error {
+	res := r.dbFrom(ctx).Where("user_id = ? AND id = ?", userID, id).Delete(&equipment.EquipmentProfile{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
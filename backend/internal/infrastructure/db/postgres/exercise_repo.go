@@ -2,9 +2,12 @@ package postgres
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -17,13 +20,25 @@ func NewExerciseRepo(db *gorm.DB) workout.ExerciseRepository {
 	return &ExerciseRepo{db: db}
 }
 
+func (r *ExerciseRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
 func (r *ExerciseRepo) Create(ctx context.Context, e *workout.Exercise) error {
-	return r.db.WithContext(ctx).Create(e).Error
+	// Secondary muscle groups are referenced by ID only; link them without
+	// upserting the muscle group rows themselves.
+	return r.dbFrom(ctx).Omit("SecondaryMuscleGroups.*").Create(e).Error
 }
 
 func (r *ExerciseRepo) GetByID(ctx context.Context, id uint) (*workout.Exercise, error) {
 	var e workout.Exercise
-	if err := r.db.WithContext(ctx).Preload("MuscleGroup").First(&e, id).Error; err != nil {
+	if err := PreloadExerciseCatalog(r.dbFrom(ctx)).First(&e, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
 		return nil, err
 	}
 	return &e, nil
@@ -31,7 +46,7 @@ func (r *ExerciseRepo) GetByID(ctx context.Context, id uint) (*workout.Exercise,
 
 func (r *ExerciseRepo) GetByMuscleGroupID(ctx context.Context, muscleGroupID *uint) ([]*workout.Exercise, error) {
 	var exercises []*workout.Exercise
-	if err := r.db.WithContext(ctx).Preload("MuscleGroup").Where("muscle_group_id = ?", muscleGroupID).Find(&exercises).Error; err != nil {
+	if err := PreloadExerciseCatalog(r.dbFrom(ctx)).Where("muscle_group_id = ?", muscleGroupID).Find(&exercises).Error; err != nil {
 		return nil, err
 	}
 	return exercises, nil
@@ -39,14 +54,14 @@ func (r *ExerciseRepo) GetByMuscleGroupID(ctx context.Context, muscleGroupID *ui
 
 func (r *ExerciseRepo) GetAll(ctx context.Context) ([]*workout.Exercise, error) {
 	var exercises []*workout.Exercise
-	if err := r.db.WithContext(ctx).Preload("MuscleGroup").Find(&exercises).Error; err != nil {
+	if err := PreloadExerciseCatalog(r.dbFrom(ctx)).Find(&exercises).Error; err != nil {
 		return nil, err
 	}
 	return exercises, nil
 }
 
 func (r *ExerciseRepo) Update(ctx context.Context, id uint, updates map[string]any) error {
-	res := r.db.WithContext(ctx).Model(&workout.Exercise{}).Where("id = ?", id).Updates(updates)
+	res := r.dbFrom(ctx).Model(&workout.Exercise{}).Where("id = ?", id).Updates(updates)
 	if res.Error != nil {
 		return res.Error
 	}
@@ -58,7 +73,7 @@ func (r *ExerciseRepo) Update(ctx context.Context, id uint, updates map[string]a
 
 func (r *ExerciseRepo) UpdateReturning(ctx context.Context, id uint, updates map[string]any) (*workout.Exercise, error) {
 	var e workout.Exercise
-	res := r.db.WithContext(ctx).Model(&e).Where("id = ?", id).Clauses(clause.Returning{}).Updates(updates)
+	res := r.dbFrom(ctx).Model(&e).Where("id = ?", id).Clauses(clause.Returning{}).Updates(updates)
 	if res.Error != nil {
 		return nil, res.Error
 	}
@@ -69,7 +84,7 @@ func (r *ExerciseRepo) UpdateReturning(ctx context.Context, id uint, updates map
 }
 
 func (r *ExerciseRepo) Delete(ctx context.Context, id uint) error {
-	res := r.db.WithContext(ctx).Delete(&workout.Exercise{}, id)
+	res := r.dbFrom(ctx).Delete(&workout.Exercise{}, id)
 
 	if res.Error != nil {
 		return res.Error
@@ -82,13 +97,119 @@ func (r *ExerciseRepo) Delete(ctx context.Context, id uint) error {
 	return nil
 }
 
-func (r *ExerciseRepo) GetExerciseNamesByMuscleName(ctx context.Context, muscleName string, limit, offset int) ([]*workout.Exercise, error) {
+func (r *ExerciseRepo) ReplaceSecondaryMuscleGroups(ctx context.Context, id uint, muscleGroupIDs []uint) error {
+	return r.dbFrom(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`DELETE FROM exercise_secondary_muscle_groups WHERE exercise_id = ?`, id).Error; err != nil {
+			return err
+		}
+		if len(muscleGroupIDs) == 0 {
+			return nil
+		}
+		return tx.Exec(`
+			INSERT INTO exercise_secondary_muscle_groups (exercise_id, muscle_group_id)
+			SELECT ?, mg.id FROM muscle_groups mg
+			WHERE mg.id IN ? AND mg.id IS DISTINCT FROM (SELECT muscle_group_id FROM exercises WHERE id = ?)
+			ON CONFLICT DO NOTHING`, id, muscleGroupIDs, id).Error
+	})
+}
+
+func (r *ExerciseRepo) ReplaceMedia(ctx context.Context, id uint, media []*workout.ExerciseMedia) error {
+	return r.dbFrom(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("exercise_id = ?", id).Delete(&workout.ExerciseMedia{}).Error; err != nil {
+			return err
+		}
+		if len(media) == 0 {
+			return nil
+		}
+		for i, m := range media {
+			m.ID = 0
+			m.ExerciseID = id
+			m.Position = i
+		}
+		return tx.Create(media).Error
+	})
+}
+
+func (r *ExerciseRepo) Search(ctx context.Context, f workout.ExerciseFilter, page, pageSize int64) ([]*workout.Exercise, int64, error) {
+	var exercises []*workout.Exercise
+	var total int64
+
+	db := r.dbFrom(ctx).Model(&workout.Exercise{})
+
+	q := strings.TrimSpace(f.Query)
+	if q != "" {
+		like := "%" + escapeLike(q) + "%"
+		// Best translated-name match across all locales; NULL when none matches.
+		db = db.Joins(`LEFT JOIN LATERAL (
+			SELECT MAX(word_similarity(?, t.value)) AS score
+			FROM translations t
+			WHERE t.namespace = 'translation'
+			  AND t.key = 'exercise.' || exercises.slug
+			  AND (t.value ILIKE ? OR ? <% t.value)
+		) tr ON TRUE`, q, like, q).
+			Where("(exercises.name ILIKE ? OR ? <% exercises.name OR tr.score IS NOT NULL)", like, q)
+	}
+	if f.MuscleGroupID != nil {
+		db = db.Where("exercises.muscle_group_id = ?", *f.MuscleGroupID)
+	}
+	if f.MuscleGroup != "" {
+		db = db.Where(`exercises.muscle_group_id IN (
+			SELECT id FROM muscle_groups WHERE LOWER(name) = LOWER(?) OR slug = LOWER(?)
+		)`, f.MuscleGroup, f.MuscleGroup)
+	}
+	if len(f.Equipment) > 0 {
+		db = db.Where("exercises.equipment IN ?", f.Equipment)
+	}
+	if f.IsBodyweight != nil {
+		db = db.Where("exercises.is_bodyweight = ?", *f.IsBodyweight)
+	}
+	if f.IsTimeBased != nil {
+		db = db.Where("exercises.is_time_based = ?", *f.IsTimeBased)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if q != "" {
+		db = db.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "GREATEST(word_similarity(?, exercises.name), COALESCE(tr.score, 0)) DESC",
+			Vars:               []any{q},
+			WithoutParentheses: true,
+		}})
+	}
+	if err := PreloadExerciseCatalog(db).Order("exercises.name ASC").
+		Offset(int((page - 1) * pageSize)).Limit(int(pageSize)).
+		Find(&exercises).Error; err != nil {
+		return nil, 0, err
+	}
+	return exercises, total, nil
+}
+
+// FindSubstitutes returns catalog exercises with the same primary muscle group
+// and movement pattern that only need the given equipment. Same-equipment
+// variants come first, then those closest in difficulty.
+func (r *ExerciseRepo) FindSubstitutes(ctx context.Context, e *workout.Exercise, equipment []string, limit int) ([]*workout.Exercise, error) {
 	var exercises []*workout.Exercise
-	err := r.db.WithContext(ctx).Select("exercises.name", "exercises.slug").
-		Joins("JOIN muscle_groups ON muscle_groups.id = exercises.muscle_group_id").
-		Where("LOWER(muscle_groups.name) = LOWER(?)", muscleName).
+	if e.MuscleGroupID == nil {
+		return exercises, nil
+	}
+
+	db := r.dbFrom(ctx).Model(&workout.Exercise{}).
+		Where("id <> ? AND muscle_group_id = ? AND movement_pattern = ?", e.ID, *e.MuscleGroupID, e.MovementPattern).
+		Where("is_time_based = ?", e.IsTimeBased)
+	if equipment != nil {
+		db = db.Where("equipment IN ?", equipment)
+	}
+
+	err := PreloadExerciseCatalog(db).
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "(equipment = ?) DESC, ABS(" + difficultyRank + " - ?) ASC",
+			Vars:               []any{e.Equipment, difficultyLevel(e.Difficulty)},
+			WithoutParentheses: true,
+		}}).
+		Order("name ASC").
 		Limit(limit).
-		Offset(offset).
 		Find(&exercises).Error
 	if err != nil {
 		return nil, err
@@ -96,3 +217,19 @@ func (r *ExerciseRepo) GetExerciseNamesByMuscleName(ctx context.Context, muscleN
 	return exercises, nil
 }
 
+const difficultyRank = "CASE difficulty WHEN 'beginner' THEN 0 WHEN 'intermediate' THEN 1 ELSE 2 END"
+
+func difficultyLevel(d string) int {
+	switch d {
+	case workout.DifficultyBeginner:
+		return 0
+	case workout.DifficultyIntermediate:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
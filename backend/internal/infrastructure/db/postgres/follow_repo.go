@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/social"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FollowRepo struct {
+	db *gorm.DB
+}
+
+func NewFollowRepo(db *gorm.DB) social.FollowRepository {
+	return &FollowRepo{db: db}
+}
+
+func (r *FollowRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Create is idempotent: following someone twice is not an error.
+func (r *FollowRepo) Create(ctx context.Context, f *social.Follow) error {
+	return r.dbFrom(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(f).Error
+}
+
+func (r *FollowRepo) Get(ctx context.Context, followerID, followeeID uint) (*social.Follow, error) {
+	var f social.Follow
+	err := r.dbFrom(ctx).
+		Where("follower_id = ? AND followee_id = ?", followerID, followeeID).
+		First(&f).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *FollowRepo) Delete(ctx context.Context, followerID, followeeID uint) error {
+	res := r.dbFrom(ctx).
+		Where("follower_id = ? AND followee_id = ?", followerID, followeeID).
+		Delete(&social.Follow{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
+
+func (r *FollowRepo) Accept(ctx context.Context, followerID, followeeID uint) error {
+	res := r.dbFrom(ctx).Model(&social.Follow{}).
+		Where("follower_id = ? AND followee_id = ? AND pending", followerID, followeeID).
+		Update("pending", false)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
+
+func (r *FollowRepo) Exists(ctx context.Context, followerID, followeeID uint) (bool, error) {
+	var n int64
+	err := r.dbFrom(ctx).Model(&social.Follow{}).
+		Where("follower_id = ? AND followee_id = ? AND NOT pending", followerID, followeeID).
+		Count(&n).Error
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *FollowRepo) GetFollowers(ctx context.Context, userID uint, page, pageSize int64) ([]*user.User, int64, error) {
+	return r.listUsers(ctx, "follows.follower_id", "follows.followee_id", userID, false, page, pageSize)
+}
+
+func (r *FollowRepo) GetFollowing(ctx context.Context, userID uint, page, pageSize int64) ([]*user.User, int64, error) {
+	return r.listUsers(ctx, "follows.followee_id", "follows.follower_id", userID, false, page, pageSize)
+}
+
+func (r *FollowRepo) GetRequests(ctx context.Context, userID uint, page, pageSize int64) ([]*user.User, int64, error) {
+	return r.listUsers(ctx, "follows.follower_id", "follows.followee_id", userID, true, page, pageSize)
+}
+
+func (r *FollowRepo) listUsers(ctx context.Context, joinCol, filterCol string, userID uint, pending bool, page, pageSize int64) ([]*user.User, int64, error) {
+	var users []*user.User
+	var total int64
+
+	db := r.dbFrom(ctx).Model(&user.User{}).
+		Joins("JOIN follows ON "+joinCol+" = users.id").
+		Where(filterCol+" = ? AND follows.pending = ?", userID, pending)
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := db.Offset(int((page - 1) * pageSize)).Limit(int(pageSize)).Order("follows.created_at DESC").Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/nutrition"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FoodRepo struct {
+	db *gorm.DB
+}
+
+func NewFoodRepo(db *gorm.DB) nutrition.FoodRepository {
+	return &FoodRepo{db: db}
+}
+
+func (r *FoodRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *FoodRepo) Create(ctx context.Context, f *nutrition.Food) error {
+	return r.dbFrom(ctx).Create(f).Error
+}
+
+func (r *FoodRepo) GetByID(ctx context.Context, userID, id uint) (*nutrition.Food, error) {
+	return r.first(r.dbFrom(ctx).Where("id = ? AND (owner_id IS NULL OR owner_id = ?)", id, userID))
+}
+
+func (r *FoodRepo) GetCatalogBySlug(ctx context.Context, slug string) (*nutrition.Food, error) {
+	return r.first(r.dbFrom(ctx).Where("owner_id IS NULL AND slug = ?", slug))
+}
+
+func (r *FoodRepo) first(db *gorm.DB) (*nutrition.Food, error) {
+	var f nutrition.Food
+	if err := db.First(&f).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *FoodRepo) Search(ctx context.Context, userID uint, query string, page, pageSize int) ([]*nutrition.Food, int64, error) {
+	var foods []*nutrition.Food
+	var total int64
+
+	db := r.dbFrom(ctx).Model(&nutrition.Food{}).Where("(foods.owner_id IS NULL OR foods.owner_id = ?)", userID)
+
+	q := strings.TrimSpace(query)
+	if q != "" {
+		like := "%" + escapeLike(q) + "%"
+		// Catalog names are matched in every locale, like exercise search.
+		db = db.Where(`(foods.name ILIKE ? OR foods.brand ILIKE ? OR EXISTS (
+			SELECT 1 FROM translations t
+			WHERE t.namespace = 'translation'
+			  AND foods.owner_id IS NULL
+			  AND t.key = 'food.' || foods.slug
+			  AND t.value ILIKE ?
+		))`, like, like, like)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:                "foods.owner_id IS NULL ASC, foods.name ASC",
+		WithoutParentheses: true,
+	}}).
+		Offset((page - 1) * pageSize).Limit(pageSize).
+		Find(&foods).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return foods, total, nil
+}
+
+func (r *FoodRepo) scoped(ctx context.Context, ownerID *uint, id uint) *gorm.DB {
+	db := r.dbFrom(ctx).Model(&nutrition.Food{}).Where("id = ?", id)
+	if ownerID == nil {
+		return db.Where("owner_id IS NULL")
+	}
+	return db.Where("owner_id = ?", *ownerID)
+}
+
+func (r *FoodRepo) Update(ctx context.Context, ownerID *uint, id uint, updates map[string]any) error {
+	res := r.scoped(ctx, ownerID, id).Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
+
+func (r *FoodRepo) Delete(ctx context.Context, ownerID *uint, id uint) error {
+	res := r.scoped(ctx, ownerID, id).Delete(&nutrition.Food{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
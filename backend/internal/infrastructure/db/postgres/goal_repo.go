@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/goal"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+)
+
+type GoalRepo struct {
+	db *gorm.DB
+}
+
+func NewGoalRepo(db *gorm.DB) goal.GoalRepository {
+	return &GoalRepo{db: db}
+}
+
+func (r *GoalRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *GoalRepo) Create(ctx context.Context, g *goal.Goal) error {
+	return r.dbFrom(ctx).Omit("IndividualExercise").Create(g).Error
+}
+
+func (r *GoalRepo) GetByID(ctx context.Context, userID, id uint) (*goal.Goal, error) {
+	var g goal.Goal
+	err := r.dbFrom(ctx).Preload("IndividualExercise").
+		Where("user_id = ? AND id = ?", userID, id).
+		First(&g).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (r *GoalRepo) GetByUserID(ctx context.Context, userID uint, status string) ([]*goal.Goal, error) {
+	db := r.dbFrom(ctx).Preload("IndividualExercise").Where("user_id = ?", userID)
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+	var out []*goal.Goal
+	if err := db.Order("created_at DESC").Find(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *GoalRepo) Update(ctx context.Context, userID, id uint, updates map[string]any) error {
+	res := r.dbFrom(ctx).Model(&goal.Goal{}).
+		Where("user_id = ? AND id = ?", userID, id).
+		Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
+
+func (r *GoalRepo) MarkAchieved(ctx context.Context, g *goal.Goal) (bool, error) {
+	res := r.dbFrom(ctx).Model(&goal.Goal{}).
+		Where("id = ? AND status = ?", g.ID, goal.StatusActive).
+		Updates(map[string]any{
+			"status":              g.Status,
+			"achieved_at":         g.AchievedAt,
+			"achieved_workout_id": g.AchievedWorkoutID,
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *GoalRepo) MarkExpired(ctx context.Context, g *goal.Goal) error {
+	return r.dbFrom(ctx).Model(&goal.Goal{}).
+		Where("id = ? AND status = ?", g.ID, goal.StatusActive).
+		Update("status", g.Status).Error
+}
+
+func (r *GoalRepo) Delete(ctx context.Context, userID, id uint) error {
+	res := r.dbFrom(ctx).Where("user_id = ? AND id = ?", userID, id).Delete(&goal.Goal{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
+
+type GoalProgressRepo struct {
+	db *gorm.DB
+}
+
+func NewGoalProgressRepo(db *gorm.DB) goal.ProgressRepository {
+	return &GoalProgressRepo{db: db}
+}
+
+func (r *GoalProgressRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *GoalProgressRepo) MaxWeightByReps(ctx context.Context, userID, individualExerciseID uint) (map[int]int, error) {
+	type row struct {
+		Reps   int
+		Weight int
+	}
+	var rows []row
+	err := r.dbFrom(ctx).Raw(`
+		SELECT ws.reps, MAX(ws.weight) AS weight
+		FROM workout_sets ws
+		JOIN workout_exercises we ON we.id = ws.workout_exercise_id
+		JOIN individual_exercises ie ON ie.id = we.individual_exercise_id
+		WHERE ie.user_id = ? AND we.individual_exercise_id = ? AND ws.completed
+			AND ws.weight > 0 AND ws.reps > 0
+		GROUP BY ws.reps`, userID, individualExerciseID).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[int]int, len(rows))
+	for _, r := range rows {
+		out[r.Reps] = r.Weight
+	}
+	return out, nil
+}
+
+// CompletionTimes shares its definition of a workout's completion with the achievement
+// stats, so frequency goals and streaks agree on when a workout happened.
+func (r *GoalProgressRepo) CompletionTimes(ctx context.Context, userID uint) ([]time.Time, error) {
+	return completionTimes(r.dbFrom(ctx), userID)
+}
@@ -62,6 +62,60 @@ func AddWorkoutIndex(db *gorm.DB, concurrently bool) error {
 
 		fmt.Sprintf(`CREATE INDEX%s IF NOT EXISTS idx_indiv_ex_user_name_mgroup
 			ON individual_exercises (user_id, name, muscle_group_id)`, cc),
+
+		fmt.Sprintf(`CREATE UNIQUE INDEX%s IF NOT EXISTS uniq_coach_clients_open
+			ON coach_clients (coach_id, client_id)
+			WHERE status IN ('pending', 'active')`, cc),
+		fmt.Sprintf(`CREATE INDEX%s IF NOT EXISTS idx_workout_comments_workout_created
+			ON workout_comments (workout_id, created_at)`, cc),
+
+		fmt.Sprintf(`CREATE INDEX%s IF NOT EXISTS idx_feed_entries_user_activity
+			ON feed_entries (user_id, activity_id DESC)`, cc),
+		fmt.Sprintf(`CREATE INDEX%s IF NOT EXISTS idx_activities_actor_id
+			ON activities (actor_id, id DESC)`, cc),
+		fmt.Sprintf(`CREATE UNIQUE INDEX%s IF NOT EXISTS uniq_workout_shares_active
+			ON workout_shares (workout_id)
+			WHERE revoked_at IS NULL`, cc),
+
+		fmt.Sprintf(`CREATE INDEX%s IF NOT EXISTS idx_challenge_participants_progress
+			ON challenge_participants (challenge_id, progress DESC)`, cc),
+
+		fmt.Sprintf(`CREATE UNIQUE INDEX%s IF NOT EXISTS uniq_equipment_profiles_default
+			ON equipment_profiles (user_id)
+			WHERE is_default = TRUE`, cc),
+
+		fmt.Sprintf(`CREATE UNIQUE INDEX%s IF NOT EXISTS uniq_foods_catalog_slug
+			ON foods (slug)
+			WHERE owner_id IS NULL`, cc),
+	}
+
+	for _, raw := range stmts {
+		sql := strings.Join(strings.Fields(raw), " ")
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddSearchIndexes enables pg_trgm and indexes the columns exercise search
+// matches against.
+func AddSearchIndexes(db *gorm.DB, concurrently bool) error {
+	cc := ""
+	if concurrently {
+		cc = " CONCURRENTLY"
+	}
+
+	stmts := []string{
+		`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+
+		fmt.Sprintf(`CREATE INDEX%s IF NOT EXISTS idx_exercises_name_trgm
+			ON exercises USING gin (name gin_trgm_ops)`, cc),
+		fmt.Sprintf(`CREATE INDEX%s IF NOT EXISTS idx_translations_exercise_value_trgm
+			ON translations USING gin (value gin_trgm_ops)
+			WHERE namespace = 'translation' AND key LIKE 'exercise.%%'`, cc),
+		fmt.Sprintf(`CREATE INDEX%s IF NOT EXISTS idx_exercises_muscle_group_equipment
+			ON exercises (muscle_group_id, equipment)`, cc),
 	}
 
 	for _, raw := range stmts {
@@ -110,6 +164,11 @@ func AddUserIndexes(db *gorm.DB, concurrently bool) error {
 
 		fmt.Sprintf(`CREATE INDEX%s IF NOT EXISTS idx_role_permissions_permission_id_role_id
 			ON role_permissions (permission_id, role_id)`, cc),
+
+		fmt.Sprintf(`CREATE INDEX%s IF NOT EXISTS idx_audit_logs_target_created
+			ON audit_logs (target_type, target_id, created_at)`, cc),
+		fmt.Sprintf(`CREATE INDEX%s IF NOT EXISTS idx_audit_logs_actor_created
+			ON audit_logs (actor_id, created_at)`, cc),
 	}
 
 	for _, raw := range stmts {
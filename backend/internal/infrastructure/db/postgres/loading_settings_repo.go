@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/equipment"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LoadingSettingsRepo struct {
+	db *gorm.DB
+}
+
+func NewLoadingSettingsRepo(db *gorm.DB) equipment.LoadingSettingsRepository {
+	return &LoadingSettingsRepo{db: db}
+}
+
+func (r *LoadingSettingsRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *LoadingSettingsRepo) GetByUserID(ctx context.Context, userID uint) (*equipment.LoadingSettings, error) {
+	var s equipment.LoadingSettings
+	err := r.dbFrom(ctx).
+		Preload("Plates", func(db *gorm.DB) *gorm.DB { return db.Order("weight DESC") }).
+		Where("user_id = ?", userID).
+		First(&s).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Upsert stores the settings row for s.UserID and replaces its plates.
+func (r *LoadingSettingsRepo) Upsert(ctx context.Context, s *equipment.LoadingSettings) error {
+	db := r.dbFrom(ctx)
+	plates := s.Plates
+	err := db.Omit("Plates").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"bar_weight", "dumbbell_increment", "updated_at"}),
+	}).Create(s).Error
+	if err != nil {
+		return err
+	}
+
+	if err := db.Where("settings_id = ?", s.ID).Delete(&equipment.LoadingPlate{}).Error; err != nil {
+		return err
+	}
+	for i := range plates {
+		plates[i].SettingsID = s.ID
+	}
+	s.Plates = plates
+	if len(plates) == 0 {
+		return nil
+	}
+	return db.Create(&plates).Error
+}
+
+func (r *LoadingSettingsRepo) DeleteByUserID(ctx context.Context, userID uint) error {
+	return r.dbFrom(ctx).Where("user_id = ?", userID).Delete(&equipment.LoadingSettings{}).Error
+}
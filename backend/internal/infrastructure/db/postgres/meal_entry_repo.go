@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/nutrition"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+)
+
+type MealEntryRepo struct {
+	db *gorm.DB
+}
+
+func NewMealEntryRepo(db *gorm.DB) nutrition.MealEntryRepository {
+	return &MealEntryRepo{db: db}
+}
+
+func (r *MealEntryRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *MealEntryRepo) Create(ctx context.Context, e *nutrition.MealEntry) error {
+	return r.dbFrom(ctx).Omit("Food").Create(e).Error
+}
+
+func (r *MealEntryRepo) GetByID(ctx context.Context, userID, id uint) (*nutrition.MealEntry, error) {
+	var e nutrition.MealEntry
+	if err := r.dbFrom(ctx).Preload("Food").Where("user_id = ? AND id = ?", userID, id).First(&e).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *MealEntryRepo) GetByRange(ctx context.Context, userID uint, from, to time.Time) ([]*nutrition.MealEntry, error) {
+	var entries []*nutrition.MealEntry
+	err := r.dbFrom(ctx).Preload("Food").
+		Where("user_id = ? AND eaten_at >= ? AND eaten_at < ?", userID, from, to).
+		Order("eaten_at ASC").Order("id ASC").
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *MealEntryRepo) CountByFoodID(ctx context.Context, foodID uint) (int64, error) {
+	var n int64
+	err := r.dbFrom(ctx).Model(&nutrition.MealEntry{}).Where("food_id = ?", foodID).Count(&n).Error
+	return n, err
+}
+
+func (r *MealEntryRepo) Update(ctx context.Context, userID, id uint, updates map[string]any) error {
+	res := r.dbFrom(ctx).Model(&nutrition.MealEntry{}).Where("user_id = ? AND id = ?", userID, id).Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
+
+func (r *MealEntryRepo) Delete(ctx context.Context, userID, id uint) error {
+	res := r.dbFrom(ctx).Where("user_id = ? AND id = ?", userID, id).Delete(&nutrition.MealEntry{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
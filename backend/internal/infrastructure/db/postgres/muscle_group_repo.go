@@ -5,6 +5,7 @@ import (
 
 	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -17,21 +18,34 @@ func NewMuscleGroupRepo(db *gorm.DB) workout.MuscleGroupRepository {
 	return &MuscleGroupRepo{db: db}
 }
 
+func (r *MuscleGroupRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
 func (r *MuscleGroupRepo) Create(ctx context.Context, mg *workout.MuscleGroup) error {
-	return r.db.WithContext(ctx).Create(mg).Error
+	return r.dbFrom(ctx).Create(mg).Error
 }
 
 func (r *MuscleGroupRepo) GetByID(ctx context.Context, id uint) (*workout.MuscleGroup, error) {
 	var mg workout.MuscleGroup
-	if err := r.db.WithContext(ctx).First(&mg, id).Error; err != nil {
+	if err := r.dbFrom(ctx).First(&mg, id).Error; err != nil {
 		return nil, err
 	}
 	return &mg, nil
 }
 
+func (r *MuscleGroupRepo) CountByIDs(ctx context.Context, ids []uint) (int64, error) {
+	var n int64
+	err := r.dbFrom(ctx).Model(&workout.MuscleGroup{}).Where("id IN ?", ids).Count(&n).Error
+	return n, err
+}
+
 func (r *MuscleGroupRepo) GetByName(ctx context.Context, name string) (*workout.MuscleGroup, error) {
 	var mg workout.MuscleGroup
-	if err := r.db.WithContext(ctx).Where("name = ?", name).First(&mg).Error; err != nil {
+	if err := r.dbFrom(ctx).Where("name = ?", name).First(&mg).Error; err != nil {
 		return nil, err
 	}
 	return &mg, nil
@@ -39,14 +53,14 @@ func (r *MuscleGroupRepo) GetByName(ctx context.Context, name string) (*workout.
 
 func (r *MuscleGroupRepo) GetAll(ctx context.Context) ([]*workout.MuscleGroup, error) {
 	var muscleGroups []*workout.MuscleGroup
-	if err := r.db.WithContext(ctx).Find(&muscleGroups).Error; err != nil {
+	if err := r.dbFrom(ctx).Find(&muscleGroups).Error; err != nil {
 		return nil, err
 	}
 	return muscleGroups, nil
 }
 
 func (r *MuscleGroupRepo) Update(ctx context.Context, id uint, updates map[string]any) error {
-	res := r.db.WithContext(ctx).Model(&workout.MuscleGroup{}).Where("id = ?", id).Updates(updates)
+	res := r.dbFrom(ctx).Model(&workout.MuscleGroup{}).Where("id = ?", id).Updates(updates)
 	if res.Error != nil {
 		return res.Error
 	}
@@ -58,7 +72,7 @@ func (r *MuscleGroupRepo) Update(ctx context.Context, id uint, updates map[strin
 
 func (r *MuscleGroupRepo) UpdateReturning(ctx context.Context, id uint, updates map[string]any) (*workout.MuscleGroup, error) {
 	var mg workout.MuscleGroup
-	res := r.db.WithContext(ctx).Model(&mg).Where("id = ?", id).Clauses(clause.Returning{}).Updates(updates)
+	res := r.dbFrom(ctx).Model(&mg).Where("id = ?", id).Clauses(clause.Returning{}).Updates(updates)
 	if res.Error != nil {
 		return nil, res.Error
 	}
@@ -69,7 +83,7 @@ func (r *MuscleGroupRepo) UpdateReturning(ctx context.Context, id uint, updates
 }
 
 func (r *MuscleGroupRepo) Delete(ctx context.Context, id uint) error {
-	res := r.db.WithContext(ctx).Delete(&workout.MuscleGroup{}, id)
+	res := r.dbFrom(ctx).Delete(&workout.MuscleGroup{}, id)
 	if res.Error != nil {
 		return res.Error
 	}
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/nutrition"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NutritionGoalRepo struct {
+	db *gorm.DB
+}
+
+func NewNutritionGoalRepo(db *gorm.DB) nutrition.GoalRepository {
+	return &NutritionGoalRepo{db: db}
+}
+
+func (r *NutritionGoalRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *NutritionGoalRepo) GetByUserID(ctx context.Context, userID uint) (*nutrition.NutritionGoal, error) {
+	var g nutrition.NutritionGoal
+	if err := r.dbFrom(ctx).Where("user_id = ?", userID).First(&g).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (r *NutritionGoalRepo) Upsert(ctx context.Context, g *nutrition.NutritionGoal) error {
+	return r.dbFrom(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"goal", "kcal", "protein", "carbs", "fat", "updated_at"}),
+	}).Create(g).Error
+}
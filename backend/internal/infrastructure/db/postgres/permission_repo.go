@@ -2,8 +2,11 @@ package postgres
 
 import (
 	"context"
+	"errors"
+
 	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -16,21 +19,31 @@ func NewPermissionRepo(db *gorm.DB) rbac.PermissionRepository {
 	return &PermissionRepo{db: db}
 }
 
+func (r *PermissionRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
 func (r *PermissionRepo) Create(ctx context.Context, permission *rbac.Permission) error {
-	return r.db.WithContext(ctx).Create(permission).Error
+	return r.dbFrom(ctx).Create(permission).Error
 }
 
 func (r *PermissionRepo) GetByKey(ctx context.Context, permKey string) (*rbac.Permission, error) {
 	var permission rbac.Permission
-	err := r.db.WithContext(ctx).First(&permission, "key = ?", permKey).Error
+	err := r.dbFrom(ctx).First(&permission, "key = ?", permKey).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
 		return nil, err
 	}
 	return &permission, nil
 }
 
 func (r *PermissionRepo) Update(ctx context.Context, id uint, updates map[string]any) error {
-	res := r.db.WithContext(ctx).Model(&rbac.Permission{}).Where("id = ?", id).Updates(updates)
+	res := r.dbFrom(ctx).Model(&rbac.Permission{}).Where("id = ?", id).Updates(updates)
 	if res.Error != nil {
 		return res.Error
 	}
@@ -42,7 +55,7 @@ func (r *PermissionRepo) Update(ctx context.Context, id uint, updates map[string
 
 func (r *PermissionRepo) UpdateReturning(ctx context.Context, id uint, updates map[string]any) (*rbac.Permission, error) {
 	var permission rbac.Permission
-	res := r.db.WithContext(ctx).Model(&permission).Where("id = ?", id).Clauses(clause.Returning{}).Updates(updates)
+	res := r.dbFrom(ctx).Model(&permission).Where("id = ?", id).Clauses(clause.Returning{}).Updates(updates)
 	if res.Error != nil {
 		return nil, res.Error
 	}
@@ -53,7 +66,7 @@ func (r *PermissionRepo) UpdateReturning(ctx context.Context, id uint, updates m
 }
 
 func (r *PermissionRepo) Delete(ctx context.Context, permKey string) error {
-	res := r.db.WithContext(ctx).Where("key = ?", permKey).Delete(&rbac.Permission{})
+	res := r.dbFrom(ctx).Where("key = ?", permKey).Delete(&rbac.Permission{})
 	if res.Error != nil {
 		return res.Error
 	}
@@ -65,7 +78,7 @@ func (r *PermissionRepo) Delete(ctx context.Context, permKey string) error {
 
 func (r *PermissionRepo) GetAll(ctx context.Context) ([]*rbac.Permission, error) {
 	var permissions []*rbac.Permission
-	err := r.db.WithContext(ctx).Find(&permissions).Error
+	err := r.dbFrom(ctx).Find(&permissions).Error
 	if err != nil {
 		return nil, err
 	}
@@ -74,7 +87,7 @@ func (r *PermissionRepo) GetAll(ctx context.Context) ([]*rbac.Permission, error)
 
 func (r *PermissionRepo) GetRolePermissionsByRoleID(ctx context.Context, roleName string) ([]*rbac.Permission, error) {
 	var permissions []*rbac.Permission
-	err := r.db.WithContext(ctx).Table("permissions").
+	err := r.dbFrom(ctx).Table("permissions").
 		Select("permissions.*").
 		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
 		Where("role_permissions.role_id = (SELECT id FROM roles WHERE name = ?)", roleName).
@@ -87,12 +100,12 @@ func (r *PermissionRepo) GetRolePermissionsByRoleID(ctx context.Context, roleNam
 
 func (r *PermissionRepo) AssignPermissionToRole(ctx context.Context, roleName, permKey string) error {
 	var role rbac.Role
-	err := r.db.WithContext(ctx).Model(&rbac.Role{}).Where("name = ?", roleName).First(&role).Error
+	err := r.dbFrom(ctx).Model(&rbac.Role{}).Where("name = ?", roleName).First(&role).Error
 	if err != nil {
 		return err
 	}
 	var permission rbac.Permission
-	err = r.db.WithContext(ctx).Model(&rbac.Permission{}).Where("key = ?", permKey).First(&permission).Error
+	err = r.dbFrom(ctx).Model(&rbac.Permission{}).Where("key = ?", permKey).First(&permission).Error
 	if err != nil {
 		return err
 	}
@@ -100,7 +113,9 @@ func (r *PermissionRepo) AssignPermissionToRole(ctx context.Context, roleName, p
 		RoleID:       role.ID,
 		PermissionID: permission.ID,
 	}
-	res := r.db.WithContext(ctx).Create(&rolePermission)
+	res := r.dbFrom(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&rolePermission)
 	if res.Error != nil {
 		return res.Error
 	}
@@ -109,12 +124,12 @@ func (r *PermissionRepo) AssignPermissionToRole(ctx context.Context, roleName, p
 
 func (r *PermissionRepo) RemovePermissionFromRole(ctx context.Context, roleName, permKey string) error {
 	var role rbac.Role
-	err := r.db.WithContext(ctx).Model(&rbac.Role{}).Where("name = ?", roleName).First(&role).Error
+	err := r.dbFrom(ctx).Model(&rbac.Role{}).Where("name = ?", roleName).First(&role).Error
 	if err != nil {
 		return err
 	}
 	var permission rbac.Permission
-	err = r.db.WithContext(ctx).Model(&rbac.Permission{}).Where("key = ?", permKey).First(&permission).Error
+	err = r.dbFrom(ctx).Model(&rbac.Permission{}).Where("key = ?", permKey).First(&permission).Error
 	if err != nil {
 		return err
 	}
@@ -122,7 +137,7 @@ func (r *PermissionRepo) RemovePermissionFromRole(ctx context.Context, roleName,
 		RoleID:       role.ID,
 		PermissionID: permission.ID,
 	}
-	res := r.db.WithContext(ctx).Delete(&rolePermission)
+	res := r.dbFrom(ctx).Delete(&rolePermission)
 	if res.Error != nil {
 		return res.Error
 	}
@@ -131,7 +146,7 @@ func (r *PermissionRepo) RemovePermissionFromRole(ctx context.Context, roleName,
 
 func (r *PermissionRepo) GetUserPermissions(ctx context.Context, userID uint) ([]*rbac.Permission, error) {
 	var permissions []*rbac.Permission
-	err := r.db.WithContext(ctx).Model(&rbac.Permission{}).
+	err := r.dbFrom(ctx).Model(&rbac.Permission{}).
 		Table("permissions").
 		Select("DISTINCT permissions.*").
 		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/auth"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+)
+
+type PersonalAccessTokenRepo struct {
+	db *gorm.DB
+}
+
+func NewPersonalAccessTokenRepo(db *gorm.DB) auth.PersonalAccessTokenRepository {
+	return &PersonalAccessTokenRepo{db: db}
+}
+
+func (r *PersonalAccessTokenRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *PersonalAccessTokenRepo) Create(ctx context.Context, t *auth.PersonalAccessToken) error {
+	return r.dbFrom(ctx).Create(t).Error
+}
+
+func (r *PersonalAccessTokenRepo) GetByHash(ctx context.Context, tokenHash string) (*auth.PersonalAccessToken, error) {
+	var t auth.PersonalAccessToken
+	if err := r.dbFrom(ctx).Where("token_hash = ?", tokenHash).First(&t).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrInvalidToken
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *PersonalAccessTokenRepo) GetByUserID(ctx context.Context, userID uint) ([]*auth.PersonalAccessToken, error) {
+	var tokens []*auth.PersonalAccessToken
+	err := r.dbFrom(ctx).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (r *PersonalAccessTokenRepo) Revoke(ctx context.Context, userID, id uint, at time.Time) error {
+	res := r.dbFrom(ctx).Model(&auth.PersonalAccessToken{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", at)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PersonalAccessTokenRepo) TouchLastUsed(ctx context.Context, id uint, at time.Time) error {
+	return r.dbFrom(ctx).Model(&auth.PersonalAccessToken{}).
+		Where("id = ?", id).
+		Update("last_used_at", at).Error
+}
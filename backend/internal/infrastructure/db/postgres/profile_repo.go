@@ -6,6 +6,7 @@ import (
 
 	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -18,13 +19,20 @@ func NewProfileRepo(db *gorm.DB) user.ProfileRepository {
 	return &ProfileRepo{db}
 }
 
+func (r *ProfileRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
 func (r *ProfileRepo) Create(ctx context.Context, p *user.Profile) error {
-	return r.db.WithContext(ctx).Create(p).Error
+	return r.dbFrom(ctx).Create(p).Error
 }
 
 func (r *ProfileRepo) GetByUserID(ctx context.Context, userID uint) (*user.Profile, error) {
 	var p user.Profile
-	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&p).Error; err != nil {
+	if err := r.dbFrom(ctx).Where("user_id = ?", userID).First(&p).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, custom_err.ErrProfileNotFound
 		}
@@ -34,7 +42,7 @@ func (r *ProfileRepo) GetByUserID(ctx context.Context, userID uint) (*user.Profi
 }
 
 func (r *ProfileRepo) Update(ctx context.Context, id uint, updates map[string]any) error {
-	res := r.db.WithContext(ctx).Model(&user.Profile{}).Where("user_id = ?", id).Updates(updates)
+	res := r.dbFrom(ctx).Model(&user.Profile{}).Where("user_id = ?", id).Updates(updates)
 	if res.Error != nil {
 		return res.Error
 	}
@@ -46,7 +54,7 @@ func (r *ProfileRepo) Update(ctx context.Context, id uint, updates map[string]an
 
 func (r *ProfileRepo) UpdateReturning(ctx context.Context, id uint, updates map[string]any) (*user.Profile, error) {
 	var p user.Profile
-	res := r.db.WithContext(ctx).Model(&p).Where("user_id = ?", id).Clauses(clause.Returning{}).Updates(updates)
+	res := r.dbFrom(ctx).Model(&p).Where("user_id = ?", id).Clauses(clause.Returning{}).Updates(updates)
 	if res.Error != nil {
 		return nil, res.Error
 	}
@@ -57,7 +65,7 @@ func (r *ProfileRepo) UpdateReturning(ctx context.Context, id uint, updates map[
 }
 
 func (r *ProfileRepo) Delete(ctx context.Context, id uint) error {
-	res := r.db.WithContext(ctx).Delete(&user.Profile{}, id)
+	res := r.dbFrom(ctx).Delete(&user.Profile{}, id)
 	if res.Error != nil {
 		return res.Error
 	}
@@ -2,8 +2,11 @@ package postgres
 
 import (
 	"context"
+	"errors"
+
 	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -16,21 +19,31 @@ func NewRoleRepo(db *gorm.DB) rbac.RoleRepository {
 	return &RoleRepo{db: db}
 }
 
+func (r *RoleRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
 func (r *RoleRepo) Create(ctx context.Context, role *rbac.Role) error {
-	return r.db.WithContext(ctx).Create(role).Error
+	return r.dbFrom(ctx).Create(role).Error
 }
 
 func (r *RoleRepo) GetByName(ctx context.Context, roleName string) (*rbac.Role, error) {
 	var role rbac.Role
-	err := r.db.WithContext(ctx).Where("name = ?", roleName).First(&role).Error
+	err := r.dbFrom(ctx).Where("name = ?", roleName).First(&role).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
 		return nil, err
 	}
 	return &role, nil
 }
 
 func (r *RoleRepo) Update(ctx context.Context, id uint, updates map[string]any) error {
-	res := r.db.WithContext(ctx).Model(&rbac.Role{}).Where("id = ?", id).Updates(updates)
+	res := r.dbFrom(ctx).Model(&rbac.Role{}).Where("id = ?", id).Updates(updates)
 	if res.Error != nil {
 		return res.Error
 	}
@@ -42,7 +55,7 @@ func (r *RoleRepo) Update(ctx context.Context, id uint, updates map[string]any)
 
 func (r *RoleRepo) UpdateReturning(ctx context.Context, id uint, updates map[string]any) (*rbac.Role, error) {
 	var role rbac.Role
-	res := r.db.WithContext(ctx).Model(&role).Where("id = ?", id).Clauses(clause.Returning{}).Updates(updates)
+	res := r.dbFrom(ctx).Model(&role).Where("id = ?", id).Clauses(clause.Returning{}).Updates(updates)
 	if res.Error != nil {
 		return nil, res.Error
 	}
@@ -53,7 +66,7 @@ func (r *RoleRepo) UpdateReturning(ctx context.Context, id uint, updates map[str
 }
 
 func (r *RoleRepo) Delete(ctx context.Context, roleName string) error {
-	res := r.db.WithContext(ctx).Where("name = ?", roleName).Delete(&rbac.Role{})
+	res := r.dbFrom(ctx).Where("name = ?", roleName).Delete(&rbac.Role{})
 	if res.Error != nil {
 		return res.Error
 	}
@@ -65,7 +78,7 @@ func (r *RoleRepo) Delete(ctx context.Context, roleName string) error {
 
 func (r *RoleRepo) GetAll(ctx context.Context) ([]*rbac.Role, error) {
 	var roles []*rbac.Role
-	err := r.db.WithContext(ctx).Find(&roles).Error
+	err := r.dbFrom(ctx).Find(&roles).Error
 	if err != nil {
 		return nil, err
 	}
@@ -74,7 +87,7 @@ func (r *RoleRepo) GetAll(ctx context.Context) ([]*rbac.Role, error) {
 
 func (r *RoleRepo) GetUserRoles(ctx context.Context, userID uint) ([]*rbac.Role, error) {
 	var roles []*rbac.Role
-	err := r.db.WithContext(ctx).Model(&rbac.Role{}).
+	err := r.dbFrom(ctx).Model(&rbac.Role{}).
 		Table("roles").
 		Select("roles.*").
 		Joins("JOIN user_roles ON user_roles.role_id = roles.id").
@@ -88,7 +101,7 @@ func (r *RoleRepo) GetUserRoles(ctx context.Context, userID uint) ([]*rbac.Role,
 
 func (r *RoleRepo) AssignRoleToUser(ctx context.Context, userID uint, roleName string) error {
 	var role rbac.Role
-	err := r.db.WithContext(ctx).Model(&rbac.Role{}).Where("name = ?", roleName).First(&role).Error
+	err := r.dbFrom(ctx).Model(&rbac.Role{}).Where("name = ?", roleName).First(&role).Error
 	if err != nil {
 		return err
 	}
@@ -96,7 +109,7 @@ func (r *RoleRepo) AssignRoleToUser(ctx context.Context, userID uint, roleName s
 		UserID: userID,
 		RoleID: role.ID,
 	}
-	err = r.db.WithContext(ctx).
+	err = r.dbFrom(ctx).
 		Clauses(clause.OnConflict{
 			Columns:   []clause.Column{{Name: "user_id"}, {Name: "role_id"}},
 			DoNothing: true,
@@ -110,7 +123,7 @@ func (r *RoleRepo) AssignRoleToUser(ctx context.Context, userID uint, roleName s
 
 func (r *RoleRepo) RemoveRoleFromUser(ctx context.Context, userID uint, roleName string) error {
 	var role rbac.Role
-	err := r.db.WithContext(ctx).Model(&rbac.Role{}).Where("name = ?", roleName).First(&role).Error
+	err := r.dbFrom(ctx).Model(&rbac.Role{}).Where("name = ?", roleName).First(&role).Error
 	if err != nil {
 		return err
 	}
@@ -118,7 +131,7 @@ func (r *RoleRepo) RemoveRoleFromUser(ctx context.Context, userID uint, roleName
 		UserID: userID,
 		RoleID: role.ID,
 	}
-	res := r.db.WithContext(ctx).Delete(&userRole)
+	res := r.dbFrom(ctx).Delete(&userRole)
 	if res.Error != nil {
 		return res.Error
 	}
@@ -126,7 +139,7 @@ func (r *RoleRepo) RemoveRoleFromUser(ctx context.Context, userID uint, roleName
 }
 
 func (r *RoleRepo) ClearUserRoles(ctx context.Context, userID uint) error {
-	res := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&rbac.UserRole{})
+	res := r.dbFrom(ctx).Where("user_id = ?", userID).Delete(&rbac.UserRole{})
 	if res.Error != nil {
 		return res.Error
 	}
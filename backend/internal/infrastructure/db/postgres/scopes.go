@@ -94,3 +94,10 @@ func PreloadWorkoutExerciseFull(db *gorm.DB) *gorm.DB {
 		Preload("IndividualExercise.MuscleGroup").
 		Preload("IndividualExercise.Exercise")
 }
+
+// PreloadExerciseCatalog loads everything the catalog shows next to an exercise.
+func PreloadExerciseCatalog(db *gorm.DB) *gorm.DB {
+	return db.Preload("MuscleGroup").
+		Preload("SecondaryMuscleGroups").
+		Preload("Media", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC").Order("id ASC") })
+}
@@ -64,6 +64,9 @@ func SeedRBAC(db *gorm.DB) error {
 			rbac.RoleRestricted: {
 				rbac.PermUserReadSelf, rbac.PermWorkoutReadSelf,
 			},
+			rbac.RoleCoach: {
+				rbac.PermWorkoutReadOthers, rbac.PermWorkoutWriteOthers,
+			},
 		}
 
 		for roleName, keys := range rolePerms {
@@ -74,7 +77,12 @@ func SeedRBAC(db *gorm.DB) error {
 				return err
 			}
 
+			// Admins may edit built-in roles, so only a new role gets its defaults. Admin is
+			// protected from edits and always gets its full set back.
 			if role.ID == 0 {
+				if roleName != rbac.RoleAdmin {
+					continue
+				}
 				if err := tx.Where("name = ?", roleName).First(&role).Error; err != nil {
 					return err
 				}
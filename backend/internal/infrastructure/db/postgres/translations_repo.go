@@ -6,7 +6,9 @@ import (
 	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
 
 	"github.com/lordmitrii/golang-web-gin/internal/domain/translations"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type translationsRepo struct {
@@ -18,20 +20,35 @@ func NewTranslationsRepo(db *gorm.DB) translations.TranslationRepository {
 		db: db,
 	}
 }
+
+func (r *translationsRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
 func (r *translationsRepo) Create(ctx context.Context, translation *translations.Translation) error {
-	return r.db.WithContext(ctx).Create(translation).Error
+	return r.dbFrom(ctx).Create(translation).Error
+}
+
+func (r *translationsRepo) Upsert(ctx context.Context, translation *translations.Translation) error {
+	return r.dbFrom(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "namespace"}, {Name: "locale"}, {Name: "key"}},
+		DoUpdates: clause.Assignments(map[string]any{"value": translation.Value, "updated_at": gorm.Expr("NOW()")}),
+	}).Create(translation).Error
 }
 
 func (r *translationsRepo) GetByID(ctx context.Context, id uint) (*translations.Translation, error) {
 	var translation translations.Translation
-	if err := r.db.WithContext(ctx).First(&translation, id).Error; err != nil {
+	if err := r.dbFrom(ctx).First(&translation, id).Error; err != nil {
 		return nil, err
 	}
 	return &translation, nil
 }
 
 func (r *translationsRepo) Update(ctx context.Context, id uint, updates map[string]any) error {
-	res := r.db.WithContext(ctx).Model(&translations.Translation{}).Where("id = ?", id).Updates(updates)
+	res := r.dbFrom(ctx).Model(&translations.Translation{}).Where("id = ?", id).Updates(updates)
 	if res.Error != nil {
 		return res.Error
 	}
@@ -42,13 +59,13 @@ func (r *translationsRepo) Update(ctx context.Context, id uint, updates map[stri
 }
 
 func (r *translationsRepo) Delete(ctx context.Context, id uint) error {
-	res := r.db.WithContext(ctx).Delete(&translations.Translation{}, id)
+	res := r.dbFrom(ctx).Delete(&translations.Translation{}, id)
 	return res.Error
 }
 
 func (r *translationsRepo) GetByNamespaceAndLocale(ctx context.Context, namespace, locale string) ([]*translations.Translation, error) {
 	var translationsList []*translations.Translation
-	if err := r.db.WithContext(ctx).Where("namespace = ? AND locale = ?", namespace, locale).Find(&translationsList).Error; err != nil {
+	if err := r.dbFrom(ctx).Where("namespace = ? AND locale = ?", namespace, locale).Find(&translationsList).Error; err != nil {
 		return nil, err
 	}
 	return translationsList, nil
@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// AddAuditLogGuards makes audit_logs append-only at the database level. Deletes stay allowed
+// so that the retention job can prune old rows.
+func AddAuditLogGuards(db *gorm.DB) error {
+	stmts := []string{
+		`CREATE OR REPLACE FUNCTION audit_logs_forbid_update() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit_logs is append-only';
+		END;
+		$$ LANGUAGE plpgsql`,
+
+		`DROP TRIGGER IF EXISTS trg_audit_logs_no_update ON audit_logs`,
+
+		`CREATE TRIGGER trg_audit_logs_no_update
+			BEFORE UPDATE ON audit_logs
+			FOR EACH ROW EXECUTE FUNCTION audit_logs_forbid_update()`,
+	}
+
+	for _, raw := range stmts {
+		sql := strings.Join(strings.Fields(raw), " ")
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
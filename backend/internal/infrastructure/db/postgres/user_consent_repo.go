@@ -5,6 +5,7 @@ import (
 
 	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -17,20 +18,32 @@ func NewUserConsentRepository(db *gorm.DB) user.UserConsentRepository {
 	return &userConsentRepo{db: db}
 }
 
+func (r *userConsentRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Create records the user's answer for a consent type, replacing an earlier answer, for
+// instance one given to a previous policy version.
 func (r *userConsentRepo) Create(ctx context.Context, uc *user.UserConsent) error {
-	return r.db.WithContext(ctx).Create(uc).Error
+	return r.dbFrom(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"version", "given", "updated_at"}),
+	}).Create(uc).Error
 }
 
 func (r *userConsentRepo) GetByUserID(ctx context.Context, userID uint) ([]*user.UserConsent, error) {
 	var ucs []*user.UserConsent
-	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&ucs).Error; err != nil {
+	if err := r.dbFrom(ctx).Where("user_id = ?", userID).Find(&ucs).Error; err != nil {
 		return nil, err
 	}
 	return ucs, nil
 }
 
 func (r *userConsentRepo) Update(ctx context.Context, id uint, updates map[string]any) error {
-	res := r.db.WithContext(ctx).Model(&user.UserConsent{}).Where("user_id = ?", id).Updates(updates)
+	res := r.dbFrom(ctx).Model(&user.UserConsent{}).Where("user_id = ?", id).Updates(updates)
 	if res.Error != nil {
 		return res.Error
 	}
@@ -42,7 +55,7 @@ func (r *userConsentRepo) Update(ctx context.Context, id uint, updates map[strin
 
 func (r *userConsentRepo) UpdateReturning(ctx context.Context, id uint, updates map[string]any) (*user.UserConsent, error) {
 	var uc user.UserConsent
-	res := r.db.WithContext(ctx).Model(&uc).Where("user_id = ?", id).Clauses(clause.Returning{}).Updates(updates)
+	res := r.dbFrom(ctx).Model(&uc).Where("user_id = ?", id).Clauses(clause.Returning{}).Updates(updates)
 	if res.Error != nil {
 		return nil, res.Error
 	}
@@ -53,7 +66,7 @@ func (r *userConsentRepo) UpdateReturning(ctx context.Context, id uint, updates
 }
 
 func (r *userConsentRepo) DeleteByUserIDAndType(ctx context.Context, userID uint, consentType, version string) error {
-	res := r.db.WithContext(ctx).Where("user_id = ? AND type = ? AND version = ?", userID, consentType, version).Delete(&user.UserConsent{})
+	res := r.dbFrom(ctx).Where("user_id = ? AND type = ? AND version = ?", userID, consentType, version).Delete(&user.UserConsent{})
 	if res.Error != nil {
 		return res.Error
 	}
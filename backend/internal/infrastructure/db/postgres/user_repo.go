@@ -8,6 +8,7 @@ import (
 
 	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -20,13 +21,20 @@ func NewUserRepo(db *gorm.DB) user.UserRepository {
 	return &UserRepo{db}
 }
 
+func (r *UserRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
 func (r *UserRepo) Create(ctx context.Context, u *user.User) error {
-	return r.db.WithContext(ctx).Create(u).Error
+	return r.dbFrom(ctx).Create(u).Error
 }
 
 func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*user.User, error) {
 	var u user.User
-	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&u).Error; err != nil {
+	if err := r.dbFrom(ctx).Where("email = ?", email).First(&u).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, custom_err.ErrUserNotFound
 		}
@@ -37,7 +45,7 @@ func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*user.User, er
 
 func (r *UserRepo) GetByUsername(ctx context.Context, username string) (*user.User, error) {
 	var u user.User
-	if err := r.db.WithContext(ctx).Where("username = ?", username).First(&u).Error; err != nil {
+	if err := r.dbFrom(ctx).Where("username = ?", username).First(&u).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, custom_err.ErrUserNotFound
 		}
@@ -48,7 +56,7 @@ func (r *UserRepo) GetByUsername(ctx context.Context, username string) (*user.Us
 
 func (r *UserRepo) GetByID(ctx context.Context, id uint) (*user.User, error) {
 	var u user.User
-	if err := r.db.WithContext(ctx).Preload("Roles").Where("id = ?", id).First(&u).Error; err != nil {
+	if err := r.dbFrom(ctx).Preload("Roles").Where("id = ?", id).First(&u).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, custom_err.ErrUserNotFound
 		}
@@ -58,7 +66,7 @@ func (r *UserRepo) GetByID(ctx context.Context, id uint) (*user.User, error) {
 }
 
 func (r *UserRepo) Update(ctx context.Context, id uint, updates map[string]any) error {
-	res := r.db.WithContext(ctx).Model(&user.User{}).Where("id = ?", id).Updates(updates)
+	res := r.dbFrom(ctx).Model(&user.User{}).Where("id = ?", id).Updates(updates)
 	if res.Error != nil {
 		return res.Error
 	}
@@ -70,7 +78,7 @@ func (r *UserRepo) Update(ctx context.Context, id uint, updates map[string]any)
 
 func (r *UserRepo) UpdateReturning(ctx context.Context, id uint, updates map[string]any) (*user.User, error) {
 	var user user.User
-	res := r.db.WithContext(ctx).
+	res := r.dbFrom(ctx).
 		Model(&user).Where("id = ?", id).
 		Clauses(clause.Returning{}).
 		Updates(updates)
@@ -84,7 +92,7 @@ func (r *UserRepo) UpdateReturning(ctx context.Context, id uint, updates map[str
 }
 
 func (r *UserRepo) UpdateByEmail(ctx context.Context, email string, updates map[string]any) error {
-	res := r.db.WithContext(ctx).Model(&user.User{}).Where("email = ?", email).Updates(updates)
+	res := r.dbFrom(ctx).Model(&user.User{}).Where("email = ?", email).Updates(updates)
 	if res.Error != nil {
 		return res.Error
 	}
@@ -95,7 +103,7 @@ func (r *UserRepo) UpdateByEmail(ctx context.Context, email string, updates map[
 }
 
 func (r *UserRepo) Delete(ctx context.Context, id uint) error {
-	res := r.db.WithContext(ctx).Delete(&user.User{}, id)
+	res := r.dbFrom(ctx).Delete(&user.User{}, id)
 	if res.Error != nil {
 		return res.Error
 	}
@@ -107,7 +115,7 @@ func (r *UserRepo) Delete(ctx context.Context, id uint) error {
 
 func (r *UserRepo) CheckEmail(ctx context.Context, email string) (bool, error) {
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&user.User{}).Where("email = ?", email).Count(&count).Error; err != nil {
+	if err := r.dbFrom(ctx).Model(&user.User{}).Where("email = ?", email).Count(&count).Error; err != nil {
 		return false, err
 	}
 	return count > 0, nil
@@ -117,7 +125,7 @@ func (r *UserRepo) GetUsers(ctx context.Context, q string, page, pageSize int64,
 	var users []*user.User
 	var total int64
 
-	db := r.db.WithContext(ctx).Model(&user.User{})
+	db := r.dbFrom(ctx).Model(&user.User{})
 	if q != "" {
 		query := "%" + q + "%"
 		db = db.Where("username ILIKE ? OR email ILIKE ? ", query, query)
@@ -149,3 +157,23 @@ func (r *UserRepo) GetUsers(ctx context.Context, q string, page, pageSize int64,
 
 	return users, total, nil
 }
+
+func (r *UserRepo) GetByRole(ctx context.Context, roleName string, page, pageSize int64) ([]*user.User, int64, error) {
+	var users []*user.User
+	var total int64
+
+	db := r.dbFrom(ctx).Model(&user.User{}).
+		Joins("JOIN user_roles ON user_roles.user_id = users.id").
+		Joins("JOIN roles ON roles.id = user_roles.role_id").
+		Where("roles.name = ?", roleName)
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := db.Offset(int((page - 1) * pageSize)).Limit(int(pageSize)).Order("users.id ASC").Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
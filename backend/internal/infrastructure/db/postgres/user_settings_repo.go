@@ -5,6 +5,7 @@ import (
 
 	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -17,13 +18,20 @@ func NewUserSettingsRepo(db *gorm.DB) user.UserSettingsRepository {
 	return &UserSettingsRepo{db}
 }
 
+func (r *UserSettingsRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
 func (r *UserSettingsRepo) Create(ctx context.Context, us *user.UserSettings) error {
-	return r.db.Create(us).Error
+	return r.dbFrom(ctx).Create(us).Error
 }
 
 func (r *UserSettingsRepo) GetByUserID(ctx context.Context, userID uint) (*user.UserSettings, error) {
 	var settings user.UserSettings
-	if err := r.db.Where("user_id = ?", userID).First(&settings).Error; err != nil {
+	if err := r.dbFrom(ctx).Where("user_id = ?", userID).First(&settings).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, custom_err.ErrNotFound
 		}
@@ -37,7 +45,7 @@ func (r *UserSettingsRepo) Update(ctx context.Context, userID uint, updates map[
 		return nil
 	}
 
-	res := r.db.Model(&user.UserSettings{}).Where("user_id = ?", userID).Updates(updates)
+	res := r.dbFrom(ctx).Model(&user.UserSettings{}).Where("user_id = ?", userID).Updates(updates)
 	if res.Error != nil {
 		return res.Error
 	}
@@ -49,7 +57,7 @@ func (r *UserSettingsRepo) Update(ctx context.Context, userID uint, updates map[
 
 func (r *UserSettingsRepo) UpdateReturning(ctx context.Context, userID uint, updates map[string]any) (*user.UserSettings, error) {
 	var settings user.UserSettings
-	res := r.db.Model(&settings).Where("user_id = ?", userID).Clauses(clause.Returning{}).Updates(updates)
+	res := r.dbFrom(ctx).Model(&settings).Where("user_id = ?", userID).Clauses(clause.Returning{}).Updates(updates)
 	if res.Error != nil {
 		return nil, res.Error
 	}
@@ -60,7 +68,7 @@ func (r *UserSettingsRepo) UpdateReturning(ctx context.Context, userID uint, upd
 }
 
 func (r *UserSettingsRepo) Delete(ctx context.Context, userID uint) error {
-	res := r.db.Delete(&user.UserSettings{}, userID)
+	res := r.dbFrom(ctx).Delete(&user.UserSettings{}, userID)
 	if res.Error != nil {
 		return res.Error
 	}
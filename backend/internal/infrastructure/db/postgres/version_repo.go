@@ -2,7 +2,11 @@ package postgres
 
 import (
 	"context"
+	"errors"
+
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/versions"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
 	"gorm.io/gorm"
 )
 
@@ -18,13 +22,20 @@ func NewVersionRepository(db *gorm.DB) versions.VersionRepository {
 	}
 }
 
+func (r *versionRepository) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
 func (r *versionRepository) Create(ctx context.Context, version *versions.Version) error {
-	return r.db.Create(version).Error
+	return r.dbFrom(ctx).Create(version).Error
 }
 
 func (r *versionRepository) GetByID(ctx context.Context, id uint) (*versions.Version, error) {
 	var version versions.Version
-	if err := r.db.First(&version, id).Error; err != nil {
+	if err := r.dbFrom(ctx).First(&version, id).Error; err != nil {
 		return nil, err
 	}
 	return &version, nil
@@ -32,7 +43,10 @@ func (r *versionRepository) GetByID(ctx context.Context, id uint) (*versions.Ver
 
 func (r *versionRepository) GetByKey(ctx context.Context, key string) (*versions.Version, error) {
 	var version versions.Version
-	if err := r.db.Where("key = ?", key).First(&version).Error; err != nil {
+	if err := r.dbFrom(ctx).Where("key = ?", key).First(&version).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
 		return nil, err
 	}
 	return &version, nil
@@ -40,25 +54,25 @@ func (r *versionRepository) GetByKey(ctx context.Context, key string) (*versions
 
 func (r *versionRepository) GetAll(ctx context.Context) ([]*versions.Version, error) {
 	var versionsList []*versions.Version
-	if err := r.db.Find(&versionsList).Error; err != nil {
+	if err := r.dbFrom(ctx).Find(&versionsList).Error; err != nil {
 		return nil, err
 	}
 	return versionsList, nil
 }
 
 func (r *versionRepository) Update(ctx context.Context, version *versions.Version) error {
-	return r.db.Save(version).Error
+	return r.dbFrom(ctx).Save(version).Error
 }
 
 func (r *versionRepository) Delete(ctx context.Context, id uint) error {
-	return r.db.Delete(&versions.Version{}, id).Error
+	return r.dbFrom(ctx).Delete(&versions.Version{}, id).Error
 }
 
 func (r *versionRepository) BumpVersion(ctx context.Context, key string) error {
 	var v versions.Version
-	if err := r.db.Where("key = ?", key).First(&v).Error; err != nil {
-		return r.db.Create(&versions.Version{Key: key, Version: DEFAULT_VERSION}).Error
+	if err := r.dbFrom(ctx).Where("key = ?", key).First(&v).Error; err != nil {
+		return r.dbFrom(ctx).Create(&versions.Version{Key: key, Version: DEFAULT_VERSION}).Error
 	}
 	v.Increment()
-	return r.db.Save(&v).Error
+	return r.dbFrom(ctx).Save(&v).Error
 }
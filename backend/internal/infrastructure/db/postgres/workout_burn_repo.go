@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/nutrition"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+)
+
+// WorkoutBurnRepo reads estimated workout calories for the nutrition balance.
+type WorkoutBurnRepo struct {
+	db *gorm.DB
+}
+
+func NewWorkoutBurnRepo(db *gorm.DB) nutrition.BurnRepository {
+	return &WorkoutBurnRepo{db: db}
+}
+
+func (r *WorkoutBurnRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+// WorkoutCalories places a workout on the day it was completed, as achievements and goals
+// do. Workouts in deleted plans are left out; cycles are not soft-deleted.
+func (r *WorkoutBurnRepo) WorkoutCalories(ctx context.Context, userID uint, from, to time.Time) ([]nutrition.WorkoutBurn, error) {
+	var out []nutrition.WorkoutBurn
+	err := r.dbFrom(ctx).Raw(`
+		SELECT w.id AS workout_id, w.name, w.estimated_calories AS kcal FROM workouts w
+		JOIN workout_cycles wc ON wc.id = w.workout_cycle_id
+		JOIN workout_plans wp ON wp.id = wc.workout_plan_id
+		WHERE wp.user_id = ? AND wp.deleted_at IS NULL AND w.completed
+			AND `+workoutCompletedAt+` >= ? AND `+workoutCompletedAt+` < ?
+		ORDER BY `+workoutCompletedAt+` ASC`, userID, from, to).Scan(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
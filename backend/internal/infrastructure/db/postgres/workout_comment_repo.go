@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/coaching"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+)
+
+type WorkoutCommentRepo struct {
+	db *gorm.DB
+}
+
+func NewWorkoutCommentRepo(db *gorm.DB) coaching.WorkoutCommentRepository {
+	return &WorkoutCommentRepo{db: db}
+}
+
+func (r *WorkoutCommentRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *WorkoutCommentRepo) Create(ctx context.Context, c *coaching.WorkoutComment) error {
+	if err := r.dbFrom(ctx).Create(c).Error; err != nil {
+		return err
+	}
+	return r.dbFrom(ctx).Preload("Author").First(c, c.ID).Error
+}
+
+func (r *WorkoutCommentRepo) GetByID(ctx context.Context, id uint) (*coaching.WorkoutComment, error) {
+	var c coaching.WorkoutComment
+	err := r.dbFrom(ctx).First(&c, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *WorkoutCommentRepo) GetByWorkoutID(ctx context.Context, workoutID uint) ([]*coaching.WorkoutComment, error) {
+	var out []*coaching.WorkoutComment
+	err := r.dbFrom(ctx).Preload("Author").
+		Where("workout_id = ?", workoutID).
+		Order("created_at ASC").
+		Find(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *WorkoutCommentRepo) Delete(ctx context.Context, id uint) error {
+	res := r.dbFrom(ctx).Delete(&coaching.WorkoutComment{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
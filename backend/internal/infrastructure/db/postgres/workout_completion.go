@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// workoutCompletedAt is when a completed workout (aliased w) was done. Workouts completed
+// before completed_at was recorded fall back to their last update.
+const workoutCompletedAt = "COALESCE(w.completed_at, w.updated_at)"
+
+// completionTimes returns when each of the user's completed workouts was done. Workouts in
+// deleted plans are left out; cycles are not soft-deleted.
+func completionTimes(db *gorm.DB, userID uint) ([]time.Time, error) {
+	var out []time.Time
+	err := db.Raw(`
+		SELECT `+workoutCompletedAt+` FROM workouts w
+		JOIN workout_cycles wc ON wc.id = w.workout_cycle_id
+		JOIN workout_plans wp ON wp.id = wc.workout_plan_id
+		WHERE wp.user_id = ? AND wp.deleted_at IS NULL AND w.completed
+			AND `+workoutCompletedAt+` IS NOT NULL`, userID).Scan(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -311,6 +311,57 @@ func (r *WorkoutExerciseRepo) GetSessionPerformancesByIndividualExerciseID(ctx c
 	return perf, nil
 }
 
+// GetCompletedSessionPerformances dates each session by workoutCompletedAt, the same
+// completion time goals, achievements and burns use.
+func (r *WorkoutExerciseRepo) GetCompletedSessionPerformances(ctx context.Context, userId, individualExerciseID, excludeWorkoutID uint) ([]*workout.ExercisePerformance, error) {
+	db := r.dbFrom(ctx)
+
+	type sessionPerformanceRow struct {
+		CompletedAt *time.Time
+		Weight      int
+		Reps        int
+	}
+
+	var rows []sessionPerformanceRow
+	err := db.Raw(`
+		SELECT completed_at, weight, reps
+		FROM (
+			SELECT
+				`+workoutCompletedAt+` AS completed_at,
+				ws.weight,
+				ws.reps,
+				ROW_NUMBER() OVER (
+					PARTITION BY ws.workout_exercise_id
+					ORDER BY ws.weight * ws.reps DESC, ws.weight DESC
+				) AS rn
+			FROM workout_sets ws
+			JOIN workout_exercises we ON ws.workout_exercise_id = we.id
+			JOIN workouts w ON w.id = we.workout_id
+			JOIN individual_exercises ie ON ie.id = we.individual_exercise_id
+			WHERE ie.user_id = ? AND we.individual_exercise_id = ? AND w.id <> ?
+				AND w.completed AND ws.completed AND ws.weight IS NOT NULL AND ws.reps IS NOT NULL
+		) ranked
+		WHERE ranked.rn = 1
+		ORDER BY completed_at ASC
+	`, userId, individualExerciseID, excludeWorkoutID).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	perf := make([]*workout.ExercisePerformance, 0, len(rows))
+	for _, row := range rows {
+		w := row.Weight
+		rp := row.Reps
+		perf = append(perf, &workout.ExercisePerformance{
+			CompletedAt: row.CompletedAt,
+			Weight:      &w,
+			Reps:        &rp,
+		})
+	}
+
+	return perf, nil
+}
+
 func (r *WorkoutExerciseRepo) GetMaxIndexByWorkoutID(ctx context.Context, userId, planId, cycleId, workoutId uint) (int, error) {
 	db := r.dbFrom(ctx)
 
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"errors"
 
 	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
@@ -144,6 +145,9 @@ func (r *WorkoutPlanRepo) GetByIDForUpdate(ctx context.Context, userId, id uint)
 		Clauses(clause.Locking{Strength: "UPDATE"}).
 		Where("workout_plans.id IN (?)", pSub).
 		First(&wp).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
 		return nil, err
 	}
 	return &wp, nil
@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/social"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+	"github.com/lordmitrii/golang-web-gin/internal/infrastructure/db/txctx"
+	"gorm.io/gorm"
+)
+
+type WorkoutShareRepo struct {
+	db *gorm.DB
+}
+
+func NewWorkoutShareRepo(db *gorm.DB) social.WorkoutShareRepository {
+	return &WorkoutShareRepo{db: db}
+}
+
+func (r *WorkoutShareRepo) dbFrom(ctx context.Context) *gorm.DB {
+	if tx, ok := txctx.From(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *WorkoutShareRepo) Create(ctx context.Context, s *social.WorkoutShare) error {
+	return r.dbFrom(ctx).Create(s).Error
+}
+
+func (r *WorkoutShareRepo) GetByToken(ctx context.Context, token string) (*social.WorkoutShare, error) {
+	var s social.WorkoutShare
+	err := r.dbFrom(ctx).Preload("User").Where("token = ?", token).First(&s).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *WorkoutShareRepo) GetActiveByWorkoutID(ctx context.Context, userID, workoutID uint) (*social.WorkoutShare, error) {
+	var s social.WorkoutShare
+	err := r.dbFrom(ctx).
+		Where("user_id = ? AND workout_id = ? AND revoked_at IS NULL", userID, workoutID).
+		First(&s).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *WorkoutShareRepo) GetByUserID(ctx context.Context, userID uint) ([]*social.WorkoutShare, error) {
+	var out []*social.WorkoutShare
+	err := r.dbFrom(ctx).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("created_at DESC").
+		Find(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *WorkoutShareRepo) Revoke(ctx context.Context, userID, id uint, at time.Time) error {
+	res := r.dbFrom(ctx).Model(&social.WorkoutShare{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", at)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return custom_err.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WorkoutShareRepo) GetWorkout(ctx context.Context, workoutID uint) (*workout.Workout, error) {
+	var w workout.Workout
+	err := r.dbFrom(ctx).
+		Scopes(PreloadWorkoutFull).
+		Preload("WorkoutExercises.IndividualExercise.MuscleGroup").
+		First(&w, workoutID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_err.ErrNotFound
+		}
+		return nil, err
+	}
+	return &w, nil
+}
@@ -0,0 +1,91 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/challenge"
+	"github.com/redis/go-redis/v9"
+)
+
+// Leaderboard keeps one sorted set per challenge, member = user ID, score = progress.
+// Postgres stays the source of truth; the sets can be rebuilt from it at any time.
+type Leaderboard struct {
+	client *redis.Client
+}
+
+func NewLeaderboard(addr, password string, db int) *Leaderboard {
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       db,
+	})
+	return &Leaderboard{client: client}
+}
+
+func leaderboardKey(challengeID uint) string {
+	return fmt.Sprintf("challenge:%d:leaderboard", challengeID)
+}
+
+func (l *Leaderboard) SetScore(ctx context.Context, challengeID, userID uint, score int64) error {
+	return l.client.ZAdd(ctx, leaderboardKey(challengeID), redis.Z{
+		Score:  float64(score),
+		Member: strconv.FormatUint(uint64(userID), 10),
+	}).Err()
+}
+
+func (l *Leaderboard) Remove(ctx context.Context, challengeID, userID uint) error {
+	return l.client.ZRem(ctx, leaderboardKey(challengeID), strconv.FormatUint(uint64(userID), 10)).Err()
+}
+
+// Top returns entries by descending score; ranks are 1-based.
+func (l *Leaderboard) Top(ctx context.Context, challengeID uint, offset, limit int) ([]challenge.LeaderboardEntry, error) {
+	zs, err := l.client.ZRevRangeWithScores(ctx, leaderboardKey(challengeID), int64(offset), int64(offset+limit-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]challenge.LeaderboardEntry, 0, len(zs))
+	for i, z := range zs {
+		member, _ := z.Member.(string)
+		id, err := strconv.ParseUint(member, 10, 64)
+		if err != nil {
+			continue
+		}
+		out = append(out, challenge.LeaderboardEntry{
+			Rank:   int64(offset + i + 1),
+			UserID: uint(id),
+			Score:  int64(z.Score),
+		})
+	}
+	return out, nil
+}
+
+// Rank returns the user's entry, or nil if they are not on the board.
+func (l *Leaderboard) Rank(ctx context.Context, challengeID, userID uint) (*challenge.LeaderboardEntry, error) {
+	key := leaderboardKey(challengeID)
+	member := strconv.FormatUint(uint64(userID), 10)
+
+	rank, err := l.client.ZRevRank(ctx, key, member).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	score, err := l.client.ZScore(ctx, key, member).Result()
+	if err != nil {
+		return nil, err
+	}
+	return &challenge.LeaderboardEntry{Rank: rank + 1, UserID: userID, Score: int64(score)}, nil
+}
+
+func (l *Leaderboard) Size(ctx context.Context, challengeID uint) (int64, error) {
+	return l.client.ZCard(ctx, leaderboardKey(challengeID)).Result()
+}
+
+func (l *Leaderboard) Delete(ctx context.Context, challengeID uint) error {
+	return l.client.Del(ctx, leaderboardKey(challengeID)).Err()
+}
@@ -8,6 +8,7 @@ import (
 )
 
 type RedisLimiter struct {
+	client  *redis.Client
 	limiter *redis_rate.Limiter
 }
 
@@ -19,6 +20,7 @@ func NewRedisLimiter(addr, password string, db int) *RedisLimiter {
 	})
 
 	return &RedisLimiter{
+		client:  client,
 		limiter: redis_rate.NewLimiter(client),
 	}
 }
@@ -34,3 +36,40 @@ func (r *RedisLimiter) Allow(ctx context.Context, key string, limit int, per tim
 	}
 	return res.Allowed > 0, res.RetryAfter, nil
 }
+
+// Incr bumps a counter and starts its expiry window on the first hit.
+func (r *RedisLimiter) Incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+	n, err := r.client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if n == 1 {
+		if err := r.client.PExpire(ctx, key, window).Err(); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func (r *RedisLimiter) Block(ctx context.Context, key string, ttl time.Duration) error {
+	return r.client.Set(ctx, key, 1, ttl).Err()
+}
+
+// BlockedFor returns the remaining TTL of a block key, or 0 if it is not set.
+func (r *RedisLimiter) BlockedFor(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := r.client.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+func (r *RedisLimiter) Reset(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return r.client.Del(ctx, keys...).Err()
+}
@@ -0,0 +1,133 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const permCacheVersionKey = "rbac:perms:version"
+
+// setIfUnchanged stores an entry only while the global version and the user's generation
+// still match the stamp handed out by the miss that led to loading it.
+var setIfUnchanged = redis.NewScript(`
+local ver = redis.call("GET", KEYS[1]) or "0"
+local gen = redis.call("GET", KEYS[2]) or "0"
+if ver .. ":" .. gen ~= ARGV[1] then
+	return 0
+end
+redis.call("SET", KEYS[3], ARGV[2], "PX", ARGV[3])
+return 1
+`)
+
+// PermissionCache keeps each user's effective permission keys in Redis.
+// Keys embed a global version so a role change can invalidate every user with one INCR;
+// a per-user generation does the same for Invalidate, so a fill racing either is dropped.
+type PermissionCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewPermissionCache(addr, password string, db int, ttl time.Duration) *PermissionCache {
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       db,
+	})
+	return &PermissionCache{client: client, ttl: ttl}
+}
+
+func genKey(userID uint) string {
+	return fmt.Sprintf("rbac:perms:gen:user:%d", userID)
+}
+
+func entryKey(ver int64, userID uint) string {
+	return fmt.Sprintf("rbac:perms:v%d:user:%d", ver, userID)
+}
+
+func (c *PermissionCache) version(ctx context.Context) (int64, error) {
+	ver, err := c.client.Get(ctx, permCacheVersionKey).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return 0, err
+	}
+	return ver, nil
+}
+
+// Get returns the cached keys and whether there was a cache entry at all. On a miss the
+// stamp records the version and generation seen, for Set.
+func (c *PermissionCache) Get(ctx context.Context, userID uint) ([]string, string, bool, error) {
+	vals, err := c.client.MGet(ctx, permCacheVersionKey, genKey(userID)).Result()
+	if err != nil {
+		return nil, "", false, err
+	}
+	var counters [2]int64
+	for i, v := range vals {
+		if v == nil {
+			continue
+		}
+		if counters[i], err = strconv.ParseInt(v.(string), 10, 64); err != nil {
+			return nil, "", false, err
+		}
+	}
+
+	raw, err := c.client.Get(ctx, entryKey(counters[0], userID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, fmt.Sprintf("%d:%d", counters[0], counters[1]), false, nil
+	}
+	if err != nil {
+		return nil, "", false, err
+	}
+	var keys []string
+	if err := json.Unmarshal(raw, &keys); err != nil {
+		return nil, "", false, err
+	}
+	return keys, "", true, nil
+}
+
+func (c *PermissionCache) Set(ctx context.Context, userID uint, stamp string, permKeys []string) error {
+	ver, _, ok := strings.Cut(stamp, ":")
+	if !ok {
+		return fmt.Errorf("invalid permission cache stamp %q", stamp)
+	}
+	v, err := strconv.ParseInt(ver, 10, 64)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(permKeys)
+	if err != nil {
+		return err
+	}
+	keys := []string{permCacheVersionKey, genKey(userID), entryKey(v, userID)}
+	return setIfUnchanged.Run(ctx, c.client, keys, stamp, raw, c.ttl.Milliseconds()).Err()
+}
+
+// Invalidate drops the users' entries and bumps their generations, so fills already in
+// flight for them are refused too.
+func (c *PermissionCache) Invalidate(ctx context.Context, userIDs ...uint) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	ver, err := c.version(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, id := range userIDs {
+			pipe.Incr(ctx, genKey(id))
+			pipe.Del(ctx, entryKey(ver, id))
+		}
+		return nil
+	})
+	return err
+}
+
+// InvalidateAll bumps the version; stale entries simply expire.
+func (c *PermissionCache) InvalidateAll(ctx context.Context) error {
+	return c.client.Incr(ctx, permCacheVersionKey).Err()
+}
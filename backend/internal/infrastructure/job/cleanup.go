@@ -7,17 +7,21 @@ import (
 
 	"gorm.io/gorm"
 
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/auth"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/email"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/events"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/goal"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
 )
 
 type CleanupJob struct {
-	db *gorm.DB
+	db             *gorm.DB
+	auditRetention time.Duration
 }
 
-func NewCleanupJob(db *gorm.DB) *CleanupJob {
-	return &CleanupJob{db: db}
+func NewCleanupJob(db *gorm.DB, auditRetention time.Duration) *CleanupJob {
+	return &CleanupJob{db: db, auditRetention: auditRetention}
 }
 
 func (j *CleanupJob) Run(ctx context.Context, interval time.Duration) {
@@ -28,6 +32,9 @@ func (j *CleanupJob) Run(ctx context.Context, interval time.Duration) {
 		j.CleanTokens(ctx)
 		j.CleanSoftDeletedUsers(ctx)
 		j.CleanOldHandlerLogs(ctx)
+		j.CleanOldAuditLogs(ctx)
+		j.CleanPersonalAccessTokens(ctx)
+		j.ExpireGoals(ctx)
 	}
 
 	// Run immediately
@@ -129,3 +136,76 @@ func (j *CleanupJob) CleanOldHandlerLogs(ctx context.Context) (int64, error) {
 	
 	return totalDeleted, nil
 }
+
+// CleanOldAuditLogs enforces the audit log retention policy. A zero retention keeps logs forever.
+func (j *CleanupJob) CleanOldAuditLogs(ctx context.Context) (int64, error) {
+	if j.auditRetention <= 0 {
+		return 0, nil
+	}
+
+	const batchSize = 1000
+	cutoff := time.Now().UTC().Add(-j.auditRetention)
+	var totalDeleted int64
+
+	for {
+		var ids []uint
+		if err := j.db.WithContext(ctx).
+			Model(&audit.AuditLog{}).
+			Where("created_at < ?", cutoff).
+			Limit(batchSize).Pluck("id", &ids).Error; err != nil {
+			return totalDeleted, err
+		}
+
+		if len(ids) == 0 {
+			break
+		}
+
+		res := j.db.WithContext(ctx).
+			Where("id IN ?", ids).
+			Delete(&audit.AuditLog{})
+		if res.Error != nil {
+			return totalDeleted, res.Error
+		}
+
+		totalDeleted += res.RowsAffected
+		log.Printf("Deleted %d old audit logs in batch (total: %d)\n", res.RowsAffected, totalDeleted)
+
+		time.Sleep(100 * time.Millisecond)
+
+		select {
+		case <-ctx.Done():
+			return totalDeleted, ctx.Err()
+		default:
+		}
+	}
+	return totalDeleted, nil
+}
+
+// CleanPersonalAccessTokens removes tokens that expired or were revoked more than 30 days ago.
+// The grace period keeps them visible in the user's token list for a while.
+func (j *CleanupJob) CleanPersonalAccessTokens(ctx context.Context) (int64, error) {
+	cutoff := time.Now().UTC().Add(-30 * 24 * time.Hour)
+
+	res := j.db.WithContext(ctx).
+		Where("expires_at < ? OR revoked_at < ?", cutoff, cutoff).
+		Delete(&auth.PersonalAccessToken{})
+	if res.Error != nil {
+		log.Println("Failed to clean personal access tokens:", res.Error)
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
+
+// ExpireGoals marks active goals whose deadline has passed as expired. Goals reached by a
+// workout before then were already marked achieved.
+func (j *CleanupJob) ExpireGoals(ctx context.Context) (int64, error) {
+	res := j.db.WithContext(ctx).
+		Model(&goal.Goal{}).
+		Where("status = ? AND deadline < ?", goal.StatusActive, time.Now().UTC()).
+		Update("status", goal.StatusExpired)
+	if res.Error != nil {
+		log.Println("Failed to expire goals:", res.Error)
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
@@ -0,0 +1,199 @@
+package graphapi
+
+import (
+	"fmt"
+	"time"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/graphql/dto"
+)
+
+// challengeTypes holds the GraphQL objects for challenges and leaderboards.
+type challengeTypes struct {
+	challenge        *gql.Object
+	participation    *gql.Object
+	leaderboardEntry *gql.Object
+	leaderboard      *gql.Object
+}
+
+func (r *resolver) defineChallengeTypes() challengeTypes {
+	t := challengeTypes{}
+
+	t.challenge = gql.NewObject(gql.ObjectConfig{
+		Name: "Challenge",
+		Fields: gql.Fields{
+			"id":          simpleField[dto.ChallengeResponse](gql.NewNonNull(gql.ID), func(c *dto.ChallengeResponse) any { return c.ID }),
+			"name":        simpleField[dto.ChallengeResponse](gql.String, func(c *dto.ChallengeResponse) any { return c.Name }),
+			"description": simpleField[dto.ChallengeResponse](gql.String, func(c *dto.ChallengeResponse) any { return c.Description }),
+			"metric":      simpleField[dto.ChallengeResponse](gql.String, func(c *dto.ChallengeResponse) any { return c.Metric }),
+			"target":      simpleField[dto.ChallengeResponse](gql.Int, func(c *dto.ChallengeResponse) any { return c.Target }),
+			"startsAt":    timeFieldFrom[dto.ChallengeResponse](func(c *dto.ChallengeResponse) *time.Time { return &c.StartsAt }),
+			"endsAt":      timeFieldFrom[dto.ChallengeResponse](func(c *dto.ChallengeResponse) *time.Time { return &c.EndsAt }),
+		},
+	})
+
+	t.participation = gql.NewObject(gql.ObjectConfig{
+		Name: "ChallengeParticipation",
+		Fields: gql.Fields{
+			"challenge": &gql.Field{
+				Type: t.challenge,
+				Resolve: func(p gql.ResolveParams) (any, error) {
+					return resolveFromSource[dto.ChallengeParticipationResponse](p, func(cp *dto.ChallengeParticipationResponse) any { return cp.Challenge })
+				},
+			},
+			"progress":    simpleField[dto.ChallengeParticipationResponse](gql.Int, func(cp *dto.ChallengeParticipationResponse) any { return cp.Progress }),
+			"completedAt": timeFieldFrom[dto.ChallengeParticipationResponse](func(cp *dto.ChallengeParticipationResponse) *time.Time { return cp.CompletedAt }),
+			"joinedAt":    timeFieldFrom[dto.ChallengeParticipationResponse](func(cp *dto.ChallengeParticipationResponse) *time.Time { return &cp.JoinedAt }),
+		},
+	})
+
+	t.leaderboardEntry = gql.NewObject(gql.ObjectConfig{
+		Name: "LeaderboardEntry",
+		Fields: gql.Fields{
+			"rank":     simpleField[dto.LeaderboardEntryResponse](gql.Int, func(e *dto.LeaderboardEntryResponse) any { return e.Rank }),
+			"userId":   simpleField[dto.LeaderboardEntryResponse](gql.NewNonNull(gql.ID), func(e *dto.LeaderboardEntryResponse) any { return e.UserID }),
+			"username": simpleField[dto.LeaderboardEntryResponse](gql.String, func(e *dto.LeaderboardEntryResponse) any { return e.Username }),
+			"score":    simpleField[dto.LeaderboardEntryResponse](gql.Int, func(e *dto.LeaderboardEntryResponse) any { return e.Score }),
+		},
+	})
+
+	t.leaderboard = gql.NewObject(gql.ObjectConfig{
+		Name: "Leaderboard",
+		Fields: gql.Fields{
+			"entries": &gql.Field{
+				Type: gql.NewNonNull(gql.NewList(t.leaderboardEntry)),
+				Resolve: func(p gql.ResolveParams) (any, error) {
+					return resolveFromSource[dto.LeaderboardResponse](p, func(l *dto.LeaderboardResponse) any { return l.Entries })
+				},
+			},
+			"total": simpleField[dto.LeaderboardResponse](gql.Int, func(l *dto.LeaderboardResponse) any { return l.Total }),
+		},
+	})
+
+	return t
+}
+
+// addChallengeFields registers challenge queries and mutations on the root objects.
+func (r *resolver) addChallengeFields(query, mutation *gql.Object) {
+	t := r.defineChallengeTypes()
+
+	query.AddFieldConfig("challenges", &gql.Field{
+		Type: gql.NewNonNull(gql.NewList(t.challenge)),
+		Args: gql.FieldConfigArgument{
+			"status": &gql.ArgumentConfig{Type: gql.String},
+		},
+		Resolve: func(p gql.ResolveParams) (any, error) {
+			if _, ok := UserIDFromCtx(p.Context); !ok {
+				return nil, fmt.Errorf("unauthorized")
+			}
+			status, _ := p.Args["status"].(string)
+			challenges, err := r.challengeSvc.ListChallenges(p.Context, status)
+			if err != nil {
+				return nil, err
+			}
+			resp := make([]dto.ChallengeResponse, 0, len(challenges))
+			for _, c := range challenges {
+				resp = append(resp, dto.ToChallengeResponse(c))
+			}
+			return resp, nil
+		},
+	})
+
+	query.AddFieldConfig("myChallenges", &gql.Field{
+		Type: gql.NewNonNull(gql.NewList(t.participation)),
+		Resolve: func(p gql.ResolveParams) (any, error) {
+			userID, ok := UserIDFromCtx(p.Context)
+			if !ok {
+				return nil, fmt.Errorf("unauthorized")
+			}
+			participations, err := r.challengeSvc.ListMyChallenges(p.Context, userID)
+			if err != nil {
+				return nil, err
+			}
+			resp := make([]dto.ChallengeParticipationResponse, 0, len(participations))
+			for _, cp := range participations {
+				resp = append(resp, dto.ToChallengeParticipationResponse(&cp.Challenge, cp))
+			}
+			return resp, nil
+		},
+	})
+
+	query.AddFieldConfig("challengeLeaderboard", &gql.Field{
+		Type: t.leaderboard,
+		Args: gql.FieldConfigArgument{
+			"challengeId": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)},
+			"offset":      &gql.ArgumentConfig{Type: gql.Int, DefaultValue: 0},
+			"limit":       &gql.ArgumentConfig{Type: gql.Int, DefaultValue: 50},
+		},
+		Resolve: func(p gql.ResolveParams) (any, error) {
+			if _, ok := UserIDFromCtx(p.Context); !ok {
+				return nil, fmt.Errorf("unauthorized")
+			}
+			id, err := toUintArg(p.Args["challengeId"])
+			if err != nil {
+				return nil, err
+			}
+			offset, _ := p.Args["offset"].(int)
+			limit, _ := p.Args["limit"].(int)
+			offset = max(offset, 0)
+			limit = min(max(limit, 1), 200)
+
+			entries, total, err := r.challengeSvc.GetLeaderboard(p.Context, id, offset, limit)
+			if err != nil {
+				return nil, err
+			}
+			resp := dto.LeaderboardResponse{Entries: make([]dto.LeaderboardEntryResponse, 0, len(entries)), Total: total}
+			for _, e := range entries {
+				resp.Entries = append(resp.Entries, dto.ToLeaderboardEntryResponse(e))
+			}
+			return resp, nil
+		},
+	})
+
+	mutation.AddFieldConfig("joinChallenge", &gql.Field{
+		Type: t.participation,
+		Args: gql.FieldConfigArgument{
+			"challengeId": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)},
+		},
+		Resolve: func(p gql.ResolveParams) (any, error) {
+			userID, ok := UserIDFromCtx(p.Context)
+			if !ok {
+				return nil, fmt.Errorf("unauthorized")
+			}
+			id, err := toUintArg(p.Args["challengeId"])
+			if err != nil {
+				return nil, err
+			}
+			cp, err := r.challengeSvc.JoinChallenge(p.Context, userID, id)
+			if err != nil {
+				return nil, err
+			}
+			c, err := r.challengeSvc.GetChallenge(p.Context, id)
+			if err != nil {
+				return nil, err
+			}
+			return dto.ToChallengeParticipationResponse(c, cp), nil
+		},
+	})
+
+	mutation.AddFieldConfig("leaveChallenge", &gql.Field{
+		Type: gql.NewNonNull(gql.Boolean),
+		Args: gql.FieldConfigArgument{
+			"challengeId": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)},
+		},
+		Resolve: func(p gql.ResolveParams) (any, error) {
+			userID, ok := UserIDFromCtx(p.Context)
+			if !ok {
+				return nil, fmt.Errorf("unauthorized")
+			}
+			id, err := toUintArg(p.Args["challengeId"])
+			if err != nil {
+				return nil, err
+			}
+			if err := r.challengeSvc.LeaveChallenge(p.Context, userID, id); err != nil {
+				return nil, err
+			}
+			return true, nil
+		},
+	})
+}
@@ -1,6 +1,9 @@
 package dto
 
-import "github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+import (
+	"github.com/lordmitrii/golang-web-gin/internal/domain/challenge"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+)
 
 func ToWorkoutPlanResponse(wp *workout.WorkoutPlan) WorkoutPlanResponse {
 	resp := WorkoutPlanResponse{
@@ -58,6 +61,8 @@ func ToWorkoutResponse(w *workout.Workout) WorkoutResponse {
 		EstimatedCalories:  w.EstimatedCalories,
 		EstimatedActiveMin: w.EstimatedActiveMin,
 		EstimatedRestMin:   w.EstimatedRestMin,
+		AISummary:          w.AISummary,
+		AISummaryAt:        w.AISummaryAt,
 	}
 	if len(w.WorkoutExercises) > 0 {
 		resp.WorkoutExercises = make([]WorkoutExerciseResponse, 0, len(w.WorkoutExercises))
@@ -155,6 +160,10 @@ func ToExerciseResponse(e *workout.Exercise) ExerciseResponse {
 		MuscleGroupID: e.MuscleGroupID,
 		MuscleGroup:   mg,
 		Slug:          e.Slug,
+
+		Equipment:       e.Equipment,
+		Difficulty:      e.Difficulty,
+		MovementPattern: e.MovementPattern,
 	}
 }
 
@@ -198,3 +207,33 @@ func ToSetDeleteResponse(kcal float64) SetDeleteResponse {
 		EstimatedCalories: kcal,
 	}
 }
+
+func ToChallengeResponse(c *challenge.Challenge) ChallengeResponse {
+	return ChallengeResponse{
+		ID:          c.ID,
+		Name:        c.Name,
+		Description: c.Description,
+		Metric:      c.Metric,
+		Target:      c.Target,
+		StartsAt:    c.StartsAt,
+		EndsAt:      c.EndsAt,
+	}
+}
+
+func ToChallengeParticipationResponse(c *challenge.Challenge, p *challenge.ChallengeParticipant) ChallengeParticipationResponse {
+	return ChallengeParticipationResponse{
+		Challenge:   ToChallengeResponse(c),
+		Progress:    p.Progress,
+		CompletedAt: p.CompletedAt,
+		JoinedAt:    p.JoinedAt,
+	}
+}
+
+func ToLeaderboardEntryResponse(e challenge.LeaderboardEntry) LeaderboardEntryResponse {
+	return LeaderboardEntryResponse{
+		Rank:     e.Rank,
+		UserID:   e.UserID,
+		Username: e.Username,
+		Score:    e.Score,
+	}
+}
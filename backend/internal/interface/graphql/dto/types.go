@@ -115,6 +115,8 @@ type WorkoutResponse struct {
 	EstimatedCalories  float64                   `json:"estimatedCalories,omitempty"`
 	EstimatedActiveMin float64                   `json:"estimatedActiveMin,omitempty"`
 	EstimatedRestMin   float64                   `json:"estimatedRestMin,omitempty"`
+	AISummary          string                    `json:"aiSummary,omitempty"`
+	AISummaryAt        *time.Time                `json:"aiSummaryAt,omitempty"`
 }
 
 type IndividualExerciseResponse struct {
@@ -177,6 +179,10 @@ type ExerciseResponse struct {
 	IsTimeBased   bool                 `json:"isTimeBased,omitempty"`
 	MuscleGroupID *uint                `json:"muscleGroupId,omitempty"`
 	MuscleGroup   *MuscleGroupResponse `json:"muscleGroup,omitempty"`
+
+	Equipment       string `json:"equipment,omitempty"`
+	Difficulty      string `json:"difficulty,omitempty"`
+	MovementPattern string `json:"movementPattern,omitempty"`
 }
 
 type WorkoutCompleteResponse struct {
@@ -201,3 +207,32 @@ type WorkoutExerciseDeleteResponse struct {
 type SetDeleteResponse struct {
 	EstimatedCalories float64 `json:"estimatedCalories,omitempty"`
 }
+
+type ChallengeResponse struct {
+	ID          uint      `json:"id,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Metric      string    `json:"metric,omitempty"`
+	Target      *int64    `json:"target,omitempty"`
+	StartsAt    time.Time `json:"startsAt"`
+	EndsAt      time.Time `json:"endsAt"`
+}
+
+type ChallengeParticipationResponse struct {
+	Challenge   ChallengeResponse `json:"challenge"`
+	Progress    int64             `json:"progress"`
+	CompletedAt *time.Time        `json:"completedAt,omitempty"`
+	JoinedAt    time.Time         `json:"joinedAt"`
+}
+
+type LeaderboardEntryResponse struct {
+	Rank     int64  `json:"rank,omitempty"`
+	UserID   uint   `json:"userId,omitempty"`
+	Username string `json:"username,omitempty"`
+	Score    int64  `json:"score"`
+}
+
+type LeaderboardResponse struct {
+	Entries []LeaderboardEntryResponse `json:"entries"`
+	Total   int64                      `json:"total"`
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	gql "github.com/graphql-go/graphql"
 	"github.com/lordmitrii/golang-web-gin/internal/usecase"
 )
@@ -14,8 +15,8 @@ type Handler struct {
 }
 
 // NewHandler builds the GraphQL schema using the provided services.
-func NewHandler(workoutSvc usecase.WorkoutService) (*Handler, error) {
-	schema, err := buildSchema(workoutSvc)
+func NewHandler(workoutSvc usecase.WorkoutService, challengeSvc usecase.ChallengeService) (*Handler, error) {
+	schema, err := buildSchema(workoutSvc, challengeSvc)
 	if err != nil {
 		return nil, err
 	}
@@ -37,7 +38,8 @@ func (h *Handler) ServeGraphQL(c *gin.Context) {
 	}
 
 	var body requestBody
-	if err := c.ShouldBindJSON(&body); err != nil {
+	// The body may already have been read to check a personal token's scope.
+	if err := c.ShouldBindBodyWith(&body, binding.JSON); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
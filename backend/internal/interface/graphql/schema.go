@@ -5,20 +5,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	gql "github.com/graphql-go/graphql"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/shared/units"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
 	"github.com/lordmitrii/golang-web-gin/internal/interface/graphql/dto"
 	"github.com/lordmitrii/golang-web-gin/internal/usecase"
 )
 
 type resolver struct {
-	workoutSvc usecase.WorkoutService
+	workoutSvc   usecase.WorkoutService
+	challengeSvc usecase.ChallengeService
 }
 
-func buildSchema(workoutSvc usecase.WorkoutService) (gql.Schema, error) {
-	r := &resolver{workoutSvc: workoutSvc}
+func buildSchema(workoutSvc usecase.WorkoutService, challengeSvc usecase.ChallengeService) (gql.Schema, error) {
+	r := &resolver{workoutSvc: workoutSvc, challengeSvc: challengeSvc}
 
 	types := r.defineTypes()
 
@@ -889,6 +892,9 @@ func buildSchema(workoutSvc usecase.WorkoutService) (gql.Schema, error) {
 					if err != nil {
 						return nil, err
 					}
+					if err := readMassInputs(p.Context, inputMap, "weight", "previousWeight"); err != nil {
+						return nil, err
+					}
 					var in dto.WorkoutSetCreateRequest
 					if err := decodeMap(inputMap, &in); err != nil {
 						return nil, err
@@ -945,6 +951,9 @@ func buildSchema(workoutSvc usecase.WorkoutService) (gql.Schema, error) {
 					if err != nil {
 						return nil, err
 					}
+					if err := readMassInputs(p.Context, inputMap, "weight"); err != nil {
+						return nil, err
+					}
 					var in dto.WorkoutSetUpdateRequest
 					if err := decodeMap(inputMap, &in); err != nil {
 						return nil, err
@@ -1120,6 +1129,8 @@ func buildSchema(workoutSvc usecase.WorkoutService) (gql.Schema, error) {
 		},
 	})
 
+	r.addChallengeFields(query, mutation)
+
 	return gql.NewSchema(gql.SchemaConfig{
 		Query:    query,
 		Mutation: mutation,
@@ -1180,12 +1191,15 @@ func (r *resolver) defineTypes() typeBundle {
 	bundle.exercise = gql.NewObject(gql.ObjectConfig{
 		Name: "Exercise",
 		Fields: gql.Fields{
-			"id":            simpleField[dto.ExerciseResponse](gql.NewNonNull(gql.ID), func(e *dto.ExerciseResponse) any { return e.ID }),
-			"name":          simpleField[dto.ExerciseResponse](gql.String, func(e *dto.ExerciseResponse) any { return e.Name }),
-			"slug":          simpleField[dto.ExerciseResponse](gql.String, func(e *dto.ExerciseResponse) any { return e.Slug }),
-			"isBodyweight":  simpleField[dto.ExerciseResponse](gql.Boolean, func(e *dto.ExerciseResponse) any { return e.IsBodyweight }),
-			"isTimeBased":   simpleField[dto.ExerciseResponse](gql.Boolean, func(e *dto.ExerciseResponse) any { return e.IsTimeBased }),
-			"muscleGroupId": simpleField[dto.ExerciseResponse](gql.ID, func(e *dto.ExerciseResponse) any { return e.MuscleGroupID }),
+			"id":              simpleField[dto.ExerciseResponse](gql.NewNonNull(gql.ID), func(e *dto.ExerciseResponse) any { return e.ID }),
+			"name":            simpleField[dto.ExerciseResponse](gql.String, func(e *dto.ExerciseResponse) any { return e.Name }),
+			"slug":            simpleField[dto.ExerciseResponse](gql.String, func(e *dto.ExerciseResponse) any { return e.Slug }),
+			"isBodyweight":    simpleField[dto.ExerciseResponse](gql.Boolean, func(e *dto.ExerciseResponse) any { return e.IsBodyweight }),
+			"isTimeBased":     simpleField[dto.ExerciseResponse](gql.Boolean, func(e *dto.ExerciseResponse) any { return e.IsTimeBased }),
+			"muscleGroupId":   simpleField[dto.ExerciseResponse](gql.ID, func(e *dto.ExerciseResponse) any { return e.MuscleGroupID }),
+			"equipment":       simpleField[dto.ExerciseResponse](gql.String, func(e *dto.ExerciseResponse) any { return e.Equipment }),
+			"difficulty":      simpleField[dto.ExerciseResponse](gql.String, func(e *dto.ExerciseResponse) any { return e.Difficulty }),
+			"movementPattern": simpleField[dto.ExerciseResponse](gql.String, func(e *dto.ExerciseResponse) any { return e.MovementPattern }),
 			"muscleGroup": &gql.Field{
 				Type: bundle.muscleGroup,
 				Resolve: func(p gql.ResolveParams) (any, error) {
@@ -1206,6 +1220,7 @@ func (r *resolver) defineTypes() typeBundle {
 			"exerciseId":                     simpleField[dto.IndividualExerciseResponse](gql.ID, func(e *dto.IndividualExerciseResponse) any { return e.ExerciseID }),
 			"lastCompletedWorkoutExerciseId": simpleField[dto.IndividualExerciseResponse](gql.ID, func(e *dto.IndividualExerciseResponse) any { return e.LastCompletedWorkoutExerciseID }),
 			"currentWeight":                  simpleField[dto.IndividualExerciseResponse](gql.Int, func(e *dto.IndividualExerciseResponse) any { return e.CurrentWeight }),
+			"displayCurrentWeight":           displayMassFieldFrom(func(e *dto.IndividualExerciseResponse) *int { return &e.CurrentWeight }),
 			"currentReps":                    simpleField[dto.IndividualExerciseResponse](gql.Int, func(e *dto.IndividualExerciseResponse) any { return e.CurrentReps }),
 			"muscleGroup": &gql.Field{
 				Type: bundle.muscleGroup,
@@ -1227,17 +1242,19 @@ func (r *resolver) defineTypes() typeBundle {
 	bundle.workoutSet = gql.NewObject(gql.ObjectConfig{
 		Name: "WorkoutSet",
 		Fields: gql.Fields{
-			"id":                simpleField[dto.WorkoutSetResponse](gql.NewNonNull(gql.ID), func(s *dto.WorkoutSetResponse) any { return s.ID }),
-			"workoutExerciseId": simpleField[dto.WorkoutSetResponse](gql.NewNonNull(gql.ID), func(s *dto.WorkoutSetResponse) any { return s.WorkoutExerciseID }),
-			"index":             simpleField[dto.WorkoutSetResponse](gql.Int, func(s *dto.WorkoutSetResponse) any { return s.Index }),
-			"weight":            simpleField[dto.WorkoutSetResponse](gql.Int, func(s *dto.WorkoutSetResponse) any { return s.Weight }),
-			"reps":              simpleField[dto.WorkoutSetResponse](gql.Int, func(s *dto.WorkoutSetResponse) any { return s.Reps }),
-			"previousWeight":    simpleField[dto.WorkoutSetResponse](gql.Int, func(s *dto.WorkoutSetResponse) any { return s.PreviousWeight }),
-			"previousReps":      simpleField[dto.WorkoutSetResponse](gql.Int, func(s *dto.WorkoutSetResponse) any { return s.PreviousReps }),
-			"completed":         simpleField[dto.WorkoutSetResponse](gql.Boolean, func(s *dto.WorkoutSetResponse) any { return s.Completed }),
-			"skipped":           simpleField[dto.WorkoutSetResponse](gql.Boolean, func(s *dto.WorkoutSetResponse) any { return s.Skipped }),
-			"createdAt":         timeFieldFrom[dto.WorkoutSetResponse](func(s *dto.WorkoutSetResponse) *time.Time { return s.CreatedAt }),
-			"updatedAt":         timeFieldFrom[dto.WorkoutSetResponse](func(s *dto.WorkoutSetResponse) *time.Time { return s.UpdatedAt }),
+			"id":                    simpleField[dto.WorkoutSetResponse](gql.NewNonNull(gql.ID), func(s *dto.WorkoutSetResponse) any { return s.ID }),
+			"workoutExerciseId":     simpleField[dto.WorkoutSetResponse](gql.NewNonNull(gql.ID), func(s *dto.WorkoutSetResponse) any { return s.WorkoutExerciseID }),
+			"index":                 simpleField[dto.WorkoutSetResponse](gql.Int, func(s *dto.WorkoutSetResponse) any { return s.Index }),
+			"weight":                simpleField[dto.WorkoutSetResponse](gql.Int, func(s *dto.WorkoutSetResponse) any { return s.Weight }),
+			"reps":                  simpleField[dto.WorkoutSetResponse](gql.Int, func(s *dto.WorkoutSetResponse) any { return s.Reps }),
+			"previousWeight":        simpleField[dto.WorkoutSetResponse](gql.Int, func(s *dto.WorkoutSetResponse) any { return s.PreviousWeight }),
+			"displayWeight":         displayMassFieldFrom(func(s *dto.WorkoutSetResponse) *int { return s.Weight }),
+			"displayPreviousWeight": displayMassFieldFrom(func(s *dto.WorkoutSetResponse) *int { return s.PreviousWeight }),
+			"previousReps":          simpleField[dto.WorkoutSetResponse](gql.Int, func(s *dto.WorkoutSetResponse) any { return s.PreviousReps }),
+			"completed":             simpleField[dto.WorkoutSetResponse](gql.Boolean, func(s *dto.WorkoutSetResponse) any { return s.Completed }),
+			"skipped":               simpleField[dto.WorkoutSetResponse](gql.Boolean, func(s *dto.WorkoutSetResponse) any { return s.Skipped }),
+			"createdAt":             timeFieldFrom[dto.WorkoutSetResponse](func(s *dto.WorkoutSetResponse) *time.Time { return s.CreatedAt }),
+			"updatedAt":             timeFieldFrom[dto.WorkoutSetResponse](func(s *dto.WorkoutSetResponse) *time.Time { return s.UpdatedAt }),
 		},
 	})
 
@@ -1291,6 +1308,8 @@ func (r *resolver) defineTypes() typeBundle {
 			"estimatedCalories":  simpleField[dto.WorkoutResponse](gql.Float, func(w *dto.WorkoutResponse) any { return w.EstimatedCalories }),
 			"estimatedActiveMin": simpleField[dto.WorkoutResponse](gql.Float, func(w *dto.WorkoutResponse) any { return w.EstimatedActiveMin }),
 			"estimatedRestMin":   simpleField[dto.WorkoutResponse](gql.Float, func(w *dto.WorkoutResponse) any { return w.EstimatedRestMin }),
+			"aiSummary":          simpleField[dto.WorkoutResponse](gql.String, func(w *dto.WorkoutResponse) any { return w.AISummary }),
+			"aiSummaryAt":        timeFieldFrom[dto.WorkoutResponse](func(w *dto.WorkoutResponse) *time.Time { return w.AISummaryAt }),
 			"createdAt":          timeFieldFrom[dto.WorkoutResponse](func(w *dto.WorkoutResponse) *time.Time { return w.CreatedAt }),
 			"updatedAt":          timeFieldFrom[dto.WorkoutResponse](func(w *dto.WorkoutResponse) *time.Time { return w.UpdatedAt }),
 		},
@@ -1513,21 +1532,24 @@ func (r *resolver) defineTypes() typeBundle {
 	bundle.inputWorkoutSet = gql.NewInputObject(gql.InputObjectConfig{
 		Name: "WorkoutSetInput",
 		Fields: gql.InputObjectConfigFieldMap{
-			"index":          &gql.InputObjectFieldConfig{Type: gql.Int},
-			"weight":         &gql.InputObjectFieldConfig{Type: gql.Int},
-			"reps":           &gql.InputObjectFieldConfig{Type: gql.Int},
-			"previousWeight": &gql.InputObjectFieldConfig{Type: gql.Int},
-			"previousReps":   &gql.InputObjectFieldConfig{Type: gql.Int},
+			"index":                 &gql.InputObjectFieldConfig{Type: gql.Int},
+			"weight":                &gql.InputObjectFieldConfig{Type: gql.Int},
+			"reps":                  &gql.InputObjectFieldConfig{Type: gql.Int},
+			"previousWeight":        &gql.InputObjectFieldConfig{Type: gql.Int},
+			"previousReps":          &gql.InputObjectFieldConfig{Type: gql.Int},
+			"displayWeight":         &gql.InputObjectFieldConfig{Type: gql.Float},
+			"displayPreviousWeight": &gql.InputObjectFieldConfig{Type: gql.Float},
 		},
 	})
 	bundle.inputWorkoutSetPatch = gql.NewInputObject(gql.InputObjectConfig{
 		Name: "WorkoutSetPatch",
 		Fields: gql.InputObjectConfigFieldMap{
-			"index":     &gql.InputObjectFieldConfig{Type: gql.Int},
-			"weight":    &gql.InputObjectFieldConfig{Type: gql.Int},
-			"reps":      &gql.InputObjectFieldConfig{Type: gql.Int},
-			"completed": &gql.InputObjectFieldConfig{Type: gql.Boolean},
-			"skipped":   &gql.InputObjectFieldConfig{Type: gql.Boolean},
+			"index":         &gql.InputObjectFieldConfig{Type: gql.Int},
+			"weight":        &gql.InputObjectFieldConfig{Type: gql.Int},
+			"displayWeight": &gql.InputObjectFieldConfig{Type: gql.Float},
+			"reps":          &gql.InputObjectFieldConfig{Type: gql.Int},
+			"completed":     &gql.InputObjectFieldConfig{Type: gql.Boolean},
+			"skipped":       &gql.InputObjectFieldConfig{Type: gql.Boolean},
 		},
 	})
 	bundle.inputIndividualExercise = gql.NewInputObject(gql.InputObjectConfig{
@@ -1637,6 +1659,46 @@ func inputAsMap(args map[string]any, key string) (map[string]any, error) {
 	return m, nil
 }
 
+// displayMassFieldFrom renders a weight in kg/lb when the request sent Accept-Units, or in
+// grams otherwise. The plain weight fields next to it always stay Int grams.
+func displayMassFieldFrom[T any](getter func(*T) *int) *gql.Field {
+	return &gql.Field{
+		Type: gql.Float,
+		Resolve: func(p gql.ResolveParams) (any, error) {
+			return resolveFromSource[T](p, func(v *T) any {
+				g := getter(v)
+				if g == nil {
+					return nil
+				}
+				s := units.FromContext(p.Context)
+				if !s.IsDisplay() {
+					return *g
+				}
+				return units.Mass(*g).In(s)
+			})
+		},
+	}
+}
+
+// readMassInputs converts each display weight, given in the request's unit system, to grams
+// under its canonical key before decoding. Sending both forms of one weight is an error.
+func readMassInputs(ctx context.Context, in map[string]any, keys ...string) error {
+	s := units.FromContext(ctx)
+	for _, k := range keys {
+		dk := "display" + strings.ToUpper(k[:1]) + k[1:]
+		v, ok := in[dk].(float64)
+		if !ok {
+			continue
+		}
+		if _, both := in[k]; both {
+			return fmt.Errorf("%s and %s cannot both be set", k, dk)
+		}
+		delete(in, dk)
+		in[k] = units.MassIn(s, v).Grams()
+	}
+	return nil
+}
+
 func timeFieldFrom[T any](getter func(*T) *time.Time) *gql.Field {
 	return &gql.Field{
 		Type: gql.String,
@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+// swagger:model
+type AchievementResponse struct {
+	Key       string     `json:"key" example:"streak_4_weeks"`
+	Kind      string     `json:"kind" example:"streak"`
+	Threshold int64      `json:"threshold" example:"4"`
+	Current   int64      `json:"current" example:"3"`
+	Progress  float64    `json:"progress" example:"0.75"`
+	Earned    bool       `json:"earned" example:"false"`
+	AwardedAt *time.Time `json:"awarded_at,omitempty"`
+}
+
+// swagger:model
+type StreakResponse struct {
+	Current      int `json:"current" example:"3"`
+	Longest      int `json:"longest" example:"8"`
+	WeeklyTarget int `json:"weekly_target" example:"3"`
+	ThisWeek     int `json:"this_week" example:"1"`
+}
+
+// swagger:model
+type AchievementsResponse struct {
+	Achievements []AchievementResponse `json:"achievements"`
+	Next         []AchievementResponse `json:"next"`
+	Streak       StreakResponse        `json:"streak"`
+}
@@ -1,6 +1,9 @@
 package dto
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // swagger:model
 type SetRolesRequest struct {
@@ -23,3 +26,63 @@ type ListUserResponse struct {
 	Users []UserResponse `json:"users"`
 	Total int64          `json:"total" example:"123"`
 }
+
+// swagger:model
+type AuditLogResponse struct {
+	ID         uint            `json:"id" example:"1"`
+	ActorID    *uint           `json:"actor_id,omitempty" example:"1"`
+	TargetType string          `json:"target_type" example:"user"`
+	TargetID   *uint           `json:"target_id,omitempty" example:"42"`
+	Action     string          `json:"action" example:"admin.user_roles_set"`
+	IP         string          `json:"ip" example:"203.0.113.7"`
+	UserAgent  string          `json:"user_agent" example:"Mozilla/5.0"`
+	Diff       json.RawMessage `json:"diff" swaggertype:"object"`
+	Metadata   json.RawMessage `json:"metadata" swaggertype:"object"`
+	CreatedAt  time.Time       `json:"created_at" example:"2023-01-01T12:00:00Z"`
+}
+
+// swagger:model
+type ListAuditLogResponse struct {
+	AuditLogs []AuditLogResponse `json:"audit_logs"`
+	Total     int64              `json:"total" example:"123"`
+}
+
+// swagger:model
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required,min=2,max=50" example:"nutritionist"`
+	Permissions []string `json:"permissions" example:"workout:read:others"`
+}
+
+// swagger:model
+type UpdateRoleRequest struct {
+	Name        *string  `json:"name" binding:"omitempty,min=2,max=50" example:"sports_nutritionist"`
+	Permissions []string `json:"permissions" example:"workout:read:others,workout:write:others"` // omit to keep the current set
+}
+
+// swagger:model
+type RoleDetailResponse struct {
+	ID          uint     `json:"id" example:"6"`
+	Name        string   `json:"name" example:"nutritionist"`
+	Builtin     bool     `json:"builtin" example:"false"`
+	Permissions []string `json:"permissions" example:"workout:read:others"`
+}
+
+// swagger:model
+type PermissionResponse struct {
+	ID  uint   `json:"id" example:"3"`
+	Key string `json:"key" example:"workout:read:self"`
+}
+
+// swagger:model
+type RoleMemberResponse struct {
+	ID         uint   `json:"id" example:"1"`
+	Username   string `json:"username" example:"johndoe"`
+	Email      string `json:"email" example:"johndoe@example.com"`
+	IsVerified bool   `json:"is_verified" example:"true"`
+}
+
+// swagger:model
+type ListRoleMembersResponse struct {
+	Users []RoleMemberResponse `json:"users"`
+	Total int64                `json:"total" example:"12"`
+}
@@ -1,16 +1,27 @@
 package dto
 
+import "time"
+
+// AIQuestionRequest starts a conversation, or continues the one whose response_id is
+// passed as previous_response_id. The ID stays the same across follow-ups.
 // swagger:model
 type AIQuestionRequest struct {
 	Question           string `json:"question" binding:"required,max=256" example:"What is the capital of France?"`
 	Language           string `json:"language" example:"en"`
-	PreviousResponseID string `json:"previous_response_id" example:"12345"`
+	PreviousResponseID string `json:"previous_response_id" example:"7"`
 }
 
 // swagger:model
 type AIQuestionResponse struct {
 	Answer     string `json:"answer" example:"The capital of France is Paris."`
-	ResponseID string `json:"response_id" example:"67890"`
+	ResponseID string `json:"response_id" example:"7"`
+}
+
+// AIStreamDelta is the payload of a "delta" event on an answer stream. The stream ends
+// with a "done" event carrying an AIQuestionResponse, or an "error" event.
+// swagger:model
+type AIStreamDelta struct {
+	Text string `json:"text" example:"Aim for "`
 }
 
 // swagger:model
@@ -20,7 +31,164 @@ type AIWorkoutPlanRequest struct {
 	Days     int    `json:"days_per_week" binding:"required,min=1,max=14" example:"4"`
 }
 
+// AIWorkoutPlanResponse carries the saved draft plan; see WorkoutPlanResponse.Draft.
 // swagger:model
 type AIWorkoutPlanResponse struct {
 	Plan WorkoutPlanResponse `json:"workout_plan"`
 }
+
+// swagger:model
+type AIConversationMessageResponse struct {
+	ID        uint      `json:"id"         example:"41"`
+	Role      string    `json:"role"       example:"user"`
+	Content   string    `json:"content"    example:"How should I progress my squat?"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AIConversationResponse is a stored conversation. Sending its response_id as
+// previous_response_id continues it.
+// swagger:model
+type AIConversationResponse struct {
+	ID         uint                            `json:"id"                 example:"7"`
+	ResponseID string                          `json:"response_id"        example:"7"`
+	Kind       string                          `json:"kind"               example:"stats"`
+	Title      string                          `json:"title"              example:"How should I progress my squat?"`
+	Messages   []AIConversationMessageResponse `json:"messages,omitempty"`
+	CreatedAt  time.Time                       `json:"created_at"`
+	UpdatedAt  time.Time                       `json:"updated_at"`
+}
+
+// swagger:model
+type ListAIConversationResponse struct {
+	Items []AIConversationResponse `json:"items"`
+	Total int64                    `json:"total" example:"12"`
+}
+
+// swagger:model
+type AIConversationRenameRequest struct {
+	Title string `json:"title" binding:"required,max=120" example:"Squat progression"`
+}
+
+// swagger:model
+type AIUsageSummaryResponse struct {
+	Endpoint      string `json:"endpoint"                 example:"ask_general"`
+	Model         string `json:"model"                    example:"gpt-5-nano"`
+	PromptVersion string `json:"prompt_version,omitempty" example:"chat.instructions/en@2+ask.general/en@0"`
+	Calls         int64  `json:"calls"                    example:"12"`
+	InputTokens   int64  `json:"input_tokens"             example:"5400"`
+	OutputTokens  int64  `json:"output_tokens"            example:"2100"`
+}
+
+// AIUsagePeriodResponse is usage against one quota. A limit of 0 means unlimited.
+//
+// swagger:model
+type AIUsagePeriodResponse struct {
+	Used     int64     `json:"used"      example:"7500"`
+	Limit    int64     `json:"limit"     example:"50000"`
+	ResetsAt time.Time `json:"resets_at" example:"2025-01-02T00:00:00Z"`
+}
+
+// swagger:model
+type AIUsageResponse struct {
+	Daily      AIUsagePeriodResponse    `json:"daily"`
+	Monthly    AIUsagePeriodResponse    `json:"monthly"`
+	ByEndpoint []AIUsageSummaryResponse `json:"by_endpoint"`
+}
+
+// swagger:model
+type AIUserUsageResponse struct {
+	UserID       uint   `json:"user_id"       example:"42"`
+	Username     string `json:"username"      example:"johndoe"`
+	Calls        int64  `json:"calls"         example:"80"`
+	InputTokens  int64  `json:"input_tokens"  example:"36000"`
+	OutputTokens int64  `json:"output_tokens" example:"14000"`
+}
+
+// swagger:model
+type AIUsageReportResponse struct {
+	From       time.Time                `json:"from" example:"2025-01-01T00:00:00Z"`
+	To         time.Time                `json:"to"   example:"2025-02-01T00:00:00Z"`
+	ByEndpoint []AIUsageSummaryResponse `json:"by_endpoint"`
+	TopUsers   []AIUserUsageResponse    `json:"top_users"`
+}
+
+// AIQuotaRequest caps the tokens, input and output together, per UTC day and month.
+// 0 means unlimited.
+//
+// swagger:model
+type AIQuotaRequest struct {
+	DailyTokens   int64 `json:"daily_tokens"   binding:"min=0" example:"50000"`
+	MonthlyTokens int64 `json:"monthly_tokens" binding:"min=0" example:"1000000"`
+}
+
+// swagger:model
+type AIQuotaResponse struct {
+	Role          string    `json:"role"           example:"member"`
+	DailyTokens   int64     `json:"daily_tokens"   example:"50000"`
+	MonthlyTokens int64     `json:"monthly_tokens" example:"1000000"`
+	UpdatedAt     time.Time `json:"updated_at"     example:"2025-01-01T12:00:00Z"`
+}
+
+// AIQuotasResponse lists per-role quotas; Default applies to every other role.
+//
+// swagger:model
+type AIQuotasResponse struct {
+	Default AIQuotaRequest    `json:"default"`
+	Roles   []AIQuotaResponse `json:"roles"`
+}
+
+// swagger:model
+type AIPromptVersionResponse struct {
+	ID        uint      `json:"id"         example:"7"`
+	Locale    string    `json:"locale"     example:"de"`
+	Version   int       `json:"version"    example:"2"`
+	Body      string    `json:"body"       example:"Der Nutzer fragt: {{.Question}}"`
+	Active    bool      `json:"active"     example:"true"`
+	CreatedAt time.Time `json:"created_at" example:"2025-01-01T12:00:00Z"`
+}
+
+// AIPromptResponse is a prompt with its built-in default, version 0, and its stored
+// versions in every locale.
+//
+// swagger:model
+type AIPromptResponse struct {
+	Name     string                    `json:"name"     example:"ask.general"`
+	Default  string                    `json:"default"  example:"The user asks: {{.Question}}"`
+	Versions []AIPromptVersionResponse `json:"versions"`
+}
+
+// AIPromptVersionRequest adds a version of a prompt, in Go text/template syntax. The
+// locale defaults to "en".
+//
+// swagger:model
+type AIPromptVersionRequest struct {
+	Locale   string `json:"locale"   binding:"omitempty,max=16" example:"de"`
+	Body     string `json:"body"     binding:"required"         example:"Der Nutzer fragt: {{.Question}}"`
+	Activate bool   `json:"activate" example:"true"`
+}
+
+// AIPromptActivateRequest picks the active version of a prompt in a locale; 0 goes back
+// to the built-in default.
+//
+// swagger:model
+type AIPromptActivateRequest struct {
+	Locale  string `json:"locale"  binding:"omitempty,max=16" example:"de"`
+	Version int    `json:"version" binding:"min=0"            example:"2"`
+}
+
+// swagger:model
+type AIBlockedRequestResponse struct {
+	ID        uint      `json:"id"         example:"3"`
+	UserID    uint      `json:"user_id"    example:"42"`
+	Username  string    `json:"username"   example:"johndoe"`
+	Endpoint  string    `json:"endpoint"   example:"ask_general"`
+	Category  string    `json:"category"   example:"off_topic"`
+	Input     string    `json:"input"      example:"Write my history essay"`
+	CreatedAt time.Time `json:"created_at" example:"2025-01-01T12:00:00Z"`
+}
+
+// swagger:model
+type ListAIBlockedRequestResponse struct {
+	Items []AIBlockedRequestResponse `json:"items"`
+	Total int64                      `json:"total" example:"5"`
+}
@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+// swagger:model
+type ChallengeRequest struct {
+	Name        string    `json:"name"        binding:"required,max=120" example:"Most volume in October"`
+	Description string    `json:"description" binding:"max=2000" example:"Total kilograms lifted across all completed sets."`
+	Metric      string    `json:"metric"      binding:"required,oneof=workouts sets reps volume" example:"volume"`
+	Target      *int64    `json:"target"      binding:"omitempty,gt=0" example:"30"`
+	StartsAt    time.Time `json:"starts_at"   binding:"required" example:"2025-10-01T00:00:00Z"`
+	EndsAt      time.Time `json:"ends_at"     binding:"required" example:"2025-11-01T00:00:00Z"`
+}
+
+// swagger:model
+type ChallengeResponse struct {
+	ID          uint      `json:"id" example:"5"`
+	Name        string    `json:"name" example:"30 workouts in 30 days"`
+	Description string    `json:"description,omitempty"`
+	Metric      string    `json:"metric" example:"workouts"`
+	Target      *int64    `json:"target,omitempty" example:"30"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+}
+
+// swagger:model
+type ChallengeParticipationResponse struct {
+	Challenge   ChallengeResponse `json:"challenge"`
+	Progress    int64             `json:"progress" example:"12"`
+	CompletedAt *time.Time        `json:"completed_at,omitempty"`
+	JoinedAt    time.Time         `json:"joined_at"`
+}
+
+// swagger:model
+type LeaderboardEntryResponse struct {
+	Rank     int64  `json:"rank,omitempty" example:"1"`
+	UserID   uint   `json:"user_id" example:"7"`
+	Username string `json:"username,omitempty" example:"ada_lovelace"`
+	Score    int64  `json:"score" example:"12450"`
+}
+
+// swagger:model
+type LeaderboardResponse struct {
+	Entries []LeaderboardEntryResponse `json:"entries"`
+	Total   int64                      `json:"total" example:"84"`
+}
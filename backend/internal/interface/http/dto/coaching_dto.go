@@ -0,0 +1,41 @@
+package dto
+
+import "time"
+
+// swagger:model
+type CoachingInviteRequest struct {
+	Client  string `json:"client"  binding:"required,max=256" example:"ada_lovelace"` // username or email
+	Message string `json:"message" binding:"max=500" example:"Happy to help with your squat!"`
+}
+
+// swagger:model
+type CoachingUserResponse struct {
+	ID       uint   `json:"id" example:"7"`
+	Username string `json:"username" example:"ada_lovelace"`
+}
+
+// swagger:model
+type CoachClientResponse struct {
+	ID          uint                  `json:"id" example:"3"`
+	Coach       *CoachingUserResponse `json:"coach,omitempty"`
+	Client      *CoachingUserResponse `json:"client,omitempty"`
+	Status      string                `json:"status" example:"pending"`
+	Message     string                `json:"message,omitempty"`
+	RespondedAt *time.Time            `json:"responded_at,omitempty"`
+	RevokedAt   *time.Time            `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+}
+
+// swagger:model
+type WorkoutCommentRequest struct {
+	Body string `json:"body" binding:"required,max=2000" example:"Great depth on the squats, add 2.5kg next week."`
+}
+
+// swagger:model
+type WorkoutCommentResponse struct {
+	ID        uint                 `json:"id" example:"12"`
+	WorkoutID uint                 `json:"workout_id" example:"100"`
+	Author    CoachingUserResponse `json:"author"`
+	Body      string               `json:"body"`
+	CreatedAt time.Time            `json:"created_at"`
+}
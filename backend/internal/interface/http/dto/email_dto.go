@@ -22,7 +22,7 @@ type SendResetPasswordEmailRequest struct {
 // swagger:model
 type ValidateTokenRequest struct {
 	Token     string `json:"token" binding:"required" example:"abcd1234efgh"`
-	TokenType string `json:"token_type" binding:"required" example:"reset_password"`
+	TokenType string `json:"token_type" binding:"required,oneof=reset_password" example:"reset_password"`
 }
 
 // swagger:model
@@ -33,5 +33,6 @@ type ResetPasswordRequest struct {
 
 // swagger:model
 type VerifyAccountRequest struct {
-	Token string `json:"token" binding:"required" example:"verifytoken123"`
+	Token string `json:"token" binding:"required" example:"123456"`
+	Email string `json:"email" binding:"required,email" example:"user@example.com"`
 }
@@ -0,0 +1,90 @@
+package dto
+
+import "time"
+
+// swagger:model
+type EquipmentProfileCreateRequest struct {
+	Name      string   `json:"name"       binding:"required,max=50"                                   example:"Home gym"`
+	Preset    string   `json:"preset"     binding:"omitempty,oneof=home_gym commercial_gym travel"    example:"home_gym"`
+	Equipment []string `json:"equipment"  binding:"max=20"                                            example:"dumbbell,band"`
+	IsDefault bool     `json:"is_default"                                                             example:"true"`
+}
+
+// swagger:model
+type EquipmentProfileUpdateRequest struct {
+	Name      *string  `json:"name"       binding:"omitempty,max=50" example:"Travel"`
+	Equipment []string `json:"equipment"  binding:"omitempty,max=20" example:"band"`
+	IsDefault *bool    `json:"is_default"                            example:"true"`
+}
+
+// swagger:model
+type EquipmentProfileResponse struct {
+	ID        uint      `json:"id"         example:"3"`
+	Name      string    `json:"name"       example:"Home gym"`
+	IsDefault bool      `json:"is_default" example:"true"`
+	Equipment []string  `json:"equipment"  example:"bodyweight,dumbbell,band"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// swagger:model
+type EquipmentSwitchRequest struct {
+	ProfileID uint `json:"profile_id" binding:"required" example:"3"`
+}
+
+// swagger:model
+type ExerciseSwapResponse struct {
+	WorkoutID         uint             `json:"workout_id"          example:"100"`
+	WorkoutExerciseID uint             `json:"workout_exercise_id" example:"501"`
+	From              ExerciseResponse `json:"from"`
+	To                ExerciseResponse `json:"to"`
+}
+
+// swagger:model
+type UnresolvedExerciseResponse struct {
+	WorkoutID         uint   `json:"workout_id"          example:"100"`
+	WorkoutExerciseID uint   `json:"workout_exercise_id" example:"502"`
+	Name              string `json:"name"                example:"Leg Press"`
+}
+
+// swagger:model
+type EquipmentSwitchResponse struct {
+	Profile    EquipmentProfileResponse     `json:"profile"`
+	Swaps      []ExerciseSwapResponse       `json:"swaps"`
+	Unresolved []UnresolvedExerciseResponse `json:"unresolved"`
+}
+
+// swagger:model
+type LoadingPlateDTO struct {
+	Weight int `json:"weight" binding:"required,min=1,max=50000" example:"20000"`
+	Pairs  int `json:"pairs"  binding:"required,min=1,max=20"    example:"2"`
+}
+
+// swagger:model
+type LoadingSettingsRequest struct {
+	BarWeight         int               `json:"bar_weight"         binding:"min=0,max=50000"          example:"20000"`
+	DumbbellIncrement int               `json:"dumbbell_increment" binding:"required,min=1,max=10000" example:"2000"`
+	Plates            []LoadingPlateDTO `json:"plates"             binding:"max=12,dive"`
+}
+
+// swagger:model
+type LoadingSettingsResponse struct {
+	BarWeight         int               `json:"bar_weight"         example:"20000"`
+	DumbbellIncrement int               `json:"dumbbell_increment" example:"2000"`
+	Plates            []LoadingPlateDTO `json:"plates"`
+	IsDefault         bool              `json:"is_default"         example:"false"`
+}
+
+// swagger:model
+type PlateCountResponse struct {
+	Weight int `json:"weight" example:"20000"`
+	Count  int `json:"count"  example:"1"`
+}
+
+// swagger:model
+type LoadoutResponse struct {
+	Target  int                  `json:"target"             example:"101000"`
+	Weight  int                  `json:"weight"             example:"100000"`
+	Bar     int                  `json:"bar,omitempty"      example:"20000"`
+	PerSide []PlateCountResponse `json:"per_side,omitempty"`
+}
@@ -7,6 +7,13 @@ type ExerciseCreateRequest struct {
 	IsTimeBased   bool   `json:"is_time_based"                                 example:"false"`
 	MuscleGroupID *uint  `json:"muscle_group_id"                               example:"3"`
 	AutoTranslate bool   `json:"auto_translate"                                example:"true"`
+
+	SecondaryMuscleGroupIDs []uint                 `json:"secondary_muscle_group_ids"                                                                                     example:"5"`
+	Equipment               string                 `json:"equipment"        binding:"omitempty,oneof=barbell dumbbell ez_bar kettlebell cable machine smith_machine band bodyweight other" example:"barbell"`
+	Difficulty              string                 `json:"difficulty"       binding:"omitempty,oneof=beginner intermediate advanced"                                         example:"intermediate"`
+	MovementPattern         string                 `json:"movement_pattern" binding:"omitempty,oneof=push pull hinge squat lunge carry core isolation"                        example:"push"`
+	Instructions            string                 `json:"instructions"     binding:"max=4000"                                                                              example:"Lower the bar to mid-chest and press it back up."`
+	Media                   []ExerciseMediaRequest `json:"media"            binding:"max=10,dive"`
 }
 
 // swagger:model
@@ -15,6 +22,32 @@ type ExerciseUpdateRequest struct {
 	IsBodyweight  *bool   `json:"is_bodyweight"    binding:"omitempty"        example:"false"`
 	IsTimeBased   *bool   `json:"is_time_based"    binding:"omitempty"        example:"false"`
 	MuscleGroupID *uint   `json:"muscle_group_id"  binding:"omitempty"        example:"4"`
+
+	Equipment       *string `json:"equipment"        binding:"omitempty,oneof=barbell dumbbell ez_bar kettlebell cable machine smith_machine band bodyweight other" example:"dumbbell"`
+	Difficulty      *string `json:"difficulty"       binding:"omitempty,oneof=beginner intermediate advanced"                                         example:"beginner"`
+	MovementPattern *string `json:"movement_pattern" binding:"omitempty,oneof=push pull hinge squat lunge carry core isolation"                        example:"push"`
+}
+
+// swagger:model
+type ExerciseMediaRequest struct {
+	Kind string `json:"kind" binding:"required,oneof=image video" example:"video"`
+	URL  string `json:"url"  binding:"required,url,max=500"       example:"https://cdn.example.com/exercises/bench-press.mp4"`
+}
+
+// swagger:model
+type ExerciseMediaUpdateRequest struct {
+	Media []ExerciseMediaRequest `json:"media" binding:"max=10,dive"`
+}
+
+// swagger:model
+type ExerciseSecondaryMuscleGroupsRequest struct {
+	MuscleGroupIDs []uint `json:"muscle_group_ids" example:"5"`
+}
+
+// swagger:model
+type ExerciseInstructionsRequest struct {
+	Instructions  string `json:"instructions"   binding:"required,max=4000" example:"Lower the bar to mid-chest and press it back up."`
+	AutoTranslate bool   `json:"auto_translate"                            example:"true"`
 }
 
 // swagger:model
@@ -44,4 +77,24 @@ type ExerciseResponse struct {
 	MuscleGroupID *uint                `json:"muscle_group_id" example:"3"`
 	MuscleGroup   *MuscleGroupResponse `json:"muscle_group,omitempty"`
 	Slug          string               `json:"slug"            example:"bench-press"`
+
+	SecondaryMuscleGroups []MuscleGroupResponse   `json:"secondary_muscle_groups"`
+	Equipment             string                  `json:"equipment"        example:"barbell"`
+	Difficulty            string                  `json:"difficulty"       example:"intermediate"`
+	MovementPattern       string                  `json:"movement_pattern" example:"push"`
+	InstructionsKey       string                  `json:"instructions_key" example:"exercise_instructions.bench-press"`
+	Media                 []ExerciseMediaResponse `json:"media"`
+}
+
+// swagger:model
+type ExerciseMediaResponse struct {
+	ID   uint   `json:"id"   example:"7"`
+	Kind string `json:"kind" example:"video"`
+	URL  string `json:"url"  example:"https://cdn.example.com/exercises/bench-press.mp4"`
+}
+
+// swagger:model
+type ListExerciseResponse struct {
+	Exercises []ExerciseResponse `json:"exercises"`
+	Total     int64              `json:"total" example:"42"`
 }
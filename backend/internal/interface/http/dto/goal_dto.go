@@ -0,0 +1,54 @@
+package dto
+
+import "time"
+
+// swagger:model
+type GoalCreateRequest struct {
+	Kind                 string     `json:"kind"                   binding:"required,oneof=lift bodyweight frequency"   example:"lift"`
+	IndividualExerciseID *uint      `json:"individual_exercise_id" binding:"omitempty"                                  example:"12"`
+	Metric               string     `json:"metric"                 binding:"omitempty,oneof=e1rm weight_reps"           example:"e1rm"`
+	TargetWeight         *Mass      `json:"target_weight"          binding:"omitempty,min=1,max=2000000"                example:"100000" swaggertype:"integer"`
+	TargetReps           int        `json:"target_reps"            binding:"omitempty,min=1,max=100"                    example:"5"`
+	PerWeek              int        `json:"per_week"               binding:"omitempty,min=1,max=14"                     example:"4"`
+	Weeks                int        `json:"weeks"                  binding:"omitempty,min=1,max=52"                     example:"4"`
+	Deadline             *time.Time `json:"deadline"                                                                    example:"2026-03-01T00:00:00Z"`
+}
+
+// swagger:model
+type GoalUpdateRequest struct {
+	Status       *string             `json:"status"        binding:"omitempty,oneof=active abandoned" example:"abandoned"`
+	TargetWeight *Mass               `json:"target_weight" binding:"omitempty,min=1,max=2000000"      example:"105000" swaggertype:"integer"`
+	TargetReps   *int                `json:"target_reps"   binding:"omitempty,min=1,max=100"          example:"3"`
+	PerWeek      *int                `json:"per_week"      binding:"omitempty,min=1,max=14"           example:"3"`
+	Weeks        *int                `json:"weeks"         binding:"omitempty,min=1,max=52"           example:"8"`
+	Deadline     Optional[time.Time] `json:"deadline"                                                 swaggertype:"string" example:"2026-04-01T00:00:00Z"`
+}
+
+// swagger:model
+type GoalProgressResponse struct {
+	// Weight goals report grams (or the requested display unit); frequency goals report weeks.
+	CurrentWeight *Mass   `json:"current_weight,omitempty" example:"92500" swaggertype:"integer"`
+	CurrentWeeks  *int    `json:"current_weeks,omitempty"  example:"2"`
+	ThisWeek      *int    `json:"this_week,omitempty"      example:"3"`
+	Fraction      float64 `json:"fraction"                 example:"0.93"`
+	Reached       bool    `json:"reached"                  example:"false"`
+}
+
+// swagger:model
+type GoalResponse struct {
+	ID                   uint                 `json:"id"                               example:"4"`
+	Kind                 string               `json:"kind"                             example:"lift"`
+	Status               string               `json:"status"                           example:"active"`
+	IndividualExerciseID *uint                `json:"individual_exercise_id,omitempty" example:"12"`
+	ExerciseName         string               `json:"exercise_name,omitempty"          example:"Bench Press"`
+	Metric               string               `json:"metric,omitempty"                 example:"e1rm"`
+	TargetWeight         *Mass                `json:"target_weight,omitempty"          example:"100000" swaggertype:"integer"`
+	TargetReps           int                  `json:"target_reps,omitempty"            example:"5"`
+	StartWeight          *Mass                `json:"start_weight,omitempty"           example:"85000" swaggertype:"integer"`
+	PerWeek              int                  `json:"per_week,omitempty"               example:"4"`
+	Weeks                int                  `json:"weeks,omitempty"                  example:"4"`
+	Deadline             *time.Time           `json:"deadline,omitempty"               example:"2026-03-01T00:00:00Z"`
+	AchievedAt           *time.Time           `json:"achieved_at,omitempty"`
+	Progress             GoalProgressResponse `json:"progress"`
+	CreatedAt            time.Time            `json:"created_at"`
+}
@@ -1,7 +1,22 @@
 package dto
 
 import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/achievement"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/auth"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/challenge"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/coaching"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/equipment"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/goal"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/nutrition"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/social"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/translations"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/versions"
@@ -14,8 +29,8 @@ func ToProfileResponse(p *user.Profile) ProfileResponse {
 	}
 	return ProfileResponse{
 		Age:       p.Age,
-		Height:    p.Height,
-		Weight:    p.Weight,
+		Height:    NewLength(p.Height),
+		Weight:    NewMass(p.Weight),
 		Sex:       p.Sex,
 		UpdatedAt: p.UpdatedAt,
 		CreatedAt: p.CreatedAt,
@@ -51,6 +66,14 @@ func ToExerciseResponse(e *workout.Exercise) ExerciseResponse {
 			Slug: e.MuscleGroup.Slug,
 		}
 	}
+	secondary := make([]MuscleGroupResponse, 0, len(e.SecondaryMuscleGroups))
+	for i := range e.SecondaryMuscleGroups {
+		secondary = append(secondary, ToMuscleGroupResponse(&e.SecondaryMuscleGroups[i]))
+	}
+	media := make([]ExerciseMediaResponse, 0, len(e.Media))
+	for _, m := range e.Media {
+		media = append(media, ExerciseMediaResponse{ID: m.ID, Kind: m.Kind, URL: m.URL})
+	}
 	return ExerciseResponse{
 		ID:            e.ID,
 		Name:          e.Name,
@@ -59,9 +82,24 @@ func ToExerciseResponse(e *workout.Exercise) ExerciseResponse {
 		MuscleGroupID: e.MuscleGroupID,
 		MuscleGroup:   mg,
 		Slug:          e.Slug,
+
+		SecondaryMuscleGroups: secondary,
+		Equipment:             e.Equipment,
+		Difficulty:            e.Difficulty,
+		MovementPattern:       e.MovementPattern,
+		InstructionsKey:       e.InstructionsKey(),
+		Media:                 media,
 	}
 }
 
+func ToExerciseMedia(req []ExerciseMediaRequest) []*workout.ExerciseMedia {
+	media := make([]*workout.ExerciseMedia, 0, len(req))
+	for i, m := range req {
+		media = append(media, &workout.ExerciseMedia{Kind: m.Kind, URL: m.URL, Position: i})
+	}
+	return media
+}
+
 func ToMuscleGroupResponse(m *workout.MuscleGroup) MuscleGroupResponse {
 	return MuscleGroupResponse{
 		ID:   m.ID,
@@ -75,6 +113,7 @@ func ToWorkoutPlanResponse(wp *workout.WorkoutPlan) WorkoutPlanResponse {
 		ID:             wp.ID,
 		Name:           wp.Name,
 		Active:         wp.Active,
+		Draft:          wp.Draft,
 		UserID:         wp.UserID,
 		CurrentCycleID: wp.CurrentCycleID,
 		CreatedAt:      wp.CreatedAt,
@@ -126,6 +165,8 @@ func ToWorkoutResponse(w *workout.Workout) WorkoutResponse {
 		EstimatedCalories:  w.EstimatedCalories,
 		EstimatedActiveMin: w.EstimatedActiveMin,
 		EstimatedRestMin:   w.EstimatedRestMin,
+		AISummary:          w.AISummary,
+		AISummaryAt:        w.AISummaryAt,
 	}
 	if len(w.WorkoutExercises) > 0 {
 		resp.WorkoutExercises = make([]WorkoutExerciseResponse, 0, len(w.WorkoutExercises))
@@ -171,10 +212,10 @@ func ToWorkoutSetResponse(s *workout.WorkoutSet) WorkoutSetResponse {
 		WorkoutExerciseID: s.WorkoutExerciseID,
 		Index:             s.Index,
 		Completed:         s.Completed,
-		Weight:            s.Weight,
+		Weight:            MassPtr(s.Weight),
 		Reps:              s.Reps,
 		Skipped:           s.Skipped,
-		PreviousWeight:    s.PreviousWeight,
+		PreviousWeight:    MassPtr(s.PreviousWeight),
 		PreviousReps:      s.PreviousReps,
 		CreatedAt:         s.CreatedAt,
 		UpdatedAt:         s.UpdatedAt,
@@ -190,7 +231,7 @@ func ToIndividualExerciseResponse(e *workout.IndividualExercise) IndividualExerc
 		MuscleGroupID:                  e.MuscleGroupID,
 		ExerciseID:                     e.ExerciseID,
 		LastCompletedWorkoutExerciseID: e.LastCompletedWorkoutExerciseID,
-		CurrentWeight:                  e.CurrentWeight,
+		CurrentWeight:                  NewMass(e.CurrentWeight),
 		CurrentReps:                    e.CurrentReps,
 		CreatedAt:                      e.CreatedAt,
 		UpdatedAt:                      e.UpdatedAt,
@@ -221,7 +262,7 @@ func ToIndividualExerciseStatsResponse(e *workout.IndividualExercise) Individual
 		IsTimeBased:   e.IsTimeBased,
 		MuscleGroupID: e.MuscleGroupID,
 		ExerciseID:    e.ExerciseID,
-		CurrentWeight: e.CurrentWeight,
+		CurrentWeight: NewMass(e.CurrentWeight),
 		CurrentReps:   e.CurrentReps,
 		CreatedAt:     e.CreatedAt,
 		UpdatedAt:     e.UpdatedAt,
@@ -249,7 +290,7 @@ func ToExercisePerformanceResponses(perf []*workout.ExercisePerformance) []Exerc
 	for _, p := range perf {
 		resp = append(resp, ExercisePerformanceResponse{
 			CompletedAt: p.CompletedAt,
-			Weight:      p.Weight,
+			Weight:      MassPtr(p.Weight),
 			Reps:        p.Reps,
 		})
 	}
@@ -262,6 +303,11 @@ func ToUserSettingsResponse(us *user.UserSettings) UserSettingsResponse {
 		BetaOptIn:          us.BetaOptIn,
 		EmailNotifications: us.EmailNotifications,
 		CalculateCalories:  us.CalculateCalories,
+
+		ActivityVisibility:  us.ActivityVisibility,
+		AllowWorkoutSharing: us.AllowWorkoutSharing,
+
+		AIWorkoutSummaries: us.AIWorkoutSummaries,
 	}
 }
 
@@ -339,3 +385,464 @@ func ToAiWorkoutPlanResponse(plan *workout.WorkoutPlan) AIWorkoutPlanResponse {
 		Plan: ToWorkoutPlanResponse(plan),
 	}
 }
+
+func ToAuditLogResponse(l *audit.AuditLog) AuditLogResponse {
+	return AuditLogResponse{
+		ID:         l.ID,
+		ActorID:    l.ActorID,
+		TargetType: l.TargetType,
+		TargetID:   l.TargetID,
+		Action:     l.Action,
+		IP:         l.IP,
+		UserAgent:  l.UserAgent,
+		Diff:       rawJSON(l.Diff),
+		Metadata:   rawJSON(l.Metadata),
+		CreatedAt:  l.CreatedAt,
+	}
+}
+
+func ToPersonalTokenResponse(t *auth.PersonalAccessToken) PersonalTokenResponse {
+	return PersonalTokenResponse{
+		ID:                 t.ID,
+		Name:               t.Name,
+		Prefix:             t.Prefix,
+		Scopes:             t.ScopeList(),
+		RateLimitPerMinute: t.RateLimitPerMinute,
+		ExpiresAt:          t.ExpiresAt,
+		LastUsedAt:         t.LastUsedAt,
+		RevokedAt:          t.RevokedAt,
+		CreatedAt:          t.CreatedAt,
+	}
+}
+
+func ToRoleDetailResponse(r *rbac.Role) RoleDetailResponse {
+	perms := make([]string, 0, len(r.Permissions))
+	for _, p := range r.Permissions {
+		perms = append(perms, p.Key)
+	}
+	return RoleDetailResponse{
+		ID:          r.ID,
+		Name:        r.Name,
+		Builtin:     rbac.IsBuiltinRole(r.Name),
+		Permissions: perms,
+	}
+}
+
+func ToCoachClientResponse(r *coaching.CoachClient) CoachClientResponse {
+	resp := CoachClientResponse{
+		ID:          r.ID,
+		Status:      r.Status,
+		Message:     r.Message,
+		RespondedAt: r.RespondedAt,
+		RevokedAt:   r.RevokedAt,
+		CreatedAt:   r.CreatedAt,
+	}
+	if r.Coach.ID != 0 {
+		resp.Coach = &CoachingUserResponse{ID: r.Coach.ID, Username: r.Coach.Username}
+	}
+	if r.Client.ID != 0 {
+		resp.Client = &CoachingUserResponse{ID: r.Client.ID, Username: r.Client.Username}
+	}
+	return resp
+}
+
+func ToWorkoutCommentResponse(c *coaching.WorkoutComment) WorkoutCommentResponse {
+	return WorkoutCommentResponse{
+		ID:        c.ID,
+		WorkoutID: c.WorkoutID,
+		Author:    CoachingUserResponse{ID: c.AuthorID, Username: c.Author.Username},
+		Body:      c.Body,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+func rawJSON(s string) json.RawMessage {
+	if s == "" {
+		return json.RawMessage("{}")
+	}
+	return json.RawMessage(s)
+}
+
+func ToSocialUserResponse(u *user.User) SocialUserResponse {
+	return SocialUserResponse{ID: u.ID, Username: u.Username}
+}
+
+func ToFollowResponse(f *social.Follow) FollowResponse {
+	return FollowResponse{SocialUserResponse: ToSocialUserResponse(&f.Followee), Pending: f.Pending}
+}
+
+func ToFeedItemResponse(item *social.FeedItem) FeedItemResponse {
+	a := item.Activity
+	reactions := item.Reactions
+	if reactions == nil {
+		reactions = map[string]int64{}
+	}
+	return FeedItemResponse{
+		ID:           a.ID,
+		Actor:        SocialUserResponse{ID: a.ActorID, Username: a.Actor.Username},
+		Type:         a.Type,
+		WorkoutID:    a.WorkoutID,
+		Title:        a.Title,
+		Payload:      rawJSON(a.Payload),
+		Visibility:   a.Visibility,
+		Reactions:    reactions,
+		MyReaction:   item.MyReaction,
+		CommentCount: item.CommentCount,
+		CreatedAt:    a.CreatedAt,
+	}
+}
+
+func ToActivityCommentResponse(c *social.ActivityComment) ActivityCommentResponse {
+	return ActivityCommentResponse{
+		ID:         c.ID,
+		ActivityID: c.ActivityID,
+		Author:     SocialUserResponse{ID: c.AuthorID, Username: c.Author.Username},
+		Body:       c.Body,
+		CreatedAt:  c.CreatedAt,
+	}
+}
+
+func ToWorkoutShareResponse(s *social.WorkoutShare) WorkoutShareResponse {
+	return WorkoutShareResponse{
+		ID:        s.ID,
+		WorkoutID: s.WorkoutID,
+		Token:     s.Token,
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+// ToSharedWorkoutResponse strips identifiers that only make sense to the owner.
+func ToSharedWorkoutResponse(s *social.WorkoutShare, w *workout.Workout) SharedWorkoutResponse {
+	resp := ToWorkoutResponse(w)
+	resp.WorkoutCycleID = 0
+	resp.PreviousWorkoutID = nil
+	resp.AISummary = ""
+	resp.AISummaryAt = nil
+	return SharedWorkoutResponse{Owner: s.User.Username, Workout: resp}
+}
+
+func ToChallengeResponse(c *challenge.Challenge) ChallengeResponse {
+	return ChallengeResponse{
+		ID:          c.ID,
+		Name:        c.Name,
+		Description: c.Description,
+		Metric:      c.Metric,
+		Target:      c.Target,
+		StartsAt:    c.StartsAt,
+		EndsAt:      c.EndsAt,
+	}
+}
+
+func ToChallengeParticipationResponse(c *challenge.Challenge, p *challenge.ChallengeParticipant) ChallengeParticipationResponse {
+	return ChallengeParticipationResponse{
+		Challenge:   ToChallengeResponse(c),
+		Progress:    p.Progress,
+		CompletedAt: p.CompletedAt,
+		JoinedAt:    p.JoinedAt,
+	}
+}
+
+func ToLeaderboardEntryResponse(e challenge.LeaderboardEntry) LeaderboardEntryResponse {
+	return LeaderboardEntryResponse{
+		Rank:     e.Rank,
+		UserID:   e.UserID,
+		Username: e.Username,
+		Score:    e.Score,
+	}
+}
+
+func ToAchievementResponse(s achievement.Status) AchievementResponse {
+	return AchievementResponse{
+		Key:       s.Rule.Key,
+		Kind:      s.Rule.Kind,
+		Threshold: s.Rule.Threshold,
+		Current:   s.Current,
+		Progress:  s.Progress(),
+		Earned:    s.Earned,
+		AwardedAt: s.AwardedAt,
+	}
+}
+
+func ToStreakResponse(s achievement.Streak) StreakResponse {
+	return StreakResponse{
+		Current:      s.Current,
+		Longest:      s.Longest,
+		WeeklyTarget: s.WeeklyTarget,
+		ThisWeek:     s.ThisWeek,
+	}
+}
+
+func ToEquipmentProfileResponse(p *equipment.EquipmentProfile) EquipmentProfileResponse {
+	return EquipmentProfileResponse{
+		ID:        p.ID,
+		Name:      p.Name,
+		IsDefault: p.IsDefault,
+		Equipment: p.Equipment(),
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+func ToEquipmentSwitchResponse(r *equipment.SwitchResult) EquipmentSwitchResponse {
+	resp := EquipmentSwitchResponse{
+		Profile:    ToEquipmentProfileResponse(r.Profile),
+		Swaps:      make([]ExerciseSwapResponse, 0, len(r.Swaps)),
+		Unresolved: make([]UnresolvedExerciseResponse, 0, len(r.Unresolved)),
+	}
+	for _, sw := range r.Swaps {
+		resp.Swaps = append(resp.Swaps, ExerciseSwapResponse{
+			WorkoutID:         sw.WorkoutID,
+			WorkoutExerciseID: sw.WorkoutExerciseID,
+			From:              ToExerciseResponse(sw.From),
+			To:                ToExerciseResponse(sw.To),
+		})
+	}
+	for _, u := range r.Unresolved {
+		resp.Unresolved = append(resp.Unresolved, UnresolvedExerciseResponse{
+			WorkoutID:         u.WorkoutID,
+			WorkoutExerciseID: u.WorkoutExerciseID,
+			Name:              u.Name,
+		})
+	}
+	return resp
+}
+
+func ToLoadingSettingsResponse(s *equipment.LoadingSettings) LoadingSettingsResponse {
+	resp := LoadingSettingsResponse{
+		BarWeight:         s.BarWeight,
+		DumbbellIncrement: s.DumbbellIncrement,
+		Plates:            make([]LoadingPlateDTO, 0, len(s.Plates)),
+		// Unsaved settings are the unit-system defaults.
+		IsDefault: s.ID == 0,
+	}
+	for _, p := range s.Plates {
+		resp.Plates = append(resp.Plates, LoadingPlateDTO{Weight: p.Weight, Pairs: p.Pairs})
+	}
+	return resp
+}
+
+func ToLoadoutResponse(l equipment.Loadout) LoadoutResponse {
+	resp := LoadoutResponse{Target: l.Target, Weight: l.Weight, Bar: l.Bar}
+	for _, p := range l.PerSide {
+		resp.PerSide = append(resp.PerSide, PlateCountResponse{Weight: p.Weight, Count: p.Count})
+	}
+	return resp
+}
+
+func ToMacrosResponse(m nutrition.Macros) MacrosResponse {
+	return MacrosResponse{
+		Kcal:    round2(m.Kcal),
+		Protein: round2(m.Protein),
+		Carbs:   round2(m.Carbs),
+		Fat:     round2(m.Fat),
+		Fiber:   round2(m.Fiber),
+	}
+}
+
+func round2(v float64) float64 { return math.Round(v*100) / 100 }
+
+func ToFoodResponse(f *nutrition.Food) FoodResponse {
+	return FoodResponse{
+		ID:             f.ID,
+		Name:           f.Name,
+		Slug:           f.Slug,
+		TranslationKey: f.TranslationKey(),
+		Brand:          f.Brand,
+		IsCustom:       !f.IsCatalog(),
+		Per100g:        ToMacrosResponse(f.Per100g()),
+		ServingSize:    f.ServingSize,
+	}
+}
+
+func ToMealEntryResponse(e *nutrition.MealEntry) MealEntryResponse {
+	resp := MealEntryResponse{
+		ID:      e.ID,
+		Meal:    e.Meal,
+		Amount:  e.Amount,
+		EatenAt: e.EatenAt,
+		Macros:  ToMacrosResponse(e.Macros()),
+	}
+	if e.Food != nil {
+		resp.Food = ToFoodResponse(e.Food)
+	}
+	return resp
+}
+
+func ToTargetsResponse(t *nutrition.Targets) *TargetsResponse {
+	if t == nil {
+		return nil
+	}
+	return &TargetsResponse{Kcal: t.Kcal, Protein: t.Protein, Carbs: t.Carbs, Fat: t.Fat}
+}
+
+func ToNutritionTargetsResponse(t *nutrition.Targets, g *nutrition.NutritionGoal) NutritionTargetsResponse {
+	return NutritionTargetsResponse{
+		Goal:    g.Goal,
+		Targets: ToTargetsResponse(t),
+		Overrides: NutritionGoalOverrides{
+			Kcal:    g.Kcal,
+			Protein: g.Protein,
+			Carbs:   g.Carbs,
+			Fat:     g.Fat,
+		},
+	}
+}
+
+func ToDailyBalanceResponse(b *nutrition.DailyBalance) DailyBalanceResponse {
+	resp := DailyBalanceResponse{
+		Date:     b.Day.Format(time.DateOnly),
+		Intake:   ToMacrosResponse(b.Intake),
+		ByMeal:   make(map[string]MacrosResponse, len(nutrition.Meals)),
+		Workouts: make([]WorkoutBurnResponse, 0, len(b.Workouts)),
+		Burned:   round2(b.Burned),
+		Net:      round2(b.Net()),
+		Targets:  ToTargetsResponse(b.Targets),
+	}
+	for _, meal := range nutrition.Meals {
+		resp.ByMeal[meal] = ToMacrosResponse(b.ByMeal[meal])
+	}
+	for _, w := range b.Workouts {
+		resp.Workouts = append(resp.Workouts, WorkoutBurnResponse{WorkoutID: w.WorkoutID, Name: w.Name, Kcal: round2(w.Kcal)})
+	}
+	if r := b.Remaining(); r != nil {
+		v := round2(*r)
+		resp.Remaining = &v
+	}
+	return resp
+}
+
+func ToGoalResponse(t *goal.Tracked) GoalResponse {
+	g, p := t.Goal, t.Progress
+	resp := GoalResponse{
+		ID:                   g.ID,
+		Kind:                 g.Kind,
+		Status:               g.Status,
+		IndividualExerciseID: g.IndividualExerciseID,
+		Metric:               g.Metric,
+		TargetReps:           g.TargetReps,
+		PerWeek:              g.PerWeek,
+		Weeks:                g.Weeks,
+		Deadline:             g.Deadline,
+		AchievedAt:           g.AchievedAt,
+		Progress:             GoalProgressResponse{Fraction: math.Round(p.Fraction*100) / 100, Reached: p.Reached},
+		CreatedAt:            g.CreatedAt,
+	}
+	if g.IndividualExercise != nil {
+		resp.ExerciseName = g.IndividualExercise.Name
+	}
+
+	switch g.Kind {
+	case goal.KindFrequency:
+		resp.Progress.CurrentWeeks = &p.Current
+		resp.Progress.ThisWeek = &p.ThisWeek
+	default:
+		resp.TargetWeight = MassPtr(&g.TargetWeight)
+		resp.Progress.CurrentWeight = MassPtr(&p.Current)
+		if g.Kind == goal.KindBodyweight {
+			resp.StartWeight = MassPtr(&g.StartWeight)
+		}
+	}
+	return resp
+}
+
+func ToAIConversationResponse(c *ai.Conversation) AIConversationResponse {
+	resp := AIConversationResponse{
+		ID:         c.ID,
+		ResponseID: strconv.FormatUint(uint64(c.ID), 10),
+		Kind:       c.Kind,
+		Title:      c.Title,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+	for _, m := range c.Messages {
+		resp.Messages = append(resp.Messages, AIConversationMessageResponse{
+			ID:        m.ID,
+			Role:      string(m.Role),
+			Content:   m.Content,
+			CreatedAt: m.CreatedAt,
+		})
+	}
+	return resp
+}
+
+func ToAIUsageSummaryResponses(rows []*ai.UsageSummary) []AIUsageSummaryResponse {
+	resp := make([]AIUsageSummaryResponse, 0, len(rows))
+	for _, r := range rows {
+		resp = append(resp, AIUsageSummaryResponse{
+			Endpoint:      r.Endpoint,
+			Model:         r.Model,
+			PromptVersion: r.PromptVersion,
+			Calls:         r.Calls,
+			InputTokens:   r.InputTokens,
+			OutputTokens:  r.OutputTokens,
+		})
+	}
+	return resp
+}
+
+func ToAIUsageResponse(r *ai.UsageReport) AIUsageResponse {
+	return AIUsageResponse{
+		Daily:      AIUsagePeriodResponse{Used: r.UsedToday, Limit: r.Limits.DailyTokens, ResetsAt: r.DayResetsAt},
+		Monthly:    AIUsagePeriodResponse{Used: r.UsedThisMonth, Limit: r.Limits.MonthlyTokens, ResetsAt: r.MonthResetsAt},
+		ByEndpoint: ToAIUsageSummaryResponses(r.ByEndpoint),
+	}
+}
+
+func ToAIUsageReportResponse(from, to time.Time, summary []*ai.UsageSummary, top []*ai.UserUsage) AIUsageReportResponse {
+	users := make([]AIUserUsageResponse, 0, len(top))
+	for _, u := range top {
+		users = append(users, AIUserUsageResponse{
+			UserID:       u.UserID,
+			Username:     u.Username,
+			Calls:        u.Calls,
+			InputTokens:  u.InputTokens,
+			OutputTokens: u.OutputTokens,
+		})
+	}
+	return AIUsageReportResponse{
+		From:       from,
+		To:         to,
+		ByEndpoint: ToAIUsageSummaryResponses(summary),
+		TopUsers:   users,
+	}
+}
+
+func ToAIQuotaResponse(q *ai.TokenQuota) AIQuotaResponse {
+	return AIQuotaResponse{
+		Role:          q.Role.Name,
+		DailyTokens:   q.DailyTokens,
+		MonthlyTokens: q.MonthlyTokens,
+		UpdatedAt:     q.UpdatedAt,
+	}
+}
+
+func ToAIPromptVersionResponse(t *ai.PromptTemplate) AIPromptVersionResponse {
+	return AIPromptVersionResponse{
+		ID:        t.ID,
+		Locale:    t.Locale,
+		Version:   t.Version,
+		Body:      t.Body,
+		Active:    t.Active,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+func ToAIPromptResponse(p *ai.PromptSet) AIPromptResponse {
+	versions := make([]AIPromptVersionResponse, 0, len(p.Versions))
+	for _, v := range p.Versions {
+		versions = append(versions, ToAIPromptVersionResponse(v))
+	}
+	return AIPromptResponse{Name: p.Name, Default: p.Default, Versions: versions}
+}
+
+func ToAIBlockedRequestResponse(b *ai.BlockedRequest) AIBlockedRequestResponse {
+	return AIBlockedRequestResponse{
+		ID:        b.ID,
+		UserID:    b.UserID,
+		Username:  b.User.Username,
+		Endpoint:  b.Endpoint,
+		Category:  b.Category,
+		Input:     b.Input,
+		CreatedAt: b.CreatedAt,
+	}
+}
@@ -0,0 +1,139 @@
+package dto
+
+import "time"
+
+// swagger:model
+type FoodCreateRequest struct {
+	Name        string  `json:"name"         binding:"required,max=100"        example:"Oatmeal"`
+	Brand       string  `json:"brand"        binding:"max=100"                 example:""`
+	Kcal        float64 `json:"kcal"         binding:"min=0,max=900"           example:"379"`
+	Protein     float64 `json:"protein"      binding:"min=0,max=100"           example:"13.2"`
+	Carbs       float64 `json:"carbs"        binding:"min=0,max=100"           example:"67.7"`
+	Fat         float64 `json:"fat"          binding:"min=0,max=100"           example:"6.5"`
+	Fiber       float64 `json:"fiber"        binding:"min=0,max=100"           example:"10.1"`
+	ServingSize int     `json:"serving_size" binding:"omitempty,min=1,max=5000" example:"40"`
+}
+
+// swagger:model
+type CatalogFoodCreateRequest struct {
+	FoodCreateRequest
+	AutoTranslate bool `json:"auto_translate" example:"true"`
+}
+
+// swagger:model
+type FoodUpdateRequest struct {
+	Name        *string  `json:"name"         binding:"omitempty,max=100"       example:"Rolled oats"`
+	Brand       *string  `json:"brand"        binding:"omitempty,max=100"       example:"Quaker"`
+	Kcal        *float64 `json:"kcal"         binding:"omitempty,min=0,max=900" example:"375"`
+	Protein     *float64 `json:"protein"      binding:"omitempty,min=0,max=100" example:"13"`
+	Carbs       *float64 `json:"carbs"        binding:"omitempty,min=0,max=100" example:"67"`
+	Fat         *float64 `json:"fat"          binding:"omitempty,min=0,max=100" example:"6.5"`
+	Fiber       *float64 `json:"fiber"        binding:"omitempty,min=0,max=100" example:"10"`
+	ServingSize *int     `json:"serving_size" binding:"omitempty,min=1,max=5000" example:"50"`
+}
+
+// swagger:model
+type MacrosResponse struct {
+	Kcal    float64 `json:"kcal"    example:"151.6"`
+	Protein float64 `json:"protein" example:"5.28"`
+	Carbs   float64 `json:"carbs"   example:"27.08"`
+	Fat     float64 `json:"fat"     example:"2.6"`
+	Fiber   float64 `json:"fiber"   example:"4.04"`
+}
+
+// swagger:model
+type FoodResponse struct {
+	ID   uint   `json:"id"   example:"7"`
+	Name string `json:"name" example:"Oatmeal"`
+	Slug string `json:"slug" example:"oatmeal"`
+	// TranslationKey is set for catalog foods; clients show its translation instead of Name.
+	TranslationKey string         `json:"translation_key,omitempty" example:"food.oatmeal"`
+	Brand          string         `json:"brand"                     example:""`
+	IsCustom       bool           `json:"is_custom"                 example:"false"`
+	Per100g        MacrosResponse `json:"per_100g"`
+	ServingSize    int            `json:"serving_size"              example:"40"`
+}
+
+// swagger:model
+type ListFoodResponse struct {
+	Items []FoodResponse `json:"items"`
+	Total int64          `json:"total" example:"42"`
+}
+
+// swagger:model
+type MealEntryCreateRequest struct {
+	FoodID  uint       `json:"food_id"  binding:"required"                                    example:"7"`
+	Meal    string     `json:"meal"     binding:"omitempty,oneof=breakfast lunch dinner snack" example:"breakfast"`
+	Amount  int        `json:"amount"   binding:"required,min=1,max=5000"                     example:"40"`
+	EatenAt *time.Time `json:"eaten_at"                                                       example:"2025-06-01T08:30:00Z"`
+}
+
+// swagger:model
+type MealEntryUpdateRequest struct {
+	FoodID  *uint      `json:"food_id"  binding:"omitempty"                                    example:"8"`
+	Meal    *string    `json:"meal"     binding:"omitempty,oneof=breakfast lunch dinner snack" example:"snack"`
+	Amount  *int       `json:"amount"   binding:"omitempty,min=1,max=5000"                     example:"60"`
+	EatenAt *time.Time `json:"eaten_at"                                                        example:"2025-06-01T10:00:00Z"`
+}
+
+// swagger:model
+type MealEntryResponse struct {
+	ID      uint           `json:"id"       example:"31"`
+	Food    FoodResponse   `json:"food"`
+	Meal    string         `json:"meal"     example:"breakfast"`
+	Amount  int            `json:"amount"   example:"40"`
+	EatenAt time.Time      `json:"eaten_at" example:"2025-06-01T08:30:00Z"`
+	Macros  MacrosResponse `json:"macros"`
+}
+
+// swagger:model
+type NutritionGoalRequest struct {
+	Goal    string `json:"goal"    binding:"required,oneof=lose maintain gain" example:"lose"`
+	Kcal    *int   `json:"kcal"    binding:"omitempty,min=800,max=10000"      example:"2200"`
+	Protein *int   `json:"protein" binding:"omitempty,min=0,max=1000"         example:"160"`
+	Carbs   *int   `json:"carbs"   binding:"omitempty,min=0,max=2000"         example:"220"`
+	Fat     *int   `json:"fat"     binding:"omitempty,min=0,max=1000"         example:"70"`
+}
+
+// swagger:model
+type TargetsResponse struct {
+	Kcal    int `json:"kcal"    example:"2200"`
+	Protein int `json:"protein" example:"140"`
+	Carbs   int `json:"carbs"   example:"248"`
+	Fat     int `json:"fat"     example:"61"`
+}
+
+// swagger:model
+type NutritionTargetsResponse struct {
+	Goal    string           `json:"goal"              example:"lose"`
+	Targets *TargetsResponse `json:"targets,omitempty"`
+	// Overrides are the values the user set by hand; the rest are derived from the profile.
+	Overrides NutritionGoalOverrides `json:"overrides"`
+}
+
+// swagger:model
+type NutritionGoalOverrides struct {
+	Kcal    *int `json:"kcal,omitempty"    example:"2200"`
+	Protein *int `json:"protein,omitempty" example:"160"`
+	Carbs   *int `json:"carbs,omitempty"   example:"220"`
+	Fat     *int `json:"fat,omitempty"     example:"70"`
+}
+
+// swagger:model
+type WorkoutBurnResponse struct {
+	WorkoutID uint    `json:"workout_id" example:"100"`
+	Name      string  `json:"name"       example:"Push day"`
+	Kcal      float64 `json:"kcal"       example:"320"`
+}
+
+// swagger:model
+type DailyBalanceResponse struct {
+	Date      string                    `json:"date"                example:"2025-06-01"`
+	Intake    MacrosResponse            `json:"intake"`
+	ByMeal    map[string]MacrosResponse `json:"by_meal"`
+	Workouts  []WorkoutBurnResponse     `json:"workouts"`
+	Burned    float64                   `json:"burned"              example:"320"`
+	Net       float64                   `json:"net"                 example:"1480"`
+	Targets   *TargetsResponse          `json:"targets,omitempty"`
+	Remaining *float64                  `json:"remaining,omitempty" example:"1040"`
+}
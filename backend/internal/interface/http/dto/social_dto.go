@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// swagger:model
+type FollowRequest struct {
+	Username string `json:"username" binding:"required,max=256" example:"ada_lovelace"`
+}
+
+// swagger:model
+type SocialUserResponse struct {
+	ID       uint   `json:"id" example:"7"`
+	Username string `json:"username" example:"ada_lovelace"`
+}
+
+// swagger:model
+type FollowResponse struct {
+	SocialUserResponse
+	// Pending is true until the followed user accepts the request.
+	Pending bool `json:"pending" example:"true"`
+}
+
+// swagger:model
+type ListSocialUsersResponse struct {
+	Users []SocialUserResponse `json:"users"`
+	Total int64                `json:"total" example:"12"`
+}
+
+// swagger:model
+type FeedItemResponse struct {
+	ID           uint               `json:"id" example:"31"`
+	Actor        SocialUserResponse `json:"actor"`
+	Type         string             `json:"type" example:"workout_completed"`
+	WorkoutID    *uint              `json:"workout_id,omitempty" example:"100"`
+	Title        string             `json:"title,omitempty" example:"Upper Body A"`
+	Payload      json.RawMessage    `json:"payload" swaggertype:"object"`
+	Visibility   string             `json:"visibility" example:"followers"`
+	Reactions    map[string]int64   `json:"reactions"`
+	MyReaction   string             `json:"my_reaction,omitempty" example:"fire"`
+	CommentCount int64              `json:"comment_count" example:"2"`
+	CreatedAt    time.Time          `json:"created_at"`
+}
+
+// swagger:model
+type ReactionRequest struct {
+	Kind string `json:"kind" binding:"required,oneof=like fire strong clap" example:"fire"`
+}
+
+// swagger:model
+type ActivityCommentRequest struct {
+	Body string `json:"body" binding:"required,max=2000" example:"Nice session!"`
+}
+
+// swagger:model
+type ActivityCommentResponse struct {
+	ID         uint               `json:"id" example:"12"`
+	ActivityID uint               `json:"activity_id" example:"31"`
+	Author     SocialUserResponse `json:"author"`
+	Body       string             `json:"body"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+// swagger:model
+type CreateWorkoutShareRequest struct {
+	WorkoutID uint `json:"workout_id" binding:"required" example:"100"`
+}
+
+// swagger:model
+type WorkoutShareResponse struct {
+	ID        uint      `json:"id" example:"4"`
+	WorkoutID uint      `json:"workout_id" example:"100"`
+	Token     string    `json:"token" example:"q8Jd0dVY3nW1b2kZ8pQeLrT4uXc5yA7m"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// swagger:model
+type SharedWorkoutResponse struct {
+	Owner   string          `json:"owner" example:"ada_lovelace"`
+	Workout WorkoutResponse `json:"workout"`
+}
@@ -0,0 +1,184 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/shared/units"
+)
+
+// Mass is a weight on the wire. By default it is integer grams; when the request
+// opted into display units it is kilograms or pounds rounded to two decimals,
+// both in responses and in request bodies.
+type Mass struct {
+	grams  units.Mass
+	system units.System
+	value  float64 // as sent by the client, before its unit system is known
+}
+
+func NewMass(grams int) Mass { return Mass{grams: units.Mass(grams)} }
+
+func MassPtr(grams *int) *Mass {
+	if grams == nil {
+		return nil
+	}
+	m := NewMass(*grams)
+	return &m
+}
+
+func (m Mass) Grams() int   { return m.grams.Grams() }
+func (m Mass) IsZero() bool { return m.grams == 0 }
+
+// GramsPtr is nil-safe so optional request fields map straight onto domain pointers.
+func (m *Mass) GramsPtr() *int {
+	if m == nil {
+		return nil
+	}
+	g := m.Grams()
+	return &g
+}
+
+func (m Mass) MarshalJSON() ([]byte, error) {
+	if !m.system.IsDisplay() {
+		return json.Marshal(m.grams.Grams())
+	}
+	return json.Marshal(m.grams.In(m.system))
+}
+
+func (m *Mass) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &m.value); err != nil {
+		return err
+	}
+	m.grams = units.MassIn(units.Canonical, m.value)
+	return nil
+}
+
+func (m *Mass) present(s units.System) { m.system = s }
+func (m *Mass) read(s units.System)    { m.grams = units.MassIn(s, m.value) }
+func (m Mass) canonical() any          { return m.Grams() }
+
+// swagger:model
+type FeetInches struct {
+	Feet   int     `json:"feet"   example:"5"`
+	Inches float64 `json:"inches" example:"8.5"`
+}
+
+// Length is a distance on the wire: integer millimetres by default, centimetres
+// for metric clients and a feet/inches object for imperial ones.
+type Length struct {
+	mm     units.Length
+	system units.System
+	value  float64 // as sent by the client; total inches when sent as feet/inches
+	inches bool
+}
+
+func NewLength(mm int) Length { return Length{mm: units.Length(mm)} }
+
+func LengthPtr(mm *int) *Length {
+	if mm == nil {
+		return nil
+	}
+	l := NewLength(*mm)
+	return &l
+}
+
+func (l Length) Millimetres() int { return l.mm.Millimetres() }
+func (l Length) IsZero() bool     { return l.mm == 0 }
+
+func (l Length) MarshalJSON() ([]byte, error) {
+	switch l.system {
+	case units.Metric:
+		return json.Marshal(l.mm.In(units.Metric))
+	case units.Imperial:
+		ft, in := l.mm.SplitFeetInches()
+		return json.Marshal(FeetInches{Feet: ft, Inches: in})
+	}
+	return json.Marshal(l.mm.Millimetres())
+}
+
+// UnmarshalJSON accepts a plain number in the request's unit (inches for imperial)
+// or an explicit feet/inches object.
+func (l *Length) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		var fi FeetInches
+		if err := json.Unmarshal(data, &fi); err != nil {
+			return err
+		}
+		l.value, l.inches = float64(fi.Feet*units.InchesPerFoot)+fi.Inches, true
+		l.mm = units.Inches(l.value)
+		return nil
+	}
+	if err := json.Unmarshal(data, &l.value); err != nil {
+		return err
+	}
+	l.mm = units.LengthIn(units.Canonical, l.value)
+	return nil
+}
+
+func (l *Length) present(s units.System) { l.system = s }
+func (l Length) canonical() any          { return l.Millimetres() }
+
+func (l *Length) read(s units.System) {
+	if l.inches {
+		s = units.Imperial
+	}
+	l.mm = units.LengthIn(s, l.value)
+}
+
+type quantity interface {
+	present(s units.System)
+	read(s units.System)
+}
+
+// canonicalQuantity lets BuildUpdatesFromPatchDTO hand services plain grams and millimetres.
+type canonicalQuantity interface{ canonical() any }
+
+// QuantityValue exposes the canonical value of Mass and Length to the validator,
+// so binding tags like min/max keep working in grams and millimetres.
+func QuantityValue(v reflect.Value) any {
+	if q, ok := v.Interface().(canonicalQuantity); ok {
+		return q.canonical()
+	}
+	return nil
+}
+
+// WithUnits returns v with every Mass and Length set to render in s.
+// Canonical leaves the response untouched.
+func WithUnits[T any](v T, s units.System) T {
+	if s.IsDisplay() {
+		walkQuantities(reflect.ValueOf(&v).Elem(), func(q quantity) { q.present(s) })
+	}
+	return v
+}
+
+// ReadUnits reinterprets the quantities of a freshly decoded request body in s.
+func ReadUnits(ptr any, s units.System) {
+	walkQuantities(reflect.ValueOf(ptr), func(q quantity) { q.read(s) })
+}
+
+func walkQuantities(v reflect.Value, fn func(quantity)) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			walkQuantities(v.Elem(), fn)
+		}
+	case reflect.Struct:
+		if v.CanAddr() {
+			if q, ok := v.Addr().Interface().(quantity); ok {
+				fn(q)
+				return
+			}
+		}
+		t := v.Type()
+		for i := range v.NumField() {
+			if t.Field(i).IsExported() {
+				walkQuantities(v.Field(i), fn)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			walkQuantities(v.Index(i), fn)
+		}
+	}
+}
@@ -41,7 +41,11 @@ func BuildUpdatesFromPatchDTO(payload any) map[string]any {
 			if fv.IsNil() {
 				continue // key absent => skip
 			}
-			updates[jsonKey] = fv.Elem().Interface()
+			val := fv.Elem().Interface()
+			if q, ok := val.(canonicalQuantity); ok {
+				val = q.canonical()
+			}
+			updates[jsonKey] = val
 			continue
 		}
 
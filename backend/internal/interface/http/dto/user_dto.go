@@ -29,16 +29,16 @@ type AccountUpdateRequest struct {
 // swagger:model
 type ProfileCreateRequest struct {
 	Age    int    `json:"age"    binding:"min=16,max=150" example:"28"`
-	Height int    `json:"height" binding:"min=200,max=5000" example:"1720"`      // mm
-	Weight int    `json:"weight" binding:"min=20000,max=500000" example:"65000"` // grams
+	Height Length `json:"height" binding:"min=200,max=5000" example:"1720" swaggertype:"integer"`      // mm
+	Weight Mass   `json:"weight" binding:"min=20000,max=500000" example:"65000" swaggertype:"integer"` // grams
 	Sex    string `json:"sex"    example:"female"`
 }
 
 // swagger:model
 type ProfileUpdateRequest struct {
 	Age    *int    `json:"age"    binding:"omitempty,min=16,max=150" example:"29"`
-	Height *int    `json:"height" binding:"omitempty,min=200,max=5000" example:"1730"`
-	Weight *int    `json:"weight" binding:"omitempty,min=20000,max=500000" example:"64000"`
+	Height *Length `json:"height" binding:"omitempty,min=200,max=5000" example:"1730" swaggertype:"integer"`
+	Weight *Mass   `json:"weight" binding:"omitempty,min=20000,max=500000" example:"64000" swaggertype:"integer"`
 	Sex    *string `json:"sex"    binding:"omitempty" example:"female"`
 }
 
@@ -61,6 +61,9 @@ type UserSettingsCreateRequest struct {
 	BetaOptIn          bool   `json:"beta_opt_in"         example:"false"`
 	EmailNotifications bool   `json:"email_notifications" example:"true"`
 	CalculateCalories  bool   `json:"calculate_calories"  example:"true"`
+
+	ActivityVisibility  string `json:"activity_visibility"   binding:"omitempty,oneof=private followers public" example:"followers"`
+	AllowWorkoutSharing bool   `json:"allow_workout_sharing" example:"false"`
 }
 
 // swagger:model
@@ -69,13 +72,18 @@ type UserSettingsUpdateRequest struct {
 	BetaOptIn          *bool   `json:"beta_opt_in"         binding:"omitempty" example:"true"`
 	EmailNotifications *bool   `json:"email_notifications" binding:"omitempty" example:"false"`
 	CalculateCalories  *bool   `json:"calculate_calories"  binding:"omitempty" example:"false"`
+
+	ActivityVisibility  *string `json:"activity_visibility"   binding:"omitempty,oneof=private followers public" example:"public"`
+	AllowWorkoutSharing *bool   `json:"allow_workout_sharing" binding:"omitempty" example:"true"`
+
+	AIWorkoutSummaries *bool `json:"ai_workout_summaries" binding:"omitempty" example:"false"`
 }
 
 // swagger:model
 type ProfileResponse struct {
 	Age       int        `json:"age"        example:"28"`
-	Height    int        `json:"height"     example:"1720"`
-	Weight    int        `json:"weight"     example:"65000"`
+	Height    Length     `json:"height"     example:"1720" swaggertype:"integer"`
+	Weight    Mass       `json:"weight"     example:"65000" swaggertype:"integer"`
 	Sex       string     `json:"sex"        example:"female"`
 	UpdatedAt *time.Time `json:"updated_at" example:"2025-09-20T12:34:56Z"`
 	CreatedAt *time.Time `json:"created_at" example:"2025-01-02T15:04:05Z"`
@@ -117,6 +125,11 @@ type UserSettingsResponse struct {
 	BetaOptIn          bool   `json:"beta_opt_in"         example:"false"`
 	EmailNotifications bool   `json:"email_notifications" example:"true"`
 	CalculateCalories  bool   `json:"calculate_calories"  example:"true"`
+
+	ActivityVisibility  string `json:"activity_visibility"   example:"followers"`
+	AllowWorkoutSharing bool   `json:"allow_workout_sharing" example:"false"`
+
+	AIWorkoutSummaries bool `json:"ai_workout_summaries" example:"true"`
 }
 
 // swagger:model
@@ -124,3 +137,29 @@ type AccountResponse struct {
 	Username string `json:"username" example:"ada_l"`
 	Email    string `json:"email"    example:"ada.new@example.com"`
 }
+
+// swagger:model
+type CreatePersonalTokenRequest struct {
+	Name      string     `json:"name"       binding:"required,max=100" example:"Home Assistant"`
+	Scopes    []string   `json:"scopes"     binding:"required,min=1" example:"workout:read:self"`
+	ExpiresAt *time.Time `json:"expires_at" example:"2026-01-01T00:00:00Z"`
+}
+
+// swagger:model
+type PersonalTokenResponse struct {
+	ID                 uint       `json:"id"                    example:"3"`
+	Name               string     `json:"name"                  example:"Home Assistant"`
+	Prefix             string     `json:"prefix"                example:"ftpat_a1b2c3"`
+	Scopes             []string   `json:"scopes"                example:"workout:read:self"`
+	RateLimitPerMinute int        `json:"rate_limit_per_minute" example:"60"`
+	ExpiresAt          *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt         *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt          *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+// swagger:model
+type CreatePersonalTokenResponse struct {
+	PersonalTokenResponse
+	Token string `json:"token" example:"ftpat_a1b2c3..."` // shown only once
+}
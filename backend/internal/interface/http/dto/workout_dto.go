@@ -20,6 +20,11 @@ type SetActiveWorkoutPlanRequest struct {
 	Active bool `json:"active" binding:"required" example:"true"`
 }
 
+// swagger:model
+type AcceptDraftWorkoutPlanRequest struct {
+	Activate bool `json:"activate" example:"true"`
+}
+
 // swagger:model
 type WorkoutCycleCreateRequest struct {
 	Name       string `json:"name"        binding:"required,max=50" example:"Week 1"`
@@ -108,17 +113,17 @@ type WorkoutExerciseReplaceRequest struct {
 
 // swagger:model
 type WorkoutSetCreateRequest struct {
-	Index          int  `json:"index"                                     example:"1"`
-	Weight         *int `json:"weight"           binding:"omitempty,min=0,max=2000000" example:"60000"`
-	Reps           *int `json:"reps"             binding:"omitempty,min=1,max=2000"    example:"10"`
-	PreviousWeight *int `json:"previous_weight"  binding:"omitempty,min=0,max=2000000" example:"55000"`
-	PreviousReps   *int `json:"previous_reps"    binding:"omitempty,min=1,max=2000"    example:"9"`
+	Index          int   `json:"index"                                     example:"1"`
+	Weight         *Mass `json:"weight"           binding:"omitempty,min=0,max=2000000" example:"60000" swaggertype:"integer"`
+	Reps           *int  `json:"reps"             binding:"omitempty,min=1,max=2000"    example:"10"`
+	PreviousWeight *Mass `json:"previous_weight"  binding:"omitempty,min=0,max=2000000" example:"55000" swaggertype:"integer"`
+	PreviousReps   *int  `json:"previous_reps"    binding:"omitempty,min=1,max=2000"    example:"9"`
 }
 
 // swagger:model
 type WorkoutSetUpdateRequest struct {
 	Index     *int  `json:"index"     binding:"omitempty"                    example:"2"`
-	Weight    *int  `json:"weight"    binding:"omitempty,min=0,max=2000000"  example:"62000"`
+	Weight    *Mass `json:"weight"    binding:"omitempty,min=0,max=2000000"  example:"62000" swaggertype:"integer"`
 	Reps      *int  `json:"reps"      binding:"omitempty,min=1,max=2000"     example:"8"`
 	Completed *bool `json:"completed" binding:"omitempty"                    example:"true"`
 	Skipped   *bool `json:"skipped"   binding:"omitempty"                    example:"false"`
@@ -149,6 +154,7 @@ type WorkoutPlanResponse struct {
 	ID             uint                   `json:"id,omitempty"              example:"1"`
 	Name           string                 `json:"name,omitempty"            example:"Push/Pull/Legs"`
 	Active         bool                   `json:"active"                    example:"true"`
+	Draft          bool                   `json:"draft"                     example:"false"`
 	UserID         uint                   `json:"user_id,omitempty"         example:"42"`
 	CurrentCycleID *uint                  `json:"current_cycle_id,omitempty" example:"12"`
 	WorkoutCycles  []WorkoutCycleResponse `json:"workout_cycles,omitempty"`
@@ -187,6 +193,8 @@ type WorkoutResponse struct {
 	EstimatedCalories  float64                   `json:"estimated_calories,omitempty" example:"200.5"`
 	EstimatedActiveMin float64                   `json:"estimated_active_min,omitempty" example:"10.0"`
 	EstimatedRestMin   float64                   `json:"estimated_rest_min,omitempty" example:"30.0"`
+	AISummary          string                    `json:"ai_summary,omitempty"         example:"Solid session with a bench press PR. Next time add 2.5 kg to the squat."`
+	AISummaryAt        *time.Time                `json:"ai_summary_at,omitempty"      example:"2025-09-25T12:35:10Z"`
 }
 
 // swagger:model
@@ -201,7 +209,7 @@ type IndividualExerciseResponse struct {
 	Exercise                       ExerciseResponse         `json:"exercise"`
 	LastCompletedWorkoutExerciseID *uint                    `json:"last_completed_workout_exercise_id,omitempty" example:"555"`
 	LastCompletedWorkoutExercise   *WorkoutExerciseResponse `json:"last_completed_workout_exercise,omitempty"`
-	CurrentWeight                  int                      `json:"current_weight,omitempty"                example:"60000"`
+	CurrentWeight                  Mass                     `json:"current_weight,omitzero"                 example:"60000" swaggertype:"integer"`
 	CurrentReps                    int                      `json:"current_reps,omitempty"                  example:"10"`
 	CreatedAt                      *time.Time               `json:"created_at"                              example:"2025-09-20T12:34:56Z"`
 	UpdatedAt                      *time.Time               `json:"updated_at"                              example:"2025-09-25T12:34:56Z"`
@@ -230,9 +238,9 @@ type WorkoutSetResponse struct {
 	Index             int        `json:"index,omitempty"               example:"1"`
 	Completed         bool       `json:"completed,omitempty"           example:"true"`
 	Skipped           bool       `json:"skipped,omitempty"             example:"false"`
-	Weight            *int       `json:"weight,omitempty"              example:"60000"`
+	Weight            *Mass      `json:"weight,omitempty"              example:"60000" swaggertype:"integer"`
 	Reps              *int       `json:"reps,omitempty"                example:"10"`
-	PreviousWeight    *int       `json:"previous_weight,omitempty"     example:"55000"`
+	PreviousWeight    *Mass      `json:"previous_weight,omitempty"     example:"55000" swaggertype:"integer"`
 	PreviousReps      *int       `json:"previous_reps,omitempty"       example:"9"`
 	CreatedAt         *time.Time `json:"created_at"                    example:"2025-09-20T12:34:56Z"`
 	UpdatedAt         *time.Time `json:"updated_at"                    example:"2025-09-25T12:34:56Z"`
@@ -248,7 +256,7 @@ type IndividualExerciseStatsResponse struct {
 	MuscleGroup   MuscleGroupResponse `json:"muscle_group"`
 	ExerciseID    *uint               `json:"exercise_id,omitempty"    example:"12"`
 	Exercise      ExerciseResponse    `json:"exercise"`
-	CurrentWeight int                 `json:"current_weight,omitempty" example:"60000"`
+	CurrentWeight Mass                `json:"current_weight,omitzero" example:"60000" swaggertype:"integer"`
 	CurrentReps   int                 `json:"current_reps,omitempty"   example:"10"`
 	CreatedAt     *time.Time          `json:"created_at" example:"2025-09-20T12:34:56Z"`
 	UpdatedAt     *time.Time          `json:"updated_at" example:"2025-09-25T12:34:56Z"`
@@ -257,7 +265,7 @@ type IndividualExerciseStatsResponse struct {
 // swagger:model
 type ExercisePerformanceResponse struct {
 	CompletedAt *time.Time `json:"completed_at,omitempty" example:"2025-09-20T12:34:56Z"`
-	Weight      *int       `json:"weight,omitempty" example:"60000"`
+	Weight      *Mass      `json:"weight,omitempty" example:"60000" swaggertype:"integer"`
 	Reps        *int       `json:"reps,omitempty" example:"10"`
 }
 
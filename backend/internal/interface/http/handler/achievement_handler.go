@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/achievement"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/dto"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/middleware"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type AchievementHandler struct {
+	svc usecase.AchievementService
+}
+
+func NewAchievementHandler(r *gin.RouterGroup, svc usecase.AchievementService) {
+	h := &AchievementHandler{svc: svc}
+
+	ach := r.Group("/achievements")
+	ach.Use(middleware.JWTMiddleware())
+	{
+		ach.GET("", h.GetAchievements)
+		ach.GET("/streak", h.GetStreak)
+	}
+}
+
+// GetAchievements godoc
+// @Summary      List achievements with progress
+// @Description  Returns the full catalog with the caller's progress, the next badge of each kind, and the current streak.
+// @Tags         achievements
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  dto.AchievementsResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /achievements [get]
+func (h *AchievementHandler) GetAchievements(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	statuses, streak, err := h.svc.GetAchievements(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	next := achievement.Next(statuses)
+	resp := dto.AchievementsResponse{
+		Achievements: make([]dto.AchievementResponse, 0, len(statuses)),
+		Next:         make([]dto.AchievementResponse, 0, len(next)),
+		Streak:       dto.ToStreakResponse(streak),
+	}
+	for _, s := range statuses {
+		resp.Achievements = append(resp.Achievements, dto.ToAchievementResponse(s))
+	}
+	for _, s := range next {
+		resp.Next = append(resp.Next, dto.ToAchievementResponse(s))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetStreak godoc
+// @Summary      Get my weekly training streak
+// @Description  A week counts when the caller completes as many workouts as the active plan schedules per week.
+// @Tags         achievements
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  dto.StreakResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /achievements/streak [get]
+func (h *AchievementHandler) GetStreak(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	streak, err := h.svc.GetStreak(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToStreakResponse(streak))
+}
@@ -3,8 +3,10 @@ package handler
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
 	"github.com/lordmitrii/golang-web-gin/internal/interface/http/dto"
 	"github.com/lordmitrii/golang-web-gin/internal/interface/http/middleware"
@@ -28,6 +30,8 @@ func NewAdminHandler(r *gin.RouterGroup, svc usecase.AdminService, rbacService u
 		admin.POST("/users/:id/roles", h.SetUserRoles)
 		admin.POST("/users/:id/password-reset", h.TriggerResetUserPassword)
 		admin.DELETE("/users/:id", h.DeleteUser)
+
+		admin.GET("/audit-logs", h.GetAuditLogs)
 	}
 }
 
@@ -200,3 +204,67 @@ func (h *AdminHandler) TriggerResetUserPassword(c *gin.Context) {
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// GetAuditLogs godoc
+// @Summary      List audit logs (admin)
+// @Description  Returns a paginated, newest-first list of security audit log entries.
+// @Tags         admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        actor_id     query     int     false  "Filter by acting user ID"
+// @Param        target_type  query     string  false  "Filter by target type"         example(user)
+// @Param        target_id    query     int     false  "Filter by target ID"
+// @Param        action       query     string  false  "Filter by action"              example(admin.user_roles_set)
+// @Param        ip           query     string  false  "Filter by client IP"
+// @Param        from         query     string  false  "Inclusive lower bound (RFC3339)"
+// @Param        to           query     string  false  "Exclusive upper bound (RFC3339)"
+// @Param        page         query     int     false  "Page number (1-based)"         minimum(1) default(1)
+// @Param        page_size    query     int     false  "Page size"                     minimum(1) maximum(200) default(50)
+// @Success      200  {object}  dto.ListAuditLogResponse
+// @Failure      400  {object}  dto.MessageResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      403  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /admin/audit-logs [get]
+func (h *AdminHandler) GetAuditLogs(c *gin.Context) {
+	f := audit.Filter{
+		TargetType: strings.TrimSpace(c.Query("target_type")),
+		Action:     strings.TrimSpace(c.Query("action")),
+		IP:         strings.TrimSpace(c.Query("ip")),
+	}
+	if id := parseUint(c.Query("actor_id"), 0); id != 0 {
+		f.ActorID = &id
+	}
+	if id := parseUint(c.Query("target_id"), 0); id != 0 {
+		f.TargetID = &id
+	}
+
+	for param, dst := range map[string]**time.Time{"from": &f.From, "to": &f.To} {
+		raw := strings.TrimSpace(c.Query(param))
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param + " timestamp, expected RFC3339"})
+			return
+		}
+		*dst = &t
+	}
+
+	page := parseInt(c.Query("page"), 1)
+	pageSize := min(parseInt(c.Query("page_size"), 50), 200)
+
+	logs, total, err := h.svc.ListAuditLogs(c.Request.Context(), f, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]dto.AuditLogResponse, 0, len(logs))
+	for _, l := range logs {
+		resp = append(resp, dto.ToAuditLogResponse(l))
+	}
+	c.JSON(http.StatusOK, dto.ListAuditLogResponse{AuditLogs: resp, Total: total})
+}
@@ -1,9 +1,16 @@
 package handler
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
 	"github.com/lordmitrii/golang-web-gin/internal/interface/http/dto"
 	"github.com/lordmitrii/golang-web-gin/internal/interface/http/middleware"
@@ -14,11 +21,11 @@ type AIHandler struct {
 	svc usecase.AIService
 }
 
-func NewAIHandler(r *gin.RouterGroup, svc usecase.AIService, rateLimiter usecase.RateLimiter, rbacService usecase.RBACService) {
+func NewAIHandler(r *gin.RouterGroup, svc usecase.AIService, rateLimiter usecase.RateLimiter, rbacService usecase.RBACService, tokens usecase.PersonalTokenService) {
 	h := &AIHandler{svc: svc}
 
 	ai := r.Group("/ai")
-	ai.Use(middleware.JWTMiddleware())
+	ai.Use(middleware.AuthMiddleware(tokens, rateLimiter))
 	ai.Use(middleware.RateLimitMiddleware(rateLimiter, 3, "ai")) // 3 requests per minute
 	ai.Use(middleware.RequirePerm(rbacService, rbac.PermAiQuestions))
 
@@ -26,13 +33,58 @@ func NewAIHandler(r *gin.RouterGroup, svc usecase.AIService, rateLimiter usecase
 		ai.POST("/ask-general", h.AskGeneralQuestion)
 		ai.POST("/ask-stats", h.AskStatsQuestion)
 		ai.POST("/ask-workouts", h.AskWorkoutsQuestion)
+		ai.POST("/coach", h.AskCoachQuestion)
 		ai.POST("/generate-workout-plan", h.GenerateWorkoutPlan)
 	}
+
+	stream := ai.Group("")
+	stream.Use(middleware.StreamLimitMiddleware(1, "ai")) // 1 open stream per caller
+	{
+		stream.POST("/ask-general/stream", h.StreamGeneralQuestion)
+		stream.POST("/ask-stats/stream", h.StreamStatsQuestion)
+		stream.POST("/ask-workouts/stream", h.StreamWorkoutsQuestion)
+		stream.POST("/coach/stream", h.StreamCoachQuestion)
+	}
+
+	// Managing stored conversations is not AI rate limited, so users can always review and
+	// delete their history. Wiping it needs a session, not a personal token.
+	conv := r.Group("/ai/conversations")
+	conv.Use(middleware.AuthMiddleware(tokens, rateLimiter))
+	conv.Use(middleware.RequirePerm(rbacService, rbac.PermAiQuestions))
+	{
+		conv.GET("", h.ListConversations)
+		conv.DELETE("", middleware.RejectPersonalToken(), h.DeleteConversations)
+		conv.GET("/export", h.ExportConversations)
+		conv.GET("/:id", h.GetConversation)
+		conv.PATCH("/:id", h.RenameConversation)
+		conv.DELETE("/:id", middleware.RejectPersonalToken(), h.DeleteConversation)
+	}
+
+	usage := r.Group("/ai/usage")
+	usage.Use(middleware.AuthMiddleware(tokens, rateLimiter))
+	usage.Use(middleware.RequirePerm(rbacService, rbac.PermAiQuestions))
+	{
+		usage.GET("", h.GetUsage)
+	}
+
+	admin := r.Group("/admin/ai")
+	admin.Use(middleware.JWTMiddleware())
+	admin.Use(middleware.RequirePerm(rbacService, rbac.PermAdmin))
+	{
+		admin.GET("/usage", h.GetUsageReport)
+		admin.GET("/quotas", h.GetQuotas)
+		admin.PUT("/quotas/:role", h.SetQuota)
+		admin.DELETE("/quotas/:role", h.DeleteQuota)
+		admin.GET("/prompts", h.GetPrompts)
+		admin.POST("/prompts/:name/versions", h.CreatePromptVersion)
+		admin.PUT("/prompts/:name/active", h.ActivatePromptVersion)
+		admin.GET("/blocked", h.ListBlockedRequests)
+	}
 }
 
 // AskStatsQuestion godoc
 // @Summary      Ask a stats-related question
-// @Description  Lets the user ask AI about stats-related information.
+// @Description  Lets the user ask AI about stats-related information. Emails, phone numbers and the user's own name are removed from the question before it is sent. Off-topic and unsafe questions are not sent on: they are answered with a refusal in the requested language and the previous response_id. Questions about pain or injuries are answered under a not-medical-advice policy.
 // @Tags         ai
 // @Security     BearerAuth
 // @Accept       json
@@ -42,7 +94,8 @@ func NewAIHandler(r *gin.RouterGroup, svc usecase.AIService, rateLimiter usecase
 // @Failure      400   {object}  dto.MessageResponse
 // @Failure      401   {object}  dto.MessageResponse
 // @Failure      403   {object}  dto.MessageResponse
-// @Failure      429   {object}  dto.MessageResponse  "Rate limited"
+// @Failure      404   {object}  dto.MessageResponse  "Conversation not found"
+// @Failure      429   {object}  dto.MessageResponse  "Rate limited or token quota exhausted"
 // @Failure      500   {object}  dto.MessageResponse
 // @Router       /ai/ask-stats [post]
 func (h *AIHandler) AskStatsQuestion(c *gin.Context) {
@@ -60,7 +113,7 @@ func (h *AIHandler) AskStatsQuestion(c *gin.Context) {
 
 	resp, prevRespID, err := h.svc.AskStatsQuestion(c.Request.Context(), userID, req.Question, req.Language, req.PreviousResponseID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		aiError(c, err)
 		return
 	}
 
@@ -72,7 +125,7 @@ func (h *AIHandler) AskStatsQuestion(c *gin.Context) {
 
 // AskWorkoutsQuestion godoc
 // @Summary      Ask a workouts-related question
-// @Description  Lets the user ask AI about workouts and training.
+// @Description  Lets the user ask AI about workouts and training. Questions pass the same guardrails as /ai/ask-stats.
 // @Tags         ai
 // @Security     BearerAuth
 // @Accept       json
@@ -82,7 +135,8 @@ func (h *AIHandler) AskStatsQuestion(c *gin.Context) {
 // @Failure      400   {object}  dto.MessageResponse
 // @Failure      401   {object}  dto.MessageResponse
 // @Failure      403   {object}  dto.MessageResponse
-// @Failure      429   {object}  dto.MessageResponse  "Rate limited"
+// @Failure      404   {object}  dto.MessageResponse  "Conversation not found"
+// @Failure      429   {object}  dto.MessageResponse  "Rate limited or token quota exhausted"
 // @Failure      500   {object}  dto.MessageResponse
 // @Router       /ai/ask-workouts [post]
 func (h *AIHandler) AskWorkoutsQuestion(c *gin.Context) {
@@ -100,7 +154,7 @@ func (h *AIHandler) AskWorkoutsQuestion(c *gin.Context) {
 
 	resp, prevRespID, err := h.svc.AskWorkoutsQuestion(c.Request.Context(), userID, req.Question, req.Language, req.PreviousResponseID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		aiError(c, err)
 		return
 	}
 
@@ -112,7 +166,7 @@ func (h *AIHandler) AskWorkoutsQuestion(c *gin.Context) {
 
 // AskGeneralQuestion godoc
 // @Summary      Ask a general AI question
-// @Description  Lets the user ask AI any general-purpose question.
+// @Description  Lets the user ask AI a general fitness question. Questions pass the same guardrails as /ai/ask-stats.
 // @Tags         ai
 // @Security     BearerAuth
 // @Accept       json
@@ -122,7 +176,8 @@ func (h *AIHandler) AskWorkoutsQuestion(c *gin.Context) {
 // @Failure      400   {object}  dto.MessageResponse
 // @Failure      401   {object}  dto.MessageResponse
 // @Failure      403   {object}  dto.MessageResponse
-// @Failure      429   {object}  dto.MessageResponse  "Rate limited"
+// @Failure      404   {object}  dto.MessageResponse  "Conversation not found"
+// @Failure      429   {object}  dto.MessageResponse  "Rate limited or token quota exhausted"
 // @Failure      500   {object}  dto.MessageResponse
 // @Router       /ai/ask-general [post]
 func (h *AIHandler) AskGeneralQuestion(c *gin.Context) {
@@ -140,7 +195,47 @@ func (h *AIHandler) AskGeneralQuestion(c *gin.Context) {
 
 	resp, prevRespID, err := h.svc.AskGeneralQuestion(c.Request.Context(), userID, req.Question, req.Language, req.PreviousResponseID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		aiError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.AIQuestionResponse{
+		Answer:     resp,
+		ResponseID: prevRespID,
+	})
+}
+
+// AskCoachQuestion godoc
+// @Summary      Ask the AI coach
+// @Description  Answers questions about the user's own training. The model looks up what it needs through read-only tools over the caller's data (profile, best sets, per-exercise history, plan weeks), on every turn of the conversation, so follow-ups see current data. Tool rounds are capped per question. Questions pass the same guardrails as /ai/ask-stats.
+// @Tags         ai
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.AIQuestionRequest  true  "Question payload"
+// @Success      200   {object}  dto.AIQuestionResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse  "Conversation not found"
+// @Failure      429   {object}  dto.MessageResponse  "Rate limited or token quota exhausted"
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /ai/coach [post]
+func (h *AIHandler) AskCoachQuestion(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	var req dto.AIQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, prevRespID, err := h.svc.AskCoachQuestion(c.Request.Context(), userID, req.Question, req.Language, req.PreviousResponseID)
+	if err != nil {
+		aiError(c, err)
 		return
 	}
 
@@ -152,18 +247,20 @@ func (h *AIHandler) AskGeneralQuestion(c *gin.Context) {
 
 // GenerateWorkoutPlan godoc
 // @Summary      Generate a workout plan
-// @Description  Generates a personalized workout plan based on user input.
+// @Description  Generates a personalized workout plan based on user input. Unknown exercises, set counts and a day count other than the requested days are corrected, by the model if need be, and the plan is saved as a draft with its first cycle and workouts. Keep it with /workout-plans/{id}/accept or drop it with /workout-plans/{id}/discard. The prompt passes the same guardrails as /ai/ask-stats; a blocked one gets a 422 with the refusal.
 // @Tags         ai
 // @Security     BearerAuth
 // @Accept       json
 // @Produce      json
 // @Param        body  body      dto.AIWorkoutPlanRequest  true  "Workout plan request payload"
-// @Success      200   {object}  dto.AIWorkoutPlanResponse
+// @Success      201   {object}  dto.AIWorkoutPlanResponse
 // @Failure      400   {object}  dto.MessageResponse
 // @Failure      401   {object}  dto.MessageResponse
 // @Failure      403   {object}  dto.MessageResponse
-// @Failure      429   {object}  dto.MessageResponse  "Rate limited"
+// @Failure      422   {object}  dto.MessageResponse  "Off-topic or unsafe prompt"
+// @Failure      429   {object}  dto.MessageResponse  "Rate limited or token quota exhausted"
 // @Failure      500   {object}  dto.MessageResponse
+// @Failure      502   {object}  dto.MessageResponse  "The model could not produce a valid plan"
 // @Router       /ai/generate-workout-plan [post]
 func (h *AIHandler) GenerateWorkoutPlan(c *gin.Context) {
 	userID, exists := currentUserID(c)
@@ -180,9 +277,648 @@ func (h *AIHandler) GenerateWorkoutPlan(c *gin.Context) {
 
 	plan, err := h.svc.GenerateWorkoutPlan(c.Request.Context(), userID, req.Prompt, req.Days, req.Language)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		aiError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToAiWorkoutPlanResponse(plan))
+}
+
+// StreamStatsQuestion godoc
+// @Summary      Stream a stats-related answer
+// @Description  Same as /ai/ask-stats, but the answer is sent as Server-Sent Events while it is generated: "delta" events carry dto.AIStreamDelta, then a final "done" event carries dto.AIQuestionResponse with the response_id to continue with, or an "error" event. Each stream counts once against the AI rate limit, and only one stream may be open at a time.
+// @Tags         ai
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      text/event-stream
+// @Param        body  body      dto.AIQuestionRequest  true  "Question payload"
+// @Success      200   {object}  dto.AIStreamDelta
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse  "Conversation not found"
+// @Failure      429   {object}  dto.MessageResponse  "Rate limited, token quota exhausted or a stream is already open"
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /ai/ask-stats/stream [post]
+func (h *AIHandler) StreamStatsQuestion(c *gin.Context) {
+	h.streamAnswer(c, h.svc.StreamStatsQuestion)
+}
+
+// StreamWorkoutsQuestion godoc
+// @Summary      Stream a workouts-related answer
+// @Description  Same as /ai/ask-workouts, streamed as Server-Sent Events. See /ai/ask-stats/stream for the events.
+// @Tags         ai
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      text/event-stream
+// @Param        body  body      dto.AIQuestionRequest  true  "Question payload"
+// @Success      200   {object}  dto.AIStreamDelta
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse  "Conversation not found"
+// @Failure      429   {object}  dto.MessageResponse  "Rate limited, token quota exhausted or a stream is already open"
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /ai/ask-workouts/stream [post]
+func (h *AIHandler) StreamWorkoutsQuestion(c *gin.Context) {
+	h.streamAnswer(c, h.svc.StreamWorkoutsQuestion)
+}
+
+// StreamGeneralQuestion godoc
+// @Summary      Stream a general AI answer
+// @Description  Same as /ai/ask-general, streamed as Server-Sent Events. See /ai/ask-stats/stream for the events.
+// @Tags         ai
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      text/event-stream
+// @Param        body  body      dto.AIQuestionRequest  true  "Question payload"
+// @Success      200   {object}  dto.AIStreamDelta
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse  "Conversation not found"
+// @Failure      429   {object}  dto.MessageResponse  "Rate limited, token quota exhausted or a stream is already open"
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /ai/ask-general/stream [post]
+func (h *AIHandler) StreamGeneralQuestion(c *gin.Context) {
+	h.streamAnswer(c, h.svc.StreamGeneralQuestion)
+}
+
+// StreamCoachQuestion godoc
+// @Summary      Stream the AI coach's answer
+// @Description  Same as /ai/coach, streamed as Server-Sent Events. See /ai/ask-stats/stream for the events. Text the model writes between tool calls is streamed as well.
+// @Tags         ai
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      text/event-stream
+// @Param        body  body      dto.AIQuestionRequest  true  "Question payload"
+// @Success      200   {object}  dto.AIStreamDelta
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse  "Conversation not found"
+// @Failure      429   {object}  dto.MessageResponse  "Rate limited, token quota exhausted or a stream is already open"
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /ai/coach/stream [post]
+func (h *AIHandler) StreamCoachQuestion(c *gin.Context) {
+	h.streamAnswer(c, h.svc.StreamCoachQuestion)
+}
+
+type streamAskFunc func(ctx context.Context, userID uint, question, lang, previousResponseID string, onDelta func(delta string) error) (string, string, error)
+
+// streamAnswer relays the answer as SSE. The event stream only starts with the first
+// delta, so failures before that still get a plain JSON error with a proper status. A
+// client that disconnects cancels the request context, which stops generation.
+func (h *AIHandler) streamAnswer(c *gin.Context, ask streamAskFunc) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	var req dto.AIQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	started := false
+	start := func() {
+		if started {
+			return
+		}
+		started = true
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+	}
+
+	answer, responseID, err := ask(ctx, userID, req.Question, req.Language, req.PreviousResponseID, func(delta string) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		start()
+		c.SSEvent("delta", dto.AIStreamDelta{Text: delta})
+		c.Writer.Flush()
+		return nil
+	})
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		if !started {
+			aiError(c, err)
+			return
+		}
+		c.SSEvent("error", gin.H{"error": err.Error()})
+		c.Writer.Flush()
+		return
+	}
+
+	start()
+	c.SSEvent("done", dto.AIQuestionResponse{
+		Answer:     answer,
+		ResponseID: responseID,
+	})
+	c.Writer.Flush()
+}
+
+// ListConversations godoc
+// @Summary      List my AI conversations
+// @Description  Most recently active first.
+// @Tags         ai
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page       query     int  false  "Page"       default(1)
+// @Param        page_size  query     int  false  "Page size"  default(20)
+// @Success      200        {object}  dto.ListAIConversationResponse
+// @Failure      401        {object}  dto.MessageResponse
+// @Failure      403        {object}  dto.MessageResponse
+// @Failure      500        {object}  dto.MessageResponse
+// @Router       /ai/conversations [get]
+func (h *AIHandler) ListConversations(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	page := parseInt(c.Query("page"), 1)
+	pageSize := min(parseInt(c.Query("page_size"), 20), 200)
+
+	convs, total, err := h.svc.ListConversations(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		aiError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ToAiWorkoutPlanResponse(plan))
+	resp := dto.ListAIConversationResponse{Items: make([]dto.AIConversationResponse, 0, len(convs)), Total: total}
+	for _, conv := range convs {
+		resp.Items = append(resp.Items, dto.ToAIConversationResponse(conv))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetConversation godoc
+// @Summary      Get an AI conversation
+// @Description  Returns the conversation with its questions and answers, to resume it.
+// @Tags         ai
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      uint  true  "Conversation ID"  example(7)
+// @Success      200  {object}  dto.AIConversationResponse
+// @Failure      400  {object}  dto.MessageResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      403  {object}  dto.MessageResponse
+// @Failure      404  {object}  dto.MessageResponse
+// @Router       /ai/conversations/{id} [get]
+func (h *AIHandler) GetConversation(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Conversation ID is required"})
+		return
+	}
+
+	conv, err := h.svc.GetConversation(c.Request.Context(), userID, id)
+	if err != nil {
+		aiError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToAIConversationResponse(conv))
+}
+
+// RenameConversation godoc
+// @Summary      Rename an AI conversation
+// @Tags         ai
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id    path      uint                             true  "Conversation ID"  example(7)
+// @Param        body  body      dto.AIConversationRenameRequest  true  "New title"
+// @Success      200   {object}  dto.AIConversationResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Router       /ai/conversations/{id} [patch]
+func (h *AIHandler) RenameConversation(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Conversation ID is required"})
+		return
+	}
+
+	var req dto.AIConversationRenameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	conv, err := h.svc.RenameConversation(c.Request.Context(), userID, id, req.Title)
+	if err != nil {
+		aiError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToAIConversationResponse(conv))
+}
+
+// DeleteConversation godoc
+// @Summary      Delete an AI conversation
+// @Tags         ai
+// @Security     BearerAuth
+// @Param        id  path      uint  true  "Conversation ID"  example(7)
+// @Success      204 {string}  string "No Content"
+// @Failure      400 {object}  dto.MessageResponse
+// @Failure      401 {object}  dto.MessageResponse
+// @Failure      403 {object}  dto.MessageResponse
+// @Failure      404 {object}  dto.MessageResponse
+// @Router       /ai/conversations/{id} [delete]
+func (h *AIHandler) DeleteConversation(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Conversation ID is required"})
+		return
+	}
+
+	if err := h.svc.DeleteConversation(c.Request.Context(), userID, id); err != nil {
+		aiError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteConversations godoc
+// @Summary      Delete all my AI conversations
+// @Tags         ai
+// @Security     BearerAuth
+// @Success      204 {string}  string "No Content"
+// @Failure      401 {object}  dto.MessageResponse
+// @Failure      403 {object}  dto.MessageResponse
+// @Failure      500 {object}  dto.MessageResponse
+// @Router       /ai/conversations [delete]
+func (h *AIHandler) DeleteConversations(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	if err := h.svc.DeleteConversations(c.Request.Context(), userID); err != nil {
+		aiError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ExportConversations godoc
+// @Summary      Export my AI conversations
+// @Description  Downloads every conversation with its messages, as JSON or as Markdown.
+// @Tags         ai
+// @Security     BearerAuth
+// @Produce      json
+// @Produce      text/markdown
+// @Param        format  query     string  false  "Export format"  Enums(json, markdown)  default(json)
+// @Success      200     {array}   dto.AIConversationResponse
+// @Failure      400     {object}  dto.MessageResponse
+// @Failure      401     {object}  dto.MessageResponse
+// @Failure      403     {object}  dto.MessageResponse
+// @Failure      500     {object}  dto.MessageResponse
+// @Router       /ai/conversations/export [get]
+func (h *AIHandler) ExportConversations(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "markdown" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or markdown"})
+		return
+	}
+
+	convs, err := h.svc.ExportConversations(c.Request.Context(), userID)
+	if err != nil {
+		aiError(c, err)
+		return
+	}
+
+	resp := make([]dto.AIConversationResponse, 0, len(convs))
+	for _, conv := range convs {
+		resp = append(resp, dto.ToAIConversationResponse(conv))
+	}
+
+	if format == "markdown" {
+		c.Header("Content-Disposition", `attachment; filename="ai-conversations.md"`)
+		c.Data(http.StatusOK, "text/markdown; charset=utf-8", conversationsMarkdown(resp))
+		return
+	}
+	c.Header("Content-Disposition", `attachment; filename="ai-conversations.json"`)
+	c.JSON(http.StatusOK, resp)
+}
+
+func conversationsMarkdown(convs []dto.AIConversationResponse) []byte {
+	var b strings.Builder
+	for i, conv := range convs {
+		if i > 0 {
+			b.WriteString("\n---\n\n")
+		}
+		fmt.Fprintf(&b, "# %s\n\n_%s, started %s_\n\n", conv.Title, conv.Kind, conv.CreatedAt.UTC().Format(time.RFC3339))
+		for _, m := range conv.Messages {
+			speaker := "Assistant"
+			if m.Role == string(ai.RoleUser) {
+				speaker = "You"
+			}
+			fmt.Fprintf(&b, "**%s** (%s):\n\n%s\n\n", speaker, m.CreatedAt.UTC().Format(time.RFC3339), m.Content)
+		}
+	}
+	return []byte(b.String())
+}
+
+// GetUsage godoc
+// @Summary      Get my AI token usage
+// @Description  Tokens used today and this month against the quotas of the caller's roles, with this month's usage per endpoint. A limit of 0 means unlimited; quotas reset at UTC midnight.
+// @Tags         ai
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  dto.AIUsageResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      403  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /ai/usage [get]
+func (h *AIHandler) GetUsage(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	report, err := h.svc.GetUsage(c.Request.Context(), userID)
+	if err != nil {
+		aiError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToAIUsageResponse(report))
+}
+
+// GetUsageReport godoc
+// @Summary      AI token usage report (admin)
+// @Description  Usage of all users per endpoint and model, and the heaviest users. Defaults to the current UTC month.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from  query     string  false  "Start, inclusive (RFC3339)"  example(2025-01-01T00:00:00Z)
+// @Param        to    query     string  false  "End, exclusive (RFC3339)"    example(2025-02-01T00:00:00Z)
+// @Success      200   {object}  dto.AIUsageReportResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /admin/ai/usage [get]
+func (h *AIHandler) GetUsageReport(c *gin.Context) {
+	from := ai.MonthStart(time.Now())
+	to := from.AddDate(0, 1, 0)
+	for param, dst := range map[string]*time.Time{"from": &from, "to": &to} {
+		raw := strings.TrimSpace(c.Query(param))
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param + " timestamp, expected RFC3339"})
+			return
+		}
+		*dst = t
+	}
+	if !to.After(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be after from"})
+		return
+	}
+
+	summary, top, err := h.svc.GetUsageReport(c.Request.Context(), from, to)
+	if err != nil {
+		aiError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToAIUsageReportResponse(from, to, summary, top))
+}
+
+// GetQuotas godoc
+// @Summary      List AI token quotas (admin)
+// @Description  Per-role quotas, and the defaults applied to roles without one. A user holding several roles gets the most generous quota; 0 means unlimited.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  dto.AIQuotasResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      403  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /admin/ai/quotas [get]
+func (h *AIHandler) GetQuotas(c *gin.Context) {
+	quotas, defaults, err := h.svc.ListQuotas(c.Request.Context())
+	if err != nil {
+		aiError(c, err)
+		return
+	}
+
+	resp := dto.AIQuotasResponse{
+		Default: dto.AIQuotaRequest{DailyTokens: defaults.DailyTokens, MonthlyTokens: defaults.MonthlyTokens},
+		Roles:   make([]dto.AIQuotaResponse, 0, len(quotas)),
+	}
+	for _, q := range quotas {
+		resp.Roles = append(resp.Roles, dto.ToAIQuotaResponse(q))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// SetQuota godoc
+// @Summary      Set a role's AI token quota (admin)
+// @Tags         admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        role  path      string              true  "Role name"
+// @Param        body  body      dto.AIQuotaRequest  true  "Token caps, 0 for unlimited"
+// @Success      200   {object}  dto.AIQuotaResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /admin/ai/quotas/{role} [put]
+func (h *AIHandler) SetQuota(c *gin.Context) {
+	var req dto.AIQuotaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	q, err := h.svc.SetQuota(c.Request.Context(), c.Param("role"), ai.Limits{DailyTokens: req.DailyTokens, MonthlyTokens: req.MonthlyTokens})
+	if err != nil {
+		aiError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToAIQuotaResponse(q))
+}
+
+// DeleteQuota godoc
+// @Summary      Reset a role's AI token quota to the default (admin)
+// @Tags         admin
+// @Security     BearerAuth
+// @Param        role  path      string  true  "Role name"
+// @Success      204   {string}  string  "No Content"
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /admin/ai/quotas/{role} [delete]
+func (h *AIHandler) DeleteQuota(c *gin.Context) {
+	if err := h.svc.DeleteQuota(c.Request.Context(), c.Param("role")); err != nil {
+		aiError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetPrompts godoc
+// @Summary      List AI prompt templates (admin)
+// @Description  Every prompt with its built-in default, version 0, and its stored versions per locale. A request uses the active version of the most specific matching locale, then "en", then the default.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   dto.AIPromptResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      403  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /admin/ai/prompts [get]
+func (h *AIHandler) GetPrompts(c *gin.Context) {
+	prompts, err := h.svc.ListPrompts(c.Request.Context())
+	if err != nil {
+		aiError(c, err)
+		return
+	}
+
+	resp := make([]dto.AIPromptResponse, 0, len(prompts))
+	for _, p := range prompts {
+		resp = append(resp, dto.ToAIPromptResponse(p))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreatePromptVersion godoc
+// @Summary      Add a version of an AI prompt (admin)
+// @Description  Stores the body as the next version of the prompt in the locale, optionally activating it. The body must render with the fields the default uses.
+// @Tags         admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        name  path      string                      true  "Prompt name"
+// @Param        body  body      dto.AIPromptVersionRequest  true  "Template"
+// @Success      201   {object}  dto.AIPromptVersionResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /admin/ai/prompts/{name}/versions [post]
+func (h *AIHandler) CreatePromptVersion(c *gin.Context) {
+	var req dto.AIPromptVersionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	t, err := h.svc.CreatePromptVersion(c.Request.Context(), c.Param("name"), req.Locale, req.Body, req.Activate)
+	if err != nil {
+		aiError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.ToAIPromptVersionResponse(t))
+}
+
+// ActivatePromptVersion godoc
+// @Summary      Activate a version of an AI prompt (admin)
+// @Description  Makes the version the one used for the locale; version 0 goes back to the built-in default.
+// @Tags         admin
+// @Security     BearerAuth
+// @Accept       json
+// @Param        name  path      string                       true  "Prompt name"
+// @Param        body  body      dto.AIPromptActivateRequest  true  "Locale and version"
+// @Success      204   {string}  string  "No Content"
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /admin/ai/prompts/{name}/active [put]
+func (h *AIHandler) ActivatePromptVersion(c *gin.Context) {
+	var req dto.AIPromptActivateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.ActivatePromptVersion(c.Request.Context(), c.Param("name"), req.Locale, req.Version); err != nil {
+		aiError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListBlockedRequests godoc
+// @Summary      List AI requests blocked by the guardrails (admin)
+// @Description  Questions and plan prompts refused as off-topic or unsafe, newest first, with PII already removed.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Param        category   query     string  false  "off_topic or unsafe"
+// @Param        page       query     int     false  "Page"       default(1)
+// @Param        page_size  query     int     false  "Page size"  default(20)
+// @Success      200        {object}  dto.ListAIBlockedRequestResponse
+// @Failure      401        {object}  dto.MessageResponse
+// @Failure      403        {object}  dto.MessageResponse
+// @Failure      500        {object}  dto.MessageResponse
+// @Router       /admin/ai/blocked [get]
+func (h *AIHandler) ListBlockedRequests(c *gin.Context) {
+	page := parseInt(c.Query("page"), 1)
+	pageSize := min(parseInt(c.Query("page_size"), 20), 200)
+
+	rows, total, err := h.svc.ListBlockedRequests(c.Request.Context(), c.Query("category"), page, pageSize)
+	if err != nil {
+		aiError(c, err)
+		return
+	}
+
+	resp := dto.ListAIBlockedRequestResponse{Items: make([]dto.AIBlockedRequestResponse, 0, len(rows)), Total: total}
+	for _, b := range rows {
+		resp.Items = append(resp.Items, dto.ToAIBlockedRequestResponse(b))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func aiError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, custom_err.ErrConversationNotFound), errors.Is(err, custom_err.ErrNotFound), errors.Is(err, custom_err.ErrUnknownPrompt):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, custom_err.ErrNoConsent):
+		c.JSON(http.StatusForbidden, gin.H{"error": "AI chat privacy consent required"})
+	case errors.Is(err, custom_err.ErrQuotaExceeded):
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "AI token quota exhausted, see /ai/usage for when it resets"})
+	case errors.Is(err, custom_err.ErrInvalidTitle), errors.Is(err, custom_err.ErrInvalidQuota), errors.Is(err, custom_err.ErrInvalidPromptTemplate):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, custom_err.ErrRequestBlocked):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, custom_err.ErrInvalidGeneratedPlan):
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
 }
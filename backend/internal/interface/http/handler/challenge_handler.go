@@ -0,0 +1,353 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/challenge"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/dto"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/middleware"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type ChallengeHandler struct {
+	svc usecase.ChallengeService
+}
+
+func NewChallengeHandler(r *gin.RouterGroup, svc usecase.ChallengeService, rbacService usecase.RBACService) {
+	h := &ChallengeHandler{svc: svc}
+
+	ch := r.Group("/challenges")
+	ch.Use(middleware.JWTMiddleware())
+	{
+		ch.GET("", h.GetChallenges)
+		ch.GET("/mine", h.GetMyChallenges)
+		ch.GET("/:id", h.GetChallenge)
+		ch.GET("/:id/leaderboard", h.GetLeaderboard)
+		ch.POST("/:id/join", h.JoinChallenge)
+		ch.DELETE("/:id/join", h.LeaveChallenge)
+
+		adminonly := ch.Group("")
+		adminonly.Use(middleware.RequirePerm(rbacService, rbac.PermAdmin))
+		{
+			adminonly.POST("", h.CreateChallenge)
+			adminonly.PUT("/:id", h.UpdateChallenge)
+			adminonly.DELETE("/:id", h.DeleteChallenge)
+		}
+	}
+}
+
+// GetChallenges godoc
+// @Summary      List challenges
+// @Tags         challenges
+// @Security     BearerAuth
+// @Produce      json
+// @Param        status  query     string  false  "Filter by status"  Enums(upcoming, active, past)
+// @Success      200     {array}   dto.ChallengeResponse
+// @Failure      400     {object}  dto.MessageResponse
+// @Failure      401     {object}  dto.MessageResponse
+// @Failure      500     {object}  dto.MessageResponse
+// @Router       /challenges [get]
+func (h *ChallengeHandler) GetChallenges(c *gin.Context) {
+	status := c.Query("status")
+	switch status {
+	case "", challenge.StatusUpcoming, challenge.StatusActive, challenge.StatusPast:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		return
+	}
+
+	challenges, err := h.svc.ListChallenges(c.Request.Context(), status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]dto.ChallengeResponse, 0, len(challenges))
+	for _, ch := range challenges {
+		resp = append(resp, dto.ToChallengeResponse(ch))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetMyChallenges godoc
+// @Summary      List challenges I joined
+// @Tags         challenges
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   dto.ChallengeParticipationResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /challenges/mine [get]
+func (h *ChallengeHandler) GetMyChallenges(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	participations, err := h.svc.ListMyChallenges(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]dto.ChallengeParticipationResponse, 0, len(participations))
+	for _, p := range participations {
+		resp = append(resp, dto.ToChallengeParticipationResponse(&p.Challenge, p))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetChallenge godoc
+// @Summary      Get a challenge
+// @Tags         challenges
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      uint  true  "Challenge ID"
+// @Success      200  {object}  dto.ChallengeResponse
+// @Failure      400  {object}  dto.MessageResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      404  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /challenges/{id} [get]
+func (h *ChallengeHandler) GetChallenge(c *gin.Context) {
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Challenge ID is required"})
+		return
+	}
+
+	ch, err := h.svc.GetChallenge(c.Request.Context(), id)
+	if err != nil {
+		challengeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToChallengeResponse(ch))
+}
+
+// GetLeaderboard godoc
+// @Summary      Get a challenge leaderboard
+// @Tags         challenges
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id      path      uint  true   "Challenge ID"
+// @Param        offset  query     int   false  "Offset"  default(0)
+// @Param        limit   query     int   false  "Limit"   default(50)
+// @Success      200     {object}  dto.LeaderboardResponse
+// @Failure      400     {object}  dto.MessageResponse
+// @Failure      401     {object}  dto.MessageResponse
+// @Failure      404     {object}  dto.MessageResponse
+// @Failure      500     {object}  dto.MessageResponse
+// @Router       /challenges/{id}/leaderboard [get]
+func (h *ChallengeHandler) GetLeaderboard(c *gin.Context) {
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Challenge ID is required"})
+		return
+	}
+	offset := max(parseInt(c.Query("offset"), 0), 0)
+	limit := min(max(parseInt(c.Query("limit"), 50), 1), 200)
+
+	entries, total, err := h.svc.GetLeaderboard(c.Request.Context(), id, int(offset), int(limit))
+	if err != nil {
+		challengeError(c, err)
+		return
+	}
+
+	resp := dto.LeaderboardResponse{Entries: make([]dto.LeaderboardEntryResponse, 0, len(entries)), Total: total}
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, dto.ToLeaderboardEntryResponse(e))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// JoinChallenge godoc
+// @Summary      Join a challenge
+// @Description  Workouts completed after joining and inside the challenge window count towards progress.
+// @Tags         challenges
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      uint  true  "Challenge ID"
+// @Success      200  {object}  dto.ChallengeParticipationResponse
+// @Failure      400  {object}  dto.MessageResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      404  {object}  dto.MessageResponse
+// @Failure      409  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /challenges/{id}/join [post]
+func (h *ChallengeHandler) JoinChallenge(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Challenge ID is required"})
+		return
+	}
+
+	p, err := h.svc.JoinChallenge(c.Request.Context(), userID, id)
+	if err != nil {
+		challengeError(c, err)
+		return
+	}
+	ch, err := h.svc.GetChallenge(c.Request.Context(), id)
+	if err != nil {
+		challengeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToChallengeParticipationResponse(ch, p))
+}
+
+// LeaveChallenge godoc
+// @Summary      Leave a challenge
+// @Description  Removes the caller's progress from the challenge.
+// @Tags         challenges
+// @Security     BearerAuth
+// @Param        id   path      uint  true  "Challenge ID"
+// @Success      204  {string}  string  "No Content"
+// @Failure      400  {object}  dto.MessageResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      404  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /challenges/{id}/join [delete]
+func (h *ChallengeHandler) LeaveChallenge(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Challenge ID is required"})
+		return
+	}
+
+	if err := h.svc.LeaveChallenge(c.Request.Context(), userID, id); err != nil {
+		challengeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CreateChallenge godoc
+// @Summary      Create a challenge (admin)
+// @Tags         challenges
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.ChallengeRequest  true  "Challenge definition"
+// @Success      201   {object}  dto.ChallengeResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /challenges [post]
+func (h *ChallengeHandler) CreateChallenge(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	var req dto.ChallengeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ch := challengeFromRequest(&req)
+	if err := h.svc.CreateChallenge(c.Request.Context(), userID, ch); err != nil {
+		challengeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.ToChallengeResponse(ch))
+}
+
+// UpdateChallenge godoc
+// @Summary      Replace a challenge definition (admin)
+// @Description  The metric and start date cannot change once the challenge has started.
+// @Tags         challenges
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id    path      uint                  true  "Challenge ID"
+// @Param        body  body      dto.ChallengeRequest  true  "Challenge definition"
+// @Success      200   {object}  dto.ChallengeResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /challenges/{id} [put]
+func (h *ChallengeHandler) UpdateChallenge(c *gin.Context) {
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Challenge ID is required"})
+		return
+	}
+
+	var req dto.ChallengeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ch, err := h.svc.UpdateChallenge(c.Request.Context(), id, challengeFromRequest(&req))
+	if err != nil {
+		challengeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToChallengeResponse(ch))
+}
+
+// DeleteChallenge godoc
+// @Summary      Delete a challenge (admin)
+// @Tags         challenges
+// @Security     BearerAuth
+// @Param        id   path      uint  true  "Challenge ID"
+// @Success      204  {string}  string  "No Content"
+// @Failure      400  {object}  dto.MessageResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      403  {object}  dto.MessageResponse
+// @Failure      404  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /challenges/{id} [delete]
+func (h *ChallengeHandler) DeleteChallenge(c *gin.Context) {
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Challenge ID is required"})
+		return
+	}
+
+	if err := h.svc.DeleteChallenge(c.Request.Context(), id); err != nil {
+		challengeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func challengeFromRequest(req *dto.ChallengeRequest) *challenge.Challenge {
+	return &challenge.Challenge{
+		Name:        req.Name,
+		Description: req.Description,
+		Metric:      req.Metric,
+		Target:      req.Target,
+		StartsAt:    req.StartsAt.UTC(),
+		EndsAt:      req.EndsAt.UTC(),
+	}
+}
+
+func challengeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, custom_err.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, custom_err.ErrInvalidChallenge):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, custom_err.ErrChallengeEnded):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
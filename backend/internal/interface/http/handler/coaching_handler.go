@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/coaching"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/dto"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/middleware"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type CoachingHandler struct {
+	svc usecase.CoachingService
+}
+
+func NewCoachingHandler(r *gin.RouterGroup, svc usecase.CoachingService) {
+	h := &CoachingHandler{svc: svc}
+
+	co := r.Group("/coaching")
+	co.Use(middleware.JWTMiddleware())
+	{
+		co.GET("/clients", h.GetClients)
+		co.POST("/clients", h.InviteClient)
+		co.GET("/coaches", h.GetCoaches)
+
+		co.POST("/relationships/:id/accept", h.AcceptInvitation)
+		co.POST("/relationships/:id/decline", h.DeclineInvitation)
+		co.DELETE("/relationships/:id", h.RevokeRelationship)
+	}
+}
+
+// InviteClient godoc
+// @Summary      Invite a client
+// @Description  Sends a coaching invitation. Requires the workout:read:others permission.
+// @Tags         coaching
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.CoachingInviteRequest  true  "Client username or email"
+// @Success      201   {object}  dto.CoachClientResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      409   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /coaching/clients [post]
+func (h *CoachingHandler) InviteClient(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	var req dto.CoachingInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rel, err := h.svc.InviteClient(c.Request.Context(), userID, req.Client, req.Message)
+	if err != nil {
+		coachingError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.ToCoachClientResponse(rel))
+}
+
+// GetClients godoc
+// @Summary      List my clients
+// @Description  Relationships where the caller is the coach, in any status.
+// @Tags         coaching
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   dto.CoachClientResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /coaching/clients [get]
+func (h *CoachingHandler) GetClients(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	rels, err := h.svc.ListClients(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, toCoachClientResponses(rels))
+}
+
+// GetCoaches godoc
+// @Summary      List my coaches and invitations
+// @Description  Relationships where the caller is the client, in any status.
+// @Tags         coaching
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   dto.CoachClientResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /coaching/coaches [get]
+func (h *CoachingHandler) GetCoaches(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	rels, err := h.svc.ListCoaches(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, toCoachClientResponses(rels))
+}
+
+// AcceptInvitation godoc
+// @Summary      Accept a coaching invitation
+// @Tags         coaching
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      uint  true  "Relationship ID"
+// @Success      200  {object}  dto.CoachClientResponse
+// @Failure      400  {object}  dto.MessageResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      404  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /coaching/relationships/{id}/accept [post]
+func (h *CoachingHandler) AcceptInvitation(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Relationship ID is required"})
+		return
+	}
+
+	rel, err := h.svc.AcceptInvitation(c.Request.Context(), userID, id)
+	if err != nil {
+		coachingError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToCoachClientResponse(rel))
+}
+
+// DeclineInvitation godoc
+// @Summary      Decline a coaching invitation
+// @Tags         coaching
+// @Security     BearerAuth
+// @Param        id   path      uint  true  "Relationship ID"
+// @Success      204  {string}  string  "No Content"
+// @Failure      400  {object}  dto.MessageResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      404  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /coaching/relationships/{id}/decline [post]
+func (h *CoachingHandler) DeclineInvitation(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Relationship ID is required"})
+		return
+	}
+
+	if err := h.svc.DeclineInvitation(c.Request.Context(), userID, id); err != nil {
+		coachingError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RevokeRelationship godoc
+// @Summary      End a coaching relationship
+// @Description  Either the coach or the client can revoke a pending or active relationship.
+// @Tags         coaching
+// @Security     BearerAuth
+// @Param        id   path      uint  true  "Relationship ID"
+// @Success      204  {string}  string  "No Content"
+// @Failure      400  {object}  dto.MessageResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      404  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /coaching/relationships/{id} [delete]
+func (h *CoachingHandler) RevokeRelationship(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Relationship ID is required"})
+		return
+	}
+
+	if err := h.svc.RevokeRelationship(c.Request.Context(), userID, id); err != nil {
+		coachingError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func toCoachClientResponses(rels []*coaching.CoachClient) []dto.CoachClientResponse {
+	resp := make([]dto.CoachClientResponse, 0, len(rels))
+	for _, rel := range rels {
+		resp = append(resp, dto.ToCoachClientResponse(rel))
+	}
+	return resp
+}
+
+func coachingError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, custom_err.ErrNotFound), errors.Is(err, custom_err.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, custom_err.ErrNotACoach):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, custom_err.ErrCoachingExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
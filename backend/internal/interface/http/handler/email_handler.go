@@ -1,9 +1,13 @@
 package handler
 
 import (
+	"errors"
+	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/auth"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
 	"github.com/lordmitrii/golang-web-gin/internal/interface/http/dto"
 	"github.com/lordmitrii/golang-web-gin/internal/interface/http/middleware"
@@ -11,11 +15,12 @@ import (
 )
 
 type EmailHandler struct {
-	svc usecase.EmailService
+	svc   usecase.EmailService
+	guard usecase.LoginGuard
 }
 
-func NewEmailHandler(r *gin.RouterGroup, svc usecase.EmailService, rateLimiter usecase.RateLimiter, rbacService usecase.RBACService) {
-	h := &EmailHandler{svc: svc}
+func NewEmailHandler(r *gin.RouterGroup, svc usecase.EmailService, rateLimiter usecase.RateLimiter, rbacService usecase.RBACService, guard usecase.LoginGuard) {
+	h := &EmailHandler{svc: svc, guard: guard}
 
 	email := r.Group("/email")
 	email.Use(middleware.RateLimitMiddleware(rateLimiter, 10, "email")) // 10 requests per minute
@@ -124,6 +129,7 @@ func (h *EmailHandler) SendResetPasswordEmail(c *gin.Context) {
 
 // ValidateToken godoc
 // @Summary      Validate email-related token
+// @Description  Only reset password tokens can be validated; verification codes are checked by /email/verify-account.
 // @Tags         email
 // @Accept       json
 // @Produce      json
@@ -162,8 +168,8 @@ func (h *EmailHandler) ValidateToken(c *gin.Context) {
 // @Param        body  body      dto.ResetPasswordRequest  true  "Password reset payload"
 // @Success      200   {object}  dto.MessageResponse
 // @Failure      400   {object}  dto.MessageResponse
-// @Failure      401   {object}  dto.MessageResponse
-// @Failure      429   {object}  dto.MessageResponse "Rate limited"
+// @Failure      401   {object}  dto.MessageResponse "Invalid/expired token"
+// @Failure      429   {object}  dto.MessageResponse "Rate limited or too many failed attempts"
 // @Failure      500   {object}  dto.MessageResponse
 // @Router       /email/reset-password [post]
 func (h *EmailHandler) ResetPassword(c *gin.Context) {
@@ -173,7 +179,18 @@ func (h *EmailHandler) ResetPassword(c *gin.Context) {
 		return
 	}
 
-	if err := h.svc.ResetPassword(c.Request.Context(), req.Token, req.NewPassword); err != nil {
+	ctx := c.Request.Context()
+	if wait, err := h.guard.Check(ctx, auth.ScopeResetPassword, "", c.ClientIP()); err != nil {
+		abortAttemptGuard(c, wait, err)
+		return
+	}
+
+	if err := h.svc.ResetPassword(ctx, req.Token, req.NewPassword); err != nil {
+		if errors.Is(err, custom_err.ErrInvalidToken) {
+			h.fail(c, auth.ScopeResetPassword, "")
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -188,8 +205,8 @@ func (h *EmailHandler) ResetPassword(c *gin.Context) {
 // @Param        body  body      dto.VerifyAccountRequest  true  "Account verification payload"
 // @Success      200   {object}  dto.MessageResponse
 // @Failure      400   {object}  dto.MessageResponse
-// @Failure      401   {object}  dto.MessageResponse
-// @Failure      429   {object}  dto.MessageResponse "Rate limited"
+// @Failure      401   {object}  dto.MessageResponse "Invalid/expired code"
+// @Failure      429   {object}  dto.MessageResponse "Rate limited or too many failed attempts"
 // @Failure      500   {object}  dto.MessageResponse
 // @Router       /email/verify-account [post]
 func (h *EmailHandler) VerifyAccount(c *gin.Context) {
@@ -199,9 +216,31 @@ func (h *EmailHandler) VerifyAccount(c *gin.Context) {
 		return
 	}
 
-	if err := h.svc.VerifyAccount(c.Request.Context(), req.Token); err != nil {
+	ctx := c.Request.Context()
+	if wait, err := h.guard.Check(ctx, auth.ScopeVerifyAccount, req.Email, c.ClientIP()); err != nil {
+		abortAttemptGuard(c, wait, err)
+		return
+	}
+
+	if err := h.svc.VerifyAccount(ctx, req.Email, req.Token); err != nil {
+		if errors.Is(err, custom_err.ErrInvalidToken) {
+			h.fail(c, auth.ScopeVerifyAccount, req.Email)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	if err := h.guard.Reset(ctx, auth.ScopeVerifyAccount, req.Email); err != nil {
+		log.Printf("verify guard: %v", err)
+	}
 	c.JSON(http.StatusOK, dto.MessageResponse{Message: "Account verified successfully"})
 }
+
+func (h *EmailHandler) fail(c *gin.Context, scope, identity string) {
+	wait, err := h.guard.Fail(c.Request.Context(), scope, identity, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		log.Printf("%s guard: %v", scope, err)
+	}
+	setRetryAfter(c, wait)
+}
@@ -0,0 +1,343 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/equipment"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/dto"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/middleware"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type EquipmentHandler struct {
+	svc usecase.EquipmentService
+}
+
+func NewEquipmentHandler(r *gin.RouterGroup, svc usecase.EquipmentService) {
+	h := &EquipmentHandler{svc: svc}
+	auth := r.Group("")
+	auth.Use(middleware.JWTMiddleware())
+
+	ep := auth.Group("/equipment-profiles")
+	{
+		ep.GET("", h.GetProfiles)
+		ep.POST("", h.CreateProfile)
+		ep.GET("/:id", h.GetProfile)
+		ep.PATCH("/:id", h.UpdateProfile)
+		ep.DELETE("/:id", h.DeleteProfile)
+	}
+
+	auth.GET("/exercises/:id/substitutes", h.GetSubstitutes)
+	auth.POST("/workout-plans/:id/switch-equipment", h.SwitchPlan)
+	auth.POST("/workout-plans/:id/workout-cycles/:cycleID/workouts/:workoutID/switch-equipment", h.SwitchWorkout)
+}
+
+// GetProfiles godoc
+// @Summary      List my equipment profiles
+// @Tags         equipment
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   dto.EquipmentProfileResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /equipment-profiles [get]
+func (h *EquipmentHandler) GetProfiles(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	profiles, err := h.svc.GetProfiles(c.Request.Context(), userID)
+	if err != nil {
+		equipmentError(c, err)
+		return
+	}
+
+	resp := make([]dto.EquipmentProfileResponse, 0, len(profiles))
+	for _, p := range profiles {
+		resp = append(resp, dto.ToEquipmentProfileResponse(p))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateProfile godoc
+// @Summary      Create equipment profile
+// @Description  Equipment defaults to the preset's list when none is given. The first profile always becomes the default.
+// @Tags         equipment
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.EquipmentProfileCreateRequest  true  "Profile payload"
+// @Success      201   {object}  dto.EquipmentProfileResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      409   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /equipment-profiles [post]
+func (h *EquipmentHandler) CreateProfile(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	var req dto.EquipmentProfileCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	items := req.Equipment
+	if len(items) == 0 && req.Preset != "" {
+		items = equipment.Presets[req.Preset]
+	}
+
+	p, err := h.svc.CreateProfile(c.Request.Context(), userID, req.Name, items, req.IsDefault)
+	if err != nil {
+		equipmentError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.ToEquipmentProfileResponse(p))
+}
+
+// GetProfile godoc
+// @Summary      Get equipment profile
+// @Tags         equipment
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      uint  true  "Profile ID"  example(3)
+// @Success      200  {object}  dto.EquipmentProfileResponse
+// @Failure      400  {object}  dto.MessageResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      404  {object}  dto.MessageResponse
+// @Router       /equipment-profiles/{id} [get]
+func (h *EquipmentHandler) GetProfile(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Profile ID is required"})
+		return
+	}
+
+	p, err := h.svc.GetProfile(c.Request.Context(), userID, id)
+	if err != nil {
+		equipmentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToEquipmentProfileResponse(p))
+}
+
+// UpdateProfile godoc
+// @Summary      Update equipment profile
+// @Description  A non-empty equipment list replaces the current one.
+// @Tags         equipment
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id    path      uint                               true  "Profile ID"  example(3)
+// @Param        body  body      dto.EquipmentProfileUpdateRequest  true  "Fields to update"
+// @Success      200   {object}  dto.EquipmentProfileResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      409   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /equipment-profiles/{id} [patch]
+func (h *EquipmentHandler) UpdateProfile(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Profile ID is required"})
+		return
+	}
+
+	var req dto.EquipmentProfileUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	p, err := h.svc.UpdateProfile(c.Request.Context(), userID, id, req.Name, req.Equipment, req.IsDefault)
+	if err != nil {
+		equipmentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToEquipmentProfileResponse(p))
+}
+
+// DeleteProfile godoc
+// @Summary      Delete equipment profile
+// @Tags         equipment
+// @Security     BearerAuth
+// @Param        id  path      uint  true  "Profile ID"  example(3)
+// @Success      204 {string}  string "No Content"
+// @Failure      400 {object}  dto.MessageResponse
+// @Failure      401 {object}  dto.MessageResponse
+// @Failure      404 {object}  dto.MessageResponse
+// @Router       /equipment-profiles/{id} [delete]
+func (h *EquipmentHandler) DeleteProfile(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Profile ID is required"})
+		return
+	}
+
+	if err := h.svc.DeleteProfile(c.Request.Context(), userID, id); err != nil {
+		equipmentError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetSubstitutes godoc
+// @Summary      Suggest substitute exercises
+// @Description  Equivalent catalog exercises (same primary muscle group and movement pattern) that the profile supports. Defaults to the caller's default profile.
+// @Tags         equipment
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id          path      uint  true   "Exercise ID"  example(12)
+// @Param        profile_id  query     uint  false  "Equipment profile ID"
+// @Param        limit       query     int   false  "Max results"  minimum(1) maximum(50) default(10)
+// @Success      200  {array}   dto.ExerciseResponse
+// @Failure      400  {object}  dto.MessageResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      404  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /exercises/{id}/substitutes [get]
+func (h *EquipmentHandler) GetSubstitutes(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Exercise ID is required"})
+		return
+	}
+	var profileID *uint
+	if pid := parseUint(c.Query("profile_id"), 0); pid != 0 {
+		profileID = &pid
+	}
+	limit := min(parseInt(c.Query("limit"), 10), 50)
+
+	exercises, err := h.svc.SuggestSubstitutes(c.Request.Context(), userID, id, profileID, int(limit))
+	if err != nil {
+		equipmentError(c, err)
+		return
+	}
+
+	resp := make([]dto.ExerciseResponse, 0, len(exercises))
+	for _, e := range exercises {
+		resp = append(resp, dto.ToExerciseResponse(e))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// SwitchPlan godoc
+// @Summary      Switch a plan to another equipment profile
+// @Description  Replaces every pending exercise the profile cannot support with its closest substitute, in one transaction. Exercises without a substitute are reported and left as they are.
+// @Tags         equipment
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id    path      uint                        true  "Workout Plan ID"  example(1)
+// @Param        body  body      dto.EquipmentSwitchRequest  true  "Target profile"
+// @Success      200   {object}  dto.EquipmentSwitchResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /workout-plans/{id}/switch-equipment [post]
+func (h *EquipmentHandler) SwitchPlan(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	planID := parseUint(c.Param("id"), 0)
+	if planID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "IDs are required"})
+		return
+	}
+
+	var req dto.EquipmentSwitchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.svc.SwitchPlan(c.Request.Context(), userID, planID, req.ProfileID)
+	if err != nil {
+		equipmentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToEquipmentSwitchResponse(res))
+}
+
+// SwitchWorkout godoc
+// @Summary      Switch a workout to another equipment profile
+// @Description  Same as switching a plan, limited to one workout.
+// @Tags         equipment
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id         path      uint                        true  "Workout Plan ID"  example(1)
+// @Param        cycleID    path      uint                        true  "Cycle ID"         example(12)
+// @Param        workoutID  path      uint                        true  "Workout ID"       example(100)
+// @Param        body       body      dto.EquipmentSwitchRequest  true  "Target profile"
+// @Success      200        {object}  dto.EquipmentSwitchResponse
+// @Failure      400        {object}  dto.MessageResponse
+// @Failure      401        {object}  dto.MessageResponse
+// @Failure      404        {object}  dto.MessageResponse
+// @Failure      500        {object}  dto.MessageResponse
+// @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/switch-equipment [post]
+func (h *EquipmentHandler) SwitchWorkout(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	planID := parseUint(c.Param("id"), 0)
+	cycleID := parseUint(c.Param("cycleID"), 0)
+	workoutID := parseUint(c.Param("workoutID"), 0)
+	if planID == 0 || cycleID == 0 || workoutID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "IDs are required"})
+		return
+	}
+
+	var req dto.EquipmentSwitchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.svc.SwitchWorkout(c.Request.Context(), userID, planID, cycleID, workoutID, req.ProfileID)
+	if err != nil {
+		equipmentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToEquipmentSwitchResponse(res))
+}
+
+func equipmentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, custom_err.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, custom_err.ErrUnknownEquipment):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, custom_err.ErrEquipmentProfileExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
 	"github.com/lordmitrii/golang-web-gin/internal/interface/http/dto"
@@ -23,6 +26,7 @@ func NewExerciseHandler(r *gin.RouterGroup, svc usecase.ExerciseService, rbacSer
 	ex := auth.Group("/exercises")
 	{
 		ex.GET("/", h.GetAllExercises)
+		ex.GET("/search", h.SearchExercises)
 		ex.GET("/:id", h.GetExerciseByID)
 		adminonly := ex.Group("")
 		adminonly.Use(middleware.RequirePerm(rbacService, rbac.PermAdmin))
@@ -30,6 +34,9 @@ func NewExerciseHandler(r *gin.RouterGroup, svc usecase.ExerciseService, rbacSer
 			adminonly.POST("/", h.CreateExercise)
 			adminonly.PATCH("/:id", h.UpdateExercise)
 			adminonly.DELETE("/:id", h.DeleteExercise)
+			adminonly.PUT("/:id/secondary-muscle-groups", h.SetExerciseSecondaryMuscleGroups)
+			adminonly.PUT("/:id/media", h.SetExerciseMedia)
+			adminonly.PUT("/:id/instructions", h.SetExerciseInstructions)
 		}
 	}
 
@@ -73,14 +80,33 @@ func (h *ExerciseHandler) CreateExercise(c *gin.Context) {
 		IsBodyweight:  req.IsBodyweight,
 		IsTimeBased:   req.IsTimeBased,
 		MuscleGroupID: req.MuscleGroupID,
+
+		Equipment:       req.Equipment,
+		Difficulty:      req.Difficulty,
+		MovementPattern: req.MovementPattern,
+	}
+	for _, id := range req.SecondaryMuscleGroupIDs {
+		if req.MuscleGroupID != nil && id == *req.MuscleGroupID {
+			continue
+		}
+		ex.SecondaryMuscleGroups = append(ex.SecondaryMuscleGroups, workout.MuscleGroup{ID: id})
+	}
+	for _, m := range dto.ToExerciseMedia(req.Media) {
+		ex.Media = append(ex.Media, *m)
+	}
+
+	if err := h.svc.CreateExercise(c.Request.Context(), &ex, req.Instructions, req.AutoTranslate); err != nil {
+		exerciseError(c, err)
+		return
 	}
 
-	if err := h.svc.CreateExercise(c.Request.Context(), &ex, req.AutoTranslate); err != nil {
+	created, err := h.svc.GetExerciseByID(c.Request.Context(), ex.ID)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, dto.ToExerciseResponse(&ex))
+	c.JSON(http.StatusCreated, dto.ToExerciseResponse(created))
 }
 
 // GetExerciseByID godoc
@@ -133,6 +159,54 @@ func (h *ExerciseHandler) GetAllExercises(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// SearchExercises godoc
+// @Summary      Search exercises
+// @Description  Matches q against the English name and every translated name, typo-tolerant; results are ranked by match quality.
+// @Tags         exercises
+// @Security     BearerAuth
+// @Produce      json
+// @Param        q                query     string  false  "Search text in any supported language"  example(bench)
+// @Param        muscle_group_id  query     int     false  "Primary muscle group ID"
+// @Param        equipment        query     string  false  "Comma-separated equipment list"          example(barbell,dumbbell)
+// @Param        bodyweight       query     bool    false  "Only bodyweight (true) or loaded (false) exercises"
+// @Param        time_based       query     bool    false  "Only time-based (true) or rep-based (false) exercises"
+// @Param        page             query     int     false  "Page number (1-based)"  minimum(1) default(1)
+// @Param        page_size        query     int     false  "Page size"              minimum(1) maximum(200) default(20)
+// @Success      200  {object}  dto.ListExerciseResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /exercises/search [get]
+func (h *ExerciseHandler) SearchExercises(c *gin.Context) {
+	page := parseInt(c.Query("page"), 1)
+	pageSize := min(parseInt(c.Query("page_size"), 20), 200)
+
+	f := workout.ExerciseFilter{
+		Query:        strings.TrimSpace(c.Query("q")),
+		IsBodyweight: parseOptionalBool(c.Query("bodyweight")),
+		IsTimeBased:  parseOptionalBool(c.Query("time_based")),
+	}
+	if id := parseUint(c.Query("muscle_group_id"), 0); id != 0 {
+		f.MuscleGroupID = &id
+	}
+	for _, e := range strings.Split(c.Query("equipment"), ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			f.Equipment = append(f.Equipment, e)
+		}
+	}
+
+	exercises, total, err := h.svc.SearchExercises(c.Request.Context(), f, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]dto.ExerciseResponse, 0, len(exercises))
+	for _, e := range exercises {
+		resp = append(resp, dto.ToExerciseResponse(e))
+	}
+	c.JSON(http.StatusOK, dto.ListExerciseResponse{Exercises: resp, Total: total})
+}
+
 // UpdateExercise godoc
 // @Summary      Update exercise (admin)
 // @Tags         exercises
@@ -164,7 +238,7 @@ func (h *ExerciseHandler) UpdateExercise(c *gin.Context) {
 
 	ex, err := h.svc.UpdateExercise(c.Request.Context(), id, updates)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		exerciseError(c, err)
 		return
 	}
 
@@ -196,6 +270,124 @@ func (h *ExerciseHandler) DeleteExercise(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// SetExerciseSecondaryMuscleGroups godoc
+// @Summary      Replace secondary muscle groups of an exercise (admin)
+// @Description  The primary muscle group is ignored if listed; unknown IDs are rejected.
+// @Tags         exercises
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id    path      uint                                      true  "Exercise ID"  example(12)
+// @Param        body  body      dto.ExerciseSecondaryMuscleGroupsRequest  true  "Muscle group IDs"
+// @Success      200   {object}  dto.ExerciseResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /exercises/{id}/secondary-muscle-groups [put]
+func (h *ExerciseHandler) SetExerciseSecondaryMuscleGroups(c *gin.Context) {
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Exercise ID is required"})
+		return
+	}
+	var req dto.ExerciseSecondaryMuscleGroupsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ex, err := h.svc.SetExerciseSecondaryMuscleGroups(c.Request.Context(), id, req.MuscleGroupIDs)
+	if err != nil {
+		exerciseError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToExerciseResponse(ex))
+}
+
+// SetExerciseMedia godoc
+// @Summary      Replace media of an exercise (admin)
+// @Description  Media are shown in the order given.
+// @Tags         exercises
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id    path      uint                            true  "Exercise ID"  example(12)
+// @Param        body  body      dto.ExerciseMediaUpdateRequest  true  "Media list"
+// @Success      200   {object}  dto.ExerciseResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /exercises/{id}/media [put]
+func (h *ExerciseHandler) SetExerciseMedia(c *gin.Context) {
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Exercise ID is required"})
+		return
+	}
+	var req dto.ExerciseMediaUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ex, err := h.svc.SetExerciseMedia(c.Request.Context(), id, dto.ToExerciseMedia(req.Media))
+	if err != nil {
+		exerciseError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToExerciseResponse(ex))
+}
+
+// SetExerciseInstructions godoc
+// @Summary      Set exercise instructions (admin)
+// @Description  Stores the text under the exercise's instructions translation key, optionally auto-translated into every locale.
+// @Tags         exercises
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id    path      uint                             true  "Exercise ID"  example(12)
+// @Param        body  body      dto.ExerciseInstructionsRequest  true  "Instructions"
+// @Success      200   {object}  dto.MessageResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /exercises/{id}/instructions [put]
+func (h *ExerciseHandler) SetExerciseInstructions(c *gin.Context) {
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Exercise ID is required"})
+		return
+	}
+	var req dto.ExerciseInstructionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.SetExerciseInstructions(c.Request.Context(), id, req.Instructions, req.AutoTranslate); err != nil {
+		exerciseError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.MessageResponse{Message: "Instructions updated"})
+}
+
+func exerciseError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, custom_err.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, custom_err.ErrUnknownMuscleGroup):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
 // GetAllMuscleGroups godoc
 // @Summary      List muscle groups
 // @Tags         muscle-groups
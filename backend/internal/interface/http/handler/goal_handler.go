@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/goal"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/dto"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/middleware"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type GoalHandler struct {
+	svc usecase.GoalService
+}
+
+func NewGoalHandler(r *gin.RouterGroup, svc usecase.GoalService) {
+	h := &GoalHandler{svc: svc}
+	g := r.Group("/goals")
+	g.Use(middleware.JWTMiddleware())
+	{
+		g.GET("", h.GetGoals)
+		g.POST("", h.CreateGoal)
+		g.GET("/:id", h.GetGoal)
+		g.PATCH("/:id", h.UpdateGoal)
+		g.DELETE("/:id", h.DeleteGoal)
+	}
+}
+
+// GetGoals godoc
+// @Summary      List my goals
+// @Description  Progress is computed from completed sets, the profile's body weight and weekly workout counts. Active goals are marked achieved when a completed workout reaches them, and expired once their deadline passes.
+// @Tags         goals
+// @Security     BearerAuth
+// @Produce      json
+// @Param        status  query     string  false  "Filter by status"  Enums(active, achieved, abandoned, expired)
+// @Success      200     {array}   dto.GoalResponse
+// @Failure      401     {object}  dto.MessageResponse
+// @Failure      500     {object}  dto.MessageResponse
+// @Router       /goals [get]
+func (h *GoalHandler) GetGoals(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	goals, err := h.svc.GetGoals(c.Request.Context(), userID, c.Query("status"))
+	if err != nil {
+		goalError(c, err)
+		return
+	}
+
+	resp := make([]dto.GoalResponse, 0, len(goals))
+	for _, g := range goals {
+		resp = append(resp, dto.ToGoalResponse(g))
+	}
+	jsonWithUnits(c, http.StatusOK, resp)
+}
+
+// CreateGoal godoc
+// @Summary      Create a goal
+// @Description  lift: individual_exercise_id and target_weight, measured as estimated 1RM or as weight for at least target_reps. bodyweight: target_weight, starting from the profile's weight. frequency: per_week workouts for weeks consecutive weeks, counted from the week the goal is set. The deadline must be in the future.
+// @Tags         goals
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.GoalCreateRequest  true  "Goal"
+// @Success      201   {object}  dto.GoalResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /goals [post]
+func (h *GoalHandler) CreateGoal(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	var req dto.GoalCreateRequest
+	if err := bindJSONWithUnits(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	g := &goal.Goal{
+		Kind:                 req.Kind,
+		IndividualExerciseID: req.IndividualExerciseID,
+		Metric:               req.Metric,
+		TargetReps:           req.TargetReps,
+		PerWeek:              req.PerWeek,
+		Weeks:                req.Weeks,
+		Deadline:             req.Deadline,
+	}
+	if req.TargetWeight != nil {
+		g.TargetWeight = req.TargetWeight.Grams()
+	}
+
+	t, err := h.svc.CreateGoal(c.Request.Context(), userID, g)
+	if err != nil {
+		goalError(c, err)
+		return
+	}
+	jsonWithUnits(c, http.StatusCreated, dto.ToGoalResponse(t))
+}
+
+// GetGoal godoc
+// @Summary      Get a goal
+// @Tags         goals
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      uint  true  "Goal ID"  example(4)
+// @Success      200  {object}  dto.GoalResponse
+// @Failure      400  {object}  dto.MessageResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      404  {object}  dto.MessageResponse
+// @Router       /goals/{id} [get]
+func (h *GoalHandler) GetGoal(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Goal ID is required"})
+		return
+	}
+
+	t, err := h.svc.GetGoal(c.Request.Context(), userID, id)
+	if err != nil {
+		goalError(c, err)
+		return
+	}
+	jsonWithUnits(c, http.StatusOK, dto.ToGoalResponse(t))
+}
+
+// UpdateGoal godoc
+// @Summary      Update a goal
+// @Description  Adjust targets or the deadline (null clears it), or abandon and resume the goal. Achieved goals cannot be changed.
+// @Tags         goals
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id    path      uint                   true  "Goal ID"  example(4)
+// @Param        body  body      dto.GoalUpdateRequest  true  "Fields to update"
+// @Success      200   {object}  dto.GoalResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /goals/{id} [patch]
+func (h *GoalHandler) UpdateGoal(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Goal ID is required"})
+		return
+	}
+
+	var req dto.GoalUpdateRequest
+	if err := bindJSONWithUnits(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	t, err := h.svc.UpdateGoal(c.Request.Context(), userID, id, dto.BuildUpdatesFromPatchDTO(req))
+	if err != nil {
+		goalError(c, err)
+		return
+	}
+	jsonWithUnits(c, http.StatusOK, dto.ToGoalResponse(t))
+}
+
+// DeleteGoal godoc
+// @Summary      Delete a goal
+// @Tags         goals
+// @Security     BearerAuth
+// @Param        id  path      uint  true  "Goal ID"  example(4)
+// @Success      204 {string}  string "No Content"
+// @Failure      400 {object}  dto.MessageResponse
+// @Failure      401 {object}  dto.MessageResponse
+// @Failure      404 {object}  dto.MessageResponse
+// @Router       /goals/{id} [delete]
+func (h *GoalHandler) DeleteGoal(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Goal ID is required"})
+		return
+	}
+
+	if err := h.svc.DeleteGoal(c.Request.Context(), userID, id); err != nil {
+		goalError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func goalError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, custom_err.ErrNotFound), errors.Is(err, custom_err.ErrIndividualExerciseNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, custom_err.ErrInvalidGoal), errors.Is(err, custom_err.ErrBodyWeightRequired):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -1,11 +1,15 @@
 package handler
 
 import (
+	"errors"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
 )
 
 func currentUserID(c *gin.Context) (uint, bool) {
@@ -17,6 +21,15 @@ func currentUserID(c *gin.Context) (uint, bool) {
 	return v.(uint), true
 }
 
+// subjectUserID is the user whose data the request acts on: a coach's client on
+// /clients/:clientID routes, otherwise the caller.
+func subjectUserID(c *gin.Context) (uint, bool) {
+	if v, ok := c.Get("subjectUserID"); ok {
+		return v.(uint), true
+	}
+	return currentUserID(c)
+}
+
 func parseUint(s string, def uint) uint {
 	if s == "" {
 		return def
@@ -39,6 +52,18 @@ func parseInt(s string, def int64) int64 {
 	return int64(n)
 }
 
+// parseOptionalBool returns nil for an empty or malformed value.
+func parseOptionalBool(s string) *bool {
+	if s == "" {
+		return nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return nil
+	}
+	return &b
+}
+
 func etagMatch(inm, etag string) bool {
 	if inm == "" || etag == "" {
 		return false
@@ -57,3 +82,19 @@ func etagMatch(inm, etag string) bool {
 	}
 	return false
 }
+
+// abortAttemptGuard maps a LoginGuard.Check error to a 429 with Retry-After.
+func abortAttemptGuard(c *gin.Context, wait time.Duration, err error) {
+	if errors.Is(err, custom_err.ErrAccountLocked) || errors.Is(err, custom_err.ErrTooManyAttempts) {
+		setRetryAfter(c, wait)
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		return
+	}
+	c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Rate limit error"})
+}
+
+func setRetryAfter(c *gin.Context, wait time.Duration) {
+	if wait > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	}
+}
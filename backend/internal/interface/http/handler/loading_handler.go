@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/equipment"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/dto"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/middleware"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type LoadingHandler struct {
+	svc usecase.LoadingService
+}
+
+func NewLoadingHandler(r *gin.RouterGroup, svc usecase.LoadingService) {
+	h := &LoadingHandler{svc: svc}
+	ls := r.Group("/loading-settings")
+	ls.Use(middleware.JWTMiddleware())
+	{
+		ls.GET("", h.GetSettings)
+		ls.PUT("", h.UpdateSettings)
+		ls.DELETE("", h.ResetSettings)
+		ls.GET("/round", h.RoundWeight)
+	}
+}
+
+// GetSettings godoc
+// @Summary      Get my loading settings
+// @Description  Bar weight, plates and dumbbell increment in grams. Users without saved settings get the defaults for their unit system.
+// @Tags         equipment
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  dto.LoadingSettingsResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /loading-settings [get]
+func (h *LoadingHandler) GetSettings(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	s, err := h.svc.GetLoadingSettings(c.Request.Context(), userID)
+	if err != nil {
+		loadingError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToLoadingSettingsResponse(s))
+}
+
+// UpdateSettings godoc
+// @Summary      Save my loading settings
+// @Description  Replaces the bar weight, dumbbell increment and the full plate list. Weights are in grams.
+// @Tags         equipment
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.LoadingSettingsRequest  true  "Loading settings"
+// @Success      200   {object}  dto.LoadingSettingsResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /loading-settings [put]
+func (h *LoadingHandler) UpdateSettings(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	var req dto.LoadingSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plates := make([]equipment.LoadingPlate, 0, len(req.Plates))
+	for _, p := range req.Plates {
+		plates = append(plates, equipment.LoadingPlate{Weight: p.Weight, Pairs: p.Pairs})
+	}
+
+	s, err := h.svc.UpdateLoadingSettings(c.Request.Context(), userID, req.BarWeight, req.DumbbellIncrement, plates)
+	if err != nil {
+		loadingError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToLoadingSettingsResponse(s))
+}
+
+// ResetSettings godoc
+// @Summary      Reset my loading settings
+// @Description  Drops saved settings and returns the defaults for the user's unit system.
+// @Tags         equipment
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  dto.LoadingSettingsResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /loading-settings [delete]
+func (h *LoadingHandler) ResetSettings(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	s, err := h.svc.ResetLoadingSettings(c.Request.Context(), userID)
+	if err != nil {
+		loadingError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToLoadingSettingsResponse(s))
+}
+
+// RoundWeight godoc
+// @Summary      Round a weight to what I can load
+// @Description  Plate-loaded equipment returns the per-side plate breakdown, with EZ bars and Smith machines weighing the same as the barbell; dumbbells and kettlebells round to the rack increment; other equipment is returned unchanged.
+// @Tags         equipment
+// @Security     BearerAuth
+// @Produce      json
+// @Param        weight     query     int     true   "Target weight in grams"  example(101000)
+// @Param        equipment  query     string  false  "Equipment"               default(barbell)
+// @Success      200        {object}  dto.LoadoutResponse
+// @Failure      400        {object}  dto.MessageResponse
+// @Failure      401        {object}  dto.MessageResponse
+// @Failure      500        {object}  dto.MessageResponse
+// @Router       /loading-settings/round [get]
+func (h *LoadingHandler) RoundWeight(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	target := parseInt(c.Query("weight"), -1)
+	if target < 0 || target > 2000000 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid weight"})
+		return
+	}
+	eq := c.DefaultQuery("equipment", workout.EquipmentBarbell)
+	if !slices.Contains(workout.Equipment, eq) {
+		loadingError(c, custom_err.ErrUnknownEquipment)
+		return
+	}
+
+	l, err := h.svc.RoundWeight(c.Request.Context(), userID, int(target), eq)
+	if err != nil {
+		loadingError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToLoadoutResponse(l))
+}
+
+func loadingError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, custom_err.ErrInvalidLoadingSettings), errors.Is(err, custom_err.ErrUnknownEquipment):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
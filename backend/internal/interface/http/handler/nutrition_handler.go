@@ -0,0 +1,608 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/nutrition"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/dto"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/middleware"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type NutritionHandler struct {
+	svc usecase.NutritionService
+}
+
+func NewNutritionHandler(r *gin.RouterGroup, svc usecase.NutritionService, rbacService usecase.RBACService) {
+	h := &NutritionHandler{svc: svc}
+	auth := r.Group("")
+	auth.Use(middleware.JWTMiddleware())
+
+	foods := auth.Group("/foods")
+	{
+		foods.GET("", h.SearchFoods)
+		foods.POST("", h.CreateFood)
+		foods.GET("/:id", h.GetFood)
+		foods.PATCH("/:id", h.UpdateFood)
+		foods.DELETE("/:id", h.DeleteFood)
+
+		adminonly := foods.Group("/catalog")
+		adminonly.Use(middleware.RequirePerm(rbacService, rbac.PermAdmin))
+		{
+			adminonly.POST("", h.CreateCatalogFood)
+			adminonly.PATCH("/:id", h.UpdateCatalogFood)
+			adminonly.DELETE("/:id", h.DeleteCatalogFood)
+		}
+	}
+
+	meals := auth.Group("/meals")
+	{
+		meals.GET("", h.GetMeals)
+		meals.POST("", h.LogMeal)
+		meals.PATCH("/:id", h.UpdateMeal)
+		meals.DELETE("/:id", h.DeleteMeal)
+	}
+
+	n := auth.Group("/nutrition")
+	{
+		n.GET("/targets", h.GetTargets)
+		n.PUT("/targets", h.SetGoal)
+		n.GET("/balance", h.GetDailyBalance)
+	}
+}
+
+// SearchFoods godoc
+// @Summary      Search foods
+// @Description  Matches the catalog (including translated names) and the user's own foods, own foods first.
+// @Tags         nutrition
+// @Security     BearerAuth
+// @Produce      json
+// @Param        q          query     string  false  "Search text"  example(oat)
+// @Param        page       query     int     false  "Page"         default(1)
+// @Param        page_size  query     int     false  "Page size"    default(20)
+// @Success      200        {object}  dto.ListFoodResponse
+// @Failure      401        {object}  dto.MessageResponse
+// @Failure      500        {object}  dto.MessageResponse
+// @Router       /foods [get]
+func (h *NutritionHandler) SearchFoods(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	page := parseInt(c.Query("page"), 1)
+	pageSize := min(parseInt(c.Query("page_size"), 20), 200)
+
+	foods, total, err := h.svc.SearchFoods(c.Request.Context(), userID, strings.TrimSpace(c.Query("q")), int(page), int(pageSize))
+	if err != nil {
+		nutritionError(c, err)
+		return
+	}
+
+	resp := dto.ListFoodResponse{Items: make([]dto.FoodResponse, 0, len(foods)), Total: total}
+	for _, f := range foods {
+		resp.Items = append(resp.Items, dto.ToFoodResponse(f))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateFood godoc
+// @Summary      Create my own food
+// @Description  Nutrient values are per 100 g. Custom foods are private and not translated.
+// @Tags         nutrition
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.FoodCreateRequest  true  "Food payload"
+// @Success      201   {object}  dto.FoodResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /foods [post]
+func (h *NutritionHandler) CreateFood(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	var req dto.FoodCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	f := foodFromRequest(req)
+	if err := h.svc.CreateFood(c.Request.Context(), userID, f); err != nil {
+		nutritionError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.ToFoodResponse(f))
+}
+
+// GetFood godoc
+// @Summary      Get food
+// @Tags         nutrition
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      uint  true  "Food ID"  example(7)
+// @Success      200  {object}  dto.FoodResponse
+// @Failure      400  {object}  dto.MessageResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      404  {object}  dto.MessageResponse
+// @Router       /foods/{id} [get]
+func (h *NutritionHandler) GetFood(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Food ID is required"})
+		return
+	}
+
+	f, err := h.svc.GetFood(c.Request.Context(), userID, id)
+	if err != nil {
+		nutritionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToFoodResponse(f))
+}
+
+// UpdateFood godoc
+// @Summary      Update my own food
+// @Tags         nutrition
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id    path      uint                   true  "Food ID"  example(7)
+// @Param        body  body      dto.FoodUpdateRequest  true  "Fields to update"
+// @Success      200   {object}  dto.FoodResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /foods/{id} [patch]
+func (h *NutritionHandler) UpdateFood(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Food ID is required"})
+		return
+	}
+
+	var req dto.FoodUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	f, err := h.svc.UpdateFood(c.Request.Context(), userID, id, dto.BuildUpdatesFromPatchDTO(req))
+	if err != nil {
+		nutritionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToFoodResponse(f))
+}
+
+// DeleteFood godoc
+// @Summary      Delete my own food
+// @Description  Foods that appear in the meal log cannot be deleted.
+// @Tags         nutrition
+// @Security     BearerAuth
+// @Param        id  path      uint  true  "Food ID"  example(7)
+// @Success      204 {string}  string "No Content"
+// @Failure      400 {object}  dto.MessageResponse
+// @Failure      401 {object}  dto.MessageResponse
+// @Failure      404 {object}  dto.MessageResponse
+// @Failure      409 {object}  dto.MessageResponse
+// @Router       /foods/{id} [delete]
+func (h *NutritionHandler) DeleteFood(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Food ID is required"})
+		return
+	}
+
+	if err := h.svc.DeleteFood(c.Request.Context(), userID, id); err != nil {
+		nutritionError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CreateCatalogFood godoc
+// @Summary      Create catalog food (admin)
+// @Description  The name is stored under the "food.<slug>" translation key; auto_translate fills the other locales.
+// @Tags         nutrition
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.CatalogFoodCreateRequest  true  "Food payload"
+// @Success      201   {object}  dto.FoodResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      409   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /foods/catalog [post]
+func (h *NutritionHandler) CreateCatalogFood(c *gin.Context) {
+	var req dto.CatalogFoodCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	f := foodFromRequest(req.FoodCreateRequest)
+	if err := h.svc.CreateCatalogFood(c.Request.Context(), f, req.AutoTranslate); err != nil {
+		nutritionError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.ToFoodResponse(f))
+}
+
+// UpdateCatalogFood godoc
+// @Summary      Update catalog food (admin)
+// @Description  Changing the name does not touch its translations.
+// @Tags         nutrition
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id    path      uint                   true  "Food ID"  example(7)
+// @Param        body  body      dto.FoodUpdateRequest  true  "Fields to update"
+// @Success      200   {object}  dto.FoodResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /foods/catalog/{id} [patch]
+func (h *NutritionHandler) UpdateCatalogFood(c *gin.Context) {
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Food ID is required"})
+		return
+	}
+
+	var req dto.FoodUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	f, err := h.svc.UpdateCatalogFood(c.Request.Context(), id, dto.BuildUpdatesFromPatchDTO(req))
+	if err != nil {
+		nutritionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToFoodResponse(f))
+}
+
+// DeleteCatalogFood godoc
+// @Summary      Delete catalog food (admin)
+// @Tags         nutrition
+// @Security     BearerAuth
+// @Param        id  path      uint  true  "Food ID"  example(7)
+// @Success      204 {string}  string "No Content"
+// @Failure      400 {object}  dto.MessageResponse
+// @Failure      401 {object}  dto.MessageResponse
+// @Failure      403 {object}  dto.MessageResponse
+// @Failure      404 {object}  dto.MessageResponse
+// @Failure      409 {object}  dto.MessageResponse
+// @Router       /foods/catalog/{id} [delete]
+func (h *NutritionHandler) DeleteCatalogFood(c *gin.Context) {
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Food ID is required"})
+		return
+	}
+
+	if err := h.svc.DeleteCatalogFood(c.Request.Context(), id); err != nil {
+		nutritionError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetMeals godoc
+// @Summary      List my meal log for a day
+// @Tags         nutrition
+// @Security     BearerAuth
+// @Produce      json
+// @Param        date  query     string  false  "Day (YYYY-MM-DD), defaults to today"  example(2025-06-01)
+// @Param        tz    query     string  false  "IANA time zone"                       default(UTC)
+// @Success      200   {array}   dto.MealEntryResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /meals [get]
+func (h *NutritionHandler) GetMeals(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	day, ok := parseDay(c)
+	if !ok {
+		return
+	}
+
+	entries, err := h.svc.GetMeals(c.Request.Context(), userID, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		nutritionError(c, err)
+		return
+	}
+
+	resp := make([]dto.MealEntryResponse, 0, len(entries))
+	for _, e := range entries {
+		resp = append(resp, dto.ToMealEntryResponse(e))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// LogMeal godoc
+// @Summary      Log a meal entry
+// @Description  Amount is in grams. eaten_at defaults to now and meal to snack.
+// @Tags         nutrition
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.MealEntryCreateRequest  true  "Meal entry"
+// @Success      201   {object}  dto.MealEntryResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /meals [post]
+func (h *NutritionHandler) LogMeal(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	var req dto.MealEntryCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	e := &nutrition.MealEntry{FoodID: req.FoodID, Meal: req.Meal, Amount: req.Amount}
+	if e.Meal == "" {
+		e.Meal = nutrition.MealSnack
+	}
+	if req.EatenAt != nil {
+		e.EatenAt = *req.EatenAt
+	}
+
+	if err := h.svc.LogMeal(c.Request.Context(), userID, e); err != nil {
+		nutritionError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.ToMealEntryResponse(e))
+}
+
+// UpdateMeal godoc
+// @Summary      Update a meal entry
+// @Tags         nutrition
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id    path      uint                        true  "Meal entry ID"  example(31)
+// @Param        body  body      dto.MealEntryUpdateRequest  true  "Fields to update"
+// @Success      200   {object}  dto.MealEntryResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /meals/{id} [patch]
+func (h *NutritionHandler) UpdateMeal(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Meal entry ID is required"})
+		return
+	}
+
+	var req dto.MealEntryUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	e, err := h.svc.UpdateMeal(c.Request.Context(), userID, id, dto.BuildUpdatesFromPatchDTO(req))
+	if err != nil {
+		nutritionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToMealEntryResponse(e))
+}
+
+// DeleteMeal godoc
+// @Summary      Delete a meal entry
+// @Tags         nutrition
+// @Security     BearerAuth
+// @Param        id  path      uint  true  "Meal entry ID"  example(31)
+// @Success      204 {string}  string "No Content"
+// @Failure      400 {object}  dto.MessageResponse
+// @Failure      401 {object}  dto.MessageResponse
+// @Failure      404 {object}  dto.MessageResponse
+// @Router       /meals/{id} [delete]
+func (h *NutritionHandler) DeleteMeal(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Meal entry ID is required"})
+		return
+	}
+
+	if err := h.svc.DeleteMeal(c.Request.Context(), userID, id); err != nil {
+		nutritionError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetTargets godoc
+// @Summary      Get my daily nutrition targets
+// @Description  Derived from the profile (Mifflin-St Jeor, sedentary baseline) and the goal, with manual overrides applied. targets is omitted when the profile is incomplete.
+// @Tags         nutrition
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  dto.NutritionTargetsResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /nutrition/targets [get]
+func (h *NutritionHandler) GetTargets(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	t, g, err := h.svc.GetTargets(c.Request.Context(), userID)
+	if err != nil {
+		nutritionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToNutritionTargetsResponse(t, g))
+}
+
+// SetGoal godoc
+// @Summary      Set my nutrition goal
+// @Description  Omitted values are derived from the profile; given ones override them.
+// @Tags         nutrition
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.NutritionGoalRequest  true  "Goal"
+// @Success      200   {object}  dto.NutritionTargetsResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /nutrition/targets [put]
+func (h *NutritionHandler) SetGoal(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	var req dto.NutritionGoalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	t, g, err := h.svc.SetGoal(c.Request.Context(), userID, &nutrition.NutritionGoal{
+		Goal:    req.Goal,
+		Kcal:    req.Kcal,
+		Protein: req.Protein,
+		Carbs:   req.Carbs,
+		Fat:     req.Fat,
+	})
+	if err != nil {
+		nutritionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToNutritionTargetsResponse(t, g))
+}
+
+// GetDailyBalance godoc
+// @Summary      Get my daily energy balance
+// @Description  Intake from the meal log against calories burned by workouts completed that day. Remaining treats training as extra budget.
+// @Tags         nutrition
+// @Security     BearerAuth
+// @Produce      json
+// @Param        date  query     string  false  "Day (YYYY-MM-DD), defaults to today"  example(2025-06-01)
+// @Param        tz    query     string  false  "IANA time zone"                       default(UTC)
+// @Success      200   {object}  dto.DailyBalanceResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /nutrition/balance [get]
+func (h *NutritionHandler) GetDailyBalance(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	day, ok := parseDay(c)
+	if !ok {
+		return
+	}
+
+	b, err := h.svc.GetDailyBalance(c.Request.Context(), userID, day)
+	if err != nil {
+		nutritionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToDailyBalanceResponse(b))
+}
+
+// parseDay reads ?date and ?tz and returns midnight of that day in the zone.
+func parseDay(c *gin.Context) (time.Time, bool) {
+	loc := time.UTC
+	if tz := strings.TrimSpace(c.Query("tz")); tz != "" {
+		l, err := time.LoadLocation(tz)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tz, expected an IANA time zone"})
+			return time.Time{}, false
+		}
+		loc = l
+	}
+
+	raw := strings.TrimSpace(c.Query("date"))
+	if raw == "" {
+		now := time.Now().In(loc)
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc), true
+	}
+	day, err := time.ParseInLocation(time.DateOnly, raw, loc)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date, expected YYYY-MM-DD"})
+		return time.Time{}, false
+	}
+	return day, true
+}
+
+func foodFromRequest(req dto.FoodCreateRequest) *nutrition.Food {
+	f := &nutrition.Food{
+		Name:        req.Name,
+		Brand:       req.Brand,
+		Kcal:        req.Kcal,
+		Protein:     req.Protein,
+		Carbs:       req.Carbs,
+		Fat:         req.Fat,
+		Fiber:       req.Fiber,
+		ServingSize: req.ServingSize,
+	}
+	if f.ServingSize == 0 {
+		f.ServingSize = 100
+	}
+	return f
+}
+
+func nutritionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, custom_err.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, custom_err.ErrFoodExists), errors.Is(err, custom_err.ErrFoodInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
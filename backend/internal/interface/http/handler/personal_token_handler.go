@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/dto"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/middleware"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type PersonalTokenHandler struct {
+	svc usecase.PersonalTokenService
+}
+
+// NewPersonalTokenHandler registers token management routes. They require a JWT on purpose:
+// a personal access token cannot be used to mint or revoke tokens.
+func NewPersonalTokenHandler(r *gin.RouterGroup, svc usecase.PersonalTokenService) {
+	h := &PersonalTokenHandler{svc: svc}
+
+	tokens := r.Group("/users/tokens")
+	tokens.Use(middleware.JWTMiddleware())
+	{
+		tokens.GET("", h.ListTokens)
+		tokens.POST("", h.CreateToken)
+		tokens.DELETE("/:id", h.RevokeToken)
+	}
+}
+
+// CreateToken godoc
+// @Summary      Create a personal access token
+// @Description  Issues a scoped token for scripts and integrations. The raw token is returned only once.
+// @Tags         users
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.CreatePersonalTokenRequest  true  "Token name, scopes and optional expiry"
+// @Success      201   {object}  dto.CreatePersonalTokenResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /users/tokens [post]
+func (h *PersonalTokenHandler) CreateToken(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	var req dto.CreatePersonalTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	t, raw, err := h.svc.CreateToken(c.Request.Context(), userID, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, custom_err.ErrInvalidScope) || errors.Is(err, custom_err.ErrInvalidExpiry) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.CreatePersonalTokenResponse{
+		PersonalTokenResponse: dto.ToPersonalTokenResponse(t),
+		Token:                 raw,
+	})
+}
+
+// ListTokens godoc
+// @Summary      List personal access tokens
+// @Tags         users
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   dto.PersonalTokenResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /users/tokens [get]
+func (h *PersonalTokenHandler) ListTokens(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	tokens, err := h.svc.ListTokens(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]dto.PersonalTokenResponse, 0, len(tokens))
+	for _, t := range tokens {
+		resp = append(resp, dto.ToPersonalTokenResponse(t))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// RevokeToken godoc
+// @Summary      Revoke a personal access token
+// @Tags         users
+// @Security     BearerAuth
+// @Param        id   path      uint  true  "Token ID"
+// @Success      204  {string}  string  "No Content"
+// @Failure      400  {object}  dto.MessageResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      404  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /users/tokens/{id} [delete]
+func (h *PersonalTokenHandler) RevokeToken(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Token ID is required"})
+		return
+	}
+
+	if err := h.svc.RevokeToken(c.Request.Context(), userID, id); err != nil {
+		if errors.Is(err, custom_err.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "token not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/dto"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/middleware"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type RBACHandler struct {
+	svc usecase.RBACService
+}
+
+func NewRBACHandler(r *gin.RouterGroup, svc usecase.RBACService) {
+	h := &RBACHandler{svc: svc}
+
+	rb := r.Group("/admin/rbac")
+	rb.Use(middleware.JWTMiddleware())
+	rb.Use(middleware.RequirePerm(svc, rbac.PermAdmin))
+
+	{
+		rb.GET("/permissions", h.GetPermissions)
+
+		rb.GET("/roles", h.GetRoles)
+		rb.POST("/roles", h.CreateRole)
+		rb.PATCH("/roles/:name", h.UpdateRole)
+		rb.DELETE("/roles/:name", h.DeleteRole)
+		rb.GET("/roles/:name/members", h.GetRoleMembers)
+		rb.PUT("/roles/:name/permissions/:key", h.AddRolePermission)
+		rb.DELETE("/roles/:name/permissions/:key", h.RemoveRolePermission)
+	}
+}
+
+// GetPermissions godoc
+// @Summary      List permissions (admin)
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   dto.PermissionResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      403  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /admin/rbac/permissions [get]
+func (h *RBACHandler) GetPermissions(c *gin.Context) {
+	perms, err := h.svc.ListPermissions(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]dto.PermissionResponse, 0, len(perms))
+	for _, p := range perms {
+		resp = append(resp, dto.PermissionResponse{ID: p.ID, Key: p.Key})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetRoles godoc
+// @Summary      List roles with permissions (admin)
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   dto.RoleDetailResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      403  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /admin/rbac/roles [get]
+func (h *RBACHandler) GetRoles(c *gin.Context) {
+	roles, err := h.svc.ListRoles(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]dto.RoleDetailResponse, 0, len(roles))
+	for _, role := range roles {
+		resp = append(resp, dto.ToRoleDetailResponse(role))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateRole godoc
+// @Summary      Create a custom role (admin)
+// @Tags         admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.CreateRoleRequest  true  "Role name and permission keys"
+// @Success      201   {object}  dto.RoleDetailResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      409   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /admin/rbac/roles [post]
+func (h *RBACHandler) CreateRole(c *gin.Context) {
+	var req dto.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, err := h.svc.CreateRole(c.Request.Context(), req.Name, req.Permissions)
+	if err != nil {
+		rbacError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.ToRoleDetailResponse(role))
+}
+
+// UpdateRole godoc
+// @Summary      Rename a custom role or replace a role's permissions (admin)
+// @Description  Built-in roles cannot be renamed, and the admin role's permissions are fixed.
+// @Tags         admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        name  path      string                 true  "Role name"
+// @Param        body  body      dto.UpdateRoleRequest  true  "Fields to change"
+// @Success      200   {object}  dto.RoleDetailResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      409   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /admin/rbac/roles/{name} [patch]
+func (h *RBACHandler) UpdateRole(c *gin.Context) {
+	var req dto.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, err := h.svc.UpdateRole(c.Request.Context(), c.Param("name"), req.Name, req.Permissions)
+	if err != nil {
+		rbacError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToRoleDetailResponse(role))
+}
+
+// DeleteRole godoc
+// @Summary      Delete a custom role (admin)
+// @Tags         admin
+// @Security     BearerAuth
+// @Param        name  path      string  true  "Role name"
+// @Success      204   {string}  string  "No Content"
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /admin/rbac/roles/{name} [delete]
+func (h *RBACHandler) DeleteRole(c *gin.Context) {
+	if err := h.svc.DeleteRole(c.Request.Context(), c.Param("name")); err != nil {
+		rbacError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetRoleMembers godoc
+// @Summary      List users holding a role (admin)
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Param        name       path      string  true   "Role name"
+// @Param        page       query     int     false  "Page number (1-based)"  minimum(1) default(1)
+// @Param        page_size  query     int     false  "Page size"              minimum(1) maximum(200) default(20)
+// @Success      200  {object}  dto.ListRoleMembersResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      403  {object}  dto.MessageResponse
+// @Failure      404  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /admin/rbac/roles/{name}/members [get]
+func (h *RBACHandler) GetRoleMembers(c *gin.Context) {
+	page := parseInt(c.Query("page"), 1)
+	pageSize := min(parseInt(c.Query("page_size"), 20), 200)
+
+	users, total, err := h.svc.ListRoleMembers(c.Request.Context(), c.Param("name"), page, pageSize)
+	if err != nil {
+		rbacError(c, err)
+		return
+	}
+
+	resp := dto.ListRoleMembersResponse{Users: make([]dto.RoleMemberResponse, 0, len(users)), Total: total}
+	for _, u := range users {
+		resp.Users = append(resp.Users, dto.RoleMemberResponse{
+			ID:         u.ID,
+			Username:   u.Username,
+			Email:      u.Email,
+			IsVerified: u.IsVerified,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// AddRolePermission godoc
+// @Summary      Attach a permission to a role (admin)
+// @Description  Works on built-in roles too, except admin.
+// @Tags         admin
+// @Security     BearerAuth
+// @Param        name  path      string  true  "Role name"
+// @Param        key   path      string  true  "Permission key"
+// @Success      204   {string}  string  "No Content"
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /admin/rbac/roles/{name}/permissions/{key} [put]
+func (h *RBACHandler) AddRolePermission(c *gin.Context) {
+	if err := h.svc.AddRolePermission(c.Request.Context(), c.Param("name"), c.Param("key")); err != nil {
+		rbacError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveRolePermission godoc
+// @Summary      Detach a permission from a role (admin)
+// @Description  Works on built-in roles too, except admin.
+// @Tags         admin
+// @Security     BearerAuth
+// @Param        name  path      string  true  "Role name"
+// @Param        key   path      string  true  "Permission key"
+// @Success      204   {string}  string  "No Content"
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /admin/rbac/roles/{name}/permissions/{key} [delete]
+func (h *RBACHandler) RemoveRolePermission(c *gin.Context) {
+	if err := h.svc.RemoveRolePermission(c.Request.Context(), c.Param("name"), c.Param("key")); err != nil {
+		rbacError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func rbacError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, custom_err.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "role not found"})
+	case errors.Is(err, custom_err.ErrBuiltinRole), errors.Is(err, custom_err.ErrProtectedRole):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, custom_err.ErrRoleExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, custom_err.ErrUnknownPermission):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,641 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/social"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/dto"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/middleware"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type SocialHandler struct {
+	svc usecase.SocialService
+}
+
+func NewSocialHandler(r *gin.RouterGroup, svc usecase.SocialService, rateLimiter usecase.RateLimiter) {
+	h := &SocialHandler{svc: svc}
+
+	so := r.Group("/social")
+	so.Use(middleware.JWTMiddleware())
+	{
+		so.POST("/follows", h.Follow)
+		so.DELETE("/follows/:userID", h.Unfollow)
+		so.GET("/followers", h.GetFollowers)
+		so.GET("/following", h.GetFollowing)
+		so.GET("/follow-requests", h.GetFollowRequests)
+		so.POST("/follow-requests/:userID", h.AcceptFollowRequest)
+		so.DELETE("/follow-requests/:userID", h.DeclineFollowRequest)
+
+		so.GET("/feed", h.GetFeed)
+		so.GET("/users/:userID/activities", h.GetUserActivities)
+
+		so.PUT("/activities/:id/reaction", h.React)
+		so.DELETE("/activities/:id/reaction", h.Unreact)
+		so.GET("/activities/:id/comments", h.GetComments)
+		so.POST("/activities/:id/comments", h.AddComment)
+		so.DELETE("/comments/:id", h.DeleteComment)
+
+		so.GET("/shares", h.GetShares)
+		so.POST("/shares", h.ShareWorkout)
+		so.DELETE("/shares/:id", h.RevokeShare)
+	}
+
+	shared := r.Group("/shared")
+	shared.Use(middleware.RateLimitMiddleware(rateLimiter, 60, "shared")) // 60 requests per minute
+	{
+		shared.GET("/workouts/:token", h.GetSharedWorkout)
+	}
+}
+
+// Follow godoc
+// @Summary      Follow a user
+// @Description  Public users are followed straight away; anyone else gets a pending request to accept.
+// @Tags         social
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.FollowRequest  true  "Username to follow"
+// @Success      200   {object}  dto.FollowResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /social/follows [post]
+func (h *SocialHandler) Follow(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	var req dto.FollowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	follow, err := h.svc.Follow(c.Request.Context(), userID, req.Username)
+	if err != nil {
+		socialError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToFollowResponse(follow))
+}
+
+// Unfollow godoc
+// @Summary      Unfollow a user
+// @Description  Also withdraws a pending follow request.
+// @Tags         social
+// @Security     BearerAuth
+// @Param        userID  path      uint  true  "User ID"
+// @Success      204     {string}  string  "No Content"
+// @Failure      400     {object}  dto.MessageResponse
+// @Failure      401     {object}  dto.MessageResponse
+// @Failure      404     {object}  dto.MessageResponse
+// @Failure      500     {object}  dto.MessageResponse
+// @Router       /social/follows/{userID} [delete]
+func (h *SocialHandler) Unfollow(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	followeeID := parseUint(c.Param("userID"), 0)
+	if followeeID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User ID is required"})
+		return
+	}
+
+	if err := h.svc.Unfollow(c.Request.Context(), userID, followeeID); err != nil {
+		socialError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetFollowers godoc
+// @Summary      List my followers
+// @Tags         social
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page       query     int  false  "Page"       default(1)
+// @Param        page_size  query     int  false  "Page size"  default(20)
+// @Success      200        {object}  dto.ListSocialUsersResponse
+// @Failure      401        {object}  dto.MessageResponse
+// @Failure      500        {object}  dto.MessageResponse
+// @Router       /social/followers [get]
+func (h *SocialHandler) GetFollowers(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	page := max(1, parseInt(c.Query("page"), 1))
+	pageSize := max(1, min(parseInt(c.Query("page_size"), 20), 200))
+
+	users, total, err := h.svc.ListFollowers(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := dto.ListSocialUsersResponse{Users: make([]dto.SocialUserResponse, 0, len(users)), Total: total}
+	for _, u := range users {
+		resp.Users = append(resp.Users, dto.ToSocialUserResponse(u))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetFollowing godoc
+// @Summary      List users I follow
+// @Tags         social
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page       query     int  false  "Page"       default(1)
+// @Param        page_size  query     int  false  "Page size"  default(20)
+// @Success      200        {object}  dto.ListSocialUsersResponse
+// @Failure      401        {object}  dto.MessageResponse
+// @Failure      500        {object}  dto.MessageResponse
+// @Router       /social/following [get]
+func (h *SocialHandler) GetFollowing(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	page := max(1, parseInt(c.Query("page"), 1))
+	pageSize := max(1, min(parseInt(c.Query("page_size"), 20), 200))
+
+	users, total, err := h.svc.ListFollowing(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := dto.ListSocialUsersResponse{Users: make([]dto.SocialUserResponse, 0, len(users)), Total: total}
+	for _, u := range users {
+		resp.Users = append(resp.Users, dto.ToSocialUserResponse(u))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetFollowRequests godoc
+// @Summary      List pending follow requests to me
+// @Tags         social
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page       query     int  false  "Page"       default(1)
+// @Param        page_size  query     int  false  "Page size"  default(20)
+// @Success      200        {object}  dto.ListSocialUsersResponse
+// @Failure      401        {object}  dto.MessageResponse
+// @Failure      500        {object}  dto.MessageResponse
+// @Router       /social/follow-requests [get]
+func (h *SocialHandler) GetFollowRequests(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	page := max(1, parseInt(c.Query("page"), 1))
+	pageSize := max(1, min(parseInt(c.Query("page_size"), 20), 200))
+
+	users, total, err := h.svc.ListFollowRequests(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := dto.ListSocialUsersResponse{Users: make([]dto.SocialUserResponse, 0, len(users)), Total: total}
+	for _, u := range users {
+		resp.Users = append(resp.Users, dto.ToSocialUserResponse(u))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// AcceptFollowRequest godoc
+// @Summary      Accept a follow request
+// @Tags         social
+// @Security     BearerAuth
+// @Param        userID  path      uint  true  "ID of the requesting user"
+// @Success      204     {string}  string  "No Content"
+// @Failure      400     {object}  dto.MessageResponse
+// @Failure      401     {object}  dto.MessageResponse
+// @Failure      404     {object}  dto.MessageResponse
+// @Failure      500     {object}  dto.MessageResponse
+// @Router       /social/follow-requests/{userID} [post]
+func (h *SocialHandler) AcceptFollowRequest(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	followerID := parseUint(c.Param("userID"), 0)
+	if followerID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User ID is required"})
+		return
+	}
+
+	if err := h.svc.AcceptFollowRequest(c.Request.Context(), userID, followerID); err != nil {
+		socialError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// DeclineFollowRequest godoc
+// @Summary      Decline a follow request
+// @Tags         social
+// @Security     BearerAuth
+// @Param        userID  path      uint  true  "ID of the requesting user"
+// @Success      204     {string}  string  "No Content"
+// @Failure      400     {object}  dto.MessageResponse
+// @Failure      401     {object}  dto.MessageResponse
+// @Failure      404     {object}  dto.MessageResponse
+// @Failure      500     {object}  dto.MessageResponse
+// @Router       /social/follow-requests/{userID} [delete]
+func (h *SocialHandler) DeclineFollowRequest(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	followerID := parseUint(c.Param("userID"), 0)
+	if followerID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User ID is required"})
+		return
+	}
+
+	if err := h.svc.DeclineFollowRequest(c.Request.Context(), userID, followerID); err != nil {
+		socialError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetFeed godoc
+// @Summary      Get my activity feed
+// @Description  Newest first. Pass the last item's id as `before` to load the next page.
+// @Tags         social
+// @Security     BearerAuth
+// @Produce      json
+// @Param        before  query     uint  false  "Return items older than this activity ID"
+// @Param        limit   query     int   false  "Page size"  default(20)
+// @Success      200     {array}   dto.FeedItemResponse
+// @Failure      401     {object}  dto.MessageResponse
+// @Failure      500     {object}  dto.MessageResponse
+// @Router       /social/feed [get]
+func (h *SocialHandler) GetFeed(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	before := parseUint(c.Query("before"), 0)
+	limit := max(1, min(parseInt(c.Query("limit"), 20), 100))
+
+	items, err := h.svc.GetFeed(c.Request.Context(), userID, before, int(limit))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, toFeedItemResponses(items))
+}
+
+// GetUserActivities godoc
+// @Summary      List a user's activities
+// @Description  Only activities visible to the caller under the user's privacy settings are returned.
+// @Tags         social
+// @Security     BearerAuth
+// @Produce      json
+// @Param        userID  path      uint  true   "User ID"
+// @Param        before  query     uint  false  "Return items older than this activity ID"
+// @Param        limit   query     int   false  "Page size"  default(20)
+// @Success      200     {array}   dto.FeedItemResponse
+// @Failure      400     {object}  dto.MessageResponse
+// @Failure      401     {object}  dto.MessageResponse
+// @Failure      500     {object}  dto.MessageResponse
+// @Router       /social/users/{userID}/activities [get]
+func (h *SocialHandler) GetUserActivities(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	actorID := parseUint(c.Param("userID"), 0)
+	if actorID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User ID is required"})
+		return
+	}
+	before := parseUint(c.Query("before"), 0)
+	limit := max(1, min(parseInt(c.Query("limit"), 20), 100))
+
+	items, err := h.svc.GetUserActivities(c.Request.Context(), userID, actorID, before, int(limit))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, toFeedItemResponses(items))
+}
+
+// React godoc
+// @Summary      React to an activity
+// @Description  Replaces the caller's previous reaction, if any.
+// @Tags         social
+// @Security     BearerAuth
+// @Accept       json
+// @Param        id    path      uint                 true  "Activity ID"
+// @Param        body  body      dto.ReactionRequest  true  "Reaction"
+// @Success      204   {string}  string  "No Content"
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /social/activities/{id}/reaction [put]
+func (h *SocialHandler) React(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Activity ID is required"})
+		return
+	}
+
+	var req dto.ReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.React(c.Request.Context(), userID, id, req.Kind); err != nil {
+		socialError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Unreact godoc
+// @Summary      Remove my reaction from an activity
+// @Tags         social
+// @Security     BearerAuth
+// @Param        id   path      uint  true  "Activity ID"
+// @Success      204  {string}  string  "No Content"
+// @Failure      400  {object}  dto.MessageResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      404  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /social/activities/{id}/reaction [delete]
+func (h *SocialHandler) Unreact(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Activity ID is required"})
+		return
+	}
+
+	if err := h.svc.Unreact(c.Request.Context(), userID, id); err != nil {
+		socialError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetComments godoc
+// @Summary      List comments on an activity
+// @Tags         social
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      uint  true  "Activity ID"
+// @Success      200  {array}   dto.ActivityCommentResponse
+// @Failure      400  {object}  dto.MessageResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      404  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /social/activities/{id}/comments [get]
+func (h *SocialHandler) GetComments(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Activity ID is required"})
+		return
+	}
+
+	comments, err := h.svc.GetComments(c.Request.Context(), userID, id)
+	if err != nil {
+		socialError(c, err)
+		return
+	}
+
+	resp := make([]dto.ActivityCommentResponse, 0, len(comments))
+	for _, cm := range comments {
+		resp = append(resp, dto.ToActivityCommentResponse(cm))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// AddComment godoc
+// @Summary      Comment on an activity
+// @Tags         social
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id    path      uint                        true  "Activity ID"
+// @Param        body  body      dto.ActivityCommentRequest  true  "Comment"
+// @Success      201   {object}  dto.ActivityCommentResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /social/activities/{id}/comments [post]
+func (h *SocialHandler) AddComment(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Activity ID is required"})
+		return
+	}
+
+	var req dto.ActivityCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := h.svc.AddComment(c.Request.Context(), userID, id, req.Body)
+	if err != nil {
+		socialError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.ToActivityCommentResponse(comment))
+}
+
+// DeleteComment godoc
+// @Summary      Delete a comment
+// @Description  Authors can delete their own comments; activity owners can delete any comment on their activity.
+// @Tags         social
+// @Security     BearerAuth
+// @Param        id   path      uint  true  "Comment ID"
+// @Success      204  {string}  string  "No Content"
+// @Failure      400  {object}  dto.MessageResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      404  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /social/comments/{id} [delete]
+func (h *SocialHandler) DeleteComment(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Comment ID is required"})
+		return
+	}
+
+	if err := h.svc.DeleteComment(c.Request.Context(), userID, id); err != nil {
+		socialError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetShares godoc
+// @Summary      List my active workout share links
+// @Tags         social
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}   dto.WorkoutShareResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /social/shares [get]
+func (h *SocialHandler) GetShares(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	shares, err := h.svc.ListShares(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]dto.WorkoutShareResponse, 0, len(shares))
+	for _, s := range shares {
+		resp = append(resp, dto.ToWorkoutShareResponse(s))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// ShareWorkout godoc
+// @Summary      Create a public share link for a completed workout
+// @Description  Requires allow_workout_sharing in user settings. Returns the existing link if the workout is already shared.
+// @Tags         social
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.CreateWorkoutShareRequest  true  "Workout to share"
+// @Success      200   {object}  dto.WorkoutShareResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      403   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      409   {object}  dto.MessageResponse
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /social/shares [post]
+func (h *SocialHandler) ShareWorkout(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+
+	var req dto.CreateWorkoutShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	share, err := h.svc.ShareWorkout(c.Request.Context(), userID, req.WorkoutID)
+	if err != nil {
+		socialError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToWorkoutShareResponse(share))
+}
+
+// RevokeShare godoc
+// @Summary      Revoke a workout share link
+// @Tags         social
+// @Security     BearerAuth
+// @Param        id   path      uint  true  "Share ID"
+// @Success      204  {string}  string  "No Content"
+// @Failure      400  {object}  dto.MessageResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      404  {object}  dto.MessageResponse
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /social/shares/{id} [delete]
+func (h *SocialHandler) RevokeShare(c *gin.Context) {
+	userID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Share ID is required"})
+		return
+	}
+
+	if err := h.svc.RevokeShare(c.Request.Context(), userID, id); err != nil {
+		socialError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetSharedWorkout godoc
+// @Summary      View a shared workout
+// @Description  Public, read-only. No authentication required.
+// @Tags         social
+// @Produce      json
+// @Param        token  path      string  true  "Share token"
+// @Success      200    {object}  dto.SharedWorkoutResponse
+// @Failure      404    {object}  dto.MessageResponse
+// @Failure      429    {object}  dto.MessageResponse
+// @Failure      500    {object}  dto.MessageResponse
+// @Router       /shared/workouts/{token} [get]
+func (h *SocialHandler) GetSharedWorkout(c *gin.Context) {
+	share, w, err := h.svc.GetSharedWorkout(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		socialError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ToSharedWorkoutResponse(share, w))
+}
+
+func toFeedItemResponses(items []*social.FeedItem) []dto.FeedItemResponse {
+	resp := make([]dto.FeedItemResponse, 0, len(items))
+	for _, item := range items {
+		resp = append(resp, dto.ToFeedItemResponse(item))
+	}
+	return resp
+}
+
+func socialError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, custom_err.ErrNotFound), errors.Is(err, custom_err.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, custom_err.ErrCannotFollowSelf), errors.Is(err, custom_err.ErrInvalidReaction):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, custom_err.ErrSharingDisabled):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, custom_err.ErrWorkoutNotCompleted):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
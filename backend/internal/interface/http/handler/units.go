@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/shared/units"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/dto"
+)
+
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterCustomTypeFunc(dto.QuantityValue, dto.Mass{}, dto.Length{})
+	}
+}
+
+// unitSystem is the system the client asked for with Accept-Units.
+func unitSystem(c *gin.Context) units.System {
+	return units.FromContext(c.Request.Context())
+}
+
+// jsonWithUnits renders obj with its weights and lengths in the request's unit system.
+func jsonWithUnits[T any](c *gin.Context, code int, obj T) {
+	s := unitSystem(c)
+	c.Header("Content-Units", string(s))
+	c.JSON(code, dto.WithUnits(obj, s))
+}
+
+// bindJSONWithUnits decodes a body whose quantities are in the request's unit
+// system, then validates it in grams and millimetres like ShouldBindJSON would.
+func bindJSONWithUnits(c *gin.Context, obj any) error {
+	if err := json.NewDecoder(c.Request.Body).Decode(obj); err != nil {
+		return err
+	}
+	dto.ReadUnits(obj, unitSystem(c))
+	return binding.Validator.ValidateStruct(obj)
+}
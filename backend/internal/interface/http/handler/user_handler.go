@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/auth"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
 	"github.com/lordmitrii/golang-web-gin/internal/interface/http/dto"
 	"github.com/lordmitrii/golang-web-gin/internal/interface/http/middleware"
@@ -12,11 +15,12 @@ import (
 )
 
 type UserHandler struct {
-	svc usecase.UserService
+	svc   usecase.UserService
+	guard usecase.LoginGuard
 }
 
-func NewUserHandler(r *gin.RouterGroup, svc usecase.UserService) {
-	h := &UserHandler{svc: svc}
+func NewUserHandler(r *gin.RouterGroup, svc usecase.UserService, guard usecase.LoginGuard, tokens usecase.PersonalTokenService, rbacService usecase.RBACService, rateLimiter usecase.RateLimiter) {
+	h := &UserHandler{svc: svc, guard: guard}
 	us := r.Group("/users")
 	{
 		us.POST("/register", h.Register)
@@ -25,24 +29,27 @@ func NewUserHandler(r *gin.RouterGroup, svc usecase.UserService) {
 		us.POST("/refresh", h.RefreshToken)
 
 		protected := us.Group("/")
-		protected.Use(middleware.JWTMiddleware())
+		protected.Use(middleware.AuthMiddleware(tokens, rateLimiter))
+		protected.Use(middleware.RequireTokenScope(rbacService, rbac.PermUserReadSelf, rbac.PermUserWriteSelf))
 		{
 			protected.GET("/me", h.Me)
-			protected.PATCH("/accounts", h.UpdateAccount)
+			protected.PATCH("/accounts", middleware.RejectPersonalToken(), h.UpdateAccount)
 
+			// user:write:self lets a token update the profile; deleting it, consents and
+			// settings need an interactive session.
 			protected.POST("/profile", h.CreateProfile)
 			protected.GET("/profile", h.GetProfile)
 			protected.PUT("/profile", h.UpdateProfile)
-			protected.DELETE("/profile", h.DeleteProfile)
+			protected.DELETE("/profile", middleware.RejectPersonalToken(), h.DeleteProfile)
 
 			protected.GET("/consents", h.GetConsents)
-			protected.POST("/consents", h.CreateConsent)
-			protected.DELETE("/consents", h.DeleteConsent)
+			protected.POST("/consents", middleware.RejectPersonalToken(), h.CreateConsent)
+			protected.DELETE("/consents", middleware.RejectPersonalToken(), h.DeleteConsent)
 
 			protected.GET("/settings", h.GetUserSettings)
-			protected.PATCH("/settings", h.UpdateUserSettings)
-			protected.POST("/settings", h.CreateUserSettings)
-			protected.DELETE("/settings", h.DeleteUserSettings)
+			protected.PATCH("/settings", middleware.RejectPersonalToken(), h.UpdateUserSettings)
+			protected.POST("/settings", middleware.RejectPersonalToken(), h.CreateUserSettings)
+			protected.DELETE("/settings", middleware.RejectPersonalToken(), h.DeleteUserSettings)
 		}
 	}
 }
@@ -90,6 +97,7 @@ func (h *UserHandler) Register(c *gin.Context) {
 // @Header       200   {string}  Set-Cookie  "refresh_token cookie (HttpOnly)"
 // @Failure      400   {object}  dto.MessageResponse
 // @Failure      401   {object}  dto.MessageResponse "Invalid credentials"
+// @Failure      429   {object}  dto.MessageResponse "Too many failed attempts or account locked"
 // @Failure      500   {object}  dto.MessageResponse
 // @Router       /users/login [post]
 func (h *UserHandler) Login(c *gin.Context) {
@@ -99,11 +107,26 @@ func (h *UserHandler) Login(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	user, err := h.svc.Authenticate(c.Request.Context(), req.Username, req.Password)
+
+	ctx := c.Request.Context()
+	if wait, err := h.guard.Check(ctx, auth.ScopeLogin, req.Username, c.ClientIP()); err != nil {
+		abortAttemptGuard(c, wait, err)
+		return
+	}
+
+	user, err := h.svc.Authenticate(ctx, req.Username, req.Password)
 	if err != nil {
+		wait, gerr := h.guard.Fail(ctx, auth.ScopeLogin, req.Username, c.ClientIP(), c.Request.UserAgent())
+		if gerr != nil {
+			log.Printf("login guard: %v", gerr)
+		}
+		setRetryAfter(c, wait)
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
 		return
 	}
+	if err := h.guard.Reset(ctx, auth.ScopeLogin, req.Username); err != nil {
+		log.Printf("login guard: %v", err)
+	}
 
 	accessToken, err := middleware.GenerateToken(user.ID)
 	if err != nil {
@@ -211,7 +234,7 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 		c.Status(http.StatusNoContent)
 		return
 	}
-	c.JSON(http.StatusOK, dto.ToProfileResponse(p))
+	jsonWithUnits(c, http.StatusOK, dto.ToProfileResponse(p))
 }
 
 // UpdateAccount godoc
@@ -256,6 +279,7 @@ func (h *UserHandler) UpdateAccount(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        body  body      dto.ProfileCreateRequest  true  "Profile payload"
+// @Param        Accept-Units header    string  false  "metric, imperial or user; defaults to grams and millimetres"
 // @Success      201   {object}  dto.ProfileResponse
 // @Failure      400   {object}  dto.MessageResponse
 // @Failure      401   {object}  dto.MessageResponse
@@ -268,7 +292,7 @@ func (h *UserHandler) CreateProfile(c *gin.Context) {
 	}
 
 	var req dto.ProfileCreateRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSONWithUnits(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -276,8 +300,8 @@ func (h *UserHandler) CreateProfile(c *gin.Context) {
 	p := &user.Profile{
 		UserID: userID,
 		Age:    req.Age,
-		Height: req.Height,
-		Weight: req.Weight,
+		Height: req.Height.Millimetres(),
+		Weight: req.Weight.Grams(),
 		Sex:    req.Sex,
 	}
 
@@ -285,7 +309,7 @@ func (h *UserHandler) CreateProfile(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusCreated, dto.ToProfileResponse(p))
+	jsonWithUnits(c, http.StatusCreated, dto.ToProfileResponse(p))
 }
 
 // UpdateProfile godoc
@@ -295,6 +319,7 @@ func (h *UserHandler) CreateProfile(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        body  body      dto.ProfileUpdateRequest  true  "Fields to update"
+// @Param        Accept-Units header    string  false  "metric, imperial or user; defaults to grams and millimetres"
 // @Success      200   {object}  dto.ProfileResponse
 // @Failure      400   {object}  dto.MessageResponse
 // @Failure      401   {object}  dto.MessageResponse
@@ -307,7 +332,7 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 	}
 
 	var req dto.ProfileUpdateRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSONWithUnits(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -319,7 +344,7 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, dto.ToProfileResponse(p))
+	jsonWithUnits(c, http.StatusOK, dto.ToProfileResponse(p))
 }
 
 // DeleteProfile godoc
@@ -601,6 +626,9 @@ func (h *UserHandler) CreateUserSettings(c *gin.Context) {
 		UnitSystem:         req.UnitSystem,
 		BetaOptIn:          req.BetaOptIn,
 		EmailNotifications: req.EmailNotifications,
+
+		ActivityVisibility:  req.ActivityVisibility,
+		AllowWorkoutSharing: req.AllowWorkoutSharing,
 	}
 
 	if err := h.svc.CreateUserSettings(c.Request.Context(), settings); err != nil {
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/interface/http/dto"
+)
+
+// GetWorkoutComments godoc
+// @Summary      List workout comments
+// @Description  Comments left by the owner and their coaches. Also available under /clients/{clientID}.
+// @Tags         workouts
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id         path      uint  true  "Workout Plan ID" example(1)
+// @Param        cycleID    path      uint  true  "Cycle ID"        example(12)
+// @Param        workoutID  path      uint  true  "Workout ID"      example(100)
+// @Success      200        {array}   dto.WorkoutCommentResponse
+// @Failure      400        {object}  dto.MessageResponse
+// @Failure      401        {object}  dto.MessageResponse
+// @Failure      404        {object}  dto.MessageResponse
+// @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/comments [get]
+func (h *WorkoutHandler) GetWorkoutComments(c *gin.Context) {
+	ownerID, exists := subjectUserID(c)
+	if !exists {
+		return
+	}
+	planID := parseUint(c.Param("id"), 0)
+	cycleID := parseUint(c.Param("cycleID"), 0)
+	workoutID := parseUint(c.Param("workoutID"), 0)
+	if planID == 0 || cycleID == 0 || workoutID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "IDs are required"})
+		return
+	}
+
+	comments, err := h.coaching.GetComments(c.Request.Context(), ownerID, planID, cycleID, workoutID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+		return
+	}
+
+	resp := make([]dto.WorkoutCommentResponse, 0, len(comments))
+	for _, cm := range comments {
+		resp = append(resp, dto.ToWorkoutCommentResponse(cm))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// AddWorkoutComment godoc
+// @Summary      Comment on a workout
+// @Tags         workouts
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id         path      uint                       true  "Workout Plan ID" example(1)
+// @Param        cycleID    path      uint                       true  "Cycle ID"        example(12)
+// @Param        workoutID  path      uint                       true  "Workout ID"      example(100)
+// @Param        body       body      dto.WorkoutCommentRequest  true  "Comment"
+// @Success      201        {object}  dto.WorkoutCommentResponse
+// @Failure      400        {object}  dto.MessageResponse
+// @Failure      401        {object}  dto.MessageResponse
+// @Failure      404        {object}  dto.MessageResponse
+// @Failure      500        {object}  dto.MessageResponse
+// @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/comments [post]
+func (h *WorkoutHandler) AddWorkoutComment(c *gin.Context) {
+	authorID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	ownerID, _ := subjectUserID(c)
+	planID := parseUint(c.Param("id"), 0)
+	cycleID := parseUint(c.Param("cycleID"), 0)
+	workoutID := parseUint(c.Param("workoutID"), 0)
+	if planID == 0 || cycleID == 0 || workoutID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "IDs are required"})
+		return
+	}
+
+	var req dto.WorkoutCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := h.coaching.AddComment(c.Request.Context(), authorID, ownerID, planID, cycleID, workoutID, req.Body)
+	if err != nil {
+		if errors.Is(err, custom_err.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, dto.ToWorkoutCommentResponse(comment))
+}
+
+// DeleteWorkoutComment godoc
+// @Summary      Delete a workout comment
+// @Description  Authors can delete their own comments; the workout owner can delete any.
+// @Tags         workouts
+// @Security     BearerAuth
+// @Param        id         path      uint  true  "Workout Plan ID" example(1)
+// @Param        cycleID    path      uint  true  "Cycle ID"        example(12)
+// @Param        workoutID  path      uint  true  "Workout ID"      example(100)
+// @Param        commentID  path      uint  true  "Comment ID"      example(12)
+// @Success      204        {string}  string  "No Content"
+// @Failure      400        {object}  dto.MessageResponse
+// @Failure      401        {object}  dto.MessageResponse
+// @Failure      404        {object}  dto.MessageResponse
+// @Failure      500        {object}  dto.MessageResponse
+// @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/comments/{commentID} [delete]
+func (h *WorkoutHandler) DeleteWorkoutComment(c *gin.Context) {
+	authorID, exists := currentUserID(c)
+	if !exists {
+		return
+	}
+	ownerID, _ := subjectUserID(c)
+	planID := parseUint(c.Param("id"), 0)
+	cycleID := parseUint(c.Param("cycleID"), 0)
+	workoutID := parseUint(c.Param("workoutID"), 0)
+	commentID := parseUint(c.Param("commentID"), 0)
+	if planID == 0 || cycleID == 0 || workoutID == 0 || commentID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "IDs are required"})
+		return
+	}
+
+	if err := h.coaching.DeleteComment(c.Request.Context(), authorID, ownerID, planID, cycleID, workoutID, commentID); err != nil {
+		if errors.Is(err, custom_err.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
 	"github.com/lordmitrii/golang-web-gin/internal/interface/http/dto"
 	"github.com/lordmitrii/golang-web-gin/internal/interface/http/middleware"
@@ -12,15 +15,25 @@ import (
 )
 
 type WorkoutHandler struct {
-	svc usecase.WorkoutService
+	svc      usecase.WorkoutService
+	coaching usecase.CoachingService
 }
 
-func NewWorkoutHandler(r *gin.RouterGroup, svc usecase.WorkoutService) {
-	h := &WorkoutHandler{svc: svc}
+func NewWorkoutHandler(r *gin.RouterGroup, svc usecase.WorkoutService, coaching usecase.CoachingService, tokens usecase.PersonalTokenService, rbacService usecase.RBACService, rateLimiter usecase.RateLimiter) {
+	h := &WorkoutHandler{svc: svc, coaching: coaching}
 
 	auth := r.Group("")
-	auth.Use(middleware.JWTMiddleware())
+	auth.Use(middleware.AuthMiddleware(tokens, rateLimiter))
+	auth.Use(middleware.RequireTokenScope(rbacService, rbac.PermWorkoutReadSelf, rbac.PermWorkoutWriteSelf))
+	h.registerRoutes(auth)
+
+	// The same routes on behalf of a client, for coaches with an active relationship.
+	client := auth.Group("/clients/:clientID")
+	client.Use(middleware.RequireClientAccess(coaching, rbacService))
+	h.registerRoutes(client)
+}
 
+func (h *WorkoutHandler) registerRoutes(auth *gin.RouterGroup) {
 	ie := auth.Group("/individual-exercises")
 	{
 		ie.GET("", h.GetIndividualExercises)
@@ -38,6 +51,8 @@ func NewWorkoutHandler(r *gin.RouterGroup, svc usecase.WorkoutService) {
 		wp.PATCH("/:id", h.UpdateWorkoutPlan)
 		wp.DELETE("/:id", h.DeleteWorkoutPlan)
 		wp.PATCH("/:id/set-active", h.SetActiveWorkoutPlan)
+		wp.POST("/:id/accept", h.AcceptDraftWorkoutPlan)
+		wp.POST("/:id/discard", h.DiscardDraftWorkoutPlan)
 
 		wp.POST("/:id/workout-cycles", h.AddWorkoutCycleToWorkoutPlan)
 		wp.GET("/:id/workout-cycles", h.GetWorkoutCyclesByWorkoutPlanID)
@@ -57,6 +72,10 @@ func NewWorkoutHandler(r *gin.RouterGroup, svc usecase.WorkoutService) {
 		wp.PATCH("/:id/workout-cycles/:cycleID/workouts/:workoutID/update-complete", h.CompleteWorkout)
 		wp.POST("/:id/workout-cycles/:cycleID/workouts/:workoutID/move", h.MoveWorkout)
 
+		wp.GET("/:id/workout-cycles/:cycleID/workouts/:workoutID/comments", h.GetWorkoutComments)
+		wp.POST("/:id/workout-cycles/:cycleID/workouts/:workoutID/comments", h.AddWorkoutComment)
+		wp.DELETE("/:id/workout-cycles/:cycleID/workouts/:workoutID/comments/:commentID", h.DeleteWorkoutComment)
+
 		wp.POST("/:id/workout-cycles/:cycleID/workouts/:workoutID/workout-exercises", h.AddWorkoutExerciseToWorkout)
 		wp.GET("/:id/workout-cycles/:cycleID/workouts/:workoutID/workout-exercises", h.GetWorkoutExercisesByWorkoutID)
 
@@ -93,7 +112,7 @@ func NewWorkoutHandler(r *gin.RouterGroup, svc usecase.WorkoutService) {
 // @Failure      500   {object}  dto.MessageResponse
 // @Router       /workout-plans [post]
 func (h *WorkoutHandler) CreateWorkoutPlan(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -116,7 +135,7 @@ func (h *WorkoutHandler) CreateWorkoutPlan(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, dto.ToWorkoutPlanResponse(wp))
+	jsonWithUnits(c, http.StatusCreated, dto.ToWorkoutPlanResponse(wp))
 }
 
 // GetWorkoutPlan godoc
@@ -131,7 +150,7 @@ func (h *WorkoutHandler) CreateWorkoutPlan(c *gin.Context) {
 // @Failure      404  {object}  dto.MessageResponse
 // @Router       /workout-plans/{id} [get]
 func (h *WorkoutHandler) GetWorkoutPlan(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -146,7 +165,7 @@ func (h *WorkoutHandler) GetWorkoutPlan(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ToWorkoutPlanResponse(wp))
+	jsonWithUnits(c, http.StatusOK, dto.ToWorkoutPlanResponse(wp))
 }
 
 // GetWorkoutPlansByUserID godoc
@@ -159,7 +178,7 @@ func (h *WorkoutHandler) GetWorkoutPlan(c *gin.Context) {
 // @Failure      404  {object}  dto.MessageResponse
 // @Router       /workout-plans [get]
 func (h *WorkoutHandler) GetWorkoutPlansByUserID(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -174,7 +193,7 @@ func (h *WorkoutHandler) GetWorkoutPlansByUserID(c *gin.Context) {
 		resp = append(resp, dto.ToWorkoutPlanResponse(wp))
 	}
 
-	c.JSON(http.StatusOK, resp)
+	jsonWithUnits(c, http.StatusOK, resp)
 }
 
 // UpdateWorkoutPlan godoc
@@ -191,7 +210,7 @@ func (h *WorkoutHandler) GetWorkoutPlansByUserID(c *gin.Context) {
 // @Failure      500   {object}  dto.MessageResponse
 // @Router       /workout-plans/{id} [patch]
 func (h *WorkoutHandler) UpdateWorkoutPlan(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -214,7 +233,7 @@ func (h *WorkoutHandler) UpdateWorkoutPlan(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ToWorkoutPlanResponse(wp))
+	jsonWithUnits(c, http.StatusOK, dto.ToWorkoutPlanResponse(wp))
 }
 
 // DeleteWorkoutPlan godoc
@@ -229,7 +248,7 @@ func (h *WorkoutHandler) UpdateWorkoutPlan(c *gin.Context) {
 // @Failure      500  {object}  dto.MessageResponse
 // @Router       /workout-plans/{id} [delete]
 func (h *WorkoutHandler) DeleteWorkoutPlan(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -247,6 +266,7 @@ func (h *WorkoutHandler) DeleteWorkoutPlan(c *gin.Context) {
 
 // SetActiveWorkoutPlan godoc
 // @Summary      Set workout plan active/inactive
+// @Description  Drafts can't be activated here; accept them with /workout-plans/{id}/accept.
 // @Tags         workout-plans
 // @Security     BearerAuth
 // @Accept       json
@@ -256,10 +276,12 @@ func (h *WorkoutHandler) DeleteWorkoutPlan(c *gin.Context) {
 // @Success      200   {object}  dto.WorkoutPlanResponse
 // @Failure      400   {object}  dto.MessageResponse
 // @Failure      401   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      409   {object}  dto.MessageResponse "The plan is a draft"
 // @Failure      500   {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/set-active [patch]
 func (h *WorkoutHandler) SetActiveWorkoutPlan(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -275,10 +297,91 @@ func (h *WorkoutHandler) SetActiveWorkoutPlan(c *gin.Context) {
 	}
 	wp, err := h.svc.SetActiveWorkoutPlan(c.Request.Context(), userId, id, req.Active)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		draftError(c, err)
+		return
+	}
+	jsonWithUnits(c, http.StatusOK, dto.ToWorkoutPlanResponse(wp))
+}
+
+// AcceptDraftWorkoutPlan godoc
+// @Summary      Accept a draft workout plan
+// @Description  Keeps a generated draft as a regular plan, optionally making it the active one.
+// @Tags         workout-plans
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id    path      uint                               true   "Workout Plan ID" example(1)
+// @Param        body  body      dto.AcceptDraftWorkoutPlanRequest  false  "Whether to activate the plan"
+// @Success      200   {object}  dto.WorkoutPlanResponse
+// @Failure      400   {object}  dto.MessageResponse
+// @Failure      401   {object}  dto.MessageResponse
+// @Failure      404   {object}  dto.MessageResponse
+// @Failure      409   {object}  dto.MessageResponse  "Not a draft"
+// @Failure      500   {object}  dto.MessageResponse
+// @Router       /workout-plans/{id}/accept [post]
+func (h *WorkoutHandler) AcceptDraftWorkoutPlan(c *gin.Context) {
+	userId, exists := subjectUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Workout Plan ID is required"})
+		return
+	}
+	var req dto.AcceptDraftWorkoutPlanRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	wp, err := h.svc.AcceptDraftWorkoutPlan(c.Request.Context(), userId, id, req.Activate)
+	if err != nil {
+		draftError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, dto.ToWorkoutPlanResponse(wp))
+	jsonWithUnits(c, http.StatusOK, dto.ToWorkoutPlanResponse(wp))
+}
+
+// DiscardDraftWorkoutPlan godoc
+// @Summary      Discard a draft workout plan
+// @Tags         workout-plans
+// @Security     BearerAuth
+// @Param        id   path  uint  true  "Workout Plan ID" example(1)
+// @Success      204  {string}  string "No Content"
+// @Failure      400  {object}  dto.MessageResponse
+// @Failure      401  {object}  dto.MessageResponse
+// @Failure      404  {object}  dto.MessageResponse
+// @Failure      409  {object}  dto.MessageResponse  "Not a draft"
+// @Failure      500  {object}  dto.MessageResponse
+// @Router       /workout-plans/{id}/discard [post]
+func (h *WorkoutHandler) DiscardDraftWorkoutPlan(c *gin.Context) {
+	userId, exists := subjectUserID(c)
+	if !exists {
+		return
+	}
+	id := parseUint(c.Param("id"), 0)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Workout Plan ID is required"})
+		return
+	}
+	if err := h.svc.DiscardDraftWorkoutPlan(c.Request.Context(), userId, id); err != nil {
+		draftError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func draftError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, custom_err.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workout plan not found"})
+	case errors.Is(err, custom_err.ErrNotADraft), errors.Is(err, custom_err.ErrDraftPlan):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
 }
 
 // AddWorkoutCycleToWorkoutPlan godoc
@@ -295,7 +398,7 @@ func (h *WorkoutHandler) SetActiveWorkoutPlan(c *gin.Context) {
 // @Failure      500   {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles [post]
 func (h *WorkoutHandler) AddWorkoutCycleToWorkoutPlan(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -322,7 +425,7 @@ func (h *WorkoutHandler) AddWorkoutCycleToWorkoutPlan(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, dto.ToWorkoutCycleResponse(wc))
+	jsonWithUnits(c, http.StatusCreated, dto.ToWorkoutCycleResponse(wc))
 }
 
 // GetWorkoutCyclesByWorkoutPlanID godoc
@@ -337,7 +440,7 @@ func (h *WorkoutHandler) AddWorkoutCycleToWorkoutPlan(c *gin.Context) {
 // @Failure      404  {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles [get]
 func (h *WorkoutHandler) GetWorkoutCyclesByWorkoutPlanID(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -358,7 +461,7 @@ func (h *WorkoutHandler) GetWorkoutCyclesByWorkoutPlanID(c *gin.Context) {
 		resp = append(resp, dto.ToWorkoutCycleResponse(wc))
 	}
 
-	c.JSON(http.StatusOK, resp)
+	jsonWithUnits(c, http.StatusOK, resp)
 }
 
 // GetWorkoutCycleByID godoc
@@ -374,7 +477,7 @@ func (h *WorkoutHandler) GetWorkoutCyclesByWorkoutPlanID(c *gin.Context) {
 // @Failure      404  {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID} [get]
 func (h *WorkoutHandler) GetWorkoutCycleByID(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -390,7 +493,7 @@ func (h *WorkoutHandler) GetWorkoutCycleByID(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
 		return
 	}
-	c.JSON(http.StatusOK, dto.ToWorkoutCycleResponse(workoutCycle))
+	jsonWithUnits(c, http.StatusOK, dto.ToWorkoutCycleResponse(workoutCycle))
 }
 
 // UpdateWorkoutCycle godoc
@@ -408,7 +511,7 @@ func (h *WorkoutHandler) GetWorkoutCycleByID(c *gin.Context) {
 // @Failure      500      {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID} [patch]
 func (h *WorkoutHandler) UpdateWorkoutCycle(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -433,7 +536,7 @@ func (h *WorkoutHandler) UpdateWorkoutCycle(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ToWorkoutCycleResponse(wc))
+	jsonWithUnits(c, http.StatusOK, dto.ToWorkoutCycleResponse(wc))
 }
 
 // DeleteWorkoutCycle godoc
@@ -449,7 +552,7 @@ func (h *WorkoutHandler) UpdateWorkoutCycle(c *gin.Context) {
 // @Failure      500  {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID} [delete]
 func (h *WorkoutHandler) DeleteWorkoutCycle(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -482,7 +585,7 @@ func (h *WorkoutHandler) DeleteWorkoutCycle(c *gin.Context) {
 // @Failure      500      {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/update-complete [patch]
 func (h *WorkoutHandler) CompleteWorkoutCycle(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -506,7 +609,7 @@ func (h *WorkoutHandler) CompleteWorkoutCycle(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ToWorkoutCycleResponse(wc))
+	jsonWithUnits(c, http.StatusOK, dto.ToWorkoutCycleResponse(wc))
 }
 
 // AddWorkoutToWorkoutCycle godoc
@@ -524,7 +627,7 @@ func (h *WorkoutHandler) CompleteWorkoutCycle(c *gin.Context) {
 // @Failure      500      {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts [post]
 func (h *WorkoutHandler) AddWorkoutToWorkoutCycle(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -553,7 +656,7 @@ func (h *WorkoutHandler) AddWorkoutToWorkoutCycle(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, dto.ToWorkoutResponse(w))
+	jsonWithUnits(c, http.StatusCreated, dto.ToWorkoutResponse(w))
 }
 
 // GetWorkoutsByWorkoutCycleID godoc
@@ -569,7 +672,7 @@ func (h *WorkoutHandler) AddWorkoutToWorkoutCycle(c *gin.Context) {
 // @Failure      404      {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts [get]
 func (h *WorkoutHandler) GetWorkoutsByWorkoutCycleID(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -591,7 +694,7 @@ func (h *WorkoutHandler) GetWorkoutsByWorkoutCycleID(c *gin.Context) {
 		resp = append(resp, dto.ToWorkoutResponse(w))
 	}
 
-	c.JSON(http.StatusOK, resp)
+	jsonWithUnits(c, http.StatusOK, resp)
 }
 
 // CreateMultipleWorkouts godoc
@@ -609,7 +712,7 @@ func (h *WorkoutHandler) GetWorkoutsByWorkoutCycleID(c *gin.Context) {
 // @Failure      500      {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/create-multiple [post]
 func (h *WorkoutHandler) CreateMultipleWorkouts(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -669,7 +772,7 @@ func (h *WorkoutHandler) CreateMultipleWorkouts(c *gin.Context) {
 // @Failure      404  {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID} [get]
 func (h *WorkoutHandler) GetWorkoutByID(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -686,7 +789,7 @@ func (h *WorkoutHandler) GetWorkoutByID(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
 		return
 	}
-	c.JSON(http.StatusOK, dto.ToWorkoutResponse(workout))
+	jsonWithUnits(c, http.StatusOK, dto.ToWorkoutResponse(workout))
 }
 
 // UpdateWorkout godoc
@@ -705,7 +808,7 @@ func (h *WorkoutHandler) GetWorkoutByID(c *gin.Context) {
 // @Failure      500        {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID} [patch]
 func (h *WorkoutHandler) UpdateWorkout(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -731,7 +834,7 @@ func (h *WorkoutHandler) UpdateWorkout(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ToWorkoutResponse(w))
+	jsonWithUnits(c, http.StatusOK, dto.ToWorkoutResponse(w))
 }
 
 // DeleteWorkout godoc
@@ -748,7 +851,7 @@ func (h *WorkoutHandler) UpdateWorkout(c *gin.Context) {
 // @Failure      500  {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID} [delete]
 func (h *WorkoutHandler) DeleteWorkout(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -783,7 +886,7 @@ func (h *WorkoutHandler) DeleteWorkout(c *gin.Context) {
 // @Failure      500        {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/update-complete [patch]
 func (h *WorkoutHandler) CompleteWorkout(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -807,7 +910,7 @@ func (h *WorkoutHandler) CompleteWorkout(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ToWorkoutCompleteResponse(w, kcal))
+	jsonWithUnits(c, http.StatusOK, dto.ToWorkoutCompleteResponse(w, kcal))
 }
 
 // MoveWorkout godoc
@@ -826,7 +929,7 @@ func (h *WorkoutHandler) CompleteWorkout(c *gin.Context) {
 // @Failure      500        {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/move [post]
 func (h *WorkoutHandler) MoveWorkout(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -867,7 +970,7 @@ func (h *WorkoutHandler) MoveWorkout(c *gin.Context) {
 // @Failure      500        {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/workout-exercises [post]
 func (h *WorkoutHandler) AddWorkoutExerciseToWorkout(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -898,7 +1001,7 @@ func (h *WorkoutHandler) AddWorkoutExerciseToWorkout(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, dto.ToWorkoutExerciseResponse(we))
+	jsonWithUnits(c, http.StatusCreated, dto.ToWorkoutExerciseResponse(we))
 }
 
 // GetWorkoutExercisesByWorkoutID godoc
@@ -915,7 +1018,7 @@ func (h *WorkoutHandler) AddWorkoutExerciseToWorkout(c *gin.Context) {
 // @Failure      404  {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/workout-exercises [get]
 func (h *WorkoutHandler) GetWorkoutExercisesByWorkoutID(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -938,7 +1041,7 @@ func (h *WorkoutHandler) GetWorkoutExercisesByWorkoutID(c *gin.Context) {
 		resp = append(resp, dto.ToWorkoutExerciseResponse(ex))
 	}
 
-	c.JSON(http.StatusOK, resp)
+	jsonWithUnits(c, http.StatusOK, resp)
 }
 
 // GetWorkoutExerciseByID godoc
@@ -956,7 +1059,7 @@ func (h *WorkoutHandler) GetWorkoutExercisesByWorkoutID(c *gin.Context) {
 // @Failure      404  {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/workout-exercises/{weID} [get]
 func (h *WorkoutHandler) GetWorkoutExerciseByID(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -974,7 +1077,7 @@ func (h *WorkoutHandler) GetWorkoutExerciseByID(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
 		return
 	}
-	c.JSON(http.StatusOK, dto.ToWorkoutExerciseResponse(exercise))
+	jsonWithUnits(c, http.StatusOK, dto.ToWorkoutExerciseResponse(exercise))
 }
 
 // UpdateWorkoutExercise godoc
@@ -994,7 +1097,7 @@ func (h *WorkoutHandler) GetWorkoutExerciseByID(c *gin.Context) {
 // @Failure      500        {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/workout-exercises/{weID} [patch]
 func (h *WorkoutHandler) UpdateWorkoutExercise(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -1022,7 +1125,7 @@ func (h *WorkoutHandler) UpdateWorkoutExercise(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ToWorkoutExerciseResponse(we))
+	jsonWithUnits(c, http.StatusOK, dto.ToWorkoutExerciseResponse(we))
 }
 
 // CompleteWorkoutExercise godoc
@@ -1042,7 +1145,7 @@ func (h *WorkoutHandler) UpdateWorkoutExercise(c *gin.Context) {
 // @Failure      500        {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/workout-exercises/{weID}/update-complete [patch]
 func (h *WorkoutHandler) CompleteWorkoutExercise(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -1066,7 +1169,7 @@ func (h *WorkoutHandler) CompleteWorkoutExercise(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ToWorkoutExerciseCompleteResponse(we, kcal))
+	jsonWithUnits(c, http.StatusOK, dto.ToWorkoutExerciseCompleteResponse(we, kcal))
 }
 
 // MoveWorkoutExercise godoc
@@ -1086,7 +1189,7 @@ func (h *WorkoutHandler) CompleteWorkoutExercise(c *gin.Context) {
 // @Failure      500        {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/workout-exercises/{weID}/move [post]
 func (h *WorkoutHandler) MoveWorkoutExercise(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -1130,7 +1233,7 @@ func (h *WorkoutHandler) MoveWorkoutExercise(c *gin.Context) {
 // @Failure      500        {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/workout-exercises/{weID}/replace [post]
 func (h *WorkoutHandler) ReplaceWorkoutExercise(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -1156,7 +1259,7 @@ func (h *WorkoutHandler) ReplaceWorkoutExercise(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ToWorkoutExerciseResponse(we))
+	jsonWithUnits(c, http.StatusOK, dto.ToWorkoutExerciseResponse(we))
 }
 
 // DeleteWorkoutExercise godoc
@@ -1174,7 +1277,7 @@ func (h *WorkoutHandler) ReplaceWorkoutExercise(c *gin.Context) {
 // @Failure      500  {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/workout-exercises/{weID} [delete]
 func (h *WorkoutHandler) DeleteWorkoutExercise(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -1192,7 +1295,7 @@ func (h *WorkoutHandler) DeleteWorkoutExercise(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, dto.ToWorkoutExerciseDeleteResponse(resKcal))
+	jsonWithUnits(c, http.StatusOK, dto.ToWorkoutExerciseDeleteResponse(resKcal))
 }
 
 // AddWorkoutSetToWorkoutExercise godoc
@@ -1206,13 +1309,14 @@ func (h *WorkoutHandler) DeleteWorkoutExercise(c *gin.Context) {
 // @Param        workoutID  path      uint                         true  "Workout ID"      example(100)
 // @Param        weID       path      uint                         true  "Workout Exercise ID" example(500)
 // @Param        body       body      dto.WorkoutSetCreateRequest  true  "Set payload"
+// @Param        Accept-Units header    string  false  "metric, imperial or user; defaults to grams and millimetres"
 // @Success      201        {object}  dto.WorkoutSetResponse
 // @Failure      400        {object}  dto.MessageResponse
 // @Failure      401        {object}  dto.MessageResponse
 // @Failure      500        {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/workout-exercises/{weID}/workout-sets [post]
 func (h *WorkoutHandler) AddWorkoutSetToWorkoutExercise(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -1226,7 +1330,7 @@ func (h *WorkoutHandler) AddWorkoutSetToWorkoutExercise(c *gin.Context) {
 	}
 
 	var req dto.WorkoutSetCreateRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSONWithUnits(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -1234,9 +1338,9 @@ func (h *WorkoutHandler) AddWorkoutSetToWorkoutExercise(c *gin.Context) {
 	ws := &workout.WorkoutSet{
 		WorkoutExerciseID: id,
 		Index:             req.Index,
-		Weight:            req.Weight,
+		Weight:            req.Weight.GramsPtr(),
 		Reps:              req.Reps,
-		PreviousWeight:    req.PreviousWeight,
+		PreviousWeight:    req.PreviousWeight.GramsPtr(),
 		PreviousReps:      req.PreviousReps,
 	}
 
@@ -1245,7 +1349,7 @@ func (h *WorkoutHandler) AddWorkoutSetToWorkoutExercise(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, dto.ToWorkoutSetResponse(ws))
+	jsonWithUnits(c, http.StatusCreated, dto.ToWorkoutSetResponse(ws))
 }
 
 // GetWorkoutSetsByWorkoutExerciseID godoc
@@ -1263,7 +1367,7 @@ func (h *WorkoutHandler) AddWorkoutSetToWorkoutExercise(c *gin.Context) {
 // @Failure      404  {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/workout-exercises/{weID}/workout-sets [get]
 func (h *WorkoutHandler) GetWorkoutSetsByWorkoutExerciseID(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -1286,7 +1390,7 @@ func (h *WorkoutHandler) GetWorkoutSetsByWorkoutExerciseID(c *gin.Context) {
 	for _, set := range sets {
 		resp = append(resp, dto.ToWorkoutSetResponse(set))
 	}
-	c.JSON(http.StatusOK, resp)
+	jsonWithUnits(c, http.StatusOK, resp)
 }
 
 // DeleteWorkoutSet godoc
@@ -1305,7 +1409,7 @@ func (h *WorkoutHandler) GetWorkoutSetsByWorkoutExerciseID(c *gin.Context) {
 // @Failure      500  {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/workout-exercises/{weID}/workout-sets/{setID} [delete]
 func (h *WorkoutHandler) DeleteWorkoutSet(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -1324,7 +1428,7 @@ func (h *WorkoutHandler) DeleteWorkoutSet(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, dto.ToSetDeleteResponse(resKcal))
+	jsonWithUnits(c, http.StatusOK, dto.ToSetDeleteResponse(resKcal))
 }
 
 // GetWorkoutSetByID godoc
@@ -1343,7 +1447,7 @@ func (h *WorkoutHandler) DeleteWorkoutSet(c *gin.Context) {
 // @Failure      404  {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/workout-exercises/{weID}/workout-sets/{setID} [get]
 func (h *WorkoutHandler) GetWorkoutSetByID(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -1363,7 +1467,7 @@ func (h *WorkoutHandler) GetWorkoutSetByID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ToWorkoutSetResponse(set))
+	jsonWithUnits(c, http.StatusOK, dto.ToWorkoutSetResponse(set))
 }
 
 // UpdateWorkoutSet godoc
@@ -1378,13 +1482,14 @@ func (h *WorkoutHandler) GetWorkoutSetByID(c *gin.Context) {
 // @Param        weID       path      uint                        true  "Workout Exercise ID" example(500)
 // @Param        setID      path      uint                        true  "Set ID"          example(700)
 // @Param        body       body      dto.WorkoutSetUpdateRequest true  "Fields to update"
+// @Param        Accept-Units header    string  false  "metric, imperial or user; defaults to grams and millimetres"
 // @Success      200        {object}  dto.WorkoutSetResponse
 // @Failure      400        {object}  dto.MessageResponse
 // @Failure      401        {object}  dto.MessageResponse
 // @Failure      500        {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/workout-exercises/{weID}/workout-sets/{setID} [patch]
 func (h *WorkoutHandler) UpdateWorkoutSet(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -1399,7 +1504,7 @@ func (h *WorkoutHandler) UpdateWorkoutSet(c *gin.Context) {
 	}
 
 	var req dto.WorkoutSetUpdateRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSONWithUnits(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -1412,7 +1517,7 @@ func (h *WorkoutHandler) UpdateWorkoutSet(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ToWorkoutSetResponse(ws))
+	jsonWithUnits(c, http.StatusOK, dto.ToWorkoutSetResponse(ws))
 }
 
 // CompleteWorkoutSet godoc
@@ -1433,7 +1538,7 @@ func (h *WorkoutHandler) UpdateWorkoutSet(c *gin.Context) {
 // @Failure      500        {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/workout-exercises/{weID}/workout-sets/{setID}/update-complete [patch]
 func (h *WorkoutHandler) CompleteWorkoutSet(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -1459,7 +1564,7 @@ func (h *WorkoutHandler) CompleteWorkoutSet(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ToWorkoutSetCompleteResponse(ws, kcal))
+	jsonWithUnits(c, http.StatusOK, dto.ToWorkoutSetCompleteResponse(ws, kcal))
 }
 
 // MoveWorkoutSet godoc
@@ -1480,7 +1585,7 @@ func (h *WorkoutHandler) CompleteWorkoutSet(c *gin.Context) {
 // @Failure      500        {object}  dto.MessageResponse
 // @Router       /workout-plans/{id}/workout-cycles/{cycleID}/workouts/{workoutID}/workout-exercises/{weID}/workout-sets/{setID}/move [post]
 func (h *WorkoutHandler) MoveWorkoutSet(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -1518,7 +1623,7 @@ func (h *WorkoutHandler) MoveWorkoutSet(c *gin.Context) {
 // @Failure      404  {object}  dto.MessageResponse
 // @Router       /individual-exercises [get]
 func (h *WorkoutHandler) GetIndividualExercises(c *gin.Context) {
-	userID, exists := currentUserID(c)
+	userID, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -1534,7 +1639,7 @@ func (h *WorkoutHandler) GetIndividualExercises(c *gin.Context) {
 		resp = append(resp, dto.ToIndividualExerciseResponse(e))
 	}
 
-	c.JSON(http.StatusOK, resp)
+	jsonWithUnits(c, http.StatusOK, resp)
 }
 
 // GetOrCreateIndividualExercise godoc
@@ -1550,7 +1655,7 @@ func (h *WorkoutHandler) GetIndividualExercises(c *gin.Context) {
 // @Failure      500   {object}  dto.MessageResponse
 // @Router       /individual-exercises [post]
 func (h *WorkoutHandler) GetOrCreateIndividualExercise(c *gin.Context) {
-	userId, exists := currentUserID(c)
+	userId, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -1575,7 +1680,7 @@ func (h *WorkoutHandler) GetOrCreateIndividualExercise(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, dto.ToIndividualExerciseResponse(ie))
+	jsonWithUnits(c, http.StatusOK, dto.ToIndividualExerciseResponse(ie))
 }
 
 // GetIndividualExercisesStats godoc
@@ -1588,7 +1693,7 @@ func (h *WorkoutHandler) GetOrCreateIndividualExercise(c *gin.Context) {
 // @Failure      500  {object}  dto.MessageResponse
 // @Router       /individual-exercises/stats [get]
 func (h *WorkoutHandler) GetIndividualExercisesStats(c *gin.Context) {
-	userID, exists := currentUserID(c)
+	userID, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -1604,7 +1709,7 @@ func (h *WorkoutHandler) GetIndividualExercisesStats(c *gin.Context) {
 		resp = append(resp, dto.ToIndividualExerciseStatsResponse(s))
 	}
 
-	c.JSON(http.StatusOK, resp)
+	jsonWithUnits(c, http.StatusOK, resp)
 }
 
 // GetIndividualExercisePerformanceHistory godoc
@@ -1619,7 +1724,7 @@ func (h *WorkoutHandler) GetIndividualExercisesStats(c *gin.Context) {
 // @Failure      500  {object}  dto.MessageResponse
 // @Router       /individual-exercises/{id}/performance-history [get]
 func (h *WorkoutHandler) GetIndividualExercisePerformanceHistory(c *gin.Context) {
-	userID, exists := currentUserID(c)
+	userID, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -1637,7 +1742,7 @@ func (h *WorkoutHandler) GetIndividualExercisePerformanceHistory(c *gin.Context)
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ToExercisePerformanceResponses(history))
+	jsonWithUnits(c, http.StatusOK, dto.ToExercisePerformanceResponses(history))
 }
 
 // GetCurrentWorkoutCycle godoc
@@ -1650,7 +1755,7 @@ func (h *WorkoutHandler) GetIndividualExercisePerformanceHistory(c *gin.Context)
 // @Failure      404  {object}  dto.MessageResponse
 // @Router       /current-cycle [get]
 func (h *WorkoutHandler) GetCurrentWorkoutCycle(c *gin.Context) {
-	userID, exists := currentUserID(c)
+	userID, exists := subjectUserID(c)
 	if !exists {
 		return
 	}
@@ -1661,5 +1766,5 @@ func (h *WorkoutHandler) GetCurrentWorkoutCycle(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ToCurrentCycleResponse(cycle))
+	jsonWithUnits(c, http.StatusOK, dto.ToCurrentCycleResponse(cycle))
 }
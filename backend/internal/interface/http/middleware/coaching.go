@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+// RequireClientAccess lets a coach act on the user in the :clientID path parameter. The coach
+// needs workout:read:others for safe methods, workout:write:others otherwise, and an active
+// coaching relationship with the client. On success subjectUserID holds the client's ID.
+func RequireClientAccess(coachingService usecase.CoachingService, rbacService usecase.RBACService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clientID, err := strconv.ParseUint(c.Param("clientID"), 10, 64)
+		if err != nil || clientID == 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid client ID"})
+			return
+		}
+
+		perm := rbac.PermWorkoutWriteOthers
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			perm = rbac.PermWorkoutReadOthers
+		}
+		if !checkPerm(c, rbacService, perm) {
+			return
+		}
+
+		ok, err := coachingService.CanAccess(c.Request.Context(), c.GetUint("userID"), uint(clientID))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "no active coaching relationship"})
+			return
+		}
+
+		c.Set("subjectUserID", uint(clientID))
+		c.Next()
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
 )
 
 var jwtSecret = []byte(os.Getenv("JWT_SECRET"))
@@ -65,6 +66,7 @@ func JWTMiddleware() gin.HandlerFunc {
 		// pull the user ID into Gin’s context
 		claims := tok.Claims.(*Claims)
 		c.Set("userID", claims.UserID)
+		c.Request = c.Request.WithContext(audit.WithActor(c.Request.Context(), claims.UserID))
 		c.Next()
 	}
 }
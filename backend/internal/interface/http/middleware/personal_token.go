@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/auth"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+// AuthMiddleware accepts either a JWT or a personal access token in the “Authorization: Bearer …”
+// header. Personal tokens populate the same userID as JWTs, plus tokenID and tokenScopes, and are
+// rate limited per token.
+func AuthMiddleware(tokens usecase.PersonalTokenService, limiter usecase.RateLimiter) gin.HandlerFunc {
+	jwtAuth := JWTMiddleware()
+	return func(c *gin.Context) {
+		parts := strings.SplitN(c.GetHeader("Authorization"), " ", 2)
+		if len(parts) != 2 || parts[0] != "Bearer" || !auth.IsPersonalToken(parts[1]) {
+			jwtAuth(c)
+			return
+		}
+
+		t, err := tokens.Authenticate(c.Request.Context(), parts[1])
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			return
+		}
+
+		allowed, retryAfter, err := limiter.Allow(c.Request.Context(), fmt.Sprintf("rl:pat:%d", t.ID), t.RateLimitPerMinute, time.Minute)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Rate limit error"})
+			return
+		}
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Rate limit exceeded"})
+			return
+		}
+
+		c.Set("userID", t.UserID)
+		c.Set("tokenID", t.ID)
+		c.Set("tokenScopes", t.ScopeList())
+		c.Request = c.Request.WithContext(audit.WithActor(c.Request.Context(), t.UserID))
+		c.Next()
+	}
+}
+
+// RequireTokenScope limits personal-token requests to readPerm for safe methods and writePerm
+// otherwise. The token must carry the scope and the user must still hold the permission.
+// Requests authenticated with a JWT pass through untouched.
+func RequireTokenScope(rbacService usecase.RBACService, readPerm, writePerm string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get("tokenScopes"); !ok {
+			c.Next()
+			return
+		}
+
+		if !checkPerm(c, rbacService, methodScope(c.Request.Method, readPerm, writePerm)) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireGraphQLScope is RequireTokenScope for the GraphQL endpoint, where every request is
+// a POST: queries need readPerm and anything else, or a request that doesn't parse, writePerm.
+func RequireGraphQLScope(rbacService usecase.RBACService, readPerm, writePerm string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get("tokenScopes"); !ok {
+			c.Next()
+			return
+		}
+
+		var body struct {
+			Query         string `json:"query"`
+			OperationName string `json:"operationName"`
+		}
+		op := ""
+		if err := c.ShouldBindBodyWith(&body, binding.JSON); err == nil {
+			op = graphQLOperation(body.Query, body.OperationName)
+		}
+		perm := writePerm
+		if op == ast.OperationTypeQuery {
+			perm = readPerm
+		}
+		if !checkPerm(c, rbacService, perm) {
+			return
+		}
+		c.Next()
+	}
+}
+
+func methodScope(method, readPerm, writePerm string) string {
+	if method == http.MethodGet || method == http.MethodHead {
+		return readPerm
+	}
+	return writePerm
+}
+
+// graphQLOperation returns the type of the operation a GraphQL request runs: the one named,
+// or the only one in the document. It is empty when the document doesn't say.
+func graphQLOperation(query, operationName string) string {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return ""
+	}
+	var ops []*ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName == "" || (op.Name != nil && op.Name.Value == operationName) {
+			ops = append(ops, op)
+		}
+	}
+	if len(ops) != 1 {
+		return ""
+	}
+	return ops[0].Operation
+}
+
+func tokenHasScope(c *gin.Context, permKey string) bool {
+	v, ok := c.Get("tokenScopes")
+	if !ok {
+		return true
+	}
+	scopes, _ := v.([]string)
+	return slices.Contains(scopes, permKey)
+}
+
+// RejectPersonalToken guards routes that must only be reachable with an interactive session,
+// such as changing credentials.
+func RejectPersonalToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get("tokenID"); ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "not allowed with a personal access token"})
+			return
+		}
+		c.Next()
+	}
+}
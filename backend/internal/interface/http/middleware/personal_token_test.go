@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+const (
+	readPerm  = "workout:read:self"
+	writePerm = "workout:write:self"
+)
+
+// holdsAll is a user holding both permissions, so only the token's scopes decide.
+type holdsAll struct{ usecase.RBACService }
+
+func (holdsAll) GetUserPermissionKeys(context.Context, uint) ([]string, error) {
+	return []string{readPerm, writePerm}, nil
+}
+
+// serve runs a request through guard, as a personal token with the given scopes or, when
+// scopes is nil, as a JWT session.
+func serve(guard gin.HandlerFunc, method, body string, scopes []string) int {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Handle(method, "/", func(c *gin.Context) {
+		c.Set("userID", uint(1))
+		if scopes != nil {
+			c.Set("tokenID", uint(1))
+			c.Set("tokenScopes", scopes)
+		}
+	}, guard, func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(method, "/", strings.NewReader(body)))
+	return w.Code
+}
+
+func TestRequireTokenScope(t *testing.T) {
+	tests := []struct {
+		method string
+		scopes []string
+		want   int
+	}{
+		{http.MethodGet, []string{readPerm}, http.StatusOK},
+		{http.MethodHead, []string{readPerm}, http.StatusOK},
+		{http.MethodGet, []string{writePerm}, http.StatusForbidden},
+		{http.MethodPost, []string{readPerm}, http.StatusForbidden},
+		{http.MethodPost, []string{writePerm}, http.StatusOK},
+		{http.MethodPatch, []string{writePerm}, http.StatusOK},
+		{http.MethodDelete, []string{readPerm}, http.StatusForbidden},
+		{http.MethodDelete, nil, http.StatusOK},
+	}
+
+	guard := RequireTokenScope(holdsAll{}, readPerm, writePerm)
+	for _, tt := range tests {
+		t.Run(tt.method+" "+strings.Join(tt.scopes, ","), func(t *testing.T) {
+			if got := serve(guard, tt.method, "", tt.scopes); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireGraphQLScope(t *testing.T) {
+	const twoOps = `query Plans { workoutPlans { id } } mutation Rename { updateWorkoutPlan(id: 1, name: \"A\") { id } }`
+
+	tests := []struct {
+		name   string
+		body   string
+		scopes []string
+		want   int
+	}{
+		{"query with read scope", `{"query": "{ workoutPlans { id } }"}`, []string{readPerm}, http.StatusOK},
+		{"named query with read scope", `{"query": "query Plans { workoutPlans { id } }"}`, []string{readPerm}, http.StatusOK},
+		{"query with write scope only", `{"query": "{ workoutPlans { id } }"}`, []string{writePerm}, http.StatusForbidden},
+		{"mutation with read scope", `{"query": "mutation { deleteWorkoutPlan(id: 1) }"}`, []string{readPerm}, http.StatusForbidden},
+		{"mutation with write scope", `{"query": "mutation { deleteWorkoutPlan(id: 1) }"}`, []string{writePerm}, http.StatusOK},
+		{"selected query", `{"query": "` + twoOps + `", "operationName": "Plans"}`, []string{readPerm}, http.StatusOK},
+		{"selected mutation", `{"query": "` + twoOps + `", "operationName": "Rename"}`, []string{readPerm}, http.StatusForbidden},
+		{"no operation selected", `{"query": "` + twoOps + `"}`, []string{readPerm}, http.StatusForbidden},
+		{"unparsable query", `{"query": "{ workoutPlans {"}`, []string{readPerm}, http.StatusForbidden},
+		{"session", `{"query": "mutation { deleteWorkoutPlan(id: 1) }"}`, nil, http.StatusOK},
+	}
+
+	guard := RequireGraphQLScope(holdsAll{}, readPerm, writePerm)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serve(guard, http.MethodPost, tt.body, tt.scopes); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,14 +17,7 @@ func RateLimitMiddleware(limiter usecase.RateLimiter, perMinute int, scope strin
 			return
 		}
 
-		var who string
-		if v, exists := c.Get("userID"); exists {
-			who = fmt.Sprintf("user:%v", v)
-		} else {
-			who = fmt.Sprintf("ip:%s", c.ClientIP())
-		}
-
-		key := fmt.Sprintf("rl:%s:%s", scope, who)
+		key := fmt.Sprintf("rl:%s:%s", scope, rateLimitSubject(c))
 
 		allowed, retryAfter, err := limiter.Allow(c.Request.Context(), key, perMinute, time.Minute)
 		if err != nil {
@@ -43,3 +36,14 @@ func RateLimitMiddleware(limiter usecase.RateLimiter, perMinute int, scope strin
 		c.Next()
 	}
 }
+
+// rateLimitSubject identifies the caller: the personal token, else the user, else the IP.
+func rateLimitSubject(c *gin.Context) string {
+	if v, exists := c.Get("tokenID"); exists {
+		return fmt.Sprintf("token:%v", v)
+	}
+	if v, exists := c.Get("userID"); exists {
+		return fmt.Sprintf("user:%v", v)
+	}
+	return fmt.Sprintf("ip:%s", c.ClientIP())
+}
@@ -1,27 +1,55 @@
 package middleware
 
 import (
+	"net/http"
+	"slices"
+
 	"github.com/gin-gonic/gin"
 	"github.com/lordmitrii/golang-web-gin/internal/usecase"
-	"net/http"
 )
 
+// RequirePerm checks the user's permission and, for personal access tokens, the token's scopes.
 func RequirePerm(rbacService usecase.RBACService, permKey string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userID := c.GetUint("userID")
-		ok, err := rbacService.HasPermission(c.Request.Context(), userID, permKey)
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+		if !checkPerm(c, rbacService, permKey) {
 			return
 		}
 		c.Next()
 	}
 }
 
+// checkPerm aborts the request and returns false when the permission is missing.
+func checkPerm(c *gin.Context, rbacService usecase.RBACService, permKey string) bool {
+	if !tokenHasScope(c, permKey) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "token lacks required scope"})
+		return false
+	}
+	keys, err := requestPermKeys(c, rbacService)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if !slices.Contains(keys, permKey) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+		return false
+	}
+	return true
+}
+
+// requestPermKeys loads the user's permission keys once per request, so stacked RequirePerm
+// checks share a single lookup.
+func requestPermKeys(c *gin.Context, rbacService usecase.RBACService) ([]string, error) {
+	if v, ok := c.Get("permKeys"); ok {
+		return v.([]string), nil
+	}
+	keys, err := rbacService.GetUserPermissionKeys(c.Request.Context(), c.GetUint("userID"))
+	if err != nil {
+		return nil, err
+	}
+	c.Set("permKeys", keys)
+	return keys, nil
+}
+
 func RequireRole(rbacService usecase.RBACService, roleName string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID := c.GetUint("userID")
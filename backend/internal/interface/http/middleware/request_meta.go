@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
+)
+
+// RequestMeta stores the client IP and user agent in the request context for audit logging.
+func RequestMeta() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := audit.WithRequestMeta(c.Request.Context(), audit.RequestMeta{
+			IP:        c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
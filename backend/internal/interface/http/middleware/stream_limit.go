@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StreamLimitMiddleware caps how many long-lived responses (such as SSE streams) one
+// caller may hold open at once. RateLimitMiddleware counts a stream once when it opens;
+// this keeps a caller from piling up streams within that budget. Counts are kept per
+// server instance.
+func StreamLimitMiddleware(maxOpen int, scope string) gin.HandlerFunc {
+	var (
+		mu   sync.Mutex
+		open = map[string]int{}
+	)
+	return func(c *gin.Context) {
+		key := fmt.Sprintf("%s:%s", scope, rateLimitSubject(c))
+
+		mu.Lock()
+		if open[key] >= maxOpen {
+			mu.Unlock()
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many open streams"})
+			c.Abort()
+			return
+		}
+		open[key]++
+		mu.Unlock()
+
+		defer func() {
+			mu.Lock()
+			if open[key]--; open[key] <= 0 {
+				delete(open, key)
+			}
+			mu.Unlock()
+		}()
+
+		c.Next()
+	}
+}
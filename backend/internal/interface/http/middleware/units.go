@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/shared/units"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+const AcceptUnitsHeader = "Accept-Units"
+
+// Units opts a request into display units via the Accept-Units header: "metric" or
+// "imperial" are used as given, "user" follows the authenticated user's UnitSystem
+// setting. Without the header (or with "canonical") quantities stay in grams and
+// millimetres, so existing clients are unaffected.
+func Units(userService usecase.UserService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		pref := strings.ToLower(strings.TrimSpace(c.GetHeader(AcceptUnitsHeader)))
+		c.Writer.Header().Add("Vary", AcceptUnitsHeader)
+		if pref == "" {
+			c.Next()
+			return
+		}
+
+		var (
+			once   sync.Once
+			system units.System
+		)
+		// The user is only known after JWTMiddleware has run, so resolve on first use.
+		resolve := func() units.System {
+			once.Do(func() {
+				if s, ok := units.Parse(pref); ok {
+					system = s
+					return
+				}
+				if pref != "user" {
+					return
+				}
+				v, ok := c.Get("userID")
+				if !ok {
+					return
+				}
+				userID, _ := v.(uint)
+				settings, err := userService.GetUserSettings(c.Request.Context(), userID)
+				if err != nil {
+					return
+				}
+				system, _ = units.Parse(settings.UnitSystem)
+			})
+			return system
+		}
+
+		c.Request = c.Request.WithContext(units.WithResolver(c.Request.Context(), resolve))
+		c.Next()
+	}
+}
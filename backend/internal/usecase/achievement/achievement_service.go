@@ -0,0 +1,104 @@
+package achievement
+
+import (
+	"context"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/achievement"
+)
+
+// EvaluateWorkout awards every rule the user now satisfies and returns the newly earned ones.
+// Already-earned achievements are skipped, so calling it again is harmless.
+func (s *achievementServiceImpl) EvaluateWorkout(ctx context.Context, userID, workoutID uint) ([]*achievement.UserAchievement, error) {
+	stats, err := s.stats(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var awarded []*achievement.UserAchievement
+	now := time.Now()
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		for _, rule := range achievement.Rules {
+			if stats.Value(rule.Kind) < rule.Threshold {
+				continue
+			}
+			a := &achievement.UserAchievement{UserID: userID, Key: rule.Key, WorkoutID: &workoutID, AwardedAt: now}
+			added, err := s.achievementRepo.Award(ctx, a)
+			if err != nil {
+				return err
+			}
+			if added {
+				awarded = append(awarded, a)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return awarded, nil
+}
+
+// GetAchievements returns the whole catalog with the user's progress on each rule.
+func (s *achievementServiceImpl) GetAchievements(ctx context.Context, userID uint) ([]achievement.Status, achievement.Streak, error) {
+	stats, err := s.stats(ctx, userID)
+	if err != nil {
+		return nil, achievement.Streak{}, err
+	}
+	earned, err := s.achievementRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, achievement.Streak{}, err
+	}
+	awardedAt := make(map[string]time.Time, len(earned))
+	for _, a := range earned {
+		awardedAt[a.Key] = a.AwardedAt
+	}
+
+	statuses := make([]achievement.Status, 0, len(achievement.Rules))
+	for _, rule := range achievement.Rules {
+		st := achievement.Status{Rule: rule, Current: stats.Value(rule.Kind)}
+		if at, ok := awardedAt[rule.Key]; ok {
+			st.Earned = true
+			st.AwardedAt = &at
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses, stats.Streak, nil
+}
+
+func (s *achievementServiceImpl) GetStreak(ctx context.Context, userID uint) (achievement.Streak, error) {
+	return s.streak(ctx, userID)
+}
+
+func (s *achievementServiceImpl) stats(ctx context.Context, userID uint) (achievement.Stats, error) {
+	var stats achievement.Stats
+	var err error
+
+	if stats.Workouts, err = s.statsRepo.CompletedWorkouts(ctx, userID); err != nil {
+		return stats, err
+	}
+	grams, err := s.statsRepo.TonnageGrams(ctx, userID)
+	if err != nil {
+		return stats, err
+	}
+	stats.TonnageKg = grams / 1000
+	if stats.PerfectCycles, err = s.statsRepo.PerfectCycles(ctx, userID); err != nil {
+		return stats, err
+	}
+	if stats.Streak, err = s.streak(ctx, userID); err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
+
+func (s *achievementServiceImpl) streak(ctx context.Context, userID uint) (achievement.Streak, error) {
+	times, err := s.statsRepo.CompletionTimes(ctx, userID)
+	if err != nil {
+		return achievement.Streak{}, err
+	}
+	target, err := s.statsRepo.WeeklyTarget(ctx, userID)
+	if err != nil {
+		return achievement.Streak{}, err
+	}
+	return achievement.CalculateStreak(times, target, time.Now()), nil
+}
@@ -0,0 +1,25 @@
+package achievement
+
+import (
+	"github.com/lordmitrii/golang-web-gin/internal/domain/achievement"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type achievementServiceImpl struct {
+	achievementRepo achievement.AchievementRepository
+	statsRepo       achievement.StatsRepository
+
+	tx usecase.TxManager
+}
+
+func NewAchievementService(
+	achievementRepo achievement.AchievementRepository,
+	statsRepo achievement.StatsRepository,
+	tx usecase.TxManager,
+) usecase.AchievementService {
+	return &achievementServiceImpl{
+		achievementRepo: achievementRepo,
+		statsRepo:       statsRepo,
+		tx:              tx,
+	}
+}
@@ -2,6 +2,9 @@ package admin
 
 import (
 	"context"
+	"log"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
 )
@@ -30,37 +33,94 @@ func (s *adminServiceImpl) ListRoles(ctx context.Context) ([]*rbac.Role, error)
 }
 
 func (s *adminServiceImpl) SetUserRoles(ctx context.Context, userID uint, roleNames []string) error {
-	err := s.roleRepo.ClearUserRoles(ctx, userID)
-	if err != nil {
-		return err
-	}
-
-	for _, roleName := range roleNames {
-		role, err := s.roleRepo.GetByName(ctx, roleName)
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		before, err := s.roleRepo.GetUserRoles(ctx, userID)
 		if err != nil {
 			return err
 		}
-		err = s.roleRepo.AssignRoleToUser(ctx, userID, role.Name)
+
+		err = s.roleRepo.ClearUserRoles(ctx, userID)
 		if err != nil {
 			return err
 		}
+
+		after := make([]string, 0, len(roleNames))
+		for _, roleName := range roleNames {
+			role, err := s.roleRepo.GetByName(ctx, roleName)
+			if err != nil {
+				return err
+			}
+			err = s.roleRepo.AssignRoleToUser(ctx, userID, role.Name)
+			if err != nil {
+				return err
+			}
+			after = append(after, role.Name)
+		}
+
+		entry := audit.NewAuditLog(ctx, audit.ActionAdminUserRolesSet, audit.TargetUser, &userID,
+			map[string]any{"roles": roleNamesOf(before)}, map[string]any{"roles": after})
+		return s.auditRepo.Create(ctx, entry)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.permCache.Invalidate(ctx, userID); err != nil {
+		log.Printf("permission cache invalidation failed: %v", err)
 	}
 	return nil
 }
 
 func (s *adminServiceImpl) TriggerResetUserPassword(ctx context.Context, userID uint) error {
-	user, err := s.userRepo.GetByID(ctx, userID)
-	if err != nil {
-		return err
-	}
+	// The reset token and both audit entries are written in one transaction, which the
+	// email service joins; a failed send rolls them back.
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		entry := audit.NewAuditLog(ctx, audit.ActionAdminPasswordResetSent, audit.TargetUser, &userID, nil, nil)
+		if err := s.auditRepo.Create(ctx, entry); err != nil {
+			return err
+		}
 
-	return s.emailSvc.SendResetPasswordEmail(ctx, user.Email, "en")
+		return s.emailSvc.SendResetPasswordEmail(ctx, user.Email, "en")
+	})
 }
 
 func (s *adminServiceImpl) DeleteUser(ctx context.Context, userID uint) error {
-	err := s.userRepo.Delete(ctx, userID)
-	if err != nil {
-		return err
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		u, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		err = s.userRepo.Delete(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		entry := audit.NewAuditLog(ctx, audit.ActionAdminUserDeleted, audit.TargetUser, &userID,
+			map[string]any{"username": u.Username, "email": u.Email}, nil)
+		return s.auditRepo.Create(ctx, entry)
+	})
+}
+
+func (s *adminServiceImpl) ListAuditLogs(ctx context.Context, f audit.Filter, page, pageSize int64) ([]*audit.AuditLog, int64, error) {
+	if page <= 0 {
+		page = 1
 	}
-	return nil
+	if pageSize <= 0 || pageSize > 200 {
+		pageSize = 50
+	}
+	return s.auditRepo.List(ctx, f, page, pageSize)
+}
+
+func roleNamesOf(roles []*rbac.Role) []string {
+	names := make([]string, 0, len(roles))
+	for _, r := range roles {
+		names = append(names, r.Name)
+	}
+	return names
 }
@@ -1,25 +1,36 @@
 package admin
 
 import (
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
 	"github.com/lordmitrii/golang-web-gin/internal/usecase"
 )
 
 type adminServiceImpl struct {
-	userRepo user.UserRepository
-	roleRepo rbac.RoleRepository
-	emailSvc usecase.EmailService
+	userRepo  user.UserRepository
+	roleRepo  rbac.RoleRepository
+	auditRepo audit.AuditLogRepository
+	emailSvc  usecase.EmailService
+	permCache usecase.PermissionCache
+
+	tx usecase.TxManager
 }
 
 func NewAdminService(
 	userRepo user.UserRepository,
 	roleRepo rbac.RoleRepository,
+	auditRepo audit.AuditLogRepository,
 	emailSvc usecase.EmailService,
+	permCache usecase.PermissionCache,
+	tx usecase.TxManager,
 ) usecase.AdminService {
 	return &adminServiceImpl{
-		userRepo: userRepo,
-		roleRepo: roleRepo,
-		emailSvc: emailSvc,
+		userRepo:  userRepo,
+		roleRepo:  roleRepo,
+		auditRepo: auditRepo,
+		emailSvc:  emailSvc,
+		permCache: permCache,
+		tx:        tx,
 	}
 }
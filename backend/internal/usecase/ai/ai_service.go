@@ -3,12 +3,36 @@ package ai
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 
+	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/goal"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
 )
 
 func (s *aiServiceImpl) AskStatsQuestion(ctx context.Context, userID uint, question, lang, previousResponseID string) (string, string, error) {
+	return s.askStats(ctx, userID, question, lang, previousResponseID, nil)
+}
+
+func (s *aiServiceImpl) StreamStatsQuestion(ctx context.Context, userID uint, question, lang, previousResponseID string, onDelta func(delta string) error) (string, string, error) {
+	return s.askStats(ctx, userID, question, lang, previousResponseID, onDelta)
+}
+
+func (s *aiServiceImpl) askStats(ctx context.Context, userID uint, question, lang, previousResponseID string, onDelta func(delta string) error) (string, string, error) {
+	if err := s.requireChatConsent(ctx, userID); err != nil {
+		return "", "", err
+	}
+	if err := s.checkQuota(ctx, userID); err != nil {
+		return "", "", err
+	}
+	in, err := s.screenInput(ctx, userID, ai.EndpointAskStats, lang, question)
+	if err != nil {
+		return refuse(err, previousResponseID, onDelta)
+	}
+	if previousResponseID != "" {
+		return s.continueConversation(ctx, userID, lang, in, previousResponseID, onDelta)
+	}
+
 	userSettings, err := s.userService.GetUserSettings(ctx, userID)
 	if err != nil {
 		return "", "", err
@@ -29,21 +53,49 @@ func (s *aiServiceImpl) AskStatsQuestion(ctx context.Context, userID uint, quest
 
 	processedStats := BuildProcessedStats(stats, profile, unitSystem)
 	statsJson, _ := json.Marshal(processedStats)
-	var fullPrompt string
 
-	if previousResponseID == "" {
-		fullPrompt = fmt.Sprintf(
-			"Here is the user's exercise and profile stats as JSON: %s\n\nThe user asks: %s\nUser uses \"%s\" unit system\nUser language: %s.",
-			statsJson, question, unitSystem, lang,
-		)
-	} else {
-		fullPrompt = fmt.Sprintf("The user asks: %s\n", question)
+	var goalsJson []byte
+	goals, err := s.goalService.GetGoals(ctx, userID, goal.StatusActive)
+	if err != nil {
+		return "", "", err
+	}
+	if len(goals) > 0 {
+		goalsJson, _ = json.Marshal(BuildProcessedGoals(goals, unitSystem))
 	}
 
-	return s.openai.CallOpenAIChat(ctx, fullPrompt, previousResponseID, 256)
+	prompt := s.render(ctx, ai.PromptAskStats, lang, map[string]any{
+		"Goals":      string(goalsJson),
+		"Stats":      string(statsJson),
+		"Question":   in.Text,
+		"UnitSystem": unitSystem,
+		"Language":   lang,
+	})
+	return s.startConversation(ctx, userID, ai.ConversationStats, lang, in, prompt, onDelta)
 }
 
 func (s *aiServiceImpl) AskWorkoutsQuestion(ctx context.Context, userID uint, question, lang, previousResponseID string) (string, string, error) {
+	return s.askWorkouts(ctx, userID, question, lang, previousResponseID, nil)
+}
+
+func (s *aiServiceImpl) StreamWorkoutsQuestion(ctx context.Context, userID uint, question, lang, previousResponseID string, onDelta func(delta string) error) (string, string, error) {
+	return s.askWorkouts(ctx, userID, question, lang, previousResponseID, onDelta)
+}
+
+func (s *aiServiceImpl) askWorkouts(ctx context.Context, userID uint, question, lang, previousResponseID string, onDelta func(delta string) error) (string, string, error) {
+	if err := s.requireChatConsent(ctx, userID); err != nil {
+		return "", "", err
+	}
+	if err := s.checkQuota(ctx, userID); err != nil {
+		return "", "", err
+	}
+	in, err := s.screenInput(ctx, userID, ai.EndpointAskWorkouts, lang, question)
+	if err != nil {
+		return refuse(err, previousResponseID, onDelta)
+	}
+	if previousResponseID != "" {
+		return s.continueConversation(ctx, userID, lang, in, previousResponseID, onDelta)
+	}
+
 	activePlan, err := s.workoutService.GetActivePlanByUserID(ctx, userID)
 	if err != nil {
 		return "", "", err
@@ -61,54 +113,61 @@ func (s *aiServiceImpl) AskWorkoutsQuestion(ctx context.Context, userID uint, qu
 		unitSystem = "metric"
 	}
 
-	if activePlan == nil || activePlan.CurrentCycleID == nil {
-		var fullPrompt string
-		if previousResponseID == "" {
-			fullPrompt = fmt.Sprintf(
-				"There is no active workout plan/cycle for this user right now.\n\nThe user asks: %s\nUser uses metric system\nUser language: %s.",
-				question, lang,
-			)
-		} else {
-			fullPrompt = fmt.Sprintf("The user asks: %s\n", question)
-		}
-		return s.openai.CallOpenAIChat(ctx, fullPrompt, previousResponseID, 256)
-	}
-
-	currentCycle, _ := s.workoutService.GetWorkoutCycleByID(ctx, userID, activePlan.ID, *activePlan.CurrentCycleID)
-
-	processedCycle := BuildProcessedCycle(currentCycle)
-	cycleJson, _ := json.Marshal(processedCycle)
-	var fullPrompt string
+	var cycleJson []byte
+	if activePlan != nil && activePlan.CurrentCycleID != nil {
+		currentCycle, _ := s.workoutService.GetWorkoutCycleByID(ctx, userID, activePlan.ID, *activePlan.CurrentCycleID)
 
-	if previousResponseID == "" {
-		fullPrompt = fmt.Sprintf(
-			"Here is the user's current workout cycle as JSON: %s\n\nThe user asks: %s\nUser uses \"%s\" unit system\nUser language: %s.",
-			cycleJson, question, unitSystem, lang,
-		)
-	} else {
-		fullPrompt = fmt.Sprintf("The user asks: %s\n", question)
+		processedCycle := BuildProcessedCycle(currentCycle)
+		cycleJson, _ = json.Marshal(processedCycle)
 	}
 
-	return s.openai.CallOpenAIChat(ctx, fullPrompt, previousResponseID, 256)
+	prompt := s.render(ctx, ai.PromptAskWorkouts, lang, map[string]any{
+		"Cycle":      string(cycleJson),
+		"Question":   in.Text,
+		"UnitSystem": unitSystem,
+		"Language":   lang,
+	})
+	return s.startConversation(ctx, userID, ai.ConversationWorkouts, lang, in, prompt, onDelta)
 }
 
 func (s *aiServiceImpl) AskGeneralQuestion(ctx context.Context, userID uint, question, lang, previousResponseID string) (string, string, error) {
-	var fullPrompt string
+	return s.askGeneral(ctx, userID, question, lang, previousResponseID, nil)
+}
 
-	if previousResponseID == "" {
-		fullPrompt = fmt.Sprintf(
-			"The user asks: %s\nUser language: %s.",
-			question, lang,
-		)
-	} else {
-		fullPrompt = fmt.Sprintf("The user asks: %s\n", question)
+func (s *aiServiceImpl) StreamGeneralQuestion(ctx context.Context, userID uint, question, lang, previousResponseID string, onDelta func(delta string) error) (string, string, error) {
+	return s.askGeneral(ctx, userID, question, lang, previousResponseID, onDelta)
+}
+
+func (s *aiServiceImpl) askGeneral(ctx context.Context, userID uint, question, lang, previousResponseID string, onDelta func(delta string) error) (string, string, error) {
+	if err := s.requireChatConsent(ctx, userID); err != nil {
+		return "", "", err
+	}
+	if err := s.checkQuota(ctx, userID); err != nil {
+		return "", "", err
+	}
+	in, err := s.screenInput(ctx, userID, ai.EndpointAskGeneral, lang, question)
+	if err != nil {
+		return refuse(err, previousResponseID, onDelta)
+	}
+	if previousResponseID != "" {
+		return s.continueConversation(ctx, userID, lang, in, previousResponseID, onDelta)
 	}
 
-	return s.openai.CallOpenAIChat(ctx, fullPrompt, previousResponseID, 256)
-	// return fmt.Sprintf("General question: %s", question), fmt.Sprintf("previous_response_id: %s", previousResponseID), nil
+	prompt := s.render(ctx, ai.PromptAskGeneral, lang, map[string]any{
+		"Question": in.Text,
+		"Language": lang,
+	})
+	return s.startConversation(ctx, userID, ai.ConversationGeneral, lang, in, prompt, onDelta)
 }
 
 func (s *aiServiceImpl) GenerateWorkoutPlan(ctx context.Context, userID uint, prompt string, days int, lang string) (*workout.WorkoutPlan, error) {
+	if err := s.checkQuota(ctx, userID); err != nil {
+		return nil, err
+	}
+	in, err := s.screenInput(ctx, userID, ai.EndpointGeneratePlan, lang, prompt)
+	if err != nil {
+		return nil, err
+	}
 	profile, _ := s.userService.GetProfile(ctx, userID)
 
 	exercises, err := s.exerciseService.GetAllExercises(ctx)
@@ -116,37 +175,27 @@ func (s *aiServiceImpl) GenerateWorkoutPlan(ctx context.Context, userID uint, pr
 		return nil, err
 	}
 
-	var fullPrompt string
-	if profile != nil {
-		fullPrompt = fmt.Sprintf(
-			"Generate a workout plan based on the following prompt: %s\nUser profile: Age %d, Height %d mm, Weight %d g. User prefers %d days per week workouts.",
-			prompt, profile.Age, profile.Height, profile.Weight, days,
-		)
-	} else {
-		fullPrompt = fmt.Sprintf(
-			"Generate a workout plan based on the following prompt: %s\nUser profile: Unknown. User prefers %d days per week workouts. User language: %s.",
-			prompt, days, lang,
-		)
-	}
+	request := s.renderPlanRequest(ctx, profile, in, days, lang)
 
-	return s.openai.GenerateWorkoutPlan(ctx, fullPrompt, exercises, 2048)
+	llm := s.metered(userID, ai.EndpointGeneratePlan)
+	plan, err := s.generatePlan(ctx, llm, lang, request, exercises)
+	if err != nil {
+		return nil, err
+	}
+	return s.saveGeneratedPlan(ctx, llm, userID, lang, request, days, plan, newExerciseCatalog(exercises))
 }
 
 func (s *aiServiceImpl) GenerateWorkoutPlanWithDB(ctx context.Context, userID uint, prompt string, days int, lang string) (*workout.WorkoutPlan, error) {
+	if err := s.checkQuota(ctx, userID); err != nil {
+		return nil, err
+	}
+	in, err := s.screenInput(ctx, userID, ai.EndpointGeneratePlan, lang, prompt)
+	if err != nil {
+		return nil, err
+	}
 	profile, _ := s.userService.GetProfile(ctx, userID)
 
-	var fullPrompt string
-	if profile != nil {
-		fullPrompt = fmt.Sprintf(
-			"Generate a workout plan based on the following prompt: %s\nUser profile: Age %d, Height %d mm, Weight %d g. User prefers %d days per week workouts.",
-			prompt, profile.Age, profile.Height, profile.Weight, days,
-		)
-	} else {
-		fullPrompt = fmt.Sprintf(
-			"Generate a workout plan based on the following prompt: %s\nUser profile: Unknown. User prefers %d days per week workouts.",
-			prompt, days,
-		)
-	}
+	request := s.renderPlanRequest(ctx, profile, in, days, lang)
 
 	listMG := func(ctx context.Context, limit int) ([]string, error) {
 
@@ -165,7 +214,11 @@ func (s *aiServiceImpl) GenerateWorkoutPlanWithDB(ctx context.Context, userID ui
 	}
 
 	searchEx := func(ctx context.Context, groupQuery string, limit, offset int) ([]map[string]any, error) {
-		rows, err := s.exerciseService.GetExerciseNamesByMuscleName(ctx, groupQuery, limit, offset)
+		if limit <= 0 {
+			limit = 20
+		}
+		page := int64(offset/limit) + 1
+		rows, _, err := s.exerciseService.SearchExercises(ctx, workout.ExerciseFilter{MuscleGroup: groupQuery}, page, int64(limit))
 		if err != nil {
 			return nil, err
 		}
@@ -176,5 +229,31 @@ func (s *aiServiceImpl) GenerateWorkoutPlanWithDB(ctx context.Context, userID ui
 		return out, nil
 	}
 
-	return s.openai.GenerateWorkoutPlanWithDB(ctx, fullPrompt, 8192, listMG, searchEx)
+	exercises, err := s.exerciseService.GetAllExercises(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	llm := s.metered(userID, ai.EndpointGeneratePlan)
+	plan, err := s.generatePlanWithTools(ctx, llm, lang, request, listMG, searchEx)
+	if err != nil {
+		return nil, err
+	}
+	return s.saveGeneratedPlan(ctx, llm, userID, lang, request, days, plan, newExerciseCatalog(exercises))
+}
+
+func (s *aiServiceImpl) renderPlanRequest(ctx context.Context, profile *user.Profile, in *screenedInput, days int, lang string) renderedPrompt {
+	data := map[string]any{
+		"Prompt":     in.Text,
+		"HasProfile": profile != nil,
+		"Age":        0,
+		"Height":     0,
+		"Weight":     0,
+		"Days":       days,
+		"Language":   lang,
+	}
+	if profile != nil {
+		data["Age"], data["Height"], data["Weight"] = profile.Age, profile.Height, profile.Weight
+	}
+	return s.withPolicy(ctx, lang, in, s.render(ctx, ai.PromptPlanRequest, lang, data))
 }
@@ -1,2 +1,392 @@
 package ai_test
 
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	domain "github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/goal"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/versions"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+	fake "github.com/lordmitrii/golang-web-gin/internal/infrastructure/ai"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase/ai"
+)
+
+const testUserID = 1
+
+// The stubs embed the interfaces they stand in for, so a call the flow under test
+// should not make panics.
+
+type stubUsers struct{ usecase.UserService }
+
+func (stubUsers) Me(context.Context, uint) (*user.User, error) {
+	return &user.User{ID: testUserID, Username: "johnd", Email: "john@example.com"}, nil
+}
+
+func (stubUsers) GetConsents(context.Context, uint) ([]*user.UserConsent, error) {
+	return []*user.UserConsent{{UserID: testUserID, Type: versions.AiChatPrivacy, Version: "1.0", Given: true}}, nil
+}
+
+func (stubUsers) GetUserSettings(context.Context, uint) (*user.UserSettings, error) {
+	return &user.UserSettings{UserID: testUserID, UnitSystem: "metric"}, nil
+}
+
+func (stubUsers) GetProfile(context.Context, uint) (*user.Profile, error) {
+	return &user.Profile{UserID: testUserID, Age: 30, Height: 1800, Weight: 80000, Sex: user.SexMale}, nil
+}
+
+type stubVersions struct{ usecase.VersionsService }
+
+func (stubVersions) GetCurrentVersion(_ context.Context, key string) (*versions.Version, error) {
+	return &versions.Version{Key: key, Version: "1.0"}, nil
+}
+
+type stubGoals struct{ usecase.GoalService }
+
+func (stubGoals) GetGoals(context.Context, uint, string) ([]*goal.Tracked, error) { return nil, nil }
+
+type stubRoles struct{ rbac.RoleRepository }
+
+func (stubRoles) GetUserRoles(context.Context, uint) ([]*rbac.Role, error) { return nil, nil }
+
+type stubPrompts struct {
+	domain.PromptTemplateRepository
+}
+
+func (stubPrompts) GetActive(context.Context) ([]*domain.PromptTemplate, error) { return nil, nil }
+
+type stubWorkouts struct {
+	usecase.WorkoutService
+	created  *workout.WorkoutPlan
+	workouts []*workout.Workout
+}
+
+func (*stubWorkouts) GetIndividualExerciseStats(context.Context, uint) ([]*workout.IndividualExercise, error) {
+	return []*workout.IndividualExercise{{ID: 7, Name: "Back Squat", CurrentWeight: 110000, CurrentReps: 5}}, nil
+}
+
+func (*stubWorkouts) GetIndividualExercisePerformanceHistory(_ context.Context, _, id uint) ([]*workout.ExercisePerformance, error) {
+	if id != 7 {
+		return nil, nil
+	}
+	w1, w2, reps := 100000, 110000, 5
+	return []*workout.ExercisePerformance{{Weight: &w1, Reps: &reps}, {Weight: &w2, Reps: &reps}}, nil
+}
+
+func (*stubWorkouts) GetActivePlanByUserID(context.Context, uint) (*workout.WorkoutPlan, error) {
+	return nil, nil
+}
+
+func (w *stubWorkouts) CreateWorkoutPlanWithWorkouts(_ context.Context, _ uint, wp *workout.WorkoutPlan, workouts []*workout.Workout) (*workout.WorkoutPlan, error) {
+	wp.ID = 1
+	w.created, w.workouts = wp, workouts
+	return wp, nil
+}
+
+type stubExercises struct {
+	usecase.ExerciseService
+	exercises []*workout.Exercise
+}
+
+func (e *stubExercises) GetAllExercises(context.Context) ([]*workout.Exercise, error) {
+	return e.exercises, nil
+}
+
+func (e *stubExercises) GetAllMuscleGroups(context.Context) ([]*workout.MuscleGroup, error) {
+	var out []*workout.MuscleGroup
+	for _, ex := range e.exercises {
+		out = append(out, ex.MuscleGroup)
+	}
+	return out, nil
+}
+
+func (e *stubExercises) SearchExercises(_ context.Context, f workout.ExerciseFilter, _, _ int64) ([]*workout.Exercise, int64, error) {
+	var out []*workout.Exercise
+	for _, ex := range e.exercises {
+		if strings.EqualFold(ex.MuscleGroup.Name, f.MuscleGroup) {
+			out = append(out, ex)
+		}
+	}
+	return out, int64(len(out)), nil
+}
+
+type memConversations struct {
+	domain.ConversationRepository
+	convs []*domain.Conversation
+	msgs  map[uint][]*domain.ConversationMessage
+}
+
+func (m *memConversations) Create(_ context.Context, c *domain.Conversation) error {
+	c.ID = uint(len(m.convs) + 1)
+	m.convs = append(m.convs, c)
+	return nil
+}
+
+func (m *memConversations) GetByID(_ context.Context, _, id uint) (*domain.Conversation, error) {
+	return m.convs[id-1], nil
+}
+
+func (m *memConversations) GetMessages(_ context.Context, id uint) ([]*domain.ConversationMessage, error) {
+	return m.msgs[id], nil
+}
+
+func (m *memConversations) AddMessages(_ context.Context, id uint, msgs ...*domain.ConversationMessage) error {
+	m.msgs[id] = append(m.msgs[id], msgs...)
+	return nil
+}
+
+type memUsage struct {
+	domain.UsageRepository
+	records []*domain.TokenUsage
+}
+
+func (m *memUsage) Create(_ context.Context, u *domain.TokenUsage) error {
+	m.records = append(m.records, u)
+	return nil
+}
+
+type memBlocked struct {
+	domain.BlockedRequestRepository
+	rows []*domain.BlockedRequest
+}
+
+func (m *memBlocked) Create(_ context.Context, b *domain.BlockedRequest) error {
+	m.rows = append(m.rows, b)
+	return nil
+}
+
+type noTx struct{}
+
+func (noTx) Do(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }
+
+func (noTx) DoIfNotInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type testEnv struct {
+	svc           usecase.AIService
+	llm           *fake.Fake
+	workouts      *stubWorkouts
+	conversations *memConversations
+	usage         *memUsage
+	blocked       *memBlocked
+}
+
+// newTestEnv wires the service to in-memory stubs and a fake LLM replaying the
+// fixture in testdata, if one is named. Quotas are unlimited.
+func newTestEnv(t *testing.T, fixture string) *testEnv {
+	t.Helper()
+	var replies []domain.Message
+	if fixture != "" {
+		var err error
+		if replies, err = fake.LoadFixture(filepath.Join("testdata", fixture)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	chest := &workout.MuscleGroup{ID: 1, Name: "Chest", Slug: "chest"}
+	back := &workout.MuscleGroup{ID: 2, Name: "Back", Slug: "back"}
+	env := &testEnv{
+		llm:           fake.NewFake(replies...),
+		workouts:      &stubWorkouts{},
+		conversations: &memConversations{msgs: map[uint][]*domain.ConversationMessage{}},
+		usage:         &memUsage{},
+		blocked:       &memBlocked{},
+	}
+	exercises := &stubExercises{exercises: []*workout.Exercise{
+		{ID: 1, Name: "Bench Press", Slug: "bench-press", MuscleGroup: chest},
+		{ID: 2, Name: "Barbell Row", Slug: "barbell-row", MuscleGroup: back},
+	}}
+	env.svc = ai.NewAIService(env.workouts, exercises, stubUsers{}, stubGoals{}, stubVersions{}, env.llm,
+		env.conversations, env.usage, nil, stubRoles{}, 0, 0, stubPrompts{}, env.blocked, noTx{})
+	return env
+}
+
+func category(c string) domain.Message {
+	return domain.Message{Content: `{"category": "` + c + `"}`}
+}
+
+func TestGenerateWorkoutPlanWithDB_ToolLoop(t *testing.T) {
+	env := newTestEnv(t, "plan_with_tools.json")
+
+	plan, err := env.svc.GenerateWorkoutPlanWithDB(context.Background(), testUserID, "Push and pull", 2, "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Draft || plan.Name != "Push Pull" {
+		t.Errorf("plan = %+v, want the draft Push Pull", plan)
+	}
+	if len(env.workouts.workouts) != 2 {
+		t.Fatalf("saved %d workouts, want 2", len(env.workouts.workouts))
+	}
+	pull := env.workouts.workouts[1].WorkoutExercises[0]
+	if *pull.IndividualExercise.ExerciseID != 2 || pull.SetsQt != 3 {
+		t.Errorf("day 2 = exercise %d x %d sets, want Barbell Row (2) x 3", *pull.IndividualExercise.ExerciseID, pull.SetsQt)
+	}
+
+	reqs := env.llm.Requests()
+	if len(reqs) != 7 {
+		t.Fatalf("made %d model calls, want classify, 5 tool loop rounds and a repair", len(reqs))
+	}
+	if last := reqs[3].Messages[len(reqs[3].Messages)-1]; last.Role != domain.RoleTool || !strings.Contains(last.Content, "bench-press") {
+		t.Errorf("chest search result = %+v", last)
+	}
+	// The plan came back after one group only, so the next round forces a search of the other.
+	steer := reqs[4]
+	if steer.ToolChoice != "search_exercises_by_muscle_group" || len(steer.Tools) != 1 {
+		t.Fatalf("steering request: tool choice %q with %d tools", steer.ToolChoice, len(steer.Tools))
+	}
+	groups := steer.Tools[0].Parameters["properties"].(map[string]any)["group_query"].(map[string]any)["enum"]
+	if got, ok := groups.([]any); !ok || len(got) != 1 || got[0] != "Back" {
+		t.Errorf("allowed groups = %v, want [Back]", groups)
+	}
+	if repair := reqs[6].Messages[2].Content; !strings.Contains(repair, "asked for 2 days") {
+		t.Errorf("repair request = %q", repair)
+	}
+
+	if len(env.usage.records) != 7 || env.usage.records[0].Endpoint != domain.EndpointModeration || env.usage.records[6].Endpoint != domain.EndpointGeneratePlan {
+		t.Errorf("usage not recorded per call: %d records", len(env.usage.records))
+	}
+}
+
+func TestAskCoachQuestion_ToolRounds(t *testing.T) {
+	env := newTestEnv(t, "coach_tools.json")
+
+	answer, ref, err := env.svc.AskCoachQuestion(context.Background(), testUserID, "Has my squat stalled?", "en", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(answer, "Your squat went from 100 kg") || ref != "1" {
+		t.Errorf("answer = %q, ref = %q", answer, ref)
+	}
+
+	reqs := env.llm.Requests()
+	if len(reqs) != 4 {
+		t.Fatalf("made %d model calls, want classify and 3 coach rounds", len(reqs))
+	}
+	bestSets := reqs[2].Messages[len(reqs[2].Messages)-1]
+	if bestSets.ToolCallID != "call_1" || !strings.Contains(bestSets.Content, "Back Squat") {
+		t.Errorf("get_best_sets result = %+v", bestSets)
+	}
+	msgs := reqs[3].Messages
+	history, invalid := msgs[len(msgs)-2], msgs[len(msgs)-1]
+	if history.ToolCallID != "call_2" || !strings.Contains(history.Content, `"weight":110`) {
+		t.Errorf("get_exercise_history result = %+v", history)
+	}
+	if invalid.ToolCallID != "call_3" || !strings.Contains(invalid.Content, "invalid_arguments") {
+		t.Errorf("call without exercise_id = %+v", invalid)
+	}
+
+	var billed int64
+	for _, u := range env.usage.records[1:] {
+		billed += u.InputTokens
+	}
+	stored := env.conversations.msgs[1]
+	if len(stored) != 2 || stored[1].InputTokens != billed {
+		t.Errorf("stored turns = %d, answer input tokens = %d, want 2 and %d", len(stored), stored[1].InputTokens, billed)
+	}
+}
+
+func TestAskStatsQuestion_ScrubsPII(t *testing.T) {
+	env := newTestEnv(t, "")
+	env.llm.Queue(category(domain.InputFitness), domain.Message{Content: "Yes, your squat is up."})
+
+	answer, _, err := env.svc.AskStatsQuestion(context.Background(), testUserID, "I'm johnd, mail me at john@example.com: am I stronger?", "en", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answer != "Yes, your squat is up." {
+		t.Errorf("answer = %q", answer)
+	}
+
+	prompt := env.llm.Requests()[1].Messages[0].Content
+	if strings.Contains(prompt, "john") || !strings.Contains(prompt, "[email]") || !strings.Contains(prompt, "Back Squat") {
+		t.Errorf("prompt = %q, want the stats and no PII", prompt)
+	}
+	if q := env.conversations.msgs[1][0].Content; q != "I'm [name], mail me at [email]: am I stronger?" {
+		t.Errorf("stored question = %q", q)
+	}
+}
+
+func TestAskWorkoutsQuestion_FollowUp(t *testing.T) {
+	env := newTestEnv(t, "")
+	env.llm.Queue(
+		category(domain.InputFitness), domain.Message{Content: "Start with a plan."},
+		category(domain.InputInjury), domain.Message{Content: "This is not medical advice."},
+	)
+	ctx := context.Background()
+
+	_, ref, err := env.svc.AskWorkoutsQuestion(ctx, testUserID, "What should I train today?", "en", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, next, err := env.svc.AskWorkoutsQuestion(ctx, testUserID, "My knee hurts, should I squat?", "en", ref); err != nil || next != ref {
+		t.Fatalf("follow-up: ref %q, err %v", next, err)
+	}
+
+	req := env.llm.Requests()[3]
+	if len(req.Messages) != 3 || !strings.Contains(req.Messages[0].Content, "no active workout plan") {
+		t.Fatalf("follow-up did not resend the conversation: %+v", req.Messages)
+	}
+	if !strings.Contains(req.Messages[2].Content, "Medical policy") || !strings.Contains(req.PromptVersion, domain.PromptGuardrailMedicalPolicy) {
+		t.Errorf("injury question sent without the medical policy: %q", req.Messages[2].Content)
+	}
+	if n := len(env.conversations.msgs[1]); n != 4 {
+		t.Errorf("stored %d turns, want 4", n)
+	}
+}
+
+func TestAskGeneralQuestion_Blocked(t *testing.T) {
+	env := newTestEnv(t, "")
+	env.llm.Queue(category(domain.InputOffTopic))
+
+	var streamed string
+	answer, ref, err := env.svc.StreamGeneralQuestion(context.Background(), testUserID, "Write my history essay", "ru-RU", "3",
+		func(delta string) error { streamed += delta; return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(answer, "Я могу помочь") || streamed != answer || ref != "3" {
+		t.Errorf("answer = %q, streamed = %q, ref = %q", answer, streamed, ref)
+	}
+	if n := len(env.llm.Requests()); n != 1 {
+		t.Errorf("made %d model calls, want only the classification", n)
+	}
+	if len(env.blocked.rows) != 1 || env.blocked.rows[0].Endpoint != domain.EndpointAskGeneral || env.blocked.rows[0].Category != domain.InputOffTopic {
+		t.Errorf("blocked requests = %+v", env.blocked.rows)
+	}
+	if len(env.conversations.convs) != 0 {
+		t.Errorf("a blocked question started a conversation")
+	}
+}
+
+func TestStreamGeneralQuestion_DisconnectIsBilled(t *testing.T) {
+	env := newTestEnv(t, "")
+	env.llm.Queue(category(domain.InputFitness), domain.Message{Content: "Train legs twice a week, with a rest day between sessions."})
+
+	gone := errors.New("client went away")
+	sent := 0
+	_, _, err := env.svc.StreamGeneralQuestion(context.Background(), testUserID, "How often should I train legs?", "en", "",
+		func(string) error {
+			if sent++; sent > 3 {
+				return gone
+			}
+			return nil
+		})
+	if !errors.Is(err, gone) {
+		t.Fatalf("err = %v, want the disconnect", err)
+	}
+
+	if len(env.usage.records) != 2 {
+		t.Fatalf("recorded %d calls, want the classification and the cut-short answer", len(env.usage.records))
+	}
+	if u := env.usage.records[1]; u.Endpoint != domain.EndpointAskGeneral || u.InputTokens == 0 || u.OutputTokens == 0 {
+		t.Errorf("cut-short answer billed as %+v, want an estimate", u)
+	}
+}
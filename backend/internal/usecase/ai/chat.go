@@ -0,0 +1,172 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/versions"
+)
+
+const (
+	chatMaxTokens = 256
+	// maxHistory bounds what is resent to the model. The first message carries the
+	// user's context and is always kept.
+	maxHistory = 21
+	// unpublishedVersion is what clients assume for a policy without a version row.
+	unpublishedVersion = "0.0"
+)
+
+// requireChatConsent checks that the user accepted the current AI chat privacy policy.
+// Conversations are stored, so nothing is sent or kept without it.
+func (s *aiServiceImpl) requireChatConsent(ctx context.Context, userID uint) error {
+	required := unpublishedVersion
+	v, err := s.versionsService.GetCurrentVersion(ctx, versions.AiChatPrivacy)
+	if err == nil {
+		required = v.Version
+	} else if !errors.Is(err, custom_err.ErrNotFound) {
+		return err
+	}
+
+	consents, err := s.userService.GetConsents(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, c := range consents {
+		if c.Type == versions.AiChatPrivacy && c.Given && c.Version == required {
+			return nil
+		}
+	}
+	return custom_err.ErrNoConsent
+}
+
+// startConversation opens a conversation with the context prompt and returns the
+// answer and the conversation ID. Clients pass the ID back as previous_response_id.
+// A non-nil onDelta receives the answer as it is generated.
+func (s *aiServiceImpl) startConversation(ctx context.Context, userID uint, kind, lang string, in *screenedInput, prompt renderedPrompt, onDelta func(delta string) error) (string, string, error) {
+	prompt = s.withPolicy(ctx, lang, in, prompt)
+	resp, version, err := s.respond(ctx, userID, kind, lang, []ai.Message{ai.UserMessage(prompt.Text)}, prompt.Version, onDelta)
+	if err != nil {
+		return "", "", err
+	}
+
+	c := &ai.Conversation{UserID: userID, Kind: kind, Title: ai.TitleFrom(in.Text)}
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.conversations.Create(ctx, c); err != nil {
+			return err
+		}
+		return s.conversations.AddMessages(ctx, c.ID, userTurn(in.Text, prompt.Text), assistantTurn(resp, version))
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return resp.Message.Content, conversationRef(c.ID), nil
+}
+
+// continueConversation rebuilds the model's context from the stored messages. The
+// history is only extended once the answer is complete, so a stream cut short by the
+// client leaves it as it was.
+func (s *aiServiceImpl) continueConversation(ctx context.Context, userID uint, lang string, in *screenedInput, ref string, onDelta func(delta string) error) (string, string, error) {
+	id, err := strconv.ParseUint(ref, 10, 64)
+	if err != nil || id == 0 {
+		return "", "", custom_err.ErrConversationNotFound
+	}
+	c, err := s.conversations.GetByID(ctx, userID, uint(id))
+	if errors.Is(err, custom_err.ErrNotFound) {
+		return "", "", custom_err.ErrConversationNotFound
+	} else if err != nil {
+		return "", "", err
+	}
+	stored, err := s.conversations.GetMessages(ctx, c.ID)
+	if err != nil {
+		return "", "", err
+	}
+
+	history := make([]ai.Message, 0, len(stored)+1)
+	for _, m := range stored {
+		history = append(history, m.ToMessage())
+	}
+	prompt := s.withPolicy(ctx, lang, in, s.render(ctx, ai.PromptFollowUp, lang, map[string]any{"Question": in.Text}))
+	history = append(history, ai.UserMessage(prompt.Text))
+
+	resp, version, err := s.respond(ctx, userID, c.Kind, lang, history, prompt.Version, onDelta)
+	if err != nil {
+		return "", "", err
+	}
+	if err := s.conversations.AddMessages(ctx, c.ID, userTurn(in.Text, prompt.Text), assistantTurn(resp, version)); err != nil {
+		return "", "", err
+	}
+	return resp.Message.Content, conversationRef(c.ID), nil
+}
+
+// respond answers the last message of history. Coach conversations get their tools on
+// every turn; the others are answered from the conversation alone. It also returns the
+// prompt versions of the answer: the instructions' and promptVersion, the last turn's.
+func (s *aiServiceImpl) respond(ctx context.Context, userID uint, kind, lang string, history []ai.Message, promptVersion string, onDelta func(delta string) error) (*ai.Response, string, error) {
+	llm := s.metered(userID, ai.EndpointForConversation(kind))
+	var resp *ai.Response
+	var err error
+	var version string
+	if kind == ai.ConversationCoach {
+		instructions := s.render(ctx, ai.PromptCoachInstructions, lang, nil)
+		version = ai.JoinPromptVersions(instructions.Version, promptVersion)
+		resp, err = s.coach(ctx, llm, userID, instructions.Text, version, history, onDelta)
+	} else {
+		instructions := s.render(ctx, ai.PromptChatInstructions, lang, nil)
+		version = ai.JoinPromptVersions(instructions.Version, promptVersion)
+		resp, err = s.complete(ctx, llm, instructions.Text, version, history, onDelta)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return resp, version, nil
+}
+
+func (s *aiServiceImpl) complete(ctx context.Context, llm ai.LLM, instructions, promptVersion string, history []ai.Message, onDelta func(delta string) error) (*ai.Response, error) {
+	req := ai.Request{
+		Instructions:  instructions,
+		Messages:      TrimHistory(history, maxHistory),
+		MaxTokens:     chatMaxTokens,
+		PromptVersion: promptVersion,
+	}
+	if onDelta != nil {
+		return llm.Stream(ctx, req, onDelta)
+	}
+	return llm.Chat(ctx, req)
+}
+
+func userTurn(question, prompt string) *ai.ConversationMessage {
+	return &ai.ConversationMessage{Role: ai.RoleUser, Content: question, Prompt: prompt}
+}
+
+func assistantTurn(resp *ai.Response, promptVersion string) *ai.ConversationMessage {
+	return &ai.ConversationMessage{
+		Role:          ai.RoleAssistant,
+		Content:       resp.Message.Content,
+		Model:         resp.Model,
+		InputTokens:   resp.Usage.InputTokens,
+		OutputTokens:  resp.Usage.OutputTokens,
+		PromptVersion: promptVersion,
+	}
+}
+
+func conversationRef(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// TrimHistory keeps the first message and the most recent ones, at most max in total.
+// The cut never starts on an assistant reply, so the kept tail opens with a user turn.
+func TrimHistory(msgs []ai.Message, max int) []ai.Message {
+	if len(msgs) <= max || max < 2 {
+		return msgs
+	}
+	tail := msgs[len(msgs)-(max-1):]
+	for len(tail) > 1 && tail[0].Role != ai.RoleUser {
+		tail = tail[1:]
+	}
+	out := make([]ai.Message, 0, len(tail)+1)
+	out = append(out, msgs[0])
+	return append(out, tail...)
+}
@@ -0,0 +1,39 @@
+package ai_test
+
+import (
+	"testing"
+
+	domain "github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase/ai"
+)
+
+func TestTrimHistory_KeepsContextAndRecentTurns(t *testing.T) {
+	msgs := []domain.Message{domain.UserMessage("context")}
+	for i := 0; i < 5; i++ {
+		msgs = append(msgs,
+			domain.Message{Role: domain.RoleAssistant, Content: "answer"},
+			domain.UserMessage("question"),
+		)
+	}
+
+	got := ai.TrimHistory(msgs, 5)
+	if len(got) != 4 {
+		t.Fatalf("len=%d, want 4", len(got))
+	}
+	if got[0].Content != "context" {
+		t.Fatalf("first=%q, want context", got[0].Content)
+	}
+	if got[1].Role != domain.RoleUser {
+		t.Fatalf("tail starts with %s, want user", got[1].Role)
+	}
+	if got[len(got)-1].Content != "question" {
+		t.Fatalf("last=%q, want the latest question", got[len(got)-1].Content)
+	}
+}
+
+func TestTrimHistory_ShortUnchanged(t *testing.T) {
+	msgs := []domain.Message{domain.UserMessage("context"), {Role: domain.RoleAssistant, Content: "answer"}}
+	if got := ai.TrimHistory(msgs, 5); len(got) != 2 {
+		t.Fatalf("len=%d, want 2", len(got))
+	}
+}
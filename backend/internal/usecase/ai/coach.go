@@ -0,0 +1,354 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/shared/units"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+)
+
+const (
+	coachMaxTokens = 1024
+	// maxCoachRounds bounds tool-call round trips per question. The last round is made
+	// without tools, so the model has to answer with what it has.
+	maxCoachRounds = 6
+	// maxHistoryRows bounds the sessions get_exercise_history returns.
+	maxHistoryRows = 50
+
+	toolGetProfile         = "get_profile"
+	toolGetBestSets        = "get_best_sets"
+	toolGetExerciseHistory = "get_exercise_history"
+	toolListCycles         = "list_cycles"
+	toolGetCycle           = "get_cycle"
+)
+
+// coachTool is a read-only tool bound to one user. Arguments never carry a user ID.
+type coachTool struct {
+	def ai.Tool
+	run func(ctx context.Context, args map[string]any) (any, error)
+}
+
+// errToolInput is reported back to the model rather than failing the question.
+type errToolInput string
+
+func (e errToolInput) Error() string { return string(e) }
+
+func (s *aiServiceImpl) AskCoachQuestion(ctx context.Context, userID uint, question, lang, previousResponseID string) (string, string, error) {
+	return s.askCoach(ctx, userID, question, lang, previousResponseID, nil)
+}
+
+func (s *aiServiceImpl) StreamCoachQuestion(ctx context.Context, userID uint, question, lang, previousResponseID string, onDelta func(delta string) error) (string, string, error) {
+	return s.askCoach(ctx, userID, question, lang, previousResponseID, onDelta)
+}
+
+func (s *aiServiceImpl) askCoach(ctx context.Context, userID uint, question, lang, previousResponseID string, onDelta func(delta string) error) (string, string, error) {
+	if err := s.requireChatConsent(ctx, userID); err != nil {
+		return "", "", err
+	}
+	if err := s.checkQuota(ctx, userID); err != nil {
+		return "", "", err
+	}
+	in, err := s.screenInput(ctx, userID, ai.EndpointAskCoach, lang, question)
+	if err != nil {
+		return refuse(err, previousResponseID, onDelta)
+	}
+	if previousResponseID != "" {
+		return s.continueConversation(ctx, userID, lang, in, previousResponseID, onDelta)
+	}
+
+	prompt := s.render(ctx, ai.PromptAskCoach, lang, map[string]any{
+		"Question":   in.Text,
+		"UnitSystem": string(s.unitSystem(ctx, userID)),
+		"Language":   lang,
+	})
+	return s.startConversation(ctx, userID, ai.ConversationCoach, lang, in, prompt, onDelta)
+}
+
+// coach answers with the tool loop. Tool results are not stored with the conversation,
+// so every follow-up looks the data up again.
+func (s *aiServiceImpl) coach(ctx context.Context, llm ai.LLM, userID uint, instructions, promptVersion string, history []ai.Message, onDelta func(delta string) error) (*ai.Response, error) {
+	tools := make(map[string]coachTool)
+	var defs []ai.Tool
+	for _, t := range s.coachTools(userID) {
+		tools[t.def.Name] = t
+		defs = append(defs, t.def)
+	}
+
+	req := ai.Request{
+		Instructions:  instructions,
+		Messages:      TrimHistory(history, maxHistory),
+		Tools:         defs,
+		ToolChoice:    ai.ToolChoiceAuto,
+		MaxTokens:     coachMaxTokens,
+		PromptVersion: promptVersion,
+	}
+	var usage ai.Usage
+
+	for round := range maxCoachRounds {
+		if round == maxCoachRounds-1 {
+			req.ToolChoice = ai.ToolChoiceNone
+		}
+
+		var resp *ai.Response
+		var err error
+		if onDelta != nil {
+			resp, err = llm.Stream(ctx, req, onDelta)
+		} else {
+			resp, err = llm.Chat(ctx, req)
+		}
+		if err != nil {
+			return nil, err
+		}
+		usage.InputTokens += resp.Usage.InputTokens
+		usage.OutputTokens += resp.Usage.OutputTokens
+
+		msg := resp.Message
+		if len(msg.ToolCalls) == 0 {
+			resp.Usage = usage
+			return resp, nil
+		}
+		req.Messages = append(req.Messages, msg)
+
+		for _, tc := range msg.ToolCalls {
+			req.Messages = append(req.Messages, ai.ToolMessage(tc.ID, string(mustJSON(runCoachTool(ctx, tools, tc)))))
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, errors.New("too many tool-call rounds without an answer")
+}
+
+// runCoachTool turns bad input and missing data into an error result for the model.
+// Other failures are reported the same way, without their details.
+func runCoachTool(ctx context.Context, tools map[string]coachTool, tc ai.ToolCall) any {
+	t, ok := tools[tc.Name]
+	if !ok {
+		return map[string]any{"error": "unknown_tool"}
+	}
+	var args map[string]any
+	if len(tc.Arguments) > 0 {
+		if err := json.Unmarshal(tc.Arguments, &args); err != nil {
+			return map[string]any{"error": "invalid_arguments"}
+		}
+	}
+
+	out, err := t.run(ctx, args)
+	var input errToolInput
+	switch {
+	case err == nil:
+		return out
+	case errors.As(err, &input):
+		return map[string]any{"error": "invalid_arguments", "message": input.Error()}
+	case errors.Is(err, custom_err.ErrNotFound):
+		return map[string]any{"error": "not_found"}
+	default:
+		return map[string]any{"error": "unavailable"}
+	}
+}
+
+func (s *aiServiceImpl) coachTools(userID uint) []coachTool {
+	return []coachTool{
+		{
+			def: ai.Tool{
+				Name:        toolGetProfile,
+				Description: "Get the user's age, sex, height, body weight and unit system.",
+				Parameters:  objectSchema(nil),
+			},
+			run: func(ctx context.Context, _ map[string]any) (any, error) {
+				system := s.unitSystem(ctx, userID)
+				out := map[string]any{"unit_system": system}
+				profile, err := s.userService.GetProfile(ctx, userID)
+				if err != nil || profile == nil {
+					return out, nil
+				}
+				out["age"] = profile.Age
+				out["sex"] = profile.Sex
+				out["height"] = units.Length(profile.Height).In(system)
+				out["weight"] = units.Mass(profile.Weight).In(system)
+				return out, nil
+			},
+		},
+		{
+			def: ai.Tool{
+				Name:        toolGetBestSets,
+				Description: "List the user's exercises with their IDs and best set (weight and reps).",
+				Parameters:  objectSchema(nil),
+			},
+			run: func(ctx context.Context, _ map[string]any) (any, error) {
+				system := s.unitSystem(ctx, userID)
+				stats, err := s.workoutService.GetIndividualExerciseStats(ctx, userID)
+				if err != nil {
+					return nil, err
+				}
+				out := make([]map[string]any, 0, len(stats))
+				for _, ie := range stats {
+					row := map[string]any{"id": ie.ID, "exercise_name": ie.Name}
+					if ie.CurrentWeight != 0 || ie.CurrentReps != 0 {
+						row["best_set_weight"] = units.Mass(ie.CurrentWeight).In(system)
+						row["best_set_reps"] = ie.CurrentReps
+					}
+					if ie.IsTimeBased {
+						row["is_time_based"] = true
+					}
+					out = append(out, row)
+				}
+				return map[string]any{"exercises": out}, nil
+			},
+		},
+		{
+			def: ai.Tool{
+				Name:        toolGetExerciseHistory,
+				Description: "Get the best set of each past session of one exercise, oldest first.",
+				Parameters: objectSchema(map[string]any{
+					"exercise_id": map[string]any{"type": "integer", "description": "ID from get_best_sets"},
+					"limit":       map[string]any{"type": "integer", "minimum": 1, "maximum": maxHistoryRows, "description": "Most recent sessions to return (default 20)"},
+				}, "exercise_id"),
+			},
+			run: func(ctx context.Context, args map[string]any) (any, error) {
+				id := intFrom(args["exercise_id"], 0)
+				if id <= 0 {
+					return nil, errToolInput("exercise_id is required")
+				}
+				limit := min(max(intFrom(args["limit"], 20), 1), maxHistoryRows)
+
+				system := s.unitSystem(ctx, userID)
+				history, err := s.workoutService.GetIndividualExercisePerformanceHistory(ctx, userID, uint(id))
+				if err != nil {
+					return nil, err
+				}
+				slices.SortStableFunc(history, func(a, b *workout.ExercisePerformance) int {
+					switch {
+					case a.CompletedAt == nil || b.CompletedAt == nil:
+						return 0
+					default:
+						return a.CompletedAt.Compare(*b.CompletedAt)
+					}
+				})
+				if len(history) > limit {
+					history = history[len(history)-limit:]
+				}
+
+				out := make([]map[string]any, 0, len(history))
+				for _, p := range history {
+					row := map[string]any{}
+					if p.CompletedAt != nil {
+						row["date"] = p.CompletedAt.Format("2006-01-02")
+					}
+					if p.Weight != nil {
+						row["weight"] = units.Mass(*p.Weight).In(system)
+					}
+					if p.Reps != nil {
+						row["reps"] = *p.Reps
+					}
+					out = append(out, row)
+				}
+				return map[string]any{"sessions": out}, nil
+			},
+		},
+		{
+			def: ai.Tool{
+				Name:        toolListCycles,
+				Description: "List the weeks (cycles) of the user's active workout plan.",
+				Parameters:  objectSchema(nil),
+			},
+			run: func(ctx context.Context, _ map[string]any) (any, error) {
+				plan, err := s.workoutService.GetActivePlanByUserID(ctx, userID)
+				if err != nil || plan == nil {
+					return map[string]any{"active_plan": nil}, nil
+				}
+				cycles, err := s.workoutService.GetWorkoutCyclesByWorkoutPlanID(ctx, userID, plan.ID)
+				if err != nil {
+					return nil, err
+				}
+				slices.SortFunc(cycles, func(a, b *workout.WorkoutCycle) int { return a.WeekNumber - b.WeekNumber })
+
+				out := make([]map[string]any, 0, len(cycles))
+				for _, c := range cycles {
+					out = append(out, map[string]any{
+						"week_number": c.WeekNumber,
+						"name":        c.Name,
+						"completed":   c.Completed,
+						"skipped":     c.Skipped,
+						"current":     plan.CurrentCycleID != nil && *plan.CurrentCycleID == c.ID,
+					})
+				}
+				return map[string]any{"active_plan": plan.Name, "cycles": out}, nil
+			},
+		},
+		{
+			def: ai.Tool{
+				Name:        toolGetCycle,
+				Description: "Get the workouts and exercises of one week of the active plan.",
+				Parameters: objectSchema(map[string]any{
+					"week_number": map[string]any{"type": "integer", "minimum": 1, "description": "Week from list_cycles; omit for the current week"},
+				}),
+			},
+			run: func(ctx context.Context, args map[string]any) (any, error) {
+				plan, err := s.workoutService.GetActivePlanByUserID(ctx, userID)
+				if err != nil || plan == nil {
+					return map[string]any{"active_plan": nil}, nil
+				}
+
+				var cycleID uint
+				if week := intFrom(args["week_number"], 0); week > 0 {
+					cycles, err := s.workoutService.GetWorkoutCyclesByWorkoutPlanID(ctx, userID, plan.ID)
+					if err != nil {
+						return nil, err
+					}
+					for _, c := range cycles {
+						if c.WeekNumber == week {
+							cycleID = c.ID
+						}
+					}
+				} else if plan.CurrentCycleID != nil {
+					cycleID = *plan.CurrentCycleID
+				}
+				if cycleID == 0 {
+					return nil, custom_err.ErrNotFound
+				}
+
+				cycle, err := s.workoutService.GetWorkoutCycleByID(ctx, userID, plan.ID, cycleID)
+				if err != nil {
+					return nil, err
+				}
+				return map[string]any{
+					"week_number": cycle.WeekNumber,
+					"name":        cycle.Name,
+					"completed":   cycle.Completed,
+					"workouts":    BuildProcessedCycle(cycle),
+				}, nil
+			},
+		},
+	}
+}
+
+// unitSystem returns the display system of the user, metric unless they chose imperial.
+func (s *aiServiceImpl) unitSystem(ctx context.Context, userID uint) units.System {
+	settings, err := s.userService.GetUserSettings(ctx, userID)
+	if err == nil && settings != nil && settings.UnitSystem == string(units.Imperial) {
+		return units.Imperial
+	}
+	return units.Metric
+}
+
+func objectSchema(props map[string]any, required ...string) map[string]any {
+	if props == nil {
+		props = map[string]any{}
+	}
+	if required == nil {
+		required = []string{}
+	}
+	return map[string]any{
+		"type":                 "object",
+		"properties":           props,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
@@ -0,0 +1,50 @@
+package ai
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+)
+
+func (s *aiServiceImpl) ListConversations(ctx context.Context, userID uint, page, pageSize int64) ([]*ai.Conversation, int64, error) {
+	return s.conversations.GetByUserID(ctx, userID, page, pageSize)
+}
+
+// GetConversation returns the conversation with its messages.
+func (s *aiServiceImpl) GetConversation(ctx context.Context, userID, id uint) (*ai.Conversation, error) {
+	c, err := s.conversations.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	c.Messages, err = s.conversations.GetMessages(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *aiServiceImpl) RenameConversation(ctx context.Context, userID, id uint, title string) (*ai.Conversation, error) {
+	title = strings.TrimSpace(title)
+	if title == "" || utf8.RuneCountInString(title) > ai.MaxTitleLength {
+		return nil, custom_err.ErrInvalidTitle
+	}
+	if err := s.conversations.Update(ctx, userID, id, map[string]any{"title": title}); err != nil {
+		return nil, err
+	}
+	return s.conversations.GetByID(ctx, userID, id)
+}
+
+func (s *aiServiceImpl) DeleteConversation(ctx context.Context, userID, id uint) error {
+	return s.conversations.Delete(ctx, userID, id)
+}
+
+func (s *aiServiceImpl) DeleteConversations(ctx context.Context, userID uint) error {
+	return s.conversations.DeleteByUserID(ctx, userID)
+}
+
+func (s *aiServiceImpl) ExportConversations(ctx context.Context, userID uint) ([]*ai.Conversation, error) {
+	return s.conversations.GetAllWithMessages(ctx, userID)
+}
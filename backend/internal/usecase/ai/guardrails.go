@@ -0,0 +1,161 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"unicode/utf8"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+)
+
+const (
+	screenMaxTokens = 256
+	// blockedInputMax bounds how much of a blocked input is kept for review.
+	blockedInputMax = 2000
+)
+
+// screenedInput is a user's free text once it has passed the guardrails.
+type screenedInput struct {
+	// Text is the input with PII scrubbed; only this is sent on or stored.
+	Text     string
+	Category string
+}
+
+// refusals are the answers to blocked input, by language and category.
+var refusals = map[string]map[string]string{
+	"en": {
+		ai.InputOffTopic: "I can only help with training, exercise and fitness. Ask me about your workouts, your progress or your goals.",
+		ai.InputUnsafe: "I can't help with that. If you are thinking about harming yourself or feel unsafe, " +
+			"please contact your local emergency number or a crisis line now.",
+	},
+	"ru": {
+		ai.InputOffTopic: "Я могу помочь только с тренировками, упражнениями и фитнесом. Спросите меня о ваших тренировках, прогрессе или целях.",
+		ai.InputUnsafe: "Я не могу с этим помочь. Если вы думаете о том, чтобы причинить себе вред, или чувствуете себя в опасности, " +
+			"пожалуйста, немедленно обратитесь в местную экстренную службу или на линию психологической помощи.",
+	},
+	"zh": {
+		ai.InputOffTopic: "我只能回答与训练、运动和健身相关的问题。您可以问我关于训练、进展或目标的问题。",
+		ai.InputUnsafe:   "我无法协助处理这个请求。如果您有伤害自己的想法或感到不安全，请立即拨打当地急救电话或心理危机热线。",
+	},
+}
+
+func refusalFor(lang, category string) string {
+	for _, locale := range ai.LocaleFallbacks(lang) {
+		if msg, ok := refusals[locale][category]; ok {
+			return msg
+		}
+	}
+	return refusals[ai.DefaultLocale][ai.InputOffTopic]
+}
+
+// screenInput scrubs PII from the user's text and classifies it. Off-topic and unsafe
+// input is recorded and rejected with an *ai.BlockedError holding the refusal in the
+// user's language. A failed classification lets the input through.
+func (s *aiServiceImpl) screenInput(ctx context.Context, userID uint, endpoint, lang, text string) (*screenedInput, error) {
+	var identifiers []string
+	if u, err := s.userService.Me(ctx, userID); err == nil {
+		identifiers = append(identifiers, u.Username, u.Email)
+	}
+	in := &screenedInput{Text: ai.ScrubPII(text, identifiers...), Category: ai.InputFitness}
+
+	category, err := s.classify(ctx, userID, in.Text)
+	if err != nil {
+		log.Printf("ai input of user %d not classified, letting it through: %v", userID, err)
+		return in, nil
+	}
+	in.Category = category
+	if !ai.Blocked(category) {
+		return in, nil
+	}
+
+	input := in.Text
+	if utf8.RuneCountInString(input) > blockedInputMax {
+		input = string([]rune(input)[:blockedInputMax])
+	}
+	err = s.blocked.Create(context.WithoutCancel(ctx), &ai.BlockedRequest{
+		UserID:   userID,
+		Endpoint: endpoint,
+		Category: category,
+		Input:    input,
+	})
+	if err != nil {
+		log.Printf("ai blocked request record failed for user %d: %v", userID, err)
+	}
+	return nil, &ai.BlockedError{Category: category, Refusal: refusalFor(lang, category)}
+}
+
+func (s *aiServiceImpl) classify(ctx context.Context, userID uint, text string) (string, error) {
+	instructions := s.render(ctx, ai.PromptGuardrailClassify, "", nil)
+	resp, err := s.metered(userID, ai.EndpointModeration).Chat(ctx, ai.Request{
+		Instructions:   instructions.Text,
+		Messages:       []ai.Message{ai.UserMessage(text)},
+		ResponseFormat: classificationFormat(),
+		MaxTokens:      screenMaxTokens,
+		PromptVersion:  instructions.Version,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var out struct {
+		Category string `json:"category"`
+	}
+	if err := json.Unmarshal([]byte(resp.Message.Content), &out); err != nil {
+		return "", err
+	}
+	for _, c := range ai.InputCategories {
+		if out.Category == c {
+			return c, nil
+		}
+	}
+	return "", errors.New("unknown input category " + out.Category)
+}
+
+func classificationFormat() *ai.Schema {
+	return &ai.Schema{
+		Name: "InputCategory",
+		Schema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"category": map[string]any{"type": "string", "enum": ai.InputCategories},
+			},
+			"required":             []any{"category"},
+			"additionalProperties": false,
+		},
+		Strict: true,
+	}
+}
+
+// withPolicy adds the medical-advice policy to a prompt built from an injury question.
+func (s *aiServiceImpl) withPolicy(ctx context.Context, lang string, in *screenedInput, prompt renderedPrompt) renderedPrompt {
+	if in.Category != ai.InputInjury {
+		return prompt
+	}
+	policy := s.render(ctx, ai.PromptGuardrailMedicalPolicy, lang, nil)
+	return renderedPrompt{
+		Text:    prompt.Text + "\n\n" + policy.Text,
+		Version: ai.JoinPromptVersions(prompt.Version, policy.Version),
+	}
+}
+
+// refuse answers a blocked question with its refusal, streamed like an answer would
+// be. The conversation, if there is one, is left as it was. Other errors are returned.
+func refuse(err error, previousResponseID string, onDelta func(delta string) error) (string, string, error) {
+	var blocked *ai.BlockedError
+	if !errors.As(err, &blocked) {
+		return "", "", err
+	}
+	if onDelta != nil {
+		if err := onDelta(blocked.Refusal); err != nil {
+			return "", "", err
+		}
+	}
+	return blocked.Refusal, previousResponseID, nil
+}
+
+// ListBlockedRequests returns the inputs the guardrails refused, newest first.
+func (s *aiServiceImpl) ListBlockedRequests(ctx context.Context, category string, page, pageSize int64) ([]*ai.BlockedRequest, int64, error) {
+	return s.blocked.GetAll(ctx, category, page, pageSize)
+}
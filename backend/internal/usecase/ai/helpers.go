@@ -1,26 +1,28 @@
 package ai
 
 import (
+	"math"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/goal"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/shared/units"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
-	"math"
 )
 
 func BuildProcessedStats(stats []*workout.IndividualExercise, profile *user.Profile, unitSystem string) []map[string]any {
 	processedStats := []map[string]any{}
-	imperial := unitSystem == "imperial"
+	system := units.Metric
+	if unitSystem == string(units.Imperial) {
+		system = units.Imperial
+	}
 
 	for _, stat := range stats {
 		if stat.CurrentWeight == 0 && stat.CurrentReps == 0 {
 			continue
 		}
 
-		var weight float64
-		if imperial {
-			weight = math.Round((float64(stat.CurrentWeight)/453.59237)*100) / 100
-		} else {
-			weight = math.Round((float64(stat.CurrentWeight)/1000)*100) / 100
-		}
+		weight := units.Mass(stat.CurrentWeight).In(system)
 
 		statMap := map[string]any{
 			"exercise_name":   stat.Name,
@@ -34,19 +36,8 @@ func BuildProcessedStats(stats []*workout.IndividualExercise, profile *user.Prof
 	}
 
 	if profile != nil {
-		var weight float64
-		if imperial {
-			weight = math.Round((float64(profile.Weight)/453.59237)*100) / 100
-		} else {
-			weight = math.Round((float64(profile.Weight)/1000)*100) / 100
-		}
-
-		var height float64
-		if imperial {
-			height = math.Round((float64(profile.Height)/304.8)*100) / 100
-		} else {
-			height = math.Round((float64(profile.Height)/10)*100) / 100
-		}
+		weight := units.Mass(profile.Weight).In(system)
+		height := units.Length(profile.Height).In(system)
 
 		processedStats = append(processedStats, map[string]any{
 			"user_weight": weight,
@@ -59,6 +50,51 @@ func BuildProcessedStats(stats []*workout.IndividualExercise, profile *user.Prof
 	return processedStats
 }
 
+// BuildProcessedGoals describes goals for the prompt. Weights are converted to the
+// user's unit system; frequency goals report weeks.
+func BuildProcessedGoals(goals []*goal.Tracked, unitSystem string) []map[string]any {
+	processedGoals := []map[string]any{}
+	system := units.Metric
+	if unitSystem == string(units.Imperial) {
+		system = units.Imperial
+	}
+
+	for _, t := range goals {
+		g, p := t.Goal, t.Progress
+		goalMap := map[string]any{
+			"goal_type": g.Kind,
+			"progress":  math.Round(p.Fraction*100) / 100,
+		}
+		switch g.Kind {
+		case goal.KindLift:
+			if g.IndividualExercise != nil {
+				goalMap["exercise_name"] = g.IndividualExercise.Name
+			}
+			goalMap["metric"] = g.Metric
+			goalMap["target_weight"] = units.Mass(g.TargetWeight).In(system)
+			goalMap["current_weight"] = units.Mass(p.Current).In(system)
+			if g.Metric == goal.MetricWeightReps {
+				goalMap["target_reps"] = g.TargetReps
+			}
+		case goal.KindBodyweight:
+			goalMap["start_weight"] = units.Mass(g.StartWeight).In(system)
+			goalMap["target_weight"] = units.Mass(g.TargetWeight).In(system)
+			goalMap["current_weight"] = units.Mass(p.Current).In(system)
+		case goal.KindFrequency:
+			goalMap["workouts_per_week"] = g.PerWeek
+			goalMap["target_weeks"] = g.Weeks
+			goalMap["current_weeks"] = p.Current
+			goalMap["workouts_this_week"] = p.ThisWeek
+		}
+		if g.Deadline != nil {
+			goalMap["deadline"] = g.Deadline.Format(time.DateOnly)
+		}
+		processedGoals = append(processedGoals, goalMap)
+	}
+
+	return processedGoals
+}
+
 func BuildProcessedCycle(cycle *workout.WorkoutCycle) []map[string]any {
 	processedCycle := []map[string]any{}
 
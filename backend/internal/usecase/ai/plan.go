@@ -0,0 +1,345 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+)
+
+const (
+	maxPlanHops       = 15
+	minDistinctGroups = 4
+	maxPlanRepairs    = 2
+	// planMaxTokens caps each completion of a plan; the tool loop may make several.
+	planMaxTokens = 8192
+
+	toolListMuscleGroups = "list_muscle_groups"
+	toolSearchExercises  = "search_exercises_by_muscle_group"
+)
+
+func workoutPlanFormat() *ai.Schema {
+	return &ai.Schema{Name: "WorkoutPlan", Schema: WorkoutPlanJSONSchema(), Strict: true}
+}
+
+type exerciseWithMuscleGroupDto struct {
+	Name            string `json:"name"`
+	MuscleGroupName string `json:"muscle_group_name"`
+	Slug            string `json:"slug"`
+}
+
+// generatePlan asks for a plan in one structured-output call, with the whole exercise
+// catalog in the instructions.
+func (s *aiServiceImpl) generatePlan(ctx context.Context, llm ai.LLM, lang string, prompt renderedPrompt, exercises []*workout.Exercise) (*generatedPlan, error) {
+	exercisesClean := make([]*exerciseWithMuscleGroupDto, 0, len(exercises))
+	for _, ex := range exercises {
+		exercisesClean = append(exercisesClean, &exerciseWithMuscleGroupDto{
+			Name:            ex.Name,
+			MuscleGroupName: ex.MuscleGroup.Name,
+			Slug:            ex.Slug,
+		})
+	}
+	exerciseList, err := json.MarshalIndent(exercisesClean, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal exercise list: %w", err)
+	}
+
+	instructions := s.render(ctx, ai.PromptPlanInstructions, lang, map[string]any{"Exercises": string(exerciseList)})
+	resp, err := llm.Chat(ctx, ai.Request{
+		Instructions:   instructions.Text,
+		Messages:       []ai.Message{ai.UserMessage(prompt.Text)},
+		ResponseFormat: workoutPlanFormat(),
+		MaxTokens:      planMaxTokens,
+		PromptVersion:  ai.JoinPromptVersions(instructions.Version, prompt.Version),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return decodePlan(resp.Message.Content)
+}
+
+func decodePlan(raw string) (*generatedPlan, error) {
+	if raw == "" {
+		return nil, errors.New("empty model output")
+	}
+	var plan *generatedPlan
+	if err := json.Unmarshal([]byte(raw), &plan); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w; raw=%s", err, raw)
+	}
+	return plan, nil
+}
+
+func listMuscleGroupsTool() ai.Tool {
+	return ai.Tool{
+		Name:        toolListMuscleGroups,
+		Description: "Return a list of available muscle-group names.",
+		Parameters: map[string]any{
+			"type":                 "object",
+			"additionalProperties": false,
+			"properties": map[string]any{
+				"limit": map[string]any{"type": "integer", "minimum": 1, "maximum": 100},
+			},
+		},
+	}
+}
+
+// searchExercisesTool restricts group_query to allowed when it is non-empty.
+func searchExercisesTool(allowed []string) ai.Tool {
+	groupQuery := map[string]any{"type": "string"}
+	if len(allowed) > 0 {
+		groupQuery["enum"] = toAnySlice(allowed)
+	}
+	return ai.Tool{
+		Name:        toolSearchExercises,
+		Description: "Find exercises by muscle-group name filter (e.g., 'chest'). Returns a small list.",
+		Parameters: map[string]any{
+			"type":                 "object",
+			"additionalProperties": false,
+			"required":             []any{"group_query"},
+			"properties": map[string]any{
+				"group_query": groupQuery,
+				"limit":       map[string]any{"type": "integer", "minimum": 1, "maximum": 20},
+				"offset":      map[string]any{"type": "integer", "minimum": 0},
+			},
+		},
+	}
+}
+
+type exerciseWithSlugDto struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// planSearch tracks which muscle groups the model has looked at so far.
+type planSearch struct {
+	groups    []string
+	groupsLC  []string
+	selected  map[string]struct{}
+	sawSearch bool
+}
+
+func (p *planSearch) remaining() []string {
+	var out []string
+	for _, g := range p.groups {
+		if _, ok := p.selected[strings.ToLower(g)]; !ok {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+// generatePlanWithTools lets the model browse the exercise database through tools. A
+// final answer is only accepted once it has searched at least minDistinctGroups
+// distinct muscle groups (or all of them, if there are fewer); until then it is
+// steered back with a forced tool call.
+func (s *aiServiceImpl) generatePlanWithTools(
+	ctx context.Context,
+	llm ai.LLM,
+	lang string,
+	prompt renderedPrompt,
+	listMuscleGroups func(ctx context.Context, limit int) ([]string, error),
+	searchExercises func(ctx context.Context, groupQuery string, limit, offset int) ([]map[string]any, error),
+) (*generatedPlan, error) {
+	allTools := []ai.Tool{listMuscleGroupsTool(), searchExercisesTool(nil)}
+	instructions := s.render(ctx, ai.PromptPlanToolsInstructions, lang, nil)
+	req := ai.Request{
+		Instructions:   instructions.Text,
+		Messages:       []ai.Message{ai.UserMessage(prompt.Text)},
+		Tools:          allTools,
+		ToolChoice:     ai.ToolChoiceAuto,
+		ResponseFormat: workoutPlanFormat(),
+		MaxTokens:      planMaxTokens,
+		PromptVersion:  ai.JoinPromptVersions(instructions.Version, prompt.Version),
+	}
+	st := &planSearch{selected: map[string]struct{}{}}
+
+	for range maxPlanHops {
+		resp, err := llm.Chat(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		msg := resp.Message
+		req.Messages = append(req.Messages, msg)
+
+		if len(msg.ToolCalls) == 0 {
+			plan, err := decodePlan(msg.Content)
+			if err != nil {
+				return nil, err
+			}
+
+			needed := min(minDistinctGroups, len(st.groups))
+			switch {
+			case !st.sawSearch && len(st.groups) > 0:
+				req.Messages = append(req.Messages, ai.SystemMessage("Before producing the final JSON, call search_exercises_by_muscle_group at least once."))
+				req.Tools, req.ToolChoice = allTools, toolSearchExercises
+			case needed == 0:
+				req.Messages = append(req.Messages, ai.SystemMessage("Call list_muscle_groups first, then iteratively search distinct groups."))
+				req.Tools, req.ToolChoice = []ai.Tool{listMuscleGroupsTool()}, toolListMuscleGroups
+			case len(st.selected) < needed:
+				done := make([]string, 0, len(st.selected))
+				for g := range st.selected {
+					done = append(done, g)
+				}
+				slices.Sort(done)
+				remaining := st.remaining()
+				req.Messages = append(req.Messages, ai.SystemMessage(fmt.Sprintf(
+					"You have searched %d distinct muscle groups: %v. "+
+						"Search more DISTINCT groups until you reach at least %d in total. "+
+						"Pick your next group from: %v",
+					len(done), done, needed, remaining)))
+				req.Tools, req.ToolChoice = []ai.Tool{searchExercisesTool(remaining)}, toolSearchExercises
+			default:
+				return plan, nil
+			}
+			continue
+		}
+
+		hadList, hadSearchError := false, false
+		for _, tc := range msg.ToolCalls {
+			var args map[string]any
+			_ = json.Unmarshal(tc.Arguments, &args)
+
+			switch tc.Name {
+			case toolListMuscleGroups:
+				hadList = true
+				names, err := listMuscleGroups(ctx, intFrom(args["limit"], 100))
+				if err != nil {
+					return nil, err
+				}
+				st.groups, st.groupsLC = names, toLowerSlice(names)
+				req.Messages = append(req.Messages, ai.ToolMessage(tc.ID, string(mustJSON(map[string]any{"muscle_groups": names}))))
+
+			case toolSearchExercises:
+				received := stringFrom(args["group_query"], "")
+				q := strings.TrimSpace(strings.ToLower(received))
+				if !slices.Contains(st.groupsLC, q) {
+					hadSearchError = true
+					req.Messages = append(req.Messages, ai.ToolMessage(tc.ID, string(mustJSON(map[string]any{
+						"error":                 "unknown_muscle_group",
+						"message":               "Pick a muscle_group from the provided list.",
+						"allowed_muscle_groups": st.groups,
+						"received":              received,
+					}))))
+					continue
+				}
+
+				rows, err := searchExercises(ctx, q, intFrom(args["limit"], 10), intFrom(args["offset"], 0))
+				if err != nil {
+					return nil, err
+				}
+				rowsClean := make([]*exerciseWithSlugDto, 0, len(rows))
+				for _, r := range rows {
+					name, _ := r["name"].(string)
+					slug, _ := r["slug"].(string)
+					rowsClean = append(rowsClean, &exerciseWithSlugDto{Name: name, Slug: slug})
+				}
+				st.sawSearch = true
+				st.selected[q] = struct{}{}
+				req.Messages = append(req.Messages, ai.ToolMessage(tc.ID, string(mustJSON(map[string]any{"exercises": rowsClean}))))
+
+			default:
+				req.Messages = append(req.Messages, ai.ToolMessage(tc.ID, string(mustJSON(map[string]any{"error": "unknown_tool"}))))
+			}
+		}
+		if hadList {
+			req.Messages = append(req.Messages, ai.SystemMessage(fmt.Sprintf(
+				"Iteratively call search_exercises_by_muscle_group for DISTINCT muscle groups from this list. "+
+					"Gather enough exercises to assemble a complete multi-day plan. Pick one group at a time, then repeat. "+
+					"Available groups: %v", st.groups)))
+		}
+
+		needed := min(minDistinctGroups, len(st.groups))
+		switch {
+		case hadSearchError && len(st.groups) > 0:
+			req.Tools, req.ToolChoice = []ai.Tool{searchExercisesTool(st.groups)}, toolSearchExercises
+		case hadList && len(st.selected) < needed:
+			req.Tools, req.ToolChoice = []ai.Tool{searchExercisesTool(st.remaining())}, toolSearchExercises
+		default:
+			req.Tools, req.ToolChoice = allTools, ai.ToolChoiceAuto
+		}
+	}
+
+	return nil, errors.New("too many tool-call hops without final output")
+}
+
+// saveGeneratedPlan validates the plan and fixes what it can itself. The rest goes back
+// to the model, at most maxPlanRepairs times, before the plan is saved as a draft.
+func (s *aiServiceImpl) saveGeneratedPlan(ctx context.Context, llm ai.LLM, userID uint, lang string, prompt renderedPrompt, days int, plan *generatedPlan, catalog *exerciseCatalog) (*workout.WorkoutPlan, error) {
+	instructions := s.render(ctx, ai.PromptPlanRepairInstructions, lang, nil)
+	for attempt := 0; ; attempt++ {
+		issues := validatePlan(plan, days, catalog)
+		if len(issues) == 0 {
+			break
+		}
+		if attempt == maxPlanRepairs {
+			return nil, fmt.Errorf("%w: %s", custom_err.ErrInvalidGeneratedPlan, strings.Join(issues, " "))
+		}
+
+		resp, err := llm.Chat(ctx, ai.Request{
+			Instructions: instructions.Text,
+			Messages: []ai.Message{
+				ai.UserMessage(prompt.Text),
+				{Role: ai.RoleAssistant, Content: string(mustJSON(plan))},
+				ai.UserMessage("Fix these problems:\n- " + strings.Join(issues, "\n- ")),
+			},
+			ResponseFormat: workoutPlanFormat(),
+			MaxTokens:      planMaxTokens,
+			PromptVersion:  ai.JoinPromptVersions(instructions.Version, prompt.Version),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if plan, err = decodePlan(resp.Message.Content); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.workoutService.CreateWorkoutPlanWithWorkouts(ctx, userID,
+		&workout.WorkoutPlan{Name: strings.TrimSpace(plan.Name), UserID: userID, Draft: true},
+		plan.toWorkouts(catalog),
+	)
+}
+
+func toAnySlice(ss []string) []any {
+	out := make([]any, len(ss))
+	for i, s := range ss {
+		out[i] = s
+	}
+	return out
+}
+
+func toLowerSlice(ss []string) []string {
+	out := make([]string, len(ss))
+	for i, s := range ss {
+		out[i] = strings.ToLower(s)
+	}
+	return out
+}
+
+func intFrom(v any, def int) int {
+	switch t := v.(type) {
+	case float64:
+		return int(t)
+	case int:
+		return t
+	}
+	return def
+}
+
+func stringFrom(v any, def string) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return def
+}
+
+func mustJSON(v any) []byte {
+	b, _ := json.Marshal(v)
+	return b
+}
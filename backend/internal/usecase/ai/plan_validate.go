@@ -0,0 +1,206 @@
+package ai
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+)
+
+const (
+	// maxSetsPerExercise matches what WorkoutService accepts for a workout exercise.
+	maxSetsPerExercise = 20
+	maxExercisesPerDay = 12
+	// maxSlugSuggestions bounds the candidates offered for an unknown slug on repair.
+	maxSlugSuggestions = 3
+)
+
+// generatedPlan is the model's answer, shaped by WorkoutPlanJSONSchema.
+type generatedPlan struct {
+	Name         string                  `json:"name"`
+	DaysPerCycle int                     `json:"days_per_cycle"`
+	Days         map[string]generatedDay `json:"workouts_in_a_cycle"`
+}
+
+type generatedDay struct {
+	Name      string              `json:"name"`
+	Exercises []generatedExercise `json:"exercises"`
+}
+
+type generatedExercise struct {
+	Slug string `json:"slug"`
+	Sets int    `json:"sets"`
+}
+
+// exerciseCatalog resolves the slugs the model picked against the exercise database.
+type exerciseCatalog struct {
+	bySlug map[string]*workout.Exercise
+	all    []*workout.Exercise
+}
+
+func newExerciseCatalog(exercises []*workout.Exercise) *exerciseCatalog {
+	c := &exerciseCatalog{bySlug: make(map[string]*workout.Exercise, len(exercises)), all: exercises}
+	for _, ex := range exercises {
+		c.bySlug[ex.Slug] = ex
+	}
+	return c
+}
+
+// resolve returns the exercise for slug, correcting near misses: case and separators
+// first, then a single closest slug or name within a few edits.
+func (c *exerciseCatalog) resolve(slug string) (*workout.Exercise, bool) {
+	if ex, ok := c.bySlug[slug]; ok {
+		return ex, true
+	}
+	norm := normalizeSlug(slug)
+	if ex, ok := c.bySlug[norm]; ok {
+		return ex, true
+	}
+
+	limit := max(1, len(norm)/5)
+	var best *workout.Exercise
+	bestDist, tie := limit+1, false
+	for _, ex := range c.all {
+		d := min(editDistance(norm, ex.Slug), editDistance(norm, normalizeSlug(ex.Name)))
+		switch {
+		case d < bestDist:
+			best, bestDist, tie = ex, d, false
+		case d == bestDist && best != nil && best.ID != ex.ID:
+			tie = true
+		}
+	}
+	if best == nil || tie {
+		return nil, false
+	}
+	return best, true
+}
+
+// suggest returns the slugs closest to slug, for the repair prompt.
+func (c *exerciseCatalog) suggest(slug string) []string {
+	norm := normalizeSlug(slug)
+	type candidate struct {
+		slug string
+		dist int
+	}
+	candidates := make([]candidate, 0, len(c.all))
+	for _, ex := range c.all {
+		candidates = append(candidates, candidate{ex.Slug, min(editDistance(norm, ex.Slug), editDistance(norm, normalizeSlug(ex.Name)))})
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int { return a.dist - b.dist })
+
+	out := make([]string, 0, maxSlugSuggestions)
+	for _, cand := range candidates[:min(maxSlugSuggestions, len(candidates))] {
+		out = append(out, cand.slug)
+	}
+	return out
+}
+
+// validatePlan checks the plan against the request and the catalog, fixing slugs it
+// can resolve in place. It returns what is still wrong, worded for the model.
+func validatePlan(p *generatedPlan, days int, catalog *exerciseCatalog) []string {
+	var issues []string
+	if strings.TrimSpace(p.Name) == "" {
+		issues = append(issues, "The plan has no name.")
+	}
+	if len(p.Days) != days {
+		issues = append(issues, fmt.Sprintf("The plan has %d days, but the user asked for %d days per week. Return exactly %d days, keyed \"1\" to \"%d\".", len(p.Days), days, days, days))
+	}
+
+	for _, key := range sortedDayKeys(p.Days) {
+		day := p.Days[key]
+		if n, err := strconv.Atoi(key); err != nil || n < 1 || n > days {
+			issues = append(issues, fmt.Sprintf("Day key %q is invalid; use \"1\" to \"%d\".", key, days))
+		}
+		if len(day.Exercises) == 0 {
+			issues = append(issues, fmt.Sprintf("Day %s has no exercises.", key))
+		}
+		if len(day.Exercises) > maxExercisesPerDay {
+			issues = append(issues, fmt.Sprintf("Day %s has %d exercises; use at most %d.", key, len(day.Exercises), maxExercisesPerDay))
+		}
+		for i, ex := range day.Exercises {
+			if resolved, ok := catalog.resolve(ex.Slug); ok {
+				day.Exercises[i].Slug = resolved.Slug
+			} else {
+				issues = append(issues, fmt.Sprintf("Day %s: unknown exercise slug %q. Closest existing slugs: %s.", key, ex.Slug, strings.Join(catalog.suggest(ex.Slug), ", ")))
+			}
+			if ex.Sets < 1 || ex.Sets > maxSetsPerExercise {
+				issues = append(issues, fmt.Sprintf("Day %s: %q has %d sets; use 1 to %d.", key, ex.Slug, ex.Sets, maxSetsPerExercise))
+			}
+		}
+	}
+	return issues
+}
+
+// toWorkouts turns a validated plan into the workouts of its first week.
+func (p *generatedPlan) toWorkouts(catalog *exerciseCatalog) []*workout.Workout {
+	workouts := make([]*workout.Workout, 0, len(p.Days))
+	for _, key := range sortedDayKeys(p.Days) {
+		day := p.Days[key]
+		name := strings.TrimSpace(day.Name)
+		if name == "" {
+			name = "Day " + key
+		}
+		w := &workout.Workout{Name: name}
+		for _, ex := range day.Exercises {
+			resolved := catalog.bySlug[ex.Slug]
+			w.WorkoutExercises = append(w.WorkoutExercises, &workout.WorkoutExercise{
+				IndividualExercise: &workout.IndividualExercise{ExerciseID: &resolved.ID},
+				SetsQt:             int64(ex.Sets),
+			})
+		}
+		workouts = append(workouts, w)
+	}
+	return workouts
+}
+
+// sortedDayKeys orders day keys numerically; keys that are not numbers go last.
+func sortedDayKeys(days map[string]generatedDay) []string {
+	keys := make([]string, 0, len(days))
+	for k := range days {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		na, errA := strconv.Atoi(a)
+		nb, errB := strconv.Atoi(b)
+		switch {
+		case errA == nil && errB == nil:
+			return na - nb
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	return keys
+}
+
+func normalizeSlug(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-'
+	}), "-")
+}
+
+// editDistance is the Levenshtein distance between a and b, in bytes.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
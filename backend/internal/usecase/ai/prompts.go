@@ -0,0 +1,224 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+)
+
+// promptCacheTTL bounds how long an edit made on another instance takes to show up.
+// Edits made on this one apply at once.
+const promptCacheTTL = time.Minute
+
+// renderedPrompt is a filled-in template and the version that produced it.
+type renderedPrompt struct {
+	Text    string
+	Version string
+}
+
+type compiledPrompt struct {
+	tmpl    *template.Template
+	version int
+}
+
+// promptCache holds the compiled active templates by name and locale, and the
+// built-in defaults.
+type promptCache struct {
+	repo    ai.PromptTemplateRepository
+	builtin map[string]*template.Template
+
+	mu       sync.RWMutex
+	active   map[string]*compiledPrompt
+	loadedAt time.Time
+}
+
+func newPromptCache(repo ai.PromptTemplateRepository) *promptCache {
+	c := &promptCache{repo: repo, builtin: make(map[string]*template.Template, len(promptDefaults))}
+	for name, def := range promptDefaults {
+		c.builtin[name] = template.Must(parsePrompt(name, def.body))
+	}
+	return c
+}
+
+func (c *promptCache) lookup(ctx context.Context, name, locale string) (*compiledPrompt, bool) {
+	c.mu.RLock()
+	active, fresh := c.active, c.active != nil && time.Since(c.loadedAt) < promptCacheTTL
+	c.mu.RUnlock()
+	if !fresh {
+		active = c.reload(ctx)
+	}
+	p, ok := active[name+"/"+locale]
+	return p, ok
+}
+
+// reload keeps what it had when the store cannot be read, and tries again after
+// promptCacheTTL.
+func (c *promptCache) reload(ctx context.Context) map[string]*compiledPrompt {
+	rows, err := c.repo.GetActive(ctx)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadedAt = time.Now()
+	if err != nil {
+		log.Printf("prompt templates not loaded, keeping the previous ones: %v", err)
+		if c.active == nil {
+			c.active = map[string]*compiledPrompt{}
+		}
+		return c.active
+	}
+
+	active := make(map[string]*compiledPrompt, len(rows))
+	for _, r := range rows {
+		t, err := parsePrompt(r.Name, r.Body)
+		if err != nil {
+			log.Printf("prompt %s skipped: %v", ai.PromptVersion(r.Name, r.Locale, r.Version), err)
+			continue
+		}
+		active[r.Name+"/"+r.Locale] = &compiledPrompt{tmpl: t, version: r.Version}
+	}
+	c.active = active
+	return active
+}
+
+func (c *promptCache) invalidate() {
+	c.mu.Lock()
+	c.active = nil
+	c.mu.Unlock()
+}
+
+// render fills in the named prompt for the language: the active version of the most
+// specific locale that has one, else the built-in default. A stored version that fails
+// to render falls back to the default as well.
+func (s *aiServiceImpl) render(ctx context.Context, name, lang string, data map[string]any) renderedPrompt {
+	if data == nil {
+		data = map[string]any{}
+	}
+	var b strings.Builder
+	for _, locale := range ai.LocaleFallbacks(lang) {
+		p, ok := s.prompts.lookup(ctx, name, locale)
+		if !ok {
+			continue
+		}
+		version := ai.PromptVersion(name, locale, p.version)
+		if err := p.tmpl.Execute(&b, data); err != nil {
+			log.Printf("prompt %s failed to render, using the default: %v", version, err)
+			b.Reset()
+			break
+		}
+		return renderedPrompt{Text: b.String(), Version: version}
+	}
+
+	if err := s.prompts.builtin[name].Execute(&b, data); err != nil {
+		log.Printf("default prompt %s failed to render: %v", name, err)
+	}
+	return renderedPrompt{Text: b.String(), Version: ai.PromptVersion(name, ai.DefaultLocale, 0)}
+}
+
+// ListPrompts returns every prompt with its default and stored versions, by name.
+func (s *aiServiceImpl) ListPrompts(ctx context.Context) ([]*ai.PromptSet, error) {
+	rows, err := s.promptRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string][]*ai.PromptTemplate)
+	for _, r := range rows {
+		byName[r.Name] = append(byName[r.Name], r)
+	}
+
+	names := make([]string, 0, len(promptDefaults))
+	for name := range promptDefaults {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	out := make([]*ai.PromptSet, 0, len(names))
+	for _, name := range names {
+		out = append(out, &ai.PromptSet{Name: name, Default: promptDefaults[name].body, Versions: byName[name]})
+	}
+	return out, nil
+}
+
+// CreatePromptVersion stores body as the next version of the prompt in the locale. The
+// body has to render with the data the prompt gets.
+func (s *aiServiceImpl) CreatePromptVersion(ctx context.Context, name, locale, body string, activate bool) (*ai.PromptTemplate, error) {
+	def, ok := promptDefaults[name]
+	if !ok {
+		return nil, custom_err.ErrUnknownPrompt
+	}
+	if err := checkPrompt(name, body, def.sample); err != nil {
+		return nil, err
+	}
+	if locale = ai.NormalizeLocale(locale); locale == "" {
+		locale = ai.DefaultLocale
+	}
+
+	t := &ai.PromptTemplate{Name: name, Locale: locale, Body: body}
+	err := s.tx.DoIfNotInTx(ctx, func(ctx context.Context) error {
+		if err := s.promptRepo.Create(ctx, t); err != nil {
+			return err
+		}
+		if !activate {
+			return nil
+		}
+		t.Active = true
+		return s.promptRepo.SetActive(ctx, name, locale, t.Version)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if activate {
+		s.prompts.invalidate()
+	}
+	return t, nil
+}
+
+// ActivatePromptVersion switches the prompt in the locale to a stored version, or back
+// to the built-in default with version 0.
+func (s *aiServiceImpl) ActivatePromptVersion(ctx context.Context, name, locale string, version int) error {
+	if _, ok := promptDefaults[name]; !ok {
+		return custom_err.ErrUnknownPrompt
+	}
+	if version < 0 {
+		return custom_err.ErrNotFound
+	}
+	if locale = ai.NormalizeLocale(locale); locale == "" {
+		locale = ai.DefaultLocale
+	}
+
+	err := s.tx.DoIfNotInTx(ctx, func(ctx context.Context) error {
+		return s.promptRepo.SetActive(ctx, name, locale, version)
+	})
+	if err != nil {
+		return err
+	}
+	s.prompts.invalidate()
+	return nil
+}
+
+// checkPrompt parses body and renders it with the sample data, so unknown fields are
+// caught on save rather than on a user's request.
+func checkPrompt(name, body string, sample map[string]any) error {
+	t, err := parsePrompt(name, body)
+	if err != nil {
+		return fmt.Errorf("%w: %v", custom_err.ErrInvalidPromptTemplate, err)
+	}
+	if sample == nil {
+		sample = map[string]any{}
+	}
+	if err := t.Execute(io.Discard, sample); err != nil {
+		return fmt.Errorf("%w: %v", custom_err.ErrInvalidPromptTemplate, err)
+	}
+	return nil
+}
+
+func parsePrompt(name, body string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=error").Parse(body)
+}
@@ -0,0 +1,30 @@
+package ai_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase/ai"
+)
+
+func TestCreatePromptVersion_RejectsBadTemplates(t *testing.T) {
+	svc := ai.NewAIService(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, 0, nil, nil, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name, prompt, body string
+		want               error
+	}{
+		{"unknown prompt", "ask.nope", "hi", custom_err.ErrUnknownPrompt},
+		{"syntax error", domain.PromptAskGeneral, "{{.Question", custom_err.ErrInvalidPromptTemplate},
+		{"unknown field", domain.PromptAskGeneral, "{{.Question}} {{.Stats}}", custom_err.ErrInvalidPromptTemplate},
+	}
+	for _, tt := range tests {
+		if _, err := svc.CreatePromptVersion(ctx, tt.prompt, "en", tt.body, false); !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package ai
 
 import (
 	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
 	"github.com/lordmitrii/golang-web-gin/internal/usecase"
 )
 
@@ -9,19 +10,52 @@ type aiServiceImpl struct {
 	workoutService  usecase.WorkoutService
 	exerciseService usecase.ExerciseService
 	userService     usecase.UserService
-	openai          ai.OpenAI
+	goalService     usecase.GoalService
+	versionsService usecase.VersionsService
+	llm             ai.LLM
+	conversations   ai.ConversationRepository
+	usage           ai.UsageRepository
+	quotas          ai.QuotaRepository
+	roles           rbac.RoleRepository
+	defaultLimits   ai.Limits
+	promptRepo      ai.PromptTemplateRepository
+	prompts         *promptCache
+	blocked         ai.BlockedRequestRepository
+
+	tx usecase.TxManager
 }
 
 func NewAIService(
 	workoutService usecase.WorkoutService,
 	exerciseService usecase.ExerciseService,
 	userService usecase.UserService,
-	openai ai.OpenAI,
+	goalService usecase.GoalService,
+	versionsService usecase.VersionsService,
+	llm ai.LLM,
+	conversations ai.ConversationRepository,
+	usage ai.UsageRepository,
+	quotas ai.QuotaRepository,
+	roles rbac.RoleRepository,
+	defaultDailyTokens, defaultMonthlyTokens int64,
+	promptRepo ai.PromptTemplateRepository,
+	blocked ai.BlockedRequestRepository,
+	tx usecase.TxManager,
 ) usecase.AIService {
 	return &aiServiceImpl{
 		workoutService:  workoutService,
 		exerciseService: exerciseService,
-		userService:    userService,
-		openai:         openai,
+		userService:     userService,
+		goalService:     goalService,
+		versionsService: versionsService,
+		llm:             llm,
+		conversations:   conversations,
+		usage:           usage,
+		quotas:          quotas,
+		roles:           roles,
+		defaultLimits:   ai.Limits{DailyTokens: defaultDailyTokens, MonthlyTokens: defaultMonthlyTokens},
+		promptRepo:      promptRepo,
+		prompts:         newPromptCache(promptRepo),
+		blocked:         blocked,
+		tx:              tx,
 	}
 }
@@ -0,0 +1,237 @@
+package ai
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+)
+
+// topUsersLimit bounds the heaviest users listed in the admin report.
+const topUsersLimit = 20
+
+// meteredLLM records the usage of every call it passes on, so tool loops are billed
+// per round trip.
+type meteredLLM struct {
+	llm      ai.LLM
+	usage    ai.UsageRepository
+	userID   uint
+	endpoint string
+}
+
+func (s *aiServiceImpl) metered(userID uint, endpoint string) ai.LLM {
+	return &meteredLLM{llm: s.llm, usage: s.usage, userID: userID, endpoint: endpoint}
+}
+
+func (m *meteredLLM) Chat(ctx context.Context, req ai.Request) (*ai.Response, error) {
+	resp, err := m.llm.Chat(ctx, req)
+	if err != nil {
+		// The provider may have read the prompt before failing.
+		m.record(ctx, req, "", estimateUsage(req, ""))
+		return nil, err
+	}
+	m.record(ctx, req, resp.Model, resp.Usage)
+	return resp, nil
+}
+
+func (m *meteredLLM) Stream(ctx context.Context, req ai.Request, onDelta func(delta string) error) (*ai.Response, error) {
+	var streamed strings.Builder
+	resp, err := m.llm.Stream(ctx, req, func(delta string) error {
+		streamed.WriteString(delta)
+		return onDelta(delta)
+	})
+	if err != nil {
+		// Providers report usage with the last chunk, so a stream cut short, say by the
+		// client going away, is billed on an estimate of what was generated until then.
+		usage, model := estimateUsage(req, streamed.String()), ""
+		if resp != nil {
+			model = resp.Model
+			if resp.Usage != (ai.Usage{}) {
+				usage = resp.Usage
+			}
+		}
+		m.record(ctx, req, model, usage)
+		return nil, err
+	}
+	m.record(ctx, req, resp.Model, resp.Usage)
+	return resp, nil
+}
+
+// record never fails the call: the answer was paid for either way.
+func (m *meteredLLM) record(ctx context.Context, req ai.Request, model string, usage ai.Usage) {
+	err := m.usage.Create(context.WithoutCancel(ctx), &ai.TokenUsage{
+		UserID:        m.userID,
+		Endpoint:      m.endpoint,
+		Model:         model,
+		InputTokens:   usage.InputTokens,
+		OutputTokens:  usage.OutputTokens,
+		PromptVersion: req.PromptVersion,
+	})
+	if err != nil {
+		log.Printf("ai usage record failed for user %d: %v", m.userID, err)
+	}
+}
+
+// charsPerToken is a rough average for English text, used where the provider reports no usage.
+const charsPerToken = 4
+
+// estimateUsage guesses the usage of a call from the length of its prompt and of the
+// output it produced.
+func estimateUsage(req ai.Request, output string) ai.Usage {
+	input := utf8.RuneCountInString(req.Instructions)
+	for _, msg := range req.Messages {
+		input += utf8.RuneCountInString(msg.Content)
+		for _, tc := range msg.ToolCalls {
+			input += len(tc.Arguments)
+		}
+	}
+	return ai.Usage{
+		InputTokens:  int64((input + charsPerToken - 1) / charsPerToken),
+		OutputTokens: int64((utf8.RuneCountInString(output) + charsPerToken - 1) / charsPerToken),
+	}
+}
+
+// limitsFor merges the quotas of the user's roles. Roles without their own quota, and
+// users without roles, get the defaults.
+func (s *aiServiceImpl) limitsFor(ctx context.Context, userID uint) (ai.Limits, error) {
+	roles, err := s.roles.GetUserRoles(ctx, userID)
+	if err != nil {
+		return ai.Limits{}, err
+	}
+	if len(roles) == 0 {
+		return s.defaultLimits, nil
+	}
+
+	ids := make([]uint, 0, len(roles))
+	for _, r := range roles {
+		ids = append(ids, r.ID)
+	}
+	quotas, err := s.quotas.GetByRoleIDs(ctx, ids)
+	if err != nil {
+		return ai.Limits{}, err
+	}
+	byRole := make(map[uint]*ai.TokenQuota, len(quotas))
+	for _, q := range quotas {
+		byRole[q.RoleID] = q
+	}
+
+	limits := make([]ai.Limits, 0, len(roles))
+	for _, r := range roles {
+		if q, ok := byRole[r.ID]; ok {
+			limits = append(limits, ai.Limits{DailyTokens: q.DailyTokens, MonthlyTokens: q.MonthlyTokens})
+		} else {
+			limits = append(limits, s.defaultLimits)
+		}
+	}
+	return ai.MergeLimits(limits...), nil
+}
+
+// checkQuota rejects a request once the user has used up a quota. A request already
+// running is not cut off, so a long tool loop may overshoot a little.
+func (s *aiServiceImpl) checkQuota(ctx context.Context, userID uint) error {
+	limits, err := s.limitsFor(ctx, userID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if limits.DailyTokens > 0 {
+		used, err := s.usage.SumTokens(ctx, userID, ai.DayStart(now))
+		if err != nil {
+			return err
+		}
+		if used >= limits.DailyTokens {
+			return custom_err.ErrQuotaExceeded
+		}
+	}
+	if limits.MonthlyTokens > 0 {
+		used, err := s.usage.SumTokens(ctx, userID, ai.MonthStart(now))
+		if err != nil {
+			return err
+		}
+		if used >= limits.MonthlyTokens {
+			return custom_err.ErrQuotaExceeded
+		}
+	}
+	return nil
+}
+
+func (s *aiServiceImpl) GetUsage(ctx context.Context, userID uint) (*ai.UsageReport, error) {
+	limits, err := s.limitsFor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	day, month := ai.DayStart(now), ai.MonthStart(now)
+
+	usedToday, err := s.usage.SumTokens(ctx, userID, day)
+	if err != nil {
+		return nil, err
+	}
+	usedThisMonth, err := s.usage.SumTokens(ctx, userID, month)
+	if err != nil {
+		return nil, err
+	}
+	nextMonth := month.AddDate(0, 1, 0)
+	byEndpoint, err := s.usage.SummarizeByUser(ctx, userID, month, nextMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ai.UsageReport{
+		Limits:        limits,
+		UsedToday:     usedToday,
+		UsedThisMonth: usedThisMonth,
+		DayResetsAt:   day.AddDate(0, 0, 1),
+		MonthResetsAt: nextMonth,
+		ByEndpoint:    byEndpoint,
+	}, nil
+}
+
+func (s *aiServiceImpl) GetUsageReport(ctx context.Context, from, to time.Time) ([]*ai.UsageSummary, []*ai.UserUsage, error) {
+	summary, err := s.usage.Summarize(ctx, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	top, err := s.usage.TopUsers(ctx, from, to, topUsersLimit)
+	if err != nil {
+		return nil, nil, err
+	}
+	return summary, top, nil
+}
+
+// ListQuotas returns the per-role quotas and the defaults for every other role.
+func (s *aiServiceImpl) ListQuotas(ctx context.Context) ([]*ai.TokenQuota, ai.Limits, error) {
+	quotas, err := s.quotas.GetAll(ctx)
+	if err != nil {
+		return nil, ai.Limits{}, err
+	}
+	return quotas, s.defaultLimits, nil
+}
+
+func (s *aiServiceImpl) SetQuota(ctx context.Context, roleName string, limits ai.Limits) (*ai.TokenQuota, error) {
+	if limits.DailyTokens < 0 || limits.MonthlyTokens < 0 {
+		return nil, custom_err.ErrInvalidQuota
+	}
+	role, err := s.roles.GetByName(ctx, roleName)
+	if err != nil {
+		return nil, err
+	}
+	q := &ai.TokenQuota{RoleID: role.ID, DailyTokens: limits.DailyTokens, MonthlyTokens: limits.MonthlyTokens}
+	if err := s.quotas.Upsert(ctx, q); err != nil {
+		return nil, err
+	}
+	q.Role = *role
+	return q, nil
+}
+
+func (s *aiServiceImpl) DeleteQuota(ctx context.Context, roleName string) error {
+	role, err := s.roles.GetByName(ctx, roleName)
+	if err != nil {
+		return err
+	}
+	return s.quotas.Delete(ctx, role.ID)
+}
@@ -0,0 +1,121 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/shared/units"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+)
+
+const summaryMaxTokens = 600
+
+// SummarizeWorkout reviews a completed workout and stores the summary on it. Users who
+// opted out, have not consented to AI processing or are out of quota are skipped.
+func (s *aiServiceImpl) SummarizeWorkout(ctx context.Context, userID, workoutID uint) error {
+	settings, err := s.userService.GetUserSettings(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !settings.AIWorkoutSummaries {
+		return nil
+	}
+	if err := s.requireChatConsent(ctx, userID); err != nil {
+		if errors.Is(err, custom_err.ErrNoConsent) {
+			return nil
+		}
+		return err
+	}
+	if err := s.checkQuota(ctx, userID); err != nil {
+		if errors.Is(err, custom_err.ErrQuotaExceeded) {
+			return nil
+		}
+		return err
+	}
+
+	w, reviews, err := s.workoutService.ReviewWorkout(ctx, userID, workoutID)
+	if err != nil {
+		return err
+	}
+	if !w.Completed || len(reviews) == 0 {
+		return nil
+	}
+
+	system := units.Metric
+	if settings.UnitSystem == string(units.Imperial) {
+		system = units.Imperial
+	}
+	instructions := s.render(ctx, ai.PromptWorkoutSummaryInstructions, "", nil)
+	resp, err := s.metered(userID, ai.EndpointWorkoutSummary).Chat(ctx, ai.Request{
+		Instructions:  instructions.Text,
+		Messages:      []ai.Message{ai.UserMessage(string(mustJSON(BuildWorkoutReview(w, reviews, system))))},
+		MaxTokens:     summaryMaxTokens,
+		PromptVersion: instructions.Version,
+	})
+	if err != nil {
+		return err
+	}
+
+	summary := strings.TrimSpace(resp.Message.Content)
+	if summary == "" {
+		return nil
+	}
+	return s.workoutService.SaveWorkoutAISummary(ctx, userID, workoutID, summary)
+}
+
+// BuildWorkoutReview shapes the reviews of a workout for the summary prompt.
+func BuildWorkoutReview(w *workout.Workout, reviews []*workout.ExerciseReview, system units.System) map[string]any {
+	exercises := make([]map[string]any, 0, len(reviews))
+	for _, r := range reviews {
+		row := map[string]any{
+			"exercise": r.Name,
+			"trend":    r.Trend(),
+			"sets":     reviewSets(r.Sets, r.IsTimeBased, system),
+		}
+		if len(r.PreviousSets) > 0 {
+			row["previous_sets"] = reviewSets(r.PreviousSets, r.IsTimeBased, system)
+		}
+		if r.BestBefore != nil && r.BestBefore.Weight != nil && r.BestBefore.Reps != nil {
+			row["best_set_before"] = reviewSet(*r.BestBefore.Weight, *r.BestBefore.Reps, r.IsTimeBased, system)
+		}
+		if r.IsBodyweight {
+			row["bodyweight"] = true
+		}
+		exercises = append(exercises, row)
+	}
+
+	unit := "kg"
+	if system == units.Imperial {
+		unit = "lb"
+	}
+	return map[string]any{
+		"workout":     w.Name,
+		"weight_unit": unit,
+		"exercises":   exercises,
+	}
+}
+
+func reviewSets(sets []*workout.WorkoutSet, timeBased bool, system units.System) []map[string]any {
+	out := make([]map[string]any, 0, len(sets))
+	for _, set := range sets {
+		if set.Weight == nil || set.Reps == nil {
+			continue
+		}
+		out = append(out, reviewSet(*set.Weight, *set.Reps, timeBased, system))
+	}
+	return out
+}
+
+// reviewSet reads reps as seconds for time-based exercises.
+func reviewSet(weight, reps int, timeBased bool, system units.System) map[string]any {
+	row := map[string]any{"weight": units.Mass(weight).In(system)}
+	if timeBased {
+		row["seconds"] = reps
+	} else {
+		row["reps"] = reps
+	}
+	return row
+}
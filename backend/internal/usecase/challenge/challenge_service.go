@@ -0,0 +1,160 @@
+package challenge
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/challenge"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+)
+
+func (s *challengeServiceImpl) CreateChallenge(ctx context.Context, createdBy uint, c *challenge.Challenge) error {
+	if err := validateChallenge(c); err != nil {
+		return err
+	}
+	c.CreatedByID = &createdBy
+
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.challengeRepo.Create(ctx, c); err != nil {
+			return err
+		}
+		entry := audit.NewAuditLog(ctx, audit.ActionChallengeCreated, audit.TargetChallenge, &c.ID, nil, c)
+		return s.auditRepo.Create(ctx, entry)
+	})
+}
+
+// UpdateChallenge replaces the challenge definition. Once a challenge has started its metric
+// and start date are frozen, since existing progress was scored under them.
+func (s *challengeServiceImpl) UpdateChallenge(ctx context.Context, id uint, c *challenge.Challenge) (*challenge.Challenge, error) {
+	if err := validateChallenge(c); err != nil {
+		return nil, err
+	}
+
+	var updated *challenge.Challenge
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		current, err := s.challengeRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !time.Now().Before(current.StartsAt) &&
+			(c.Metric != current.Metric || !c.StartsAt.Equal(current.StartsAt)) {
+			return custom_err.ErrInvalidChallenge
+		}
+
+		before := *current
+		current.Name = c.Name
+		current.Description = c.Description
+		current.Metric = c.Metric
+		current.Target = c.Target
+		current.StartsAt = c.StartsAt
+		current.EndsAt = c.EndsAt
+		if err := s.challengeRepo.Update(ctx, current); err != nil {
+			return err
+		}
+		updated = current
+
+		entry := audit.NewAuditLog(ctx, audit.ActionChallengeUpdated, audit.TargetChallenge, &id, before, current)
+		return s.auditRepo.Create(ctx, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *challengeServiceImpl) DeleteChallenge(ctx context.Context, id uint) error {
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		current, err := s.challengeRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.challengeRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		entry := audit.NewAuditLog(ctx, audit.ActionChallengeDeleted, audit.TargetChallenge, &id, current, nil)
+		return s.auditRepo.Create(ctx, entry)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.leaderboard.Delete(ctx, id); err != nil {
+		log.Printf("challenge %d: failed to delete leaderboard: %v", id, err)
+	}
+	return nil
+}
+
+func (s *challengeServiceImpl) GetChallenge(ctx context.Context, id uint) (*challenge.Challenge, error) {
+	return s.challengeRepo.GetByID(ctx, id)
+}
+
+func (s *challengeServiceImpl) ListChallenges(ctx context.Context, status string) ([]*challenge.Challenge, error) {
+	return s.challengeRepo.List(ctx, status, time.Now())
+}
+
+func (s *challengeServiceImpl) JoinChallenge(ctx context.Context, userID, id uint) (*challenge.ChallengeParticipant, error) {
+	c, err := s.challengeRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c.HasEnded(time.Now()) {
+		return nil, custom_err.ErrChallengeEnded
+	}
+
+	if err := s.participantRepo.Create(ctx, &challenge.ChallengeParticipant{ChallengeID: id, UserID: userID}); err != nil {
+		return nil, err
+	}
+	p, err := s.participantRepo.Get(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.leaderboard.SetScore(ctx, id, userID, p.Progress); err != nil {
+		log.Printf("challenge %d: failed to update leaderboard: %v", id, err)
+	}
+	return p, nil
+}
+
+func (s *challengeServiceImpl) LeaveChallenge(ctx context.Context, userID, id uint) error {
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.participantRepo.Delete(ctx, id, userID)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.leaderboard.Remove(ctx, id, userID); err != nil {
+		log.Printf("challenge %d: failed to update leaderboard: %v", id, err)
+	}
+	return nil
+}
+
+func (s *challengeServiceImpl) GetParticipation(ctx context.Context, userID, id uint) (*challenge.ChallengeParticipant, error) {
+	return s.participantRepo.Get(ctx, id, userID)
+}
+
+func (s *challengeServiceImpl) ListMyChallenges(ctx context.Context, userID uint) ([]*challenge.ChallengeParticipant, error) {
+	return s.participantRepo.GetByUserID(ctx, userID)
+}
+
+// validateChallenge enforces the challenge rules server-side; clients only see the result.
+func validateChallenge(c *challenge.Challenge) error {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return custom_err.ErrInvalidChallenge
+	}
+	if !slices.Contains(challenge.Metrics, c.Metric) {
+		return custom_err.ErrInvalidChallenge
+	}
+	if c.Target != nil && *c.Target <= 0 {
+		return custom_err.ErrInvalidChallenge
+	}
+	if !c.EndsAt.After(c.StartsAt) || c.EndsAt.Sub(c.StartsAt) > challenge.MaxDuration {
+		return custom_err.ErrInvalidChallenge
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package challenge
+
+import (
+	"context"
+	"log"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/challenge"
+)
+
+const rebuildBatchSize = 1000
+
+// GetLeaderboard reads from Redis, rebuilding the sorted set from Postgres when it is missing
+// and falling back to Postgres entirely when Redis is unavailable.
+func (s *challengeServiceImpl) GetLeaderboard(ctx context.Context, id uint, offset, limit int) ([]challenge.LeaderboardEntry, int64, error) {
+	if _, err := s.challengeRepo.GetByID(ctx, id); err != nil {
+		return nil, 0, err
+	}
+	total, err := s.participantRepo.Count(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries, err := s.leaderboardFromCache(ctx, id, offset, limit, total)
+	if err != nil {
+		log.Printf("challenge %d: leaderboard cache unavailable, reading from db: %v", id, err)
+		entries, err = s.leaderboardFromDB(ctx, id, offset, limit)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	return entries, total, nil
+}
+
+func (s *challengeServiceImpl) GetMyRank(ctx context.Context, userID, id uint) (*challenge.LeaderboardEntry, error) {
+	p, err := s.participantRepo.Get(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.leaderboard.Rank(ctx, id, userID)
+	if err != nil || entry == nil {
+		if err != nil {
+			log.Printf("challenge %d: leaderboard cache unavailable: %v", id, err)
+		}
+		// Not ranked in the cache yet; report the score without a rank.
+		return &challenge.LeaderboardEntry{UserID: userID, Score: p.Progress}, nil
+	}
+	return entry, nil
+}
+
+func (s *challengeServiceImpl) leaderboardFromCache(ctx context.Context, id uint, offset, limit int, total int64) ([]challenge.LeaderboardEntry, error) {
+	size, err := s.leaderboard.Size(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if size < total {
+		if err := s.rebuildLeaderboard(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := s.leaderboard.Top(ctx, id, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uint, len(entries))
+	for i, e := range entries {
+		userIDs[i] = e.UserID
+	}
+	participants, err := s.participantRepo.GetByUserIDs(ctx, id, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uint]string, len(participants))
+	for _, p := range participants {
+		names[p.UserID] = p.User.Username
+	}
+	for i := range entries {
+		entries[i].Username = names[entries[i].UserID]
+	}
+	return entries, nil
+}
+
+func (s *challengeServiceImpl) leaderboardFromDB(ctx context.Context, id uint, offset, limit int) ([]challenge.LeaderboardEntry, error) {
+	participants, err := s.participantRepo.Top(ctx, id, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]challenge.LeaderboardEntry, len(participants))
+	for i, p := range participants {
+		entries[i] = challenge.LeaderboardEntry{
+			Rank:     int64(offset + i + 1),
+			UserID:   p.UserID,
+			Username: p.User.Username,
+			Score:    p.Progress,
+		}
+	}
+	return entries, nil
+}
+
+func (s *challengeServiceImpl) rebuildLeaderboard(ctx context.Context, id uint) error {
+	for offset := 0; ; offset += rebuildBatchSize {
+		participants, err := s.participantRepo.Top(ctx, id, offset, rebuildBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, p := range participants {
+			if err := s.leaderboard.SetScore(ctx, id, p.UserID, p.Progress); err != nil {
+				return err
+			}
+		}
+		if len(participants) < rebuildBatchSize {
+			return nil
+		}
+	}
+}
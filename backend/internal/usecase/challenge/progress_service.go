@@ -0,0 +1,63 @@
+package challenge
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/challenge"
+)
+
+// RecordWorkout scores a completed workout against every running challenge the user joined.
+// Each (challenge, workout) pair is counted once, so replays are harmless.
+func (s *challengeServiceImpl) RecordWorkout(ctx context.Context, userID, workoutID uint, at time.Time) error {
+	challenges, err := s.challengeRepo.GetRunningForUser(ctx, userID, at)
+	if err != nil || len(challenges) == 0 {
+		return err
+	}
+
+	w, err := s.workoutRepo.GetOnlyByID(ctx, userID, workoutID)
+	if err != nil {
+		return err
+	}
+
+	scores := make(map[uint]int64, len(challenges))
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		for _, c := range challenges {
+			added, err := s.participantRepo.AddContribution(ctx, &challenge.ChallengeContribution{
+				ChallengeID: c.ID,
+				UserID:      userID,
+				WorkoutID:   workoutID,
+				Value:       challenge.Score(c.Metric, w),
+			})
+			if err != nil {
+				return err
+			}
+			if !added {
+				continue
+			}
+
+			p, err := s.participantRepo.Get(ctx, c.ID, userID)
+			if err != nil {
+				return err
+			}
+			if c.Target != nil && p.Progress >= *c.Target && p.CompletedAt == nil {
+				if err := s.participantRepo.SetCompletedAt(ctx, c.ID, userID, at); err != nil {
+					return err
+				}
+			}
+			scores[c.ID] = p.Progress
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for challengeID, score := range scores {
+		if err := s.leaderboard.SetScore(ctx, challengeID, userID, score); err != nil {
+			log.Printf("challenge %d: failed to update leaderboard: %v", challengeID, err)
+		}
+	}
+	return nil
+}
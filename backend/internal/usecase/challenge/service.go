@@ -0,0 +1,36 @@
+package challenge
+
+import (
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/challenge"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type challengeServiceImpl struct {
+	challengeRepo   challenge.ChallengeRepository
+	participantRepo challenge.ParticipantRepository
+	workoutRepo     workout.WorkoutRepository
+	auditRepo       audit.AuditLogRepository
+	leaderboard     usecase.Leaderboard
+
+	tx usecase.TxManager
+}
+
+func NewChallengeService(
+	challengeRepo challenge.ChallengeRepository,
+	participantRepo challenge.ParticipantRepository,
+	workoutRepo workout.WorkoutRepository,
+	auditRepo audit.AuditLogRepository,
+	leaderboard usecase.Leaderboard,
+	tx usecase.TxManager,
+) usecase.ChallengeService {
+	return &challengeServiceImpl{
+		challengeRepo:   challengeRepo,
+		participantRepo: participantRepo,
+		workoutRepo:     workoutRepo,
+		auditRepo:       auditRepo,
+		leaderboard:     leaderboard,
+		tx:              tx,
+	}
+}
@@ -0,0 +1,171 @@
+package coaching
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/coaching"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+)
+
+// InviteClient creates a pending relationship. client may be a username or an email address.
+func (s *coachingServiceImpl) InviteClient(ctx context.Context, coachID uint, client, message string) (*coaching.CoachClient, error) {
+	ok, err := s.rbacService.HasPermission(ctx, coachID, rbac.PermWorkoutReadOthers)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, custom_err.ErrNotACoach
+	}
+
+	target, err := s.lookupUser(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	if target.ID == coachID {
+		return nil, custom_err.ErrNotACoach
+	}
+
+	rel := &coaching.CoachClient{
+		CoachID:  coachID,
+		ClientID: target.ID,
+		Status:   coaching.StatusPending,
+		Message:  strings.TrimSpace(message),
+	}
+
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.relationRepo.GetOpen(ctx, coachID, target.ID); err == nil {
+			return custom_err.ErrCoachingExists
+		} else if !errors.Is(err, custom_err.ErrNotFound) {
+			return err
+		}
+
+		if err := s.relationRepo.Create(ctx, rel); err != nil {
+			return err
+		}
+		entry := audit.NewAuditLog(ctx, audit.ActionCoachingInvited, audit.TargetCoachClient, &rel.ID, nil,
+			map[string]any{"coach_id": coachID, "client_id": target.ID})
+		return s.auditRepo.Create(ctx, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	rel.Client = *target
+	return rel, nil
+}
+
+func (s *coachingServiceImpl) AcceptInvitation(ctx context.Context, clientID, id uint) (*coaching.CoachClient, error) {
+	var rel *coaching.CoachClient
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		rel, err = s.pendingFor(ctx, clientID, id)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := s.relationRepo.Update(ctx, rel.ID, map[string]any{
+			"status":       coaching.StatusActive,
+			"responded_at": now,
+		}); err != nil {
+			return err
+		}
+		rel.Status = coaching.StatusActive
+		rel.RespondedAt = &now
+
+		entry := audit.NewAuditLog(ctx, audit.ActionCoachingAccepted, audit.TargetCoachClient, &rel.ID,
+			map[string]any{"status": coaching.StatusPending}, map[string]any{"status": coaching.StatusActive})
+		return s.auditRepo.Create(ctx, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rel, nil
+}
+
+func (s *coachingServiceImpl) DeclineInvitation(ctx context.Context, clientID, id uint) error {
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		rel, err := s.pendingFor(ctx, clientID, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.relationRepo.Update(ctx, rel.ID, map[string]any{
+			"status":       coaching.StatusDeclined,
+			"responded_at": time.Now(),
+		}); err != nil {
+			return err
+		}
+
+		entry := audit.NewAuditLog(ctx, audit.ActionCoachingDeclined, audit.TargetCoachClient, &rel.ID,
+			map[string]any{"status": coaching.StatusPending}, map[string]any{"status": coaching.StatusDeclined})
+		return s.auditRepo.Create(ctx, entry)
+	})
+}
+
+// RevokeRelationship ends a pending or active relationship. Either the coach or the client may revoke.
+func (s *coachingServiceImpl) RevokeRelationship(ctx context.Context, userID, id uint) error {
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		rel, err := s.relationRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if rel.CoachID != userID && rel.ClientID != userID {
+			return custom_err.ErrNotFound
+		}
+		if !rel.IsOpen() {
+			return nil
+		}
+
+		if err := s.relationRepo.Update(ctx, rel.ID, map[string]any{
+			"status":     coaching.StatusRevoked,
+			"revoked_at": time.Now(),
+		}); err != nil {
+			return err
+		}
+
+		entry := audit.NewAuditLog(ctx, audit.ActionCoachingRevoked, audit.TargetCoachClient, &rel.ID,
+			map[string]any{"status": rel.Status}, map[string]any{"status": coaching.StatusRevoked})
+		return s.auditRepo.Create(ctx, entry)
+	})
+}
+
+func (s *coachingServiceImpl) ListClients(ctx context.Context, coachID uint) ([]*coaching.CoachClient, error) {
+	return s.relationRepo.GetByCoachID(ctx, coachID)
+}
+
+func (s *coachingServiceImpl) ListCoaches(ctx context.Context, clientID uint) ([]*coaching.CoachClient, error) {
+	return s.relationRepo.GetByClientID(ctx, clientID)
+}
+
+// CanAccess reports whether coachID may currently act on clientID's workouts.
+func (s *coachingServiceImpl) CanAccess(ctx context.Context, coachID, clientID uint) (bool, error) {
+	if coachID == clientID {
+		return true, nil
+	}
+	return s.relationRepo.IsActive(ctx, coachID, clientID)
+}
+
+func (s *coachingServiceImpl) pendingFor(ctx context.Context, clientID, id uint) (*coaching.CoachClient, error) {
+	rel, err := s.relationRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rel.ClientID != clientID || rel.Status != coaching.StatusPending {
+		return nil, custom_err.ErrNotFound
+	}
+	return rel, nil
+}
+
+func (s *coachingServiceImpl) lookupUser(ctx context.Context, identifier string) (*user.User, error) {
+	identifier = strings.TrimSpace(identifier)
+	if strings.Contains(identifier, "@") {
+		return s.userRepo.GetByEmail(ctx, identifier)
+	}
+	return s.userRepo.GetByUsername(ctx, identifier)
+}
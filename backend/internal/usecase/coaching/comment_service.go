@@ -0,0 +1,50 @@
+package coaching
+
+import (
+	"context"
+	"strings"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/coaching"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+)
+
+// AddComment attaches a comment to ownerID's workout. Access for a coach acting on a client
+// is checked by the caller; here we only make sure the workout belongs to ownerID.
+func (s *coachingServiceImpl) AddComment(ctx context.Context, authorID, ownerID, planID, cycleID, workoutID uint, body string) (*coaching.WorkoutComment, error) {
+	if _, err := s.workoutService.GetWorkoutByID(ctx, ownerID, planID, cycleID, workoutID); err != nil {
+		return nil, err
+	}
+
+	c := &coaching.WorkoutComment{
+		WorkoutID: workoutID,
+		AuthorID:  authorID,
+		Body:      strings.TrimSpace(body),
+	}
+	if err := s.commentRepo.Create(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *coachingServiceImpl) GetComments(ctx context.Context, ownerID, planID, cycleID, workoutID uint) ([]*coaching.WorkoutComment, error) {
+	if _, err := s.workoutService.GetWorkoutByID(ctx, ownerID, planID, cycleID, workoutID); err != nil {
+		return nil, err
+	}
+	return s.commentRepo.GetByWorkoutID(ctx, workoutID)
+}
+
+// DeleteComment lets authors remove their own comments and owners remove any comment on their workout.
+func (s *coachingServiceImpl) DeleteComment(ctx context.Context, authorID, ownerID, planID, cycleID, workoutID, id uint) error {
+	if _, err := s.workoutService.GetWorkoutByID(ctx, ownerID, planID, cycleID, workoutID); err != nil {
+		return err
+	}
+
+	c, err := s.commentRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if c.WorkoutID != workoutID || (c.AuthorID != authorID && ownerID != authorID) {
+		return custom_err.ErrNotFound
+	}
+	return s.commentRepo.Delete(ctx, id)
+}
@@ -0,0 +1,39 @@
+package coaching
+
+import (
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/coaching"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type coachingServiceImpl struct {
+	relationRepo   coaching.CoachClientRepository
+	commentRepo    coaching.WorkoutCommentRepository
+	userRepo       user.UserRepository
+	auditRepo      audit.AuditLogRepository
+	rbacService    usecase.RBACService
+	workoutService usecase.WorkoutService
+
+	tx usecase.TxManager
+}
+
+func NewCoachingService(
+	relationRepo coaching.CoachClientRepository,
+	commentRepo coaching.WorkoutCommentRepository,
+	userRepo user.UserRepository,
+	auditRepo audit.AuditLogRepository,
+	rbacService usecase.RBACService,
+	workoutService usecase.WorkoutService,
+	tx usecase.TxManager,
+) usecase.CoachingService {
+	return &coachingServiceImpl{
+		relationRepo:   relationRepo,
+		commentRepo:    commentRepo,
+		userRepo:       userRepo,
+		auditRepo:      auditRepo,
+		rbacService:    rbacService,
+		workoutService: workoutService,
+		tx:             tx,
+	}
+}
@@ -5,7 +5,17 @@ import (
 
 	"time"
 
+	"github.com/lordmitrii/golang-web-gin/internal/domain/achievement"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/auth"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/challenge"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/coaching"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/equipment"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/goal"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/nutrition"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/social"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/translations"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/versions"
@@ -15,6 +25,9 @@ import (
 type (
 	WorkoutService interface {
 		CreateWorkoutPlan(ctx context.Context, userId uint, wp *workout.WorkoutPlan) (*workout.WorkoutPlan, error)
+		CreateWorkoutPlanWithWorkouts(ctx context.Context, userId uint, wp *workout.WorkoutPlan, workouts []*workout.Workout) (*workout.WorkoutPlan, error)
+		AcceptDraftWorkoutPlan(ctx context.Context, userId, id uint, activate bool) (*workout.WorkoutPlan, error)
+		DiscardDraftWorkoutPlan(ctx context.Context, userId, id uint) error
 		GetWorkoutPlanByID(ctx context.Context, userId, id uint) (*workout.WorkoutPlan, error)
 		GetWorkoutPlansByUserID(ctx context.Context, userId uint) ([]*workout.WorkoutPlan, error)
 		UpdateWorkoutPlan(ctx context.Context, userId, id uint, updates map[string]any) (*workout.WorkoutPlan, error)
@@ -39,6 +52,8 @@ type (
 		CompleteWorkout(ctx context.Context, userId, planId, cycleId, id uint, completed, skipped bool) (*workout.Workout, float64, error)
 		MoveWorkout(ctx context.Context, userId, planId, cycleId, id uint, direction string) error
 		CalculateWorkoutSummary(ctx context.Context, userId, workoutID uint) (float64, float64, float64, error)
+		ReviewWorkout(ctx context.Context, userId, workoutID uint) (*workout.Workout, []*workout.ExerciseReview, error)
+		SaveWorkoutAISummary(ctx context.Context, userId, workoutID uint, summary string) error
 
 		CreateWorkoutExercise(ctx context.Context, userId, planId, cycleId, workoutId uint, e *workout.WorkoutExercise) error
 		GetWorkoutExerciseByID(ctx context.Context, userId, planId, cycleId, workoutId, id uint) (*workout.WorkoutExercise, error)
@@ -66,13 +81,16 @@ type (
 	}
 
 	ExerciseService interface {
-		CreateExercise(ctx context.Context, e *workout.Exercise, autoTranslate bool) error
+		CreateExercise(ctx context.Context, e *workout.Exercise, instructions string, autoTranslate bool) error
 		GetExerciseByID(ctx context.Context, id uint) (*workout.Exercise, error)
 		GetExercisesByMuscleGroupID(ctx context.Context, muscleGroupID *uint) ([]*workout.Exercise, error)
 		GetAllExercises(ctx context.Context) ([]*workout.Exercise, error)
 		UpdateExercise(ctx context.Context, id uint, updates map[string]any) (*workout.Exercise, error)
 		DeleteExercise(ctx context.Context, id uint) error
-		GetExerciseNamesByMuscleName(ctx context.Context, muscleName string, limit, offset int) ([]*workout.Exercise, error)
+		SearchExercises(ctx context.Context, f workout.ExerciseFilter, page, pageSize int64) ([]*workout.Exercise, int64, error)
+		SetExerciseSecondaryMuscleGroups(ctx context.Context, id uint, muscleGroupIDs []uint) (*workout.Exercise, error)
+		SetExerciseMedia(ctx context.Context, id uint, media []*workout.ExerciseMedia) (*workout.Exercise, error)
+		SetExerciseInstructions(ctx context.Context, id uint, instructions string, autoTranslate bool) error
 
 		CreateMuscleGroup(ctx context.Context, mg *workout.MuscleGroup, autoTranslate bool) error
 		GetMuscleGroupByID(ctx context.Context, id uint) (*workout.MuscleGroup, error)
@@ -112,6 +130,7 @@ type (
 		SetUserRoles(ctx context.Context, userID uint, roleNames []string) error
 		TriggerResetUserPassword(ctx context.Context, userID uint) error
 		DeleteUser(ctx context.Context, userID uint) error
+		ListAuditLogs(ctx context.Context, f audit.Filter, page, pageSize int64) ([]*audit.AuditLog, int64, error)
 	}
 
 	RBACService interface {
@@ -119,6 +138,16 @@ type (
 		HasPermission(ctx context.Context, userID uint, permKey string) (bool, error)
 		GetUserRoles(ctx context.Context, userID uint) ([]*rbac.Role, error)
 		GetUserPermissions(ctx context.Context, userID uint) ([]*rbac.Permission, error)
+		GetUserPermissionKeys(ctx context.Context, userID uint) ([]string, error)
+
+		ListRoles(ctx context.Context) ([]*rbac.Role, error)
+		ListPermissions(ctx context.Context) ([]*rbac.Permission, error)
+		CreateRole(ctx context.Context, name string, permKeys []string) (*rbac.Role, error)
+		UpdateRole(ctx context.Context, roleName string, newName *string, permKeys []string) (*rbac.Role, error)
+		DeleteRole(ctx context.Context, roleName string) error
+		AddRolePermission(ctx context.Context, roleName, permKey string) error
+		RemoveRolePermission(ctx context.Context, roleName, permKey string) error
+		ListRoleMembers(ctx context.Context, roleName string, page, pageSize int64) ([]*user.User, int64, error)
 	}
 )
 
@@ -126,6 +155,29 @@ type AIService interface {
 	AskStatsQuestion(ctx context.Context, userID uint, question, lang, previousResponseID string) (string, string, error)
 	AskWorkoutsQuestion(ctx context.Context, userID uint, question, lang, previousResponseID string) (string, string, error)
 	AskGeneralQuestion(ctx context.Context, userID uint, question, lang, previousResponseID string) (string, string, error)
+	StreamStatsQuestion(ctx context.Context, userID uint, question, lang, previousResponseID string, onDelta func(delta string) error) (string, string, error)
+	StreamWorkoutsQuestion(ctx context.Context, userID uint, question, lang, previousResponseID string, onDelta func(delta string) error) (string, string, error)
+	StreamGeneralQuestion(ctx context.Context, userID uint, question, lang, previousResponseID string, onDelta func(delta string) error) (string, string, error)
+	AskCoachQuestion(ctx context.Context, userID uint, question, lang, previousResponseID string) (string, string, error)
+	StreamCoachQuestion(ctx context.Context, userID uint, question, lang, previousResponseID string, onDelta func(delta string) error) (string, string, error)
+	SummarizeWorkout(ctx context.Context, userID, workoutID uint) error
+
+	ListConversations(ctx context.Context, userID uint, page, pageSize int64) ([]*ai.Conversation, int64, error)
+	GetConversation(ctx context.Context, userID, id uint) (*ai.Conversation, error)
+	RenameConversation(ctx context.Context, userID, id uint, title string) (*ai.Conversation, error)
+	DeleteConversation(ctx context.Context, userID, id uint) error
+	DeleteConversations(ctx context.Context, userID uint) error
+	ExportConversations(ctx context.Context, userID uint) ([]*ai.Conversation, error)
+
+	GetUsage(ctx context.Context, userID uint) (*ai.UsageReport, error)
+	GetUsageReport(ctx context.Context, from, to time.Time) ([]*ai.UsageSummary, []*ai.UserUsage, error)
+	ListQuotas(ctx context.Context) ([]*ai.TokenQuota, ai.Limits, error)
+	SetQuota(ctx context.Context, roleName string, limits ai.Limits) (*ai.TokenQuota, error)
+	DeleteQuota(ctx context.Context, roleName string) error
+	ListPrompts(ctx context.Context) ([]*ai.PromptSet, error)
+	CreatePromptVersion(ctx context.Context, name, locale, body string, activate bool) (*ai.PromptTemplate, error)
+	ActivatePromptVersion(ctx context.Context, name, locale string, version int) error
+	ListBlockedRequests(ctx context.Context, category string, page, pageSize int64) ([]*ai.BlockedRequest, int64, error)
 	GenerateWorkoutPlan(ctx context.Context, userID uint, prompt string, days int, lang string) (*workout.WorkoutPlan, error)
 	GenerateWorkoutPlanWithDB(ctx context.Context, userID uint, prompt string, days int, lang string) (*workout.WorkoutPlan, error)
 }
@@ -136,7 +188,14 @@ type EmailService interface {
 	SendResetPasswordEmail(ctx context.Context, to, lang string) error
 	ValidateToken(ctx context.Context, token, tokenType string) (bool, error)
 	ResetPassword(ctx context.Context, token, newPassword string) error
-	VerifyAccount(ctx context.Context, token string) error
+	VerifyAccount(ctx context.Context, to, token string) error
+}
+
+// LoginGuard tracks failed attempts per identity and per IP for a given scope.
+type LoginGuard interface {
+	Check(ctx context.Context, scope, identity, ip string) (time.Duration, error)
+	Fail(ctx context.Context, scope, identity, ip, userAgent string) (time.Duration, error)
+	Reset(ctx context.Context, scope, identity string) error
 }
 type TranslationService interface {
 	GetTranslations(ctx context.Context, namespace, locale string) ([]*translations.Translation, error)
@@ -154,6 +213,151 @@ type RateLimiter interface {
 	Allow(ctx context.Context, key string, limit int, per time.Duration) (bool, time.Duration, error)
 }
 
+type PersonalTokenService interface {
+	CreateToken(ctx context.Context, userID uint, name string, scopes []string, expiresAt *time.Time) (*auth.PersonalAccessToken, string, error)
+	ListTokens(ctx context.Context, userID uint) ([]*auth.PersonalAccessToken, error)
+	RevokeToken(ctx context.Context, userID, id uint) error
+	Authenticate(ctx context.Context, raw string) (*auth.PersonalAccessToken, error)
+}
+
+type CoachingService interface {
+	InviteClient(ctx context.Context, coachID uint, client, message string) (*coaching.CoachClient, error)
+	AcceptInvitation(ctx context.Context, clientID, id uint) (*coaching.CoachClient, error)
+	DeclineInvitation(ctx context.Context, clientID, id uint) error
+	RevokeRelationship(ctx context.Context, userID, id uint) error
+	ListClients(ctx context.Context, coachID uint) ([]*coaching.CoachClient, error)
+	ListCoaches(ctx context.Context, clientID uint) ([]*coaching.CoachClient, error)
+	CanAccess(ctx context.Context, coachID, clientID uint) (bool, error)
+
+	AddComment(ctx context.Context, authorID, ownerID, planID, cycleID, workoutID uint, body string) (*coaching.WorkoutComment, error)
+	GetComments(ctx context.Context, ownerID, planID, cycleID, workoutID uint) ([]*coaching.WorkoutComment, error)
+	DeleteComment(ctx context.Context, authorID, ownerID, planID, cycleID, workoutID, id uint) error
+}
+
+type SocialService interface {
+	Follow(ctx context.Context, followerID uint, username string) (*social.Follow, error)
+	Unfollow(ctx context.Context, followerID, followeeID uint) error
+	ListFollowers(ctx context.Context, userID uint, page, pageSize int64) ([]*user.User, int64, error)
+	ListFollowing(ctx context.Context, userID uint, page, pageSize int64) ([]*user.User, int64, error)
+	ListFollowRequests(ctx context.Context, userID uint, page, pageSize int64) ([]*user.User, int64, error)
+	AcceptFollowRequest(ctx context.Context, userID, followerID uint) error
+	DeclineFollowRequest(ctx context.Context, userID, followerID uint) error
+
+	GetFeed(ctx context.Context, userID, beforeID uint, limit int) ([]*social.FeedItem, error)
+	GetUserActivities(ctx context.Context, viewerID, actorID, beforeID uint, limit int) ([]*social.FeedItem, error)
+	PublishWorkoutCompleted(ctx context.Context, userID, workoutID uint, at time.Time) error
+
+	React(ctx context.Context, userID, activityID uint, kind string) error
+	Unreact(ctx context.Context, userID, activityID uint) error
+	AddComment(ctx context.Context, userID, activityID uint, body string) (*social.ActivityComment, error)
+	GetComments(ctx context.Context, viewerID, activityID uint) ([]*social.ActivityComment, error)
+	DeleteComment(ctx context.Context, userID, commentID uint) error
+
+	ShareWorkout(ctx context.Context, userID, workoutID uint) (*social.WorkoutShare, error)
+	ListShares(ctx context.Context, userID uint) ([]*social.WorkoutShare, error)
+	RevokeShare(ctx context.Context, userID, shareID uint) error
+	GetSharedWorkout(ctx context.Context, token string) (*social.WorkoutShare, *workout.Workout, error)
+}
+
+type ChallengeService interface {
+	CreateChallenge(ctx context.Context, createdBy uint, c *challenge.Challenge) error
+	UpdateChallenge(ctx context.Context, id uint, c *challenge.Challenge) (*challenge.Challenge, error)
+	DeleteChallenge(ctx context.Context, id uint) error
+	GetChallenge(ctx context.Context, id uint) (*challenge.Challenge, error)
+	ListChallenges(ctx context.Context, status string) ([]*challenge.Challenge, error)
+
+	JoinChallenge(ctx context.Context, userID, id uint) (*challenge.ChallengeParticipant, error)
+	LeaveChallenge(ctx context.Context, userID, id uint) error
+	GetParticipation(ctx context.Context, userID, id uint) (*challenge.ChallengeParticipant, error)
+	ListMyChallenges(ctx context.Context, userID uint) ([]*challenge.ChallengeParticipant, error)
+
+	GetLeaderboard(ctx context.Context, id uint, offset, limit int) ([]challenge.LeaderboardEntry, int64, error)
+	GetMyRank(ctx context.Context, userID, id uint) (*challenge.LeaderboardEntry, error)
+	RecordWorkout(ctx context.Context, userID, workoutID uint, at time.Time) error
+}
+
+type AchievementService interface {
+	EvaluateWorkout(ctx context.Context, userID, workoutID uint) ([]*achievement.UserAchievement, error)
+	GetAchievements(ctx context.Context, userID uint) ([]achievement.Status, achievement.Streak, error)
+	GetStreak(ctx context.Context, userID uint) (achievement.Streak, error)
+}
+
+type GoalService interface {
+	CreateGoal(ctx context.Context, userID uint, g *goal.Goal) (*goal.Tracked, error)
+	GetGoal(ctx context.Context, userID, id uint) (*goal.Tracked, error)
+	GetGoals(ctx context.Context, userID uint, status string) ([]*goal.Tracked, error)
+	UpdateGoal(ctx context.Context, userID, id uint, updates map[string]any) (*goal.Tracked, error)
+	DeleteGoal(ctx context.Context, userID, id uint) error
+	EvaluateWorkout(ctx context.Context, userID, workoutID uint) ([]*goal.Goal, error)
+}
+
+type EquipmentService interface {
+	CreateProfile(ctx context.Context, userID uint, name string, items []string, isDefault bool) (*equipment.EquipmentProfile, error)
+	GetProfiles(ctx context.Context, userID uint) ([]*equipment.EquipmentProfile, error)
+	GetProfile(ctx context.Context, userID, id uint) (*equipment.EquipmentProfile, error)
+	UpdateProfile(ctx context.Context, userID, id uint, name *string, items []string, isDefault *bool) (*equipment.EquipmentProfile, error)
+	DeleteProfile(ctx context.Context, userID, id uint) error
+
+	SuggestSubstitutes(ctx context.Context, userID, exerciseID uint, profileID *uint, limit int) ([]*workout.Exercise, error)
+	SwitchWorkout(ctx context.Context, userID, planID, cycleID, workoutID, profileID uint) (*equipment.SwitchResult, error)
+	SwitchPlan(ctx context.Context, userID, planID, profileID uint) (*equipment.SwitchResult, error)
+}
+
+// LoadingService rounds target weights to what a user can actually load with their bar, plates and dumbbells.
+type LoadingService interface {
+	GetLoadingSettings(ctx context.Context, userID uint) (*equipment.LoadingSettings, error)
+	UpdateLoadingSettings(ctx context.Context, userID uint, barWeight, dumbbellIncrement int, plates []equipment.LoadingPlate) (*equipment.LoadingSettings, error)
+	ResetLoadingSettings(ctx context.Context, userID uint) (*equipment.LoadingSettings, error)
+	RoundWeight(ctx context.Context, userID uint, target int, equipment string) (equipment.Loadout, error)
+}
+
+type NutritionService interface {
+	CreateCatalogFood(ctx context.Context, f *nutrition.Food, autoTranslate bool) error
+	UpdateCatalogFood(ctx context.Context, id uint, updates map[string]any) (*nutrition.Food, error)
+	DeleteCatalogFood(ctx context.Context, id uint) error
+	CreateFood(ctx context.Context, userID uint, f *nutrition.Food) error
+	GetFood(ctx context.Context, userID, id uint) (*nutrition.Food, error)
+	SearchFoods(ctx context.Context, userID uint, query string, page, pageSize int) ([]*nutrition.Food, int64, error)
+	UpdateFood(ctx context.Context, userID, id uint, updates map[string]any) (*nutrition.Food, error)
+	DeleteFood(ctx context.Context, userID, id uint) error
+
+	LogMeal(ctx context.Context, userID uint, e *nutrition.MealEntry) error
+	GetMeals(ctx context.Context, userID uint, from, to time.Time) ([]*nutrition.MealEntry, error)
+	UpdateMeal(ctx context.Context, userID, id uint, updates map[string]any) (*nutrition.MealEntry, error)
+	DeleteMeal(ctx context.Context, userID, id uint) error
+
+	GetTargets(ctx context.Context, userID uint) (*nutrition.Targets, *nutrition.NutritionGoal, error)
+	SetGoal(ctx context.Context, userID uint, g *nutrition.NutritionGoal) (*nutrition.Targets, *nutrition.NutritionGoal, error)
+	GetDailyBalance(ctx context.Context, userID uint, day time.Time) (*nutrition.DailyBalance, error)
+}
+
+// Leaderboard ranks challenge participants by score. Postgres remains the source of truth.
+type Leaderboard interface {
+	SetScore(ctx context.Context, challengeID, userID uint, score int64) error
+	Remove(ctx context.Context, challengeID, userID uint) error
+	Top(ctx context.Context, challengeID uint, offset, limit int) ([]challenge.LeaderboardEntry, error)
+	Rank(ctx context.Context, challengeID, userID uint) (*challenge.LeaderboardEntry, error)
+	Size(ctx context.Context, challengeID uint) (int64, error)
+	Delete(ctx context.Context, challengeID uint) error
+}
+
+// PermissionCache stores each user's effective permission keys between requests.
+type PermissionCache interface {
+	// Get returns the cached keys, or on a miss a stamp to hand to Set with the keys loaded
+	// afterwards. Set drops them if an invalidation happened in between.
+	Get(ctx context.Context, userID uint) (keys []string, stamp string, ok bool, err error)
+	Set(ctx context.Context, userID uint, stamp string, permKeys []string) error
+	Invalidate(ctx context.Context, userIDs ...uint) error
+	InvalidateAll(ctx context.Context) error
+}
+
+type AttemptCounter interface {
+	Incr(ctx context.Context, key string, window time.Duration) (int64, error)
+	Block(ctx context.Context, key string, ttl time.Duration) error
+	BlockedFor(ctx context.Context, key string) (time.Duration, error)
+	Reset(ctx context.Context, keys ...string) error
+}
+
 type TxManager interface {
 	Do(ctx context.Context, fn func(ctx context.Context) error) error
 	DoIfNotInTx(ctx context.Context, fn func(ctx context.Context) error) error
@@ -2,15 +2,22 @@ package email
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
 	"fmt"
+	"log"
 	"time"
 
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/email"
 	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// maxCodeAttempts is how many wrong guesses a verification code survives.
+const maxCodeAttempts = 5
+
 func (s *emailServiceImpl) SendNotificationEmail(ctx context.Context, to, subject, body string) error {
 	exists, err := s.userRepo.CheckEmail(ctx, to)
 	if err != nil {
@@ -66,13 +73,20 @@ func (s *emailServiceImpl) SendResetPasswordEmail(ctx context.Context, to, lang
 	}
 
 	t := time.Now().Add(15 * time.Minute)
-	err = s.emailTokenRepo.Create(ctx, &email.EmailToken{
-		UserID:    user.ID,
-		Token:     token,
-		Type:      "reset_password",
-		ExpiresAt: &t,
+	err = s.tx.DoIfNotInTx(ctx, func(ctx context.Context) error {
+		err := s.emailTokenRepo.Create(ctx, &email.EmailToken{
+			UserID:    user.ID,
+			Token:     token,
+			Type:      "reset_password",
+			ExpiresAt: &t,
+		})
+		if err != nil {
+			return err
+		}
+
+		entry := audit.NewAuditLog(ctx, audit.ActionAuthPasswordResetRequest, audit.TargetUser, &user.ID, nil, nil)
+		return s.auditRepo.Create(ctx, entry)
 	})
-
 	if err != nil {
 		return err
 	}
@@ -81,9 +95,16 @@ func (s *emailServiceImpl) SendResetPasswordEmail(ctx context.Context, to, lang
 	return s.emailSender.SendResetPasswordEmail(to, link, lang)
 }
 
+// ValidateToken checks a reset password token. Verification codes are short enough to guess,
+// so they are only checked by VerifyAccount, which counts wrong guesses.
 func (s *emailServiceImpl) ValidateToken(ctx context.Context, token, tokenType string) (bool, error) {
+	if tokenType != "reset_password" {
+		return false, nil
+	}
 	emailToken, err := s.emailTokenRepo.GetByTokenAndType(ctx, token, tokenType)
-	if err != nil {
+	if errors.Is(err, custom_err.ErrInvalidToken) {
+		return false, nil
+	} else if err != nil {
 		return false, err
 	}
 	if emailToken == nil || emailToken.IsExpired() {
@@ -93,8 +114,10 @@ func (s *emailServiceImpl) ValidateToken(ctx context.Context, token, tokenType s
 	return true, nil
 }
 
-func (s *emailServiceImpl) VerifyAccount(ctx context.Context, token string) error {
-	emailToken, err := s.emailTokenRepo.GetByTokenAndType(ctx, token, "verification")
+// VerifyAccount checks the code against the latest code of the account with the email only,
+// so wrong guesses are counted against it.
+func (s *emailServiceImpl) VerifyAccount(ctx context.Context, to, token string) error {
+	emailToken, err := s.checkVerificationCode(ctx, to, token)
 	if err != nil {
 		return err
 	}
@@ -102,23 +125,67 @@ func (s *emailServiceImpl) VerifyAccount(ctx context.Context, token string) erro
 		return nil
 	}
 
-	err = s.roleRepo.RemoveRoleFromUser(ctx, emailToken.UserID, rbac.RoleRestricted)
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		err := s.roleRepo.RemoveRoleFromUser(ctx, emailToken.UserID, rbac.RoleRestricted)
+		if err != nil {
+			return err
+		}
+
+		err = s.roleRepo.AssignRoleToUser(ctx, emailToken.UserID, rbac.RoleVerified)
+		if err != nil {
+			return err
+		}
+
+		err = s.userRepo.Update(ctx, emailToken.UserID, map[string]any{"is_verified": true})
+		if err != nil {
+			return err
+		}
+
+		if err := s.emailTokenRepo.Delete(ctx, emailToken.ID); err != nil {
+			return err
+		}
+
+		entry := audit.NewAuditLog(ctx, audit.ActionAuthAccountVerified, audit.TargetUser, &emailToken.UserID,
+			map[string]any{"is_verified": false}, map[string]any{"is_verified": true})
+		return s.auditRepo.Create(ctx, entry)
+	})
 	if err != nil {
 		return err
 	}
 
-	err = s.roleRepo.AssignRoleToUser(ctx, emailToken.UserID, rbac.RoleVerified)
-	if err != nil {
-		return err
+	if err := s.permCache.Invalidate(ctx, emailToken.UserID); err != nil {
+		log.Printf("permission cache invalidation failed: %v", err)
+	}
+	return nil
+}
+
+// checkVerificationCode burns the user's verification codes after maxCodeAttempts wrong guesses.
+func (s *emailServiceImpl) checkVerificationCode(ctx context.Context, to, code string) (*email.EmailToken, error) {
+	user, err := s.userRepo.GetByEmail(ctx, to)
+	if err != nil && err == custom_err.ErrUserNotFound {
+		return nil, custom_err.ErrInvalidToken
+	} else if err != nil {
+		return nil, err
 	}
 
-	err = s.userRepo.Update(ctx, emailToken.UserID, map[string]any{"is_verified": true})
+	emailToken, err := s.emailTokenRepo.GetLatestByUserIDAndType(ctx, user.ID, "verification")
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(emailToken.Token), []byte(code)) == 1 {
+		return emailToken, nil
 	}
 
-	s.emailTokenRepo.Delete(ctx, emailToken.ID)
-	return nil
+	attempts, err := s.emailTokenRepo.IncrementAttempts(ctx, emailToken.ID)
+	if err != nil {
+		return nil, err
+	}
+	if attempts >= maxCodeAttempts {
+		if err := s.emailTokenRepo.DeleteByUserIDAndType(ctx, user.ID, "verification"); err != nil {
+			return nil, err
+		}
+	}
+	return nil, custom_err.ErrInvalidToken
 }
 
 func (s *emailServiceImpl) ResetPassword(ctx context.Context, token, newPassword string) error {
@@ -127,7 +194,7 @@ func (s *emailServiceImpl) ResetPassword(ctx context.Context, token, newPassword
 		return err
 	}
 	if emailToken == nil || emailToken.IsExpired() {
-		return fmt.Errorf("invalid or expired token")
+		return custom_err.ErrInvalidToken
 	}
 
 	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
@@ -135,11 +202,18 @@ func (s *emailServiceImpl) ResetPassword(ctx context.Context, token, newPassword
 		return err
 	}
 
-	err = s.userRepo.Update(ctx, emailToken.UserID, map[string]any{"password_hash": string(hash)})
-	if err != nil {
-		return err
-	}
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		err := s.userRepo.Update(ctx, emailToken.UserID, map[string]any{"password_hash": string(hash)})
+		if err != nil {
+			return err
+		}
 
-	s.emailTokenRepo.Delete(ctx, emailToken.ID)
-	return nil
+		if err := s.emailTokenRepo.Delete(ctx, emailToken.ID); err != nil {
+			return err
+		}
+
+		entry := audit.NewAuditLog(ctx, audit.ActionAuthPasswordReset, audit.TargetUser, &emailToken.UserID,
+			nil, map[string]any{"password": true})
+		return s.auditRepo.Create(ctx, entry)
+	})
 }
@@ -1,6 +1,7 @@
 package email
 
 import (
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/email"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
@@ -12,6 +13,10 @@ type emailServiceImpl struct {
 	roleRepo       rbac.RoleRepository
 	emailSender    email.EmailSender
 	emailTokenRepo email.EmailTokenRepository
+	auditRepo      audit.AuditLogRepository
+	permCache      usecase.PermissionCache
+
+	tx usecase.TxManager
 }
 
 func NewEmailService(
@@ -19,11 +24,17 @@ func NewEmailService(
 	roleRepo rbac.RoleRepository,
 	emailSender email.EmailSender,
 	emailTokenRepo email.EmailTokenRepository,
+	auditRepo audit.AuditLogRepository,
+	permCache usecase.PermissionCache,
+	tx usecase.TxManager,
 ) usecase.EmailService {
 	return &emailServiceImpl{
 		userRepo:       userRepo,
 		roleRepo:       roleRepo,
 		emailSender:    emailSender,
 		emailTokenRepo: emailTokenRepo,
+		auditRepo:      auditRepo,
+		permCache:      permCache,
+		tx:             tx,
 	}
 }
@@ -0,0 +1,113 @@
+package equipment
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/equipment"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+// Plate limits keep rounding cheap; no gym floor needs more.
+const (
+	maxPlateSizes = 12
+	maxPlatePairs = 20
+)
+
+type loadingServiceImpl struct {
+	loadingRepo  equipment.LoadingSettingsRepository
+	settingsRepo user.UserSettingsRepository
+
+	tx usecase.TxManager
+}
+
+func NewLoadingService(loadingRepo equipment.LoadingSettingsRepository, settingsRepo user.UserSettingsRepository, tx usecase.TxManager) usecase.LoadingService {
+	return &loadingServiceImpl{
+		loadingRepo:  loadingRepo,
+		settingsRepo: settingsRepo,
+		tx:           tx,
+	}
+}
+
+// GetLoadingSettings returns the user's saved settings, or the defaults for their unit system.
+func (s *loadingServiceImpl) GetLoadingSettings(ctx context.Context, userID uint) (*equipment.LoadingSettings, error) {
+	ls, err := s.loadingRepo.GetByUserID(ctx, userID)
+	if err == nil {
+		return ls, nil
+	}
+	if !errors.Is(err, custom_err.ErrNotFound) {
+		return nil, err
+	}
+
+	unitSystem := "metric"
+	if us, err := s.settingsRepo.GetByUserID(ctx, userID); err == nil {
+		unitSystem = us.UnitSystem
+	} else if !errors.Is(err, custom_err.ErrNotFound) {
+		return nil, err
+	}
+
+	ls = equipment.DefaultLoadingSettings(unitSystem)
+	ls.UserID = userID
+	return ls, nil
+}
+
+func (s *loadingServiceImpl) UpdateLoadingSettings(ctx context.Context, userID uint, barWeight, dumbbellIncrement int, plates []equipment.LoadingPlate) (*equipment.LoadingSettings, error) {
+	if barWeight < 0 || dumbbellIncrement <= 0 {
+		return nil, custom_err.ErrInvalidLoadingSettings
+	}
+
+	merged := make([]equipment.LoadingPlate, 0, len(plates))
+	for _, p := range plates {
+		if p.Weight <= 0 || p.Pairs <= 0 {
+			return nil, custom_err.ErrInvalidLoadingSettings
+		}
+		i := slices.IndexFunc(merged, func(m equipment.LoadingPlate) bool { return m.Weight == p.Weight })
+		if i < 0 {
+			merged = append(merged, equipment.LoadingPlate{Weight: p.Weight, Pairs: p.Pairs})
+			continue
+		}
+		merged[i].Pairs += p.Pairs
+	}
+	for _, p := range merged {
+		if p.Pairs > maxPlatePairs {
+			return nil, custom_err.ErrInvalidLoadingSettings
+		}
+	}
+	if len(merged) > maxPlateSizes {
+		return nil, custom_err.ErrInvalidLoadingSettings
+	}
+	slices.SortFunc(merged, func(a, b equipment.LoadingPlate) int { return b.Weight - a.Weight })
+
+	ls := &equipment.LoadingSettings{
+		UserID:            userID,
+		BarWeight:         barWeight,
+		DumbbellIncrement: dumbbellIncrement,
+		Plates:            merged,
+	}
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.loadingRepo.Upsert(ctx, ls)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ls, nil
+}
+
+// ResetLoadingSettings drops saved settings so the unit-system defaults apply again.
+func (s *loadingServiceImpl) ResetLoadingSettings(ctx context.Context, userID uint) (*equipment.LoadingSettings, error) {
+	if err := s.loadingRepo.DeleteByUserID(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.GetLoadingSettings(ctx, userID)
+}
+
+func (s *loadingServiceImpl) RoundWeight(ctx context.Context, userID uint, target int, equipmentName string) (equipment.Loadout, error) {
+	ls, err := s.GetLoadingSettings(ctx, userID)
+	if err != nil {
+		return equipment.Loadout{}, err
+	}
+	return ls.Round(target, equipmentName), nil
+}
@@ -0,0 +1,137 @@
+package equipment
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/equipment"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+)
+
+func (s *equipmentServiceImpl) CreateProfile(ctx context.Context, userID uint, name string, items []string, isDefault bool) (*equipment.EquipmentProfile, error) {
+	name = strings.TrimSpace(name)
+	items = normalizeItems(items)
+	if !equipment.IsKnown(items) {
+		return nil, custom_err.ErrUnknownEquipment
+	}
+
+	var p *equipment.EquipmentProfile
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.ensureNameFree(ctx, userID, name, 0); err != nil {
+			return err
+		}
+
+		existing, err := s.profileRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		// The first profile becomes the default so substitutions always have one to fall back on.
+		isDefault = isDefault || len(existing) == 0
+		if isDefault {
+			if err := s.profileRepo.ClearDefault(ctx, userID); err != nil {
+				return err
+			}
+		}
+
+		p = &equipment.EquipmentProfile{UserID: userID, Name: name, IsDefault: isDefault}
+		for _, e := range items {
+			p.Items = append(p.Items, equipment.EquipmentProfileItem{Equipment: e})
+		}
+		return s.profileRepo.Create(ctx, p)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *equipmentServiceImpl) GetProfiles(ctx context.Context, userID uint) ([]*equipment.EquipmentProfile, error) {
+	return s.profileRepo.GetByUserID(ctx, userID)
+}
+
+func (s *equipmentServiceImpl) GetProfile(ctx context.Context, userID, id uint) (*equipment.EquipmentProfile, error) {
+	return s.profileRepo.GetByID(ctx, userID, id)
+}
+
+// UpdateProfile renames a profile, replaces its equipment when items is non-nil
+// and can make it the default. Unsetting the default is not supported; make
+// another profile the default instead.
+func (s *equipmentServiceImpl) UpdateProfile(ctx context.Context, userID, id uint, name *string, items []string, isDefault *bool) (*equipment.EquipmentProfile, error) {
+	if items != nil {
+		items = normalizeItems(items)
+		if !equipment.IsKnown(items) {
+			return nil, custom_err.ErrUnknownEquipment
+		}
+	}
+
+	var p *equipment.EquipmentProfile
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		current, err := s.profileRepo.GetByID(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		updates := map[string]any{}
+		if name != nil {
+			n := strings.TrimSpace(*name)
+			if err := s.ensureNameFree(ctx, userID, n, current.ID); err != nil {
+				return err
+			}
+			updates["name"] = n
+		}
+		if isDefault != nil && *isDefault && !current.IsDefault {
+			if err := s.profileRepo.ClearDefault(ctx, userID); err != nil {
+				return err
+			}
+			updates["is_default"] = true
+		}
+		if len(updates) > 0 {
+			if err := s.profileRepo.Update(ctx, userID, id, updates); err != nil {
+				return err
+			}
+		}
+		if items != nil {
+			if err := s.profileRepo.ReplaceItems(ctx, id, items); err != nil {
+				return err
+			}
+		}
+
+		p, err = s.profileRepo.GetByID(ctx, userID, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *equipmentServiceImpl) DeleteProfile(ctx context.Context, userID, id uint) error {
+	return s.profileRepo.Delete(ctx, userID, id)
+}
+
+func (s *equipmentServiceImpl) ensureNameFree(ctx context.Context, userID uint, name string, exceptID uint) error {
+	existing, err := s.profileRepo.GetByName(ctx, userID, name)
+	if errors.Is(err, custom_err.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != exceptID {
+		return custom_err.ErrEquipmentProfileExists
+	}
+	return nil
+}
+
+func normalizeItems(items []string) []string {
+	out := make([]string, 0, len(items))
+	for _, e := range items {
+		e = strings.ToLower(strings.TrimSpace(e))
+		if e != "" && !slices.Contains(out, e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
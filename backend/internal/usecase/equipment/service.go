@@ -0,0 +1,29 @@
+package equipment
+
+import (
+	"github.com/lordmitrii/golang-web-gin/internal/domain/equipment"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type equipmentServiceImpl struct {
+	profileRepo    equipment.ProfileRepository
+	exerciseRepo   workout.ExerciseRepository
+	workoutService usecase.WorkoutService
+
+	tx usecase.TxManager
+}
+
+func NewEquipmentService(
+	profileRepo equipment.ProfileRepository,
+	exerciseRepo workout.ExerciseRepository,
+	workoutService usecase.WorkoutService,
+	tx usecase.TxManager,
+) usecase.EquipmentService {
+	return &equipmentServiceImpl{
+		profileRepo:    profileRepo,
+		exerciseRepo:   exerciseRepo,
+		workoutService: workoutService,
+		tx:             tx,
+	}
+}
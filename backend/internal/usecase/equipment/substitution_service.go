@@ -0,0 +1,185 @@
+package equipment
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/equipment"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+)
+
+// SuggestSubstitutes lists catalog exercises equivalent to exerciseID that the
+// given profile supports. Without a profile the user's default is used, and
+// without any profile every piece of equipment counts as available.
+func (s *equipmentServiceImpl) SuggestSubstitutes(ctx context.Context, userID, exerciseID uint, profileID *uint, limit int) ([]*workout.Exercise, error) {
+	ex, err := s.exerciseRepo.GetByID(ctx, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.resolveProfile(ctx, userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	var available []string
+	if profile != nil {
+		available = profile.Equipment()
+	}
+	return s.exerciseRepo.FindSubstitutes(ctx, ex, available, limit)
+}
+
+// SwitchWorkout replaces every pending exercise of a workout that the profile
+// cannot support with its best substitute, all in one transaction.
+func (s *equipmentServiceImpl) SwitchWorkout(ctx context.Context, userID, planID, cycleID, workoutID, profileID uint) (*equipment.SwitchResult, error) {
+	var res *equipment.SwitchResult
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		profile, err := s.profileRepo.GetByID(ctx, userID, profileID)
+		if err != nil {
+			return err
+		}
+		w, err := s.workoutService.GetWorkoutByID(ctx, userID, planID, cycleID, workoutID)
+		if err != nil {
+			return err
+		}
+
+		res = &equipment.SwitchResult{Profile: profile}
+		sw := newSwitcher(s, profile, res)
+		return sw.switchWorkout(ctx, userID, planID, cycleID, w)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SwitchPlan does the same as SwitchWorkout for every unfinished workout of a plan.
+func (s *equipmentServiceImpl) SwitchPlan(ctx context.Context, userID, planID, profileID uint) (*equipment.SwitchResult, error) {
+	var res *equipment.SwitchResult
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		profile, err := s.profileRepo.GetByID(ctx, userID, profileID)
+		if err != nil {
+			return err
+		}
+		cycles, err := s.workoutService.GetWorkoutCyclesByWorkoutPlanID(ctx, userID, planID)
+		if err != nil {
+			return err
+		}
+
+		res = &equipment.SwitchResult{Profile: profile}
+		sw := newSwitcher(s, profile, res)
+		for _, c := range cycles {
+			if c.Completed || c.Skipped {
+				continue
+			}
+			workouts, err := s.workoutService.GetWorkoutsByWorkoutCycleID(ctx, userID, planID, c.ID)
+			if err != nil {
+				return err
+			}
+			for _, w := range workouts {
+				if err := sw.switchWorkout(ctx, userID, planID, c.ID, w); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *equipmentServiceImpl) resolveProfile(ctx context.Context, userID uint, profileID *uint) (*equipment.EquipmentProfile, error) {
+	if profileID != nil {
+		return s.profileRepo.GetByID(ctx, userID, *profileID)
+	}
+	p, err := s.profileRepo.GetDefault(ctx, userID)
+	if errors.Is(err, custom_err.ErrNotFound) {
+		return nil, nil
+	}
+	return p, err
+}
+
+// switcher carries per-switch state so that each catalog exercise is looked
+// up and substituted only once, however many workouts use it.
+type switcher struct {
+	s       *equipmentServiceImpl
+	profile *equipment.EquipmentProfile
+	res     *equipment.SwitchResult
+	subs    map[uint]*workout.Exercise
+}
+
+func newSwitcher(s *equipmentServiceImpl, profile *equipment.EquipmentProfile, res *equipment.SwitchResult) *switcher {
+	return &switcher{s: s, profile: profile, res: res, subs: map[uint]*workout.Exercise{}}
+}
+
+func (sw *switcher) switchWorkout(ctx context.Context, userID, planID, cycleID uint, w *workout.Workout) error {
+	if w.Completed || w.Skipped {
+		return nil
+	}
+
+	exercises, err := sw.s.workoutService.GetWorkoutExercisesByWorkoutID(ctx, userID, planID, cycleID, w.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, we := range exercises {
+		if we.Completed || we.Skipped || we.IndividualExercise == nil {
+			continue
+		}
+		ie := we.IndividualExercise
+		if ie.Exercise == nil {
+			if ie.ExerciseID == nil && !ie.IsBodyweight {
+				sw.res.Unresolved = append(sw.res.Unresolved, equipment.Unresolved{
+					WorkoutID: w.ID, WorkoutExerciseID: we.ID, Name: ie.Name,
+				})
+			}
+			continue
+		}
+		if sw.profile.Has(ie.Exercise.Equipment) {
+			continue
+		}
+
+		sub, err := sw.substitute(ctx, ie.Exercise)
+		if err != nil {
+			return err
+		}
+		if sub == nil {
+			sw.res.Unresolved = append(sw.res.Unresolved, equipment.Unresolved{
+				WorkoutID: w.ID, WorkoutExerciseID: we.ID, Name: ie.Name,
+			})
+			continue
+		}
+
+		target, err := sw.s.workoutService.GetOrCreateIndividualExercise(ctx, userID, &workout.IndividualExercise{ExerciseID: &sub.ID})
+		if err != nil {
+			return err
+		}
+		sets := max(int64(len(we.WorkoutSets)), 1)
+		replaced, err := sw.s.workoutService.ReplaceWorkoutExercise(ctx, userID, planID, cycleID, w.ID, we.ID, target.ID, sets)
+		if err != nil {
+			return err
+		}
+		sw.res.Swaps = append(sw.res.Swaps, equipment.Swap{
+			WorkoutID: w.ID, WorkoutExerciseID: replaced.ID, From: ie.Exercise, To: sub,
+		})
+	}
+	return nil
+}
+
+func (sw *switcher) substitute(ctx context.Context, ex *workout.Exercise) (*workout.Exercise, error) {
+	if sub, ok := sw.subs[ex.ID]; ok {
+		return sub, nil
+	}
+	found, err := sw.s.exerciseRepo.FindSubstitutes(ctx, ex, sw.profile.Equipment(), 1)
+	if err != nil {
+		return nil, err
+	}
+	var sub *workout.Exercise
+	if len(found) > 0 {
+		sub = found[0]
+	}
+	sw.subs[ex.ID] = sub
+	return sub, nil
+}
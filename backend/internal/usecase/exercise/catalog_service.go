@@ -0,0 +1,107 @@
+package exercise
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/translations"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/versions"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+)
+
+func (s *exerciseServiceImpl) SetExerciseSecondaryMuscleGroups(ctx context.Context, id uint, muscleGroupIDs []uint) (*workout.Exercise, error) {
+	if _, err := s.exerciseRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.checkMuscleGroups(ctx, muscleGroupIDs); err != nil {
+		return nil, err
+	}
+	if err := s.exerciseRepo.ReplaceSecondaryMuscleGroups(ctx, id, muscleGroupIDs); err != nil {
+		return nil, err
+	}
+	return s.exerciseRepo.GetByID(ctx, id)
+}
+
+func (s *exerciseServiceImpl) SetExerciseMedia(ctx context.Context, id uint, media []*workout.ExerciseMedia) (*workout.Exercise, error) {
+	if _, err := s.exerciseRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.exerciseRepo.ReplaceMedia(ctx, id, media); err != nil {
+		return nil, err
+	}
+	return s.exerciseRepo.GetByID(ctx, id)
+}
+
+// SetExerciseInstructions stores the how-to text under the exercise's
+// instructions key. Without auto-translation the text is saved as English
+// only and other locales fall back to it.
+func (s *exerciseServiceImpl) SetExerciseInstructions(ctx context.Context, id uint, instructions string, autoTranslate bool) error {
+	e, err := s.exerciseRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	texts, err := s.translateInstructions(ctx, instructions, autoTranslate)
+	if err != nil {
+		return err
+	}
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		for locale, val := range texts {
+			if err := s.saveTranslation(ctx, locale, e.InstructionsKey(), val); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// translateInstructions returns the text to save per locale.
+func (s *exerciseServiceImpl) translateInstructions(ctx context.Context, instructions string, autoTranslate bool) (map[string]string, error) {
+	if !autoTranslate {
+		return map[string]string{"en": instructions}, nil
+	}
+
+	texts := make(map[string]string, len(translations.ISO2Locale))
+	for iso, locale := range translations.ISO2Locale {
+		val, err := s.translator.Translate(ctx, instructions, iso)
+		if err != nil {
+			return nil, fmt.Errorf("auto-translate exercise instructions failed for locale %s: %w", locale, err)
+		}
+		texts[locale] = val
+	}
+	return texts, nil
+}
+
+// checkMuscleGroups fails with ErrUnknownMuscleGroup unless every ID exists.
+func (s *exerciseServiceImpl) checkMuscleGroups(ctx context.Context, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	ids = slices.Compact(slices.Sorted(slices.Values(ids)))
+	n, err := s.muscleGroupRepo.CountByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	if n != int64(len(ids)) {
+		return custom_err.ErrUnknownMuscleGroup
+	}
+	return nil
+}
+
+func (s *exerciseServiceImpl) saveTranslation(ctx context.Context, locale, key, value string) error {
+	tr := &translations.Translation{
+		Namespace: "translation",
+		Locale:    locale,
+		Key:       key,
+		Value:     value,
+	}
+	if err := s.translationRepo.Upsert(ctx, tr); err != nil {
+		return fmt.Errorf("saving translation failed for locale %s: %w", locale, err)
+	}
+	if err := s.versionRepo.BumpVersion(ctx, versions.VersionTranslationKey(locale, "translation")); err != nil {
+		return fmt.Errorf("bumping version failed for locale %s: %w", locale, err)
+	}
+	return nil
+}
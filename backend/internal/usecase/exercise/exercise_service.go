@@ -6,47 +6,65 @@ import (
 	"unicode"
 
 	"github.com/lordmitrii/golang-web-gin/internal/domain/translations"
-	"github.com/lordmitrii/golang-web-gin/internal/domain/versions"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
 )
 
-func (s *exerciseServiceImpl) CreateExercise(ctx context.Context, e *workout.Exercise, autoTranslate bool) error {
-	err := s.exerciseRepo.Create(ctx, e)
-	if err != nil {
-		return err
+// CreateExercise stores the exercise together with its name translations and
+// instructions in one transaction. Translation runs first, outside of it, and
+// unknown muscle groups are rejected before anything is written.
+func (s *exerciseServiceImpl) CreateExercise(ctx context.Context, e *workout.Exercise, instructions string, autoTranslate bool) error {
+	ids := make([]uint, 0, len(e.SecondaryMuscleGroups)+1)
+	if e.MuscleGroupID != nil {
+		ids = append(ids, *e.MuscleGroupID)
 	}
-	if !autoTranslate {
-		return nil
+	for _, mg := range e.SecondaryMuscleGroups {
+		ids = append(ids, mg.ID)
+	}
+	if err := s.checkMuscleGroups(ctx, ids); err != nil {
+		return err
 	}
 
-	for iso, locale := range translations.ISO2Locale {
-		val, err := s.translator.Translate(ctx, e.Name, iso)
-		if err != nil {
-			return fmt.Errorf("auto-translate exercise name failed for locale %s: %w", locale, err)
-		}
+	names := map[string]string{}
+	if autoTranslate {
+		for iso, locale := range translations.ISO2Locale {
+			val, err := s.translator.Translate(ctx, e.Name, iso)
+			if err != nil {
+				return fmt.Errorf("auto-translate exercise name failed for locale %s: %w", locale, err)
+			}
 
-		if len(val) > 0 {
-			runes := []rune(val)
-			runes[0] = unicode.ToUpper(runes[0])
-			val = string(runes)
+			if len(val) > 0 {
+				runes := []rune(val)
+				runes[0] = unicode.ToUpper(runes[0])
+				val = string(runes)
+			}
+			names[locale] = val
 		}
+	}
 
-		tr := &translations.Translation{
-			Namespace: "translation",
-			Locale:    locale,
-			Key:       fmt.Sprintf("exercise.%s", e.Slug),
-			Value:     val,
+	var texts map[string]string
+	if instructions != "" {
+		var err error
+		if texts, err = s.translateInstructions(ctx, instructions, autoTranslate); err != nil {
+			return err
 		}
+	}
 
-		if err := s.translationRepo.Create(ctx, tr); err != nil {
-			return fmt.Errorf("saving translation failed for locale %s: %w", locale, err)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.exerciseRepo.Create(ctx, e); err != nil {
+			return err
 		}
-
-		if err := s.versionRepo.BumpVersion(ctx, versions.VersionTranslationKey(locale, "translation")); err != nil {
-			return fmt.Errorf("bumping version failed for locale %s: %w", locale, err)
+		for locale, val := range names {
+			if err := s.saveTranslation(ctx, locale, fmt.Sprintf("exercise.%s", e.Slug), val); err != nil {
+				return err
+			}
 		}
-	}
-	return nil
+		for locale, val := range texts {
+			if err := s.saveTranslation(ctx, locale, e.InstructionsKey(), val); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 func (s *exerciseServiceImpl) GetExerciseByID(ctx context.Context, id uint) (*workout.Exercise, error) {
@@ -62,13 +80,16 @@ func (s *exerciseServiceImpl) GetAllExercises(ctx context.Context) ([]*workout.E
 }
 
 func (s *exerciseServiceImpl) UpdateExercise(ctx context.Context, id uint, updates map[string]any) (*workout.Exercise, error) {
-	return s.exerciseRepo.UpdateReturning(ctx, id, updates)
+	if _, err := s.exerciseRepo.UpdateReturning(ctx, id, updates); err != nil {
+		return nil, err
+	}
+	return s.exerciseRepo.GetByID(ctx, id)
 }
 
 func (s *exerciseServiceImpl) DeleteExercise(ctx context.Context, id uint) error {
 	return s.exerciseRepo.Delete(ctx, id)
 }
 
-func (s *exerciseServiceImpl) GetExerciseNamesByMuscleName(ctx context.Context, muscleName string, limit, offset int) ([]*workout.Exercise, error) {
-	return s.exerciseRepo.GetExerciseNamesByMuscleName(ctx, muscleName, limit, offset)
+func (s *exerciseServiceImpl) SearchExercises(ctx context.Context, f workout.ExerciseFilter, page, pageSize int64) ([]*workout.Exercise, int64, error) {
+	return s.exerciseRepo.Search(ctx, f, page, pageSize)
 }
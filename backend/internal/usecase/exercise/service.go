@@ -13,6 +13,8 @@ type exerciseServiceImpl struct {
 	translator      translations.Translator
 	translationRepo translations.TranslationRepository
 	versionRepo     versions.VersionRepository
+
+	tx usecase.TxManager
 }
 
 func NewExerciseService(
@@ -21,6 +23,7 @@ func NewExerciseService(
 	translator translations.Translator,
 	translationRepo translations.TranslationRepository,
 	versionRepo versions.VersionRepository,
+	tx usecase.TxManager,
 ) usecase.ExerciseService {
 	return &exerciseServiceImpl{
 		exerciseRepo:    exerciseRepo,
@@ -28,5 +31,6 @@ func NewExerciseService(
 		translator:      translator,
 		translationRepo: translationRepo,
 		versionRepo:     versionRepo,
+		tx:              tx,
 	}
 }
@@ -0,0 +1,347 @@
+package goal
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/achievement"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/goal"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+const maxPerWeek = 14
+
+func (s *goalServiceImpl) CreateGoal(ctx context.Context, userID uint, g *goal.Goal) (*goal.Tracked, error) {
+	g.UserID = userID
+	g.Status = goal.StatusActive
+	g.AchievedAt, g.AchievedWorkoutID = nil, nil
+	if g.Deadline != nil && !g.Deadline.After(time.Now()) {
+		return nil, custom_err.ErrInvalidGoal
+	}
+
+	switch g.Kind {
+	case goal.KindLift:
+		if g.IndividualExerciseID == nil {
+			return nil, custom_err.ErrInvalidGoal
+		}
+		ie, err := s.individualExerciseRepo.GetByID(ctx, userID, *g.IndividualExerciseID)
+		if err != nil {
+			return nil, err
+		}
+		if ie.IsTimeBased {
+			return nil, custom_err.ErrInvalidGoal
+		}
+		if g.Metric == "" {
+			g.Metric = goal.MetricE1RM
+		}
+		if g.Metric == goal.MetricE1RM {
+			g.TargetReps = 0
+		}
+		g.IndividualExercise = ie
+		g.StartWeight, g.PerWeek, g.Weeks = 0, 0, 0
+	case goal.KindBodyweight:
+		profile, err := s.profileRepo.GetByUserID(ctx, userID)
+		if errors.Is(err, custom_err.ErrProfileNotFound) || (err == nil && profile.Weight <= 0) {
+			return nil, custom_err.ErrBodyWeightRequired
+		} else if err != nil {
+			return nil, err
+		}
+		if g.TargetWeight == profile.Weight {
+			return nil, custom_err.ErrInvalidGoal
+		}
+		g.StartWeight = profile.Weight
+		g.IndividualExerciseID, g.Metric, g.TargetReps, g.PerWeek, g.Weeks = nil, "", 0, 0, 0
+	case goal.KindFrequency:
+		if g.Weeks == 0 {
+			g.Weeks = 1
+		}
+		g.IndividualExerciseID, g.Metric, g.TargetWeight, g.TargetReps, g.StartWeight = nil, "", 0, 0, 0
+	}
+	if err := validate(g); err != nil {
+		return nil, err
+	}
+
+	if err := s.goalRepo.Create(ctx, g); err != nil {
+		return nil, err
+	}
+	tracked, err := s.evaluate(ctx, userID, []*goal.Goal{g}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return tracked[0], nil
+}
+
+func (s *goalServiceImpl) GetGoal(ctx context.Context, userID, id uint) (*goal.Tracked, error) {
+	g, err := s.goalRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	tracked, err := s.track(ctx, userID, []*goal.Goal{g})
+	if err != nil {
+		return nil, err
+	}
+	return tracked[0], nil
+}
+
+// GetGoals returns goals with fresh progress. Reading changes nothing: goals are marked
+// achieved when a workout, or an update to the goal, reaches them.
+func (s *goalServiceImpl) GetGoals(ctx context.Context, userID uint, status string) ([]*goal.Tracked, error) {
+	goals, err := s.goalRepo.GetByUserID(ctx, userID, status)
+	if err != nil {
+		return nil, err
+	}
+	tracked, err := s.track(ctx, userID, goals)
+	if err != nil {
+		return nil, err
+	}
+	// Goals shown as expired before the cleanup job stores it are filtered the same way.
+	out := tracked[:0]
+	for _, t := range tracked {
+		if status == "" || t.Goal.Status == status {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// UpdateGoal changes targets and the deadline, or abandons and resumes a goal. Achieved
+// goals are final; an expired goal resumed without a later deadline expires again.
+func (s *goalServiceImpl) UpdateGoal(ctx context.Context, userID, id uint, updates map[string]any) (*goal.Tracked, error) {
+	var g *goal.Goal
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		cur, err := s.goalRepo.GetByID(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+		if cur.Status == goal.StatusAchieved {
+			return custom_err.ErrInvalidGoal
+		}
+
+		next := *cur
+		if v, ok := updates["status"].(string); ok {
+			next.Status = v
+		}
+		if v, ok := updates["target_weight"].(int); ok {
+			next.TargetWeight = v
+		}
+		if v, ok := updates["target_reps"].(int); ok {
+			next.TargetReps = v
+		}
+		if v, ok := updates["per_week"].(int); ok {
+			next.PerWeek = v
+		}
+		if v, ok := updates["weeks"].(int); ok {
+			next.Weeks = v
+		}
+		if next.Status == goal.StatusAchieved {
+			return custom_err.ErrInvalidGoal
+		}
+		if err := validate(&next); err != nil {
+			return err
+		}
+
+		if err := s.goalRepo.Update(ctx, userID, id, updates); err != nil {
+			return err
+		}
+		g, err = s.goalRepo.GetByID(ctx, userID, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	tracked, err := s.evaluate(ctx, userID, []*goal.Goal{g}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return tracked[0], nil
+}
+
+func (s *goalServiceImpl) DeleteGoal(ctx context.Context, userID, id uint) error {
+	return s.goalRepo.Delete(ctx, userID, id)
+}
+
+// EvaluateWorkout re-checks the user's active goals after a completed workout and
+// returns the ones it reached.
+func (s *goalServiceImpl) EvaluateWorkout(ctx context.Context, userID, workoutID uint) ([]*goal.Goal, error) {
+	goals, err := s.goalRepo.GetByUserID(ctx, userID, goal.StatusActive)
+	if err != nil {
+		return nil, err
+	}
+	tracked, err := s.evaluate(ctx, userID, goals, &workoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	var achieved []*goal.Goal
+	for _, t := range tracked {
+		if t.Goal.AchievedWorkoutID != nil && *t.Goal.AchievedWorkoutID == workoutID {
+			achieved = append(achieved, t.Goal)
+		}
+	}
+	return achieved, nil
+}
+
+// track measures each goal without storing anything. Goals past their deadline are
+// shown as expired; the cleanup job stores that.
+func (s *goalServiceImpl) track(ctx context.Context, userID uint, goals []*goal.Goal) ([]*goal.Tracked, error) {
+	m := &measurements{s: s, userID: userID}
+	now := time.Now()
+
+	out := make([]*goal.Tracked, 0, len(goals))
+	for _, g := range goals {
+		p, err := m.progress(ctx, g)
+		if err != nil {
+			return nil, err
+		}
+		g.Expire(now)
+		out = append(out, &goal.Tracked{Goal: g, Progress: p})
+	}
+	return out, nil
+}
+
+// evaluate measures each goal, marks the active ones past their deadline as expired and
+// the others that are reached as achieved, and publishes GoalAchieved for those.
+func (s *goalServiceImpl) evaluate(ctx context.Context, userID uint, goals []*goal.Goal, workoutID *uint) ([]*goal.Tracked, error) {
+	m := &measurements{s: s, userID: userID}
+	acc := &usecase.EventAccumulator{}
+	now := time.Now()
+
+	out := make([]*goal.Tracked, 0, len(goals))
+	for _, g := range goals {
+		p, err := m.progress(ctx, g)
+		if err != nil {
+			return nil, err
+		}
+		if g.Overdue(now) {
+			g.Expire(now)
+			if err := s.goalRepo.MarkExpired(ctx, g); err != nil {
+				return nil, err
+			}
+		}
+		if g.IsActive() && p.Reached {
+			g.Achieve(now, workoutID)
+			marked, err := s.goalRepo.MarkAchieved(ctx, g)
+			if err != nil {
+				return nil, err
+			}
+			if marked {
+				for _, e := range g.PendingEvents() {
+					acc.Add(e)
+				}
+			}
+			g.ClearPendingEvents()
+		}
+		out = append(out, &goal.Tracked{Goal: g, Progress: p})
+	}
+
+	if evs := acc.Drain(); len(evs) > 0 {
+		if err := s.bus.Publish(ctx, evs...); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// measurements loads each input at most once per evaluation.
+type measurements struct {
+	s      *goalServiceImpl
+	userID uint
+
+	profile *user.Profile
+	streaks map[streakKey]achievement.Streak
+	times   []time.Time
+	loaded  bool
+}
+
+// streakKey tells apart frequency goals that need different streaks.
+type streakKey struct {
+	perWeek int
+	from    time.Time
+}
+
+func (m *measurements) progress(ctx context.Context, g *goal.Goal) (goal.Progress, error) {
+	switch g.Kind {
+	case goal.KindLift:
+		if g.IndividualExerciseID == nil {
+			return goal.Progress{Target: g.TargetWeight}, nil
+		}
+		byReps, err := m.s.progressRepo.MaxWeightByReps(ctx, m.userID, *g.IndividualExerciseID)
+		if err != nil {
+			return goal.Progress{}, err
+		}
+		return goal.LiftProgress(g, goal.BestLift(g, byReps)), nil
+	case goal.KindBodyweight:
+		if m.profile == nil {
+			p, err := m.s.profileRepo.GetByUserID(ctx, m.userID)
+			if err != nil && !errors.Is(err, custom_err.ErrProfileNotFound) {
+				return goal.Progress{}, err
+			}
+			if p == nil {
+				p = &user.Profile{}
+			}
+			m.profile = p
+		}
+		return goal.BodyweightProgress(g, m.profile.Weight), nil
+	case goal.KindFrequency:
+		if !m.loaded {
+			times, err := m.s.progressRepo.CompletionTimes(ctx, m.userID)
+			if err != nil {
+				return goal.Progress{}, err
+			}
+			m.times, m.loaded = times, true
+			m.streaks = map[streakKey]achievement.Streak{}
+		}
+		// Only workouts from the week the goal was set in count towards it.
+		key := streakKey{perWeek: g.PerWeek, from: g.CountsFrom()}
+		st, ok := m.streaks[key]
+		if !ok {
+			var since []time.Time
+			for _, t := range m.times {
+				if !t.Before(key.from) {
+					since = append(since, t)
+				}
+			}
+			st = achievement.CalculateStreak(since, g.PerWeek, time.Now())
+			m.streaks[key] = st
+		}
+		return goal.FrequencyProgress(g, st), nil
+	}
+	return goal.Progress{}, nil
+}
+
+func validate(g *goal.Goal) error {
+	switch g.Status {
+	case goal.StatusActive, goal.StatusAbandoned, goal.StatusAchieved, goal.StatusExpired:
+	default:
+		return custom_err.ErrInvalidGoal
+	}
+	switch g.Kind {
+	case goal.KindLift:
+		if g.TargetWeight <= 0 {
+			return custom_err.ErrInvalidGoal
+		}
+		switch g.Metric {
+		case goal.MetricE1RM:
+		case goal.MetricWeightReps:
+			if g.TargetReps <= 0 {
+				return custom_err.ErrInvalidGoal
+			}
+		default:
+			return custom_err.ErrInvalidGoal
+		}
+	case goal.KindBodyweight:
+		if g.TargetWeight <= 0 {
+			return custom_err.ErrInvalidGoal
+		}
+	case goal.KindFrequency:
+		if g.PerWeek < 1 || g.PerWeek > maxPerWeek || g.Weeks < 1 {
+			return custom_err.ErrInvalidGoal
+		}
+	default:
+		return custom_err.ErrInvalidGoal
+	}
+	return nil
+}
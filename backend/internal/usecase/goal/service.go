@@ -0,0 +1,36 @@
+package goal
+
+import (
+	"github.com/lordmitrii/golang-web-gin/internal/domain/goal"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type goalServiceImpl struct {
+	goalRepo               goal.GoalRepository
+	progressRepo           goal.ProgressRepository
+	profileRepo            user.ProfileRepository
+	individualExerciseRepo workout.IndividualExerciseRepository
+
+	tx  usecase.TxManager
+	bus usecase.EventBus
+}
+
+func NewGoalService(
+	goalRepo goal.GoalRepository,
+	progressRepo goal.ProgressRepository,
+	profileRepo user.ProfileRepository,
+	individualExerciseRepo workout.IndividualExerciseRepository,
+	tx usecase.TxManager,
+	bus usecase.EventBus,
+) usecase.GoalService {
+	return &goalServiceImpl{
+		goalRepo:               goalRepo,
+		progressRepo:           progressRepo,
+		profileRepo:            profileRepo,
+		individualExerciseRepo: individualExerciseRepo,
+		tx:                     tx,
+		bus:                    bus,
+	}
+}
@@ -0,0 +1,79 @@
+package nutrition
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/nutrition"
+)
+
+// GetTargets derives daily targets from the profile and applies the user's overrides.
+// Targets are nil when the profile is incomplete and no calorie override is set.
+func (s *nutritionServiceImpl) GetTargets(ctx context.Context, userID uint) (*nutrition.Targets, *nutrition.NutritionGoal, error) {
+	goal, err := s.goalRepo.GetByUserID(ctx, userID)
+	if errors.Is(err, custom_err.ErrNotFound) {
+		goal = &nutrition.NutritionGoal{UserID: userID, Goal: nutrition.GoalMaintain}
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	profile, err := s.profileRepo.GetByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, custom_err.ErrProfileNotFound) {
+		return nil, nil, err
+	}
+
+	derived, ok := nutrition.DeriveTargets(profile, goal.Goal)
+	if !ok && goal.Kcal == nil {
+		return nil, goal, nil
+	}
+	t := goal.Apply(derived)
+	return &t, goal, nil
+}
+
+func (s *nutritionServiceImpl) SetGoal(ctx context.Context, userID uint, g *nutrition.NutritionGoal) (*nutrition.Targets, *nutrition.NutritionGoal, error) {
+	g.UserID = userID
+	if g.Goal == "" {
+		g.Goal = nutrition.GoalMaintain
+	}
+	if err := s.goalRepo.Upsert(ctx, g); err != nil {
+		return nil, nil, err
+	}
+	return s.GetTargets(ctx, userID)
+}
+
+// GetDailyBalance totals intake and training expenditure for the day starting at
+// day, which callers pass as midnight in the user's time zone.
+func (s *nutritionServiceImpl) GetDailyBalance(ctx context.Context, userID uint, day time.Time) (*nutrition.DailyBalance, error) {
+	from, to := day, day.AddDate(0, 0, 1)
+
+	entries, err := s.mealRepo.GetByRange(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	burns, err := s.burnRepo.WorkoutCalories(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	targets, _, err := s.GetTargets(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &nutrition.DailyBalance{
+		Day:      day,
+		ByMeal:   map[string]nutrition.Macros{},
+		Workouts: burns,
+		Targets:  targets,
+	}
+	for _, e := range entries {
+		m := e.Macros()
+		b.Intake = b.Intake.Add(m)
+		b.ByMeal[e.Meal] = b.ByMeal[e.Meal].Add(m)
+	}
+	for _, w := range burns {
+		b.Burned += w.Kcal
+	}
+	return b, nil
+}
@@ -0,0 +1,123 @@
+package nutrition
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gosimple/slug"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/nutrition"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/translations"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/versions"
+)
+
+// CreateCatalogFood adds a food everyone can log. Its name is stored under
+// "food.<slug>", translated to every locale when autoTranslate is set and
+// English only otherwise.
+func (s *nutritionServiceImpl) CreateCatalogFood(ctx context.Context, f *nutrition.Food, autoTranslate bool) error {
+	f.OwnerID = nil
+	f.Name = strings.TrimSpace(f.Name)
+	if f.Slug == "" {
+		f.Slug = slug.Make(f.Name)
+	}
+
+	names := map[string]string{"en": f.Name}
+	if autoTranslate {
+		for iso, locale := range translations.ISO2Locale {
+			val, err := s.translator.Translate(ctx, f.Name, iso)
+			if err != nil {
+				return fmt.Errorf("auto-translate food failed for locale %s: %w", locale, err)
+			}
+			names[locale] = val
+		}
+	}
+
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.foodRepo.GetCatalogBySlug(ctx, f.Slug); err == nil {
+			return custom_err.ErrFoodExists
+		} else if !errors.Is(err, custom_err.ErrNotFound) {
+			return err
+		}
+		if err := s.foodRepo.Create(ctx, f); err != nil {
+			return err
+		}
+		for locale, name := range names {
+			if err := s.saveTranslation(ctx, locale, f.TranslationKey(), name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *nutritionServiceImpl) saveTranslation(ctx context.Context, locale, key, value string) error {
+	tr := &translations.Translation{
+		Namespace: "translation",
+		Locale:    locale,
+		Key:       key,
+		Value:     value,
+	}
+	if err := s.translationRepo.Upsert(ctx, tr); err != nil {
+		return fmt.Errorf("saving translation failed for locale %s: %w", locale, err)
+	}
+	if err := s.versionRepo.BumpVersion(ctx, versions.VersionTranslationKey(locale, "translation")); err != nil {
+		return fmt.Errorf("bumping version failed for locale %s: %w", locale, err)
+	}
+	return nil
+}
+
+// CreateFood adds a private food. It is not translated.
+func (s *nutritionServiceImpl) CreateFood(ctx context.Context, userID uint, f *nutrition.Food) error {
+	f.OwnerID = &userID
+	f.Slug = ""
+	f.Name = strings.TrimSpace(f.Name)
+	return s.foodRepo.Create(ctx, f)
+}
+
+func (s *nutritionServiceImpl) GetFood(ctx context.Context, userID, id uint) (*nutrition.Food, error) {
+	return s.foodRepo.GetByID(ctx, userID, id)
+}
+
+func (s *nutritionServiceImpl) SearchFoods(ctx context.Context, userID uint, query string, page, pageSize int) ([]*nutrition.Food, int64, error) {
+	return s.foodRepo.Search(ctx, userID, query, page, pageSize)
+}
+
+func (s *nutritionServiceImpl) UpdateFood(ctx context.Context, userID, id uint, updates map[string]any) (*nutrition.Food, error) {
+	if err := s.foodRepo.Update(ctx, &userID, id, updates); err != nil {
+		return nil, err
+	}
+	return s.foodRepo.GetByID(ctx, userID, id)
+}
+
+// UpdateCatalogFood changes nutrient values and the fallback name. Translations
+// are edited through the translations admin like any other key.
+func (s *nutritionServiceImpl) UpdateCatalogFood(ctx context.Context, id uint, updates map[string]any) (*nutrition.Food, error) {
+	if err := s.foodRepo.Update(ctx, nil, id, updates); err != nil {
+		return nil, err
+	}
+	return s.foodRepo.GetByID(ctx, 0, id)
+}
+
+func (s *nutritionServiceImpl) DeleteFood(ctx context.Context, userID, id uint) error {
+	return s.deleteFood(ctx, &userID, id)
+}
+
+func (s *nutritionServiceImpl) DeleteCatalogFood(ctx context.Context, id uint) error {
+	return s.deleteFood(ctx, nil, id)
+}
+
+// deleteFood refuses to remove foods that appear in the meal log, so history keeps its numbers.
+func (s *nutritionServiceImpl) deleteFood(ctx context.Context, ownerID *uint, id uint) error {
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		n, err := s.mealRepo.CountByFoodID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			return custom_err.ErrFoodInUse
+		}
+		return s.foodRepo.Delete(ctx, ownerID, id)
+	})
+}
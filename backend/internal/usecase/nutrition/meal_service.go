@@ -0,0 +1,45 @@
+package nutrition
+
+import (
+	"context"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/nutrition"
+)
+
+func (s *nutritionServiceImpl) LogMeal(ctx context.Context, userID uint, e *nutrition.MealEntry) error {
+	food, err := s.foodRepo.GetByID(ctx, userID, e.FoodID)
+	if err != nil {
+		return err
+	}
+
+	e.UserID = userID
+	if e.EatenAt.IsZero() {
+		e.EatenAt = time.Now()
+	}
+	if err := s.mealRepo.Create(ctx, e); err != nil {
+		return err
+	}
+	e.Food = food
+	return nil
+}
+
+func (s *nutritionServiceImpl) GetMeals(ctx context.Context, userID uint, from, to time.Time) ([]*nutrition.MealEntry, error) {
+	return s.mealRepo.GetByRange(ctx, userID, from, to)
+}
+
+func (s *nutritionServiceImpl) UpdateMeal(ctx context.Context, userID, id uint, updates map[string]any) (*nutrition.MealEntry, error) {
+	if foodID, ok := updates["food_id"].(uint); ok {
+		if _, err := s.foodRepo.GetByID(ctx, userID, foodID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.mealRepo.Update(ctx, userID, id, updates); err != nil {
+		return nil, err
+	}
+	return s.mealRepo.GetByID(ctx, userID, id)
+}
+
+func (s *nutritionServiceImpl) DeleteMeal(ctx context.Context, userID, id uint) error {
+	return s.mealRepo.Delete(ctx, userID, id)
+}
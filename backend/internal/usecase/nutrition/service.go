@@ -0,0 +1,46 @@
+package nutrition
+
+import (
+	"github.com/lordmitrii/golang-web-gin/internal/domain/nutrition"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/translations"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/versions"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type nutritionServiceImpl struct {
+	foodRepo        nutrition.FoodRepository
+	mealRepo        nutrition.MealEntryRepository
+	goalRepo        nutrition.GoalRepository
+	burnRepo        nutrition.BurnRepository
+	profileRepo     user.ProfileRepository
+	translator      translations.Translator
+	translationRepo translations.TranslationRepository
+	versionRepo     versions.VersionRepository
+
+	tx usecase.TxManager
+}
+
+func NewNutritionService(
+	foodRepo nutrition.FoodRepository,
+	mealRepo nutrition.MealEntryRepository,
+	goalRepo nutrition.GoalRepository,
+	burnRepo nutrition.BurnRepository,
+	profileRepo user.ProfileRepository,
+	translator translations.Translator,
+	translationRepo translations.TranslationRepository,
+	versionRepo versions.VersionRepository,
+	tx usecase.TxManager,
+) usecase.NutritionService {
+	return &nutritionServiceImpl{
+		foodRepo:        foodRepo,
+		mealRepo:        mealRepo,
+		goalRepo:        goalRepo,
+		burnRepo:        burnRepo,
+		profileRepo:     profileRepo,
+		translator:      translator,
+		translationRepo: translationRepo,
+		versionRepo:     versionRepo,
+		tx:              tx,
+	}
+}
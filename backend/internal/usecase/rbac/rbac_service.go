@@ -2,6 +2,9 @@ package rbac
 
 import (
 	"context"
+	"log"
+	"slices"
+
 	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
 )
 
@@ -19,16 +22,11 @@ func (s *rbacServiceImpl) HasRole(ctx context.Context, userID uint, roleName str
 }
 
 func (s *rbacServiceImpl) HasPermission(ctx context.Context, userID uint, permKey string) (bool, error) {
-	permissions, err := s.permissionRepo.GetUserPermissions(ctx, userID)
+	keys, err := s.GetUserPermissionKeys(ctx, userID)
 	if err != nil {
 		return false, err
 	}
-	for _, perm := range permissions {
-		if perm.Key == permKey {
-			return true, nil
-		}
-	}
-	return false, nil
+	return slices.Contains(keys, permKey), nil
 }
 
 func (s *rbacServiceImpl) GetUserRoles(ctx context.Context, userID uint) ([]*rbac.Role, error) {
@@ -46,3 +44,34 @@ func (s *rbacServiceImpl) GetUserPermissions(ctx context.Context, userID uint) (
 	}
 	return permissions, nil
 }
+
+// GetUserPermissionKeys reads through the permission cache. Cache errors are logged and
+// the database is used instead, so a Redis outage never denies access on its own.
+// The fill is guarded by the stamp from the miss, so a role change committed while the
+// keys were loading cannot leave them cached.
+func (s *rbacServiceImpl) GetUserPermissionKeys(ctx context.Context, userID uint) ([]string, error) {
+	keys, stamp, ok, err := s.cache.Get(ctx, userID)
+	if err != nil {
+		log.Printf("permission cache read failed: %v", err)
+	}
+	if ok {
+		return keys, nil
+	}
+
+	permissions, err := s.permissionRepo.GetUserPermissions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	keys = make([]string, 0, len(permissions))
+	for _, perm := range permissions {
+		keys = append(keys, perm.Key)
+	}
+
+	if stamp == "" {
+		return keys, nil // the cache read failed, so there is nothing safe to fill
+	}
+	if err := s.cache.Set(ctx, userID, stamp, keys); err != nil {
+		log.Printf("permission cache write failed: %v", err)
+	}
+	return keys, nil
+}
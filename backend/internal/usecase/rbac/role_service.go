@@ -0,0 +1,258 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+)
+
+func (s *rbacServiceImpl) ListRoles(ctx context.Context) ([]*rbac.Role, error) {
+	roles, err := s.roleRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		if err := s.loadPermissions(ctx, role); err != nil {
+			return nil, err
+		}
+	}
+	return roles, nil
+}
+
+func (s *rbacServiceImpl) ListPermissions(ctx context.Context) ([]*rbac.Permission, error) {
+	return s.permissionRepo.GetAll(ctx)
+}
+
+func (s *rbacServiceImpl) CreateRole(ctx context.Context, name string, permKeys []string) (*rbac.Role, error) {
+	name = strings.TrimSpace(name)
+	if rbac.IsBuiltinRole(name) {
+		return nil, custom_err.ErrRoleExists
+	}
+
+	var role *rbac.Role
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.roleRepo.GetByName(ctx, name); err == nil {
+			return custom_err.ErrRoleExists
+		} else if !errors.Is(err, custom_err.ErrNotFound) {
+			return err
+		}
+
+		role = &rbac.Role{Name: name}
+		if err := s.roleRepo.Create(ctx, role); err != nil {
+			return err
+		}
+		for _, key := range permKeys {
+			if err := s.assignPermission(ctx, name, key); err != nil {
+				return err
+			}
+		}
+		if err := s.loadPermissions(ctx, role); err != nil {
+			return err
+		}
+
+		entry := audit.NewAuditLog(ctx, audit.ActionRoleCreated, audit.TargetRole, &role.ID, nil,
+			map[string]any{"name": role.Name, "permissions": permissionKeysOf(role)})
+		return s.auditRepo.Create(ctx, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// UpdateRole renames a custom role and, when permKeys is non-nil, replaces its permission set.
+// Built-in roles keep their name; the protected admin role also keeps its permissions.
+func (s *rbacServiceImpl) UpdateRole(ctx context.Context, roleName string, newName *string, permKeys []string) (*rbac.Role, error) {
+	if permKeys != nil && rbac.IsProtectedRole(roleName) {
+		return nil, custom_err.ErrProtectedRole
+	}
+
+	var role *rbac.Role
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		current, err := s.roleRepo.GetByName(ctx, roleName)
+		if err != nil {
+			return err
+		}
+		if err := s.loadPermissions(ctx, current); err != nil {
+			return err
+		}
+		before := map[string]any{"name": current.Name, "permissions": permissionKeysOf(current)}
+
+		if newName != nil {
+			name := strings.TrimSpace(*newName)
+			if name != current.Name {
+				if rbac.IsBuiltinRole(current.Name) {
+					return custom_err.ErrBuiltinRole
+				}
+				if rbac.IsBuiltinRole(name) {
+					return custom_err.ErrRoleExists
+				}
+				if _, err := s.roleRepo.GetByName(ctx, name); err == nil {
+					return custom_err.ErrRoleExists
+				} else if !errors.Is(err, custom_err.ErrNotFound) {
+					return err
+				}
+				if err := s.roleRepo.Update(ctx, current.ID, map[string]any{"name": name}); err != nil {
+					return err
+				}
+				current.Name = name
+			}
+		}
+
+		if permKeys != nil {
+			have := permissionKeysOf(current)
+			for _, key := range permKeys {
+				if !slices.Contains(have, key) {
+					if err := s.assignPermission(ctx, current.Name, key); err != nil {
+						return err
+					}
+				}
+			}
+			for _, key := range have {
+				if !slices.Contains(permKeys, key) {
+					if err := s.permissionRepo.RemovePermissionFromRole(ctx, current.Name, key); err != nil {
+						return err
+					}
+				}
+			}
+			if err := s.loadPermissions(ctx, current); err != nil {
+				return err
+			}
+		}
+
+		role = current
+		entry := audit.NewAuditLog(ctx, audit.ActionRoleUpdated, audit.TargetRole, &role.ID, before,
+			map[string]any{"name": role.Name, "permissions": permissionKeysOf(role)})
+		return s.auditRepo.Create(ctx, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateAll(ctx)
+	return role, nil
+}
+
+func (s *rbacServiceImpl) DeleteRole(ctx context.Context, roleName string) error {
+	if rbac.IsBuiltinRole(roleName) {
+		return custom_err.ErrBuiltinRole
+	}
+
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		role, err := s.roleRepo.GetByName(ctx, roleName)
+		if err != nil {
+			return err
+		}
+		if err := s.roleRepo.Delete(ctx, roleName); err != nil {
+			return err
+		}
+		entry := audit.NewAuditLog(ctx, audit.ActionRoleDeleted, audit.TargetRole, &role.ID,
+			map[string]any{"name": role.Name}, nil)
+		return s.auditRepo.Create(ctx, entry)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.invalidateAll(ctx)
+	return nil
+}
+
+func (s *rbacServiceImpl) AddRolePermission(ctx context.Context, roleName, permKey string) error {
+	return s.changeRolePermission(ctx, roleName, permKey, true)
+}
+
+func (s *rbacServiceImpl) RemoveRolePermission(ctx context.Context, roleName, permKey string) error {
+	return s.changeRolePermission(ctx, roleName, permKey, false)
+}
+
+func (s *rbacServiceImpl) ListRoleMembers(ctx context.Context, roleName string, page, pageSize int64) ([]*user.User, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 200 {
+		pageSize = 20
+	}
+	if _, err := s.roleRepo.GetByName(ctx, roleName); err != nil {
+		return nil, 0, err
+	}
+	return s.userRepo.GetByRole(ctx, roleName, page, pageSize)
+}
+
+func (s *rbacServiceImpl) changeRolePermission(ctx context.Context, roleName, permKey string, add bool) error {
+	if rbac.IsProtectedRole(roleName) {
+		return custom_err.ErrProtectedRole
+	}
+
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		role, err := s.roleRepo.GetByName(ctx, roleName)
+		if err != nil {
+			return err
+		}
+
+		action := audit.ActionRolePermissionAdded
+		if add {
+			err = s.assignPermission(ctx, roleName, permKey)
+		} else {
+			action = audit.ActionRolePermissionRemoved
+			err = s.permissionRepo.RemovePermissionFromRole(ctx, roleName, permKey)
+		}
+		if err != nil {
+			return err
+		}
+
+		entry := audit.NewAuditLog(ctx, action, audit.TargetRole, &role.ID, nil, nil).
+			WithMetadata(map[string]any{"role": roleName, "permission": permKey})
+		return s.auditRepo.Create(ctx, entry)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.invalidateAll(ctx)
+	return nil
+}
+
+func (s *rbacServiceImpl) assignPermission(ctx context.Context, roleName, permKey string) error {
+	if _, err := s.permissionRepo.GetByKey(ctx, permKey); err != nil {
+		if errors.Is(err, custom_err.ErrNotFound) {
+			return custom_err.ErrUnknownPermission
+		}
+		return err
+	}
+	return s.permissionRepo.AssignPermissionToRole(ctx, roleName, permKey)
+}
+
+func (s *rbacServiceImpl) loadPermissions(ctx context.Context, role *rbac.Role) error {
+	perms, err := s.permissionRepo.GetRolePermissionsByRoleID(ctx, role.Name)
+	if err != nil {
+		return err
+	}
+	role.Permissions = make([]rbac.Permission, 0, len(perms))
+	for _, p := range perms {
+		role.Permissions = append(role.Permissions, *p)
+	}
+	return nil
+}
+
+// invalidateAll runs after commit: a role edit can affect any number of users.
+func (s *rbacServiceImpl) invalidateAll(ctx context.Context) {
+	if err := s.cache.InvalidateAll(ctx); err != nil {
+		log.Printf("permission cache invalidation failed: %v", err)
+	}
+}
+
+func permissionKeysOf(role *rbac.Role) []string {
+	keys := make([]string, 0, len(role.Permissions))
+	for _, p := range role.Permissions {
+		keys = append(keys, p.Key)
+	}
+	return keys
+}
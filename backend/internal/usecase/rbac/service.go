@@ -1,6 +1,7 @@
 package rbac
 
 import (
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
 	"github.com/lordmitrii/golang-web-gin/internal/usecase"
@@ -10,16 +11,26 @@ type rbacServiceImpl struct {
 	roleRepo       rbac.RoleRepository
 	permissionRepo rbac.PermissionRepository
 	userRepo       user.UserRepository
+	auditRepo      audit.AuditLogRepository
+	cache          usecase.PermissionCache
+
+	tx usecase.TxManager
 }
 
 func NewRBACService(
 	roleRepo rbac.RoleRepository,
 	permissionRepo rbac.PermissionRepository,
 	userRepo user.UserRepository,
+	auditRepo audit.AuditLogRepository,
+	cache usecase.PermissionCache,
+	tx usecase.TxManager,
 ) usecase.RBACService {
 	return &rbacServiceImpl{
 		roleRepo:       roleRepo,
 		permissionRepo: permissionRepo,
 		userRepo:       userRepo,
+		auditRepo:      auditRepo,
+		cache:          cache,
+		tx:             tx,
 	}
 }
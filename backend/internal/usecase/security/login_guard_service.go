@@ -0,0 +1,181 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/auth"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+)
+
+type subject struct {
+	kind      string // "user" or "ip"
+	value     string
+	lockAfter int
+}
+
+func (s subject) key(scope, suffix string) string {
+	return fmt.Sprintf("bf:%s:%s:%s:%s", scope, s.kind, s.value, suffix)
+}
+
+func (s *loginGuardImpl) subjects(scope, identity, ip string) []subject {
+	var out []subject
+	if id := normalizeIdentity(scope, identity); id != "" {
+		out = append(out, subject{kind: "user", value: id, lockAfter: s.policy.LockAfter})
+	}
+	if ip != "" {
+		out = append(out, subject{kind: "ip", value: ip, lockAfter: s.policy.IPLockAfter})
+	}
+	return out
+}
+
+// Check reports whether another attempt is currently allowed. A lockout wins over a delay.
+func (s *loginGuardImpl) Check(ctx context.Context, scope, identity, ip string) (time.Duration, error) {
+	subs := s.subjects(scope, identity, ip)
+
+	for _, sub := range subs {
+		ttl, err := s.counter.BlockedFor(ctx, sub.key(scope, "lock"))
+		if err != nil {
+			return 0, err
+		}
+		if ttl > 0 {
+			return ttl, custom_err.ErrAccountLocked
+		}
+	}
+
+	for _, sub := range subs {
+		ttl, err := s.counter.BlockedFor(ctx, sub.key(scope, "delay"))
+		if err != nil {
+			return 0, err
+		}
+		if ttl > 0 {
+			return ttl, custom_err.ErrTooManyAttempts
+		}
+	}
+	return 0, nil
+}
+
+// Fail records a failed attempt and returns how long the caller has to wait before the next one.
+func (s *loginGuardImpl) Fail(ctx context.Context, scope, identity, ip, userAgent string) (time.Duration, error) {
+	var wait time.Duration
+
+	for _, sub := range s.subjects(scope, identity, ip) {
+		n, err := s.counter.Incr(ctx, sub.key(scope, "fails"), s.policy.Window)
+		if err != nil {
+			return 0, err
+		}
+
+		if sub.lockAfter > 0 && int(n) >= sub.lockAfter {
+			if err := s.counter.Block(ctx, sub.key(scope, "lock"), s.policy.LockDuration); err != nil {
+				return 0, err
+			}
+			if err := s.counter.Reset(ctx, sub.key(scope, "fails"), sub.key(scope, "delay")); err != nil {
+				return 0, err
+			}
+			s.onLockout(ctx, scope, sub, ip, userAgent, n)
+			wait = max(wait, s.policy.LockDuration)
+			continue
+		}
+
+		if d := s.delayFor(n); d > 0 {
+			if err := s.counter.Block(ctx, sub.key(scope, "delay"), d); err != nil {
+				return 0, err
+			}
+			wait = max(wait, d)
+		}
+	}
+	return wait, nil
+}
+
+// Reset forgets failures for an identity after a successful attempt. IP counters are kept.
+func (s *loginGuardImpl) Reset(ctx context.Context, scope, identity string) error {
+	id := normalizeIdentity(scope, identity)
+	if id == "" {
+		return nil
+	}
+	sub := subject{kind: "user", value: id}
+	return s.counter.Reset(ctx, sub.key(scope, "fails"), sub.key(scope, "delay"))
+}
+
+// delayFor doubles the delay for every failure past DelayAfter, capped at MaxDelay.
+func (s *loginGuardImpl) delayFor(failures int64) time.Duration {
+	over := int(failures) - s.policy.DelayAfter
+	if over < 0 {
+		return 0
+	}
+	if over > 16 {
+		return s.policy.MaxDelay
+	}
+	d := s.policy.BaseDelay << over
+	if d > s.policy.MaxDelay {
+		return s.policy.MaxDelay
+	}
+	return d
+}
+
+func (s *loginGuardImpl) onLockout(ctx context.Context, scope string, sub subject, ip, userAgent string, failures int64) {
+	var target *user.User
+	if sub.kind == "user" {
+		target = s.lookupUser(ctx, scope, sub.value)
+	}
+
+	var targetID *uint
+	if target != nil {
+		targetID = &target.ID
+	}
+	entry := audit.NewAuditLog(ctx, audit.ActionAuthLockout, audit.TargetUser, targetID, nil, nil).
+		WithMetadata(map[string]any{
+			"scope":          scope,
+			"subject":        sub.kind,
+			"identity":       sub.value,
+			"failures":       failures,
+			"locked_for_sec": int(s.policy.LockDuration.Seconds()),
+		})
+	entry.IP = ip
+	entry.UserAgent = userAgent
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		log.Printf("failed to write lockout audit log: %v", err)
+	}
+
+	if !s.policy.NotifyOnLockout || target == nil || target.Email == "" {
+		return
+	}
+	body := fmt.Sprintf(
+		"We noticed %d failed attempts to access your account and temporarily locked it for %d minutes. "+
+			"If this wasn't you, consider resetting your password.",
+		failures, int(s.policy.LockDuration.Minutes()),
+	)
+	if err := s.emailSender.SendNotificationEmail(target.Email, "Your account was temporarily locked", body, "en"); err != nil {
+		log.Printf("failed to send lockout notification: %v", err)
+	}
+}
+
+func (s *loginGuardImpl) lookupUser(ctx context.Context, scope, identity string) *user.User {
+	var (
+		u   *user.User
+		err error
+	)
+	if scope == auth.ScopeLogin {
+		u, err = s.userRepo.GetByUsername(ctx, identity)
+	} else {
+		u, err = s.userRepo.GetByEmail(ctx, identity)
+	}
+	if err != nil {
+		return nil
+	}
+	return u
+}
+
+// normalizeIdentity lowercases emails; usernames are case-sensitive and kept as-is.
+func normalizeIdentity(scope, identity string) string {
+	identity = strings.TrimSpace(identity)
+	if scope == auth.ScopeLogin {
+		return identity
+	}
+	return strings.ToLower(identity)
+}
@@ -0,0 +1,139 @@
+package security
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/auth"
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+)
+
+// lastUsedResolution limits how often LastUsedAt is written for a busy token.
+const lastUsedResolution = time.Minute
+
+// CreateToken issues a new token. The raw value is returned once and only its hash is stored.
+func (s *personalTokenServiceImpl) CreateToken(ctx context.Context, userID uint, name string, scopes []string, expiresAt *time.Time) (*auth.PersonalAccessToken, string, error) {
+	scopes, err := s.validateScopes(ctx, userID, scopes)
+	if err != nil {
+		return nil, "", err
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", custom_err.ErrInvalidExpiry
+	}
+
+	raw, err := generatePersonalToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	t := &auth.PersonalAccessToken{
+		UserID:    userID,
+		Name:      strings.TrimSpace(name),
+		Prefix:    raw[:len(auth.PersonalTokenPrefix)+6],
+		TokenHash: hashToken(raw),
+		Scopes:    strings.Join(scopes, ","),
+		ExpiresAt: expiresAt,
+	}
+
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.tokenRepo.Create(ctx, t); err != nil {
+			return err
+		}
+		entry := audit.NewAuditLog(ctx, audit.ActionTokenCreated, audit.TargetPersonalAccessToken, &t.ID, nil,
+			map[string]any{"name": t.Name, "scopes": scopes, "expires_at": t.ExpiresAt})
+		return s.auditRepo.Create(ctx, entry)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return t, raw, nil
+}
+
+func (s *personalTokenServiceImpl) ListTokens(ctx context.Context, userID uint) ([]*auth.PersonalAccessToken, error) {
+	return s.tokenRepo.GetByUserID(ctx, userID)
+}
+
+func (s *personalTokenServiceImpl) RevokeToken(ctx context.Context, userID, id uint) error {
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.tokenRepo.Revoke(ctx, userID, id, time.Now()); err != nil {
+			return err
+		}
+		entry := audit.NewAuditLog(ctx, audit.ActionTokenRevoked, audit.TargetPersonalAccessToken, &id, nil, nil)
+		return s.auditRepo.Create(ctx, entry)
+	})
+}
+
+// Authenticate resolves a raw bearer token to an active personal access token.
+func (s *personalTokenServiceImpl) Authenticate(ctx context.Context, raw string) (*auth.PersonalAccessToken, error) {
+	if !auth.IsPersonalToken(raw) {
+		return nil, custom_err.ErrInvalidToken
+	}
+	t, err := s.tokenRepo.GetByHash(ctx, hashToken(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !t.IsActive(now) {
+		return nil, custom_err.ErrInvalidToken
+	}
+
+	if t.LastUsedAt == nil || now.Sub(*t.LastUsedAt) > lastUsedResolution {
+		// Last-used tracking is best effort and never rejects a valid token.
+		if err := s.tokenRepo.TouchLastUsed(ctx, t.ID, now); err != nil {
+			log.Printf("personal token %d last used not recorded: %v", t.ID, err)
+		} else {
+			t.LastUsedAt = &now
+		}
+	}
+	return t, nil
+}
+
+// validateScopes only allows token scopes the user currently holds.
+func (s *personalTokenServiceImpl) validateScopes(ctx context.Context, userID uint, scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, custom_err.ErrInvalidScope
+	}
+
+	perms, err := s.permissionRepo.GetUserPermissions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	held := make(map[string]bool, len(perms))
+	for _, p := range perms {
+		held[p.Key] = true
+	}
+
+	out := make([]string, 0, len(scopes))
+	for _, sc := range scopes {
+		sc = strings.TrimSpace(sc)
+		if !slices.Contains(auth.TokenScopes, sc) || !held[sc] {
+			return nil, fmt.Errorf("%w: %s", custom_err.ErrInvalidScope, sc)
+		}
+		if !slices.Contains(out, sc) {
+			out = append(out, sc)
+		}
+	}
+	return out, nil
+}
+
+func generatePersonalToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return auth.PersonalTokenPrefix + hex.EncodeToString(b), nil
+}
+
+func hashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
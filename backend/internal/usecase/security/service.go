@@ -0,0 +1,82 @@
+package security
+
+import (
+	"time"
+
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/auth"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/email"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+// Policy controls how failed attempts turn into delays and lockouts.
+type Policy struct {
+	Window          time.Duration // how long failures are remembered
+	DelayAfter      int           // failures before progressive delays start
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	LockAfter       int // failures per identity before a temporary lockout
+	IPLockAfter     int // failures per IP before a temporary lockout
+	LockDuration    time.Duration
+	NotifyOnLockout bool
+}
+
+func DefaultPolicy() Policy {
+	return Policy{
+		Window:       15 * time.Minute,
+		DelayAfter:   3,
+		BaseDelay:    time.Second,
+		MaxDelay:     30 * time.Second,
+		LockAfter:    10,
+		IPLockAfter:  50,
+		LockDuration: 15 * time.Minute,
+	}
+}
+
+type loginGuardImpl struct {
+	counter     usecase.AttemptCounter
+	userRepo    user.UserRepository
+	auditRepo   audit.AuditLogRepository
+	emailSender email.EmailSender
+	policy      Policy
+}
+
+func NewLoginGuard(
+	counter usecase.AttemptCounter,
+	userRepo user.UserRepository,
+	auditRepo audit.AuditLogRepository,
+	emailSender email.EmailSender,
+	policy Policy,
+) usecase.LoginGuard {
+	return &loginGuardImpl{
+		counter:     counter,
+		userRepo:    userRepo,
+		auditRepo:   auditRepo,
+		emailSender: emailSender,
+		policy:      policy,
+	}
+}
+
+type personalTokenServiceImpl struct {
+	tokenRepo      auth.PersonalAccessTokenRepository
+	permissionRepo rbac.PermissionRepository
+	auditRepo      audit.AuditLogRepository
+
+	tx usecase.TxManager
+}
+
+func NewPersonalTokenService(
+	tokenRepo auth.PersonalAccessTokenRepository,
+	permissionRepo rbac.PermissionRepository,
+	auditRepo audit.AuditLogRepository,
+	tx usecase.TxManager,
+) usecase.PersonalTokenService {
+	return &personalTokenServiceImpl{
+		tokenRepo:      tokenRepo,
+		permissionRepo: permissionRepo,
+		auditRepo:      auditRepo,
+		tx:             tx,
+	}
+}
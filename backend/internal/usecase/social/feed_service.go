@@ -0,0 +1,268 @@
+package social
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/social"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+)
+
+// PublishWorkoutCompleted records a workout_completed activity and fans it out to followers,
+// followed by a personal_record activity when the workout beat any earlier best.
+// It is called from the async WorkoutCompleted handler and is a no-op for private users.
+func (s *socialServiceImpl) PublishWorkoutCompleted(ctx context.Context, userID, workoutID uint, at time.Time) error {
+	settings, err := s.settingsFor(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if settings.ActivityVisibility == user.VisibilityPrivate {
+		return nil
+	}
+
+	w, reviews, err := s.workoutService.ReviewWorkout(ctx, userID, workoutID)
+	if err != nil {
+		return err
+	}
+
+	sets := 0
+	for _, we := range w.WorkoutExercises {
+		sets += len(we.WorkoutSets)
+	}
+	payload, err := json.Marshal(map[string]any{
+		"exercises":          len(w.WorkoutExercises),
+		"sets":               sets,
+		"estimated_calories": w.EstimatedCalories,
+	})
+	if err != nil {
+		return err
+	}
+	err = s.publish(ctx, &social.Activity{
+		ActorID:    userID,
+		Type:       social.ActivityWorkoutCompleted,
+		WorkoutID:  &workoutID,
+		Title:      w.Name,
+		Payload:    string(payload),
+		Visibility: settings.ActivityVisibility,
+		CreatedAt:  at,
+	})
+	if err != nil {
+		return err
+	}
+
+	records := make([]map[string]any, 0)
+	for _, r := range reviews {
+		if r.Trend() != workout.TrendPR {
+			continue
+		}
+		top := workout.TopSet(r.Sets)
+		records = append(records, map[string]any{
+			"exercise": r.Name,
+			"weight":   *top.Weight,
+			"reps":     *top.Reps,
+		})
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	payload, err = json.Marshal(map[string]any{"records": records})
+	if err != nil {
+		return err
+	}
+	return s.publish(ctx, &social.Activity{
+		ActorID:    userID,
+		Type:       social.ActivityPersonalRecord,
+		WorkoutID:  &workoutID,
+		Title:      w.Name,
+		Payload:    string(payload),
+		Visibility: settings.ActivityVisibility,
+		CreatedAt:  at,
+	})
+}
+
+// publish creates the activity and fans it out, once per workout and type so redelivered events are harmless.
+func (s *socialServiceImpl) publish(ctx context.Context, a *social.Activity) error {
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		exists, err := s.activityRepo.ExistsForWorkout(ctx, a.ActorID, *a.WorkoutID, a.Type)
+		if err != nil || exists {
+			return err
+		}
+		if err := s.activityRepo.Create(ctx, a); err != nil {
+			return err
+		}
+		return s.activityRepo.FanOut(ctx, a)
+	})
+}
+
+func (s *socialServiceImpl) GetFeed(ctx context.Context, userID, beforeID uint, limit int) ([]*social.FeedItem, error) {
+	activities, err := s.activityRepo.GetFeed(ctx, userID, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return s.decorate(ctx, userID, activities)
+}
+
+// GetUserActivities lists one user's activities as the viewer is allowed to see them.
+func (s *socialServiceImpl) GetUserActivities(ctx context.Context, viewerID, actorID, beforeID uint, limit int) ([]*social.FeedItem, error) {
+	visibilities, err := s.visibleLevels(ctx, viewerID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if len(visibilities) == 0 {
+		return []*social.FeedItem{}, nil
+	}
+	activities, err := s.activityRepo.GetByActor(ctx, actorID, visibilities, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return s.decorate(ctx, viewerID, activities)
+}
+
+func (s *socialServiceImpl) React(ctx context.Context, userID, activityID uint, kind string) error {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if !slices.Contains(social.ReactionKinds, kind) {
+		return custom_err.ErrInvalidReaction
+	}
+	if _, err := s.visibleActivity(ctx, userID, activityID); err != nil {
+		return err
+	}
+	return s.activityRepo.UpsertReaction(ctx, &social.ActivityReaction{ActivityID: activityID, UserID: userID, Kind: kind})
+}
+
+func (s *socialServiceImpl) Unreact(ctx context.Context, userID, activityID uint) error {
+	return s.activityRepo.DeleteReaction(ctx, activityID, userID)
+}
+
+func (s *socialServiceImpl) AddComment(ctx context.Context, userID, activityID uint, body string) (*social.ActivityComment, error) {
+	if _, err := s.visibleActivity(ctx, userID, activityID); err != nil {
+		return nil, err
+	}
+
+	c := &social.ActivityComment{
+		ActivityID: activityID,
+		AuthorID:   userID,
+		Body:       strings.TrimSpace(body),
+	}
+	if err := s.activityRepo.CreateComment(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *socialServiceImpl) GetComments(ctx context.Context, viewerID, activityID uint) ([]*social.ActivityComment, error) {
+	if _, err := s.visibleActivity(ctx, viewerID, activityID); err != nil {
+		return nil, err
+	}
+	return s.activityRepo.GetComments(ctx, activityID)
+}
+
+// DeleteComment lets authors remove their own comments and actors remove any comment on their activity.
+func (s *socialServiceImpl) DeleteComment(ctx context.Context, userID, commentID uint) error {
+	c, err := s.activityRepo.GetCommentByID(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	if c.AuthorID != userID {
+		a, err := s.activityRepo.GetByID(ctx, c.ActivityID)
+		if err != nil {
+			return err
+		}
+		if a.ActorID != userID {
+			return custom_err.ErrNotFound
+		}
+	}
+	return s.activityRepo.DeleteComment(ctx, commentID)
+}
+
+// visibleActivity loads an activity the viewer may see. Hidden activities look like missing ones.
+func (s *socialServiceImpl) visibleActivity(ctx context.Context, viewerID, activityID uint) (*social.Activity, error) {
+	a, err := s.activityRepo.GetByID(ctx, activityID)
+	if err != nil {
+		return nil, err
+	}
+	visibilities, err := s.visibleLevels(ctx, viewerID, a.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(visibilities, a.Visibility) {
+		return nil, custom_err.ErrNotFound
+	}
+	return a, nil
+}
+
+// visibleLevels lists the stamped visibilities the viewer may see of the actor's activities.
+// The actor's current setting applies too, so narrowing it hides activities published before.
+func (s *socialServiceImpl) visibleLevels(ctx context.Context, viewerID, actorID uint) ([]string, error) {
+	if viewerID == actorID {
+		return []string{user.VisibilityPrivate, user.VisibilityFollowers, user.VisibilityPublic}, nil
+	}
+	settings, err := s.settingsFor(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.ActivityVisibility == user.VisibilityPrivate {
+		return nil, nil
+	}
+	follows, err := s.followRepo.Exists(ctx, viewerID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if follows {
+		return []string{user.VisibilityFollowers, user.VisibilityPublic}, nil
+	}
+	if settings.ActivityVisibility == user.VisibilityPublic {
+		return []string{user.VisibilityPublic}, nil
+	}
+	return nil, nil
+}
+
+func (s *socialServiceImpl) decorate(ctx context.Context, viewerID uint, activities []*social.Activity) ([]*social.FeedItem, error) {
+	ids := make([]uint, len(activities))
+	for i, a := range activities {
+		ids[i] = a.ID
+	}
+
+	reactions, err := s.activityRepo.ReactionCounts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	mine, err := s.activityRepo.UserReactions(ctx, viewerID, ids)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := s.activityRepo.CommentCounts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*social.FeedItem, len(activities))
+	for i, a := range activities {
+		items[i] = &social.FeedItem{
+			Activity:     a,
+			Reactions:    reactions[a.ID],
+			MyReaction:   mine[a.ID],
+			CommentCount: comments[a.ID],
+		}
+	}
+	return items, nil
+}
+
+// settingsFor falls back to defaults for users who never saved settings.
+func (s *socialServiceImpl) settingsFor(ctx context.Context, userID uint) (*user.UserSettings, error) {
+	settings, err := s.settingsRepo.GetByUserID(ctx, userID)
+	if err == custom_err.ErrNotFound {
+		return &user.UserSettings{UserID: userID, ActivityVisibility: user.VisibilityFollowers}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if settings.ActivityVisibility == "" {
+		settings.ActivityVisibility = user.VisibilityFollowers
+	}
+	return settings, nil
+}
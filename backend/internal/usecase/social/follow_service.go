@@ -0,0 +1,80 @@
+package social
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/social"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+)
+
+// Follow follows a public user straight away; anyone else gets a pending request to accept.
+// Following again returns the existing follow or request.
+func (s *socialServiceImpl) Follow(ctx context.Context, followerID uint, username string) (*social.Follow, error) {
+	followee, err := s.userRepo.GetByUsername(ctx, strings.TrimSpace(username))
+	if err != nil {
+		return nil, err
+	}
+	if followee.ID == followerID {
+		return nil, custom_err.ErrCannotFollowSelf
+	}
+
+	f, err := s.followRepo.Get(ctx, followerID, followee.ID)
+	if err == nil {
+		f.Followee = *followee
+		return f, nil
+	}
+	if !errors.Is(err, custom_err.ErrNotFound) {
+		return nil, err
+	}
+
+	settings, err := s.settingsFor(ctx, followee.ID)
+	if err != nil {
+		return nil, err
+	}
+	f = &social.Follow{
+		FollowerID: followerID,
+		FolloweeID: followee.ID,
+		Pending:    settings.ActivityVisibility != user.VisibilityPublic,
+	}
+	if err := s.followRepo.Create(ctx, f); err != nil {
+		return nil, err
+	}
+	f.Followee = *followee
+	return f, nil
+}
+
+// Unfollow also withdraws a pending request.
+func (s *socialServiceImpl) Unfollow(ctx context.Context, followerID, followeeID uint) error {
+	return s.followRepo.Delete(ctx, followerID, followeeID)
+}
+
+func (s *socialServiceImpl) ListFollowers(ctx context.Context, userID uint, page, pageSize int64) ([]*user.User, int64, error) {
+	return s.followRepo.GetFollowers(ctx, userID, page, pageSize)
+}
+
+func (s *socialServiceImpl) ListFollowing(ctx context.Context, userID uint, page, pageSize int64) ([]*user.User, int64, error) {
+	return s.followRepo.GetFollowing(ctx, userID, page, pageSize)
+}
+
+func (s *socialServiceImpl) ListFollowRequests(ctx context.Context, userID uint, page, pageSize int64) ([]*user.User, int64, error) {
+	return s.followRepo.GetRequests(ctx, userID, page, pageSize)
+}
+
+func (s *socialServiceImpl) AcceptFollowRequest(ctx context.Context, userID, followerID uint) error {
+	return s.followRepo.Accept(ctx, followerID, userID)
+}
+
+// DeclineFollowRequest drops a pending request; accepted followers are not touched.
+func (s *socialServiceImpl) DeclineFollowRequest(ctx context.Context, userID, followerID uint) error {
+	f, err := s.followRepo.Get(ctx, followerID, userID)
+	if err != nil {
+		return err
+	}
+	if !f.Pending {
+		return custom_err.ErrNotFound
+	}
+	return s.followRepo.Delete(ctx, followerID, userID)
+}
@@ -0,0 +1,43 @@
+package social
+
+import (
+	"github.com/lordmitrii/golang-web-gin/internal/domain/social"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+	"github.com/lordmitrii/golang-web-gin/internal/usecase"
+)
+
+type socialServiceImpl struct {
+	followRepo   social.FollowRepository
+	activityRepo social.ActivityRepository
+	shareRepo    social.WorkoutShareRepository
+	userRepo     user.UserRepository
+	settingsRepo user.UserSettingsRepository
+	workoutRepo  workout.WorkoutRepository
+
+	workoutService usecase.WorkoutService
+
+	tx usecase.TxManager
+}
+
+func NewSocialService(
+	followRepo social.FollowRepository,
+	activityRepo social.ActivityRepository,
+	shareRepo social.WorkoutShareRepository,
+	userRepo user.UserRepository,
+	settingsRepo user.UserSettingsRepository,
+	workoutRepo workout.WorkoutRepository,
+	workoutService usecase.WorkoutService,
+	tx usecase.TxManager,
+) usecase.SocialService {
+	return &socialServiceImpl{
+		followRepo:     followRepo,
+		activityRepo:   activityRepo,
+		shareRepo:      shareRepo,
+		userRepo:       userRepo,
+		settingsRepo:   settingsRepo,
+		workoutRepo:    workoutRepo,
+		workoutService: workoutService,
+		tx:             tx,
+	}
+}
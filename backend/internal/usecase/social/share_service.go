@@ -0,0 +1,91 @@
+package social
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/social"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
+)
+
+// ShareWorkout returns the active share link for a completed workout, creating one if needed.
+func (s *socialServiceImpl) ShareWorkout(ctx context.Context, userID, workoutID uint) (*social.WorkoutShare, error) {
+	settings, err := s.settingsFor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.AllowWorkoutSharing {
+		return nil, custom_err.ErrSharingDisabled
+	}
+
+	w, err := s.workoutRepo.GetOnlyByID(ctx, userID, workoutID)
+	if err != nil {
+		return nil, err
+	}
+	if !w.Completed {
+		return nil, custom_err.ErrWorkoutNotCompleted
+	}
+
+	existing, err := s.shareRepo.GetActiveByWorkoutID(ctx, userID, workoutID)
+	if err == nil {
+		return existing, nil
+	}
+	if err != custom_err.ErrNotFound {
+		return nil, err
+	}
+
+	token, err := generateShareToken()
+	if err != nil {
+		return nil, err
+	}
+	share := &social.WorkoutShare{WorkoutID: workoutID, UserID: userID, Token: token}
+	if err := s.shareRepo.Create(ctx, share); err != nil {
+		return nil, err
+	}
+	return share, nil
+}
+
+func (s *socialServiceImpl) ListShares(ctx context.Context, userID uint) ([]*social.WorkoutShare, error) {
+	return s.shareRepo.GetByUserID(ctx, userID)
+}
+
+func (s *socialServiceImpl) RevokeShare(ctx context.Context, userID, shareID uint) error {
+	return s.shareRepo.Revoke(ctx, userID, shareID, time.Now())
+}
+
+// GetSharedWorkout resolves a public share token. Revoked links and owners who turned sharing
+// off both read as not found.
+func (s *socialServiceImpl) GetSharedWorkout(ctx context.Context, token string) (*social.WorkoutShare, *workout.Workout, error) {
+	share, err := s.shareRepo.GetByToken(ctx, token)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !share.IsActive() {
+		return nil, nil, custom_err.ErrNotFound
+	}
+
+	settings, err := s.settingsFor(ctx, share.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !settings.AllowWorkoutSharing {
+		return nil, nil, custom_err.ErrNotFound
+	}
+
+	w, err := s.shareRepo.GetWorkout(ctx, share.WorkoutID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return share, w, nil
+}
+
+func generateShareToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
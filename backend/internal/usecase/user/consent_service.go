@@ -2,7 +2,9 @@ package user
 
 import (
 	"context"
+
 	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/versions"
 )
 
 func (s *userServiceImpl) GetConsents(ctx context.Context, userID uint) ([]*user.UserConsent, error) {
@@ -10,13 +12,60 @@ func (s *userServiceImpl) GetConsents(ctx context.Context, userID uint) ([]*user
 }
 
 func (s *userServiceImpl) CreateConsent(ctx context.Context, consent *user.UserConsent) error {
-	return s.userConsentRepo.Create(ctx, consent)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.userConsentRepo.Create(ctx, consent); err != nil {
+			return err
+		}
+		if consent.Type == versions.AiChatPrivacy {
+			return s.forgetAIChatsIfWithdrawn(ctx, consent.UserID)
+		}
+		return nil
+	})
 }
 
 func (s *userServiceImpl) UpdateConsent(ctx context.Context, id uint, updates map[string]any) (*user.UserConsent, error) {
-	return s.userConsentRepo.UpdateReturning(ctx, id, updates)
+	var consent *user.UserConsent
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		consent, err = s.userConsentRepo.UpdateReturning(ctx, id, updates)
+		if err != nil {
+			return err
+		}
+		if given, ok := updates["given"].(bool); ok && !given {
+			return s.forgetAIChatsIfWithdrawn(ctx, consent.UserID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return consent, nil
 }
 
 func (s *userServiceImpl) DeleteConsent(ctx context.Context, userID uint, consentType, version string) error {
-	return s.userConsentRepo.DeleteByUserIDAndType(ctx, userID, consentType, version)
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.userConsentRepo.DeleteByUserIDAndType(ctx, userID, consentType, version); err != nil {
+			return err
+		}
+		if consentType == versions.AiChatPrivacy {
+			return s.forgetAIChatsIfWithdrawn(ctx, userID)
+		}
+		return nil
+	})
+}
+
+// forgetAIChatsIfWithdrawn deletes the user's stored AI conversations once they no
+// longer consent to AI chat. Consent given to an older policy version still counts, so
+// a policy update alone keeps the history.
+func (s *userServiceImpl) forgetAIChatsIfWithdrawn(ctx context.Context, userID uint) error {
+	consents, err := s.userConsentRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, c := range consents {
+		if c.Type == versions.AiChatPrivacy && c.Given {
+			return nil
+		}
+	}
+	return s.conversations.DeleteByUserID(ctx, userID)
 }
@@ -1,6 +1,8 @@
 package user
 
 import (
+	"github.com/lordmitrii/golang-web-gin/internal/domain/ai"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
 	"github.com/lordmitrii/golang-web-gin/internal/usecase"
@@ -13,6 +15,10 @@ type userServiceImpl struct {
 	roleRepo        rbac.RoleRepository
 	permissionRepo  rbac.PermissionRepository
 	settingsRepo    user.UserSettingsRepository
+	auditRepo       audit.AuditLogRepository
+	conversations   ai.ConversationRepository
+
+	tx usecase.TxManager
 }
 
 func NewUserService(
@@ -22,6 +28,9 @@ func NewUserService(
 	roleRepo rbac.RoleRepository,
 	permissionRepo rbac.PermissionRepository,
 	settingsRepo user.UserSettingsRepository,
+	auditRepo audit.AuditLogRepository,
+	conversations ai.ConversationRepository,
+	tx usecase.TxManager,
 ) usecase.UserService {
 	return &userServiceImpl{
 		authRepo:        ur,
@@ -30,5 +39,8 @@ func NewUserService(
 		roleRepo:        roleRepo,
 		permissionRepo:  permissionRepo,
 		settingsRepo:    settingsRepo,
+		auditRepo:       auditRepo,
+		conversations:   conversations,
+		tx:              tx,
 	}
 }
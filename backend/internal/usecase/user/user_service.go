@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"github.com/lordmitrii/golang-web-gin/internal/domain/audit"
 	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/rbac"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/user"
@@ -20,57 +21,66 @@ func (s *userServiceImpl) Register(ctx context.Context, username, email, passwor
 		return custom_err.ErrNoConsent
 	}
 
-	err = s.authRepo.Create(ctx, u)
-	if err != nil {
-		return err
-	}
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		err := s.authRepo.Create(ctx, u)
+		if err != nil {
+			return err
+		}
 
-	healthCons := &user.UserConsent{
-		UserID:  u.ID,
-		Type:    "health_data",
-		Given:   healthDataConsent,
-		Version: healthDataPolicyVersion,
-	}
+		healthCons := &user.UserConsent{
+			UserID:  u.ID,
+			Type:    "health_data",
+			Given:   healthDataConsent,
+			Version: healthDataPolicyVersion,
+		}
 
-	privacyCons := &user.UserConsent{
-		UserID:  u.ID,
-		Type:    "user_privacy",
-		Given:   privacyConsent,
-		Version: privacyPolicyVersion,
-	}
+		privacyCons := &user.UserConsent{
+			UserID:  u.ID,
+			Type:    "user_privacy",
+			Given:   privacyConsent,
+			Version: privacyPolicyVersion,
+		}
 
-	err = s.userConsentRepo.Create(ctx, healthCons)
-	if err != nil {
-		return err
-	}
+		err = s.userConsentRepo.Create(ctx, healthCons)
+		if err != nil {
+			return err
+		}
 
-	err = s.userConsentRepo.Create(ctx, privacyCons)
-	if err != nil {
-		return err
-	}
+		err = s.userConsentRepo.Create(ctx, privacyCons)
+		if err != nil {
+			return err
+		}
 
-	err = s.settingsRepo.Create(ctx, &user.UserSettings{UserID: u.ID})
-	if err != nil {
-		return err
-	}
+		err = s.settingsRepo.Create(ctx, &user.UserSettings{UserID: u.ID})
+		if err != nil {
+			return err
+		}
 
-	err = s.roleRepo.AssignRoleToUser(ctx, u.ID, rbac.RoleRestricted)
-	if err != nil {
-		return err
-	}
+		err = s.roleRepo.AssignRoleToUser(ctx, u.ID, rbac.RoleRestricted)
+		if err != nil {
+			return err
+		}
 
-	return nil
+		entry := audit.NewAuditLog(ctx, audit.ActionUserRegistered, audit.TargetUser, &u.ID, nil, auditUser(u))
+		entry.ActorID = &u.ID
+		return s.auditRepo.Create(ctx, entry)
+	})
 }
 
-// Authenticate verifies credentials.
+// Authenticate verifies credentials. Both outcomes are written to the audit log.
 func (s *userServiceImpl) Authenticate(ctx context.Context, username, password string) (*user.User, error) {
 	u, err := s.authRepo.GetByUsername(ctx, username)
 	if err != nil {
+		if err == custom_err.ErrUserNotFound {
+			s.auditLogin(ctx, audit.ActionAuthLoginFailed, nil, username)
+		}
 		return nil, err
 	}
 	if bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) != nil {
+		s.auditLogin(ctx, audit.ActionAuthLoginFailed, &u.ID, username)
 		return nil, custom_err.ErrUserNotFound
 	}
+	s.auditLogin(ctx, audit.ActionAuthLogin, &u.ID, username)
 	return u, nil
 }
 
@@ -79,9 +89,48 @@ func (s *userServiceImpl) Me(ctx context.Context, userID uint) (*user.User, erro
 }
 
 func (s *userServiceImpl) UpdateAccount(ctx context.Context, userID uint, updates map[string]any) (*user.User, error) {
-	return s.authRepo.UpdateReturning(ctx, userID, updates)
+	var res *user.User
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		before, err := s.authRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		after, err := s.authRepo.UpdateReturning(ctx, userID, updates)
+		if err != nil {
+			return err
+		}
+		res = after
+
+		entry := audit.NewAuditLog(ctx, audit.ActionUserAccountUpdated, audit.TargetUser, &userID, auditUser(before), auditUser(after))
+		return s.auditRepo.Create(ctx, entry)
+	})
+	return res, err
 }
 
 func (s *userServiceImpl) TouchLastSeen(ctx context.Context, userID uint) error {
 	return s.authRepo.Update(ctx, userID, map[string]any{"last_seen_at": time.Now()})
 }
+
+// auditLogin records a login attempt against the account. Only a successful login makes
+// the user its actor; a failed one may well be someone else guessing.
+func (s *userServiceImpl) auditLogin(ctx context.Context, action string, userID *uint, username string) {
+	entry := audit.NewAuditLog(ctx, action, audit.TargetUser, userID, nil, nil).
+		WithMetadata(map[string]any{"username": username})
+	if action == audit.ActionAuthLogin {
+		entry.ActorID = userID
+	}
+	// A failed audit write must not block the login itself.
+	_ = s.auditRepo.Create(ctx, entry)
+}
+
+// auditUser is the subset of user fields that is safe to keep in the audit log.
+func auditUser(u *user.User) map[string]any {
+	if u == nil {
+		return nil
+	}
+	return map[string]any{
+		"username":    u.Username,
+		"email":       u.Email,
+		"is_verified": u.IsVerified,
+	}
+}
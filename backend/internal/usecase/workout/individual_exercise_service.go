@@ -32,7 +32,7 @@ func (s *workoutServiceImpl) GetIndividualExercisesByUserID(ctx context.Context,
 
 func (s *workoutServiceImpl) GetOrCreateIndividualExercise(ctx context.Context, userId uint, individualExercise *workout.IndividualExercise) (*workout.IndividualExercise, error) {
 	var result *workout.IndividualExercise
-	err := s.tx.Do(ctx, func(ctx context.Context) error {
+	err := s.tx.DoIfNotInTx(ctx, func(ctx context.Context) error {
 		if individualExercise.ExerciseID != nil {
 			existingIndividualExercise, err := s.individualExerciseRepo.GetByUserAndExerciseID(ctx, userId, *individualExercise.ExerciseID)
 			if err == nil {
@@ -17,6 +17,8 @@ type workoutServiceImpl struct {
 	individualExerciseRepo workout.IndividualExerciseRepository
 	exerciseRepo           workout.ExerciseRepository
 
+	loadingService usecase.LoadingService
+
 	tx         usecase.TxManager
 	bus        usecase.EventBus
 	dispatcher *domainevt.Dispatcher
@@ -32,6 +34,8 @@ func NewWorkoutService(
 	individualExerciseRepo workout.IndividualExerciseRepository,
 	exerciseRepo workout.ExerciseRepository,
 
+	loadingService usecase.LoadingService,
+
 	tx usecase.TxManager,
 	bus usecase.EventBus,
 	dispatcher *domainevt.Dispatcher,
@@ -45,6 +49,7 @@ func NewWorkoutService(
 		workoutSetRepo:         workoutSetRepo,
 		individualExerciseRepo: individualExerciseRepo,
 		exerciseRepo:           exerciseRepo,
+		loadingService:         loadingService,
 		tx:                     tx,
 		bus:                    bus,
 		dispatcher:             dispatcher,
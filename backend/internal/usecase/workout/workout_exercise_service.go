@@ -188,16 +188,16 @@ func (s *workoutServiceImpl) CompleteWorkoutExercise(ctx context.Context, userId
 			wkSkipped = wkCompleted && (skippedExercises == totalExercises)
 		}
 
-		if err := s.workoutRepo.Update(ctx, userId, planId, cycleId, workoutId,
-			map[string]any{"completed": wkCompleted, "skipped": wkSkipped}); err != nil {
-			return err
-		}
-
 		if wkCompleted {
 			wk.Complete(now, userId)
 			// resKcal, _, _, _ = s.CalculateWorkoutSummary(ctx, userId, workoutId)
 		}
 
+		if err := s.workoutRepo.Update(ctx, userId, planId, cycleId, workoutId,
+			map[string]any{"completed": wkCompleted, "skipped": wkSkipped, "completed_at": wk.CompletedAt}); err != nil {
+			return err
+		}
+
 		events := wk.PendingEvents()
 		if err := s.dispatcher.Dispatch(ctx, events); err != nil {
 			return err
@@ -274,7 +274,7 @@ func (s *workoutServiceImpl) MoveWorkoutExercise(ctx context.Context, userId, pl
 // 4. workout_sets
 func (s *workoutServiceImpl) ReplaceWorkoutExercise(ctx context.Context, userId, planId, cycleId, workoutID, exerciseID, individualExerciseID uint, sets int64) (*workout.WorkoutExercise, error) {
 	var res *workout.WorkoutExercise
-	err := s.tx.Do(ctx, func(ctx context.Context) error {
+	err := s.tx.DoIfNotInTx(ctx, func(ctx context.Context) error {
 		if sets <= 0 {
 			return fmt.Errorf("sets quantity must be greater than 0")
 		}
@@ -391,13 +391,13 @@ func (s *workoutServiceImpl) DeleteWorkoutExercise(ctx context.Context, userId,
 			wkSkipped = wkCompleted && (skippedExercises == totalExercises)
 		}
 
-		if err := s.workoutRepo.Update(ctx, userId, planId, cycleId, workoutID, map[string]any{"completed": wkCompleted, "skipped": wkSkipped}); err != nil {
-			return err
-		}
 		if wkCompleted {
 			workout.Complete(now, userId)
 			// resKcal, _, _, _ = s.CalculateWorkoutSummary(ctx, userId, workoutID)
 		}
+		if err := s.workoutRepo.Update(ctx, userId, planId, cycleId, workoutID, map[string]any{"completed": wkCompleted, "skipped": wkSkipped, "completed_at": workout.CompletedAt}); err != nil {
+			return err
+		}
 
 		events := workout.PendingEvents()
 		if err := s.dispatcher.Dispatch(ctx, events); err != nil {
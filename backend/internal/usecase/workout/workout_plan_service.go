@@ -3,6 +3,7 @@ package workout
 import (
 	"context"
 
+	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
 )
 
@@ -11,11 +12,12 @@ import (
 // 2. workout_cycles
 func (s *workoutServiceImpl) CreateWorkoutPlan(ctx context.Context, userId uint, in *workout.WorkoutPlan) (*workout.WorkoutPlan, error) {
 	var wp *workout.WorkoutPlan
-	err := s.tx.Do(ctx, func(ctx context.Context) error {
+	err := s.tx.DoIfNotInTx(ctx, func(ctx context.Context) error {
 		res, err := s.workoutPlanRepo.CreateReturning(ctx, userId, &workout.WorkoutPlan{
 			Name:   in.Name,
 			UserID: in.UserID,
 			Active: false,
+			Draft:  in.Draft,
 		})
 		if err != nil {
 			return err
@@ -55,6 +57,90 @@ func (s *workoutServiceImpl) CreateWorkoutPlan(ctx context.Context, userId uint,
 	return wp, err
 }
 
+// CreateWorkoutPlanWithWorkouts creates the plan with the given workouts as its first
+// week, all or nothing. Workout exercises may reference their exercise through
+// IndividualExercise instead of an ID; the user's individual exercise is then found or
+// created.
+func (s *workoutServiceImpl) CreateWorkoutPlanWithWorkouts(ctx context.Context, userId uint, in *workout.WorkoutPlan, workouts []*workout.Workout) (*workout.WorkoutPlan, error) {
+	var wp *workout.WorkoutPlan
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		wp, err = s.CreateWorkoutPlan(ctx, userId, in)
+		if err != nil {
+			return err
+		}
+
+		for _, w := range workouts {
+			for _, we := range w.WorkoutExercises {
+				if we.IndividualExerciseID != 0 || we.IndividualExercise == nil {
+					continue
+				}
+				ie, err := s.GetOrCreateIndividualExercise(ctx, userId, we.IndividualExercise)
+				if err != nil {
+					return err
+				}
+				we.IndividualExerciseID = ie.ID
+			}
+		}
+		if err := s.CreateMultipleWorkouts(ctx, userId, wp.ID, *wp.CurrentCycleID, workouts); err != nil {
+			return err
+		}
+
+		cycle, err := s.workoutCycleRepo.GetByID(ctx, userId, wp.ID, *wp.CurrentCycleID)
+		if err != nil {
+			return err
+		}
+		wp.WorkoutCycles = []*workout.WorkoutCycle{cycle}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return wp, nil
+}
+
+// AcceptDraftWorkoutPlan keeps a draft as a regular plan, optionally making it active.
+//
+// Order of locks used:
+// 1. workout_plans
+func (s *workoutServiceImpl) AcceptDraftWorkoutPlan(ctx context.Context, userId, id uint, activate bool) (*workout.WorkoutPlan, error) {
+	var wp *workout.WorkoutPlan
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		res, err := s.workoutPlanRepo.GetByIDForUpdate(ctx, userId, id)
+		if err != nil {
+			return err
+		}
+		if !res.Draft {
+			return custom_err.ErrNotADraft
+		}
+
+		updates := map[string]any{"draft": false}
+		if activate {
+			if err := s.workoutPlanRepo.DeactivateOthers(ctx, userId, res.ID); err != nil {
+				return err
+			}
+			updates["active"] = true
+		}
+		wp, err = s.workoutPlanRepo.UpdateReturning(ctx, userId, res.ID, updates)
+		return err
+	})
+	return wp, err
+}
+
+// DiscardDraftWorkoutPlan deletes a draft. Accepted plans are deleted the usual way.
+func (s *workoutServiceImpl) DiscardDraftWorkoutPlan(ctx context.Context, userId, id uint) error {
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		res, err := s.workoutPlanRepo.GetByIDForUpdate(ctx, userId, id)
+		if err != nil {
+			return err
+		}
+		if !res.Draft {
+			return custom_err.ErrNotADraft
+		}
+		return s.workoutPlanRepo.Delete(ctx, userId, id)
+	})
+}
+
 func (s *workoutServiceImpl) GetWorkoutPlanByID(ctx context.Context, userId, id uint) (*workout.WorkoutPlan, error) {
 	return s.workoutPlanRepo.GetByID(ctx, userId, id)
 
@@ -87,6 +173,8 @@ func (s *workoutServiceImpl) DeleteWorkoutPlan(ctx context.Context, userId, id u
 	})
 }
 
+// SetActiveWorkoutPlan activates or deactivates a plan. A draft is only activated by accepting it.
+//
 // Order of locks used:
 // 1. workout_plans
 func (s *workoutServiceImpl) SetActiveWorkoutPlan(ctx context.Context, userId, id uint, active bool) (*workout.WorkoutPlan, error) {
@@ -97,6 +185,9 @@ func (s *workoutServiceImpl) SetActiveWorkoutPlan(ctx context.Context, userId, i
 			return err
 		}
 
+		if active && res.Draft {
+			return custom_err.ErrDraftPlan
+		}
 		if res.Active == active {
 			wp = res
 			return nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"slices"
 	"strings"
 	"time"
 
@@ -36,7 +37,7 @@ func (s *workoutServiceImpl) CreateWorkout(ctx context.Context, userId, planId,
 // 4. workout_exercises
 // 5. workout_sets
 func (s *workoutServiceImpl) CreateMultipleWorkouts(ctx context.Context, userId, planId, id uint, workouts []*workout.Workout) error {
-	return s.tx.Do(ctx, func(ctx context.Context) error {
+	return s.tx.DoIfNotInTx(ctx, func(ctx context.Context) error {
 		if err := s.workoutCycleRepo.LockByIDForUpdate(ctx, userId, planId, id); err != nil {
 			return err
 		}
@@ -218,7 +219,7 @@ func (s *workoutServiceImpl) CompleteWorkout(ctx context.Context, userId, planId
 		}
 
 		if err := s.workoutRepo.Update(ctx, userId, planId, cycleId, id,
-			map[string]any{"completed": w.Completed, "skipped": w.Skipped}); err != nil {
+			map[string]any{"completed": w.Completed, "skipped": w.Skipped, "completed_at": w.CompletedAt}); err != nil {
 			return err
 		}
 
@@ -365,3 +366,76 @@ func (s *workoutServiceImpl) CalculateWorkoutSummary(ctx context.Context, userID
 	}
 	return res["calories"], res["active_min"], res["rest_min"], nil
 }
+
+// ReviewWorkout compares each completed exercise of a workout with its previous session
+// and with the best set of the workouts completed before it.
+func (s *workoutServiceImpl) ReviewWorkout(ctx context.Context, userId, workoutID uint) (*workout.Workout, []*workout.ExerciseReview, error) {
+	w, err := s.workoutRepo.GetOnlyByID(ctx, userId, workoutID)
+	if err != nil {
+		return nil, nil, err
+	}
+	slices.SortFunc(w.WorkoutExercises, func(a, b *workout.WorkoutExercise) int { return a.Index - b.Index })
+
+	// Earlier bests are the sessions completed before this workout was, whenever they were created.
+	completedAt := time.Now()
+	if w.CompletedAt != nil {
+		completedAt = *w.CompletedAt
+	} else if w.Completed && w.UpdatedAt != nil {
+		completedAt = *w.UpdatedAt
+	}
+
+	reviews := make([]*workout.ExerciseReview, 0, len(w.WorkoutExercises))
+	for _, we := range w.WorkoutExercises {
+		if we == nil || we.Skipped || !we.Completed {
+			continue
+		}
+		ie, err := s.individualExerciseRepo.GetByID(ctx, userId, we.IndividualExerciseID)
+		if err != nil {
+			if err == custom_err.ErrIndividualExerciseNotFound {
+				continue
+			}
+			return nil, nil, err
+		}
+		slices.SortFunc(we.WorkoutSets, func(a, b *workout.WorkoutSet) int { return a.Index - b.Index })
+
+		r := &workout.ExerciseReview{
+			WorkoutExerciseID: we.ID,
+			Name:              ie.Name,
+			IsBodyweight:      ie.IsBodyweight,
+			IsTimeBased:       ie.IsTimeBased,
+			Sets:              completedSets(we.WorkoutSets),
+		}
+		if we.PreviousExerciseID != nil {
+			prev, err := s.workoutSetRepo.GetOnlyByWorkoutExerciseID(ctx, userId, *we.PreviousExerciseID)
+			if err != nil {
+				return nil, nil, err
+			}
+			r.PreviousSets = completedSets(prev)
+		}
+		sessions, err := s.workoutExerciseRepo.GetCompletedSessionPerformances(ctx, userId, we.IndividualExerciseID, w.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		r.BestBefore = workout.BestBefore(sessions, completedAt)
+		reviews = append(reviews, r)
+	}
+	return w, reviews, nil
+}
+
+// SaveWorkoutAISummary stores the generated summary on the workout.
+func (s *workoutServiceImpl) SaveWorkoutAISummary(ctx context.Context, userId, workoutID uint, summary string) error {
+	return s.workoutRepo.UpdateOnlyById(ctx, userId, workoutID, map[string]any{
+		"ai_summary":    summary,
+		"ai_summary_at": time.Now(),
+	})
+}
+
+func completedSets(sets []*workout.WorkoutSet) []*workout.WorkoutSet {
+	out := make([]*workout.WorkoutSet, 0, len(sets))
+	for _, set := range sets {
+		if set != nil && set.Completed {
+			out = append(out, set)
+		}
+	}
+	return out
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/lordmitrii/golang-web-gin/internal/domain/equipment"
 	custom_err "github.com/lordmitrii/golang-web-gin/internal/domain/errors"
 	"github.com/lordmitrii/golang-web-gin/internal/domain/workout"
 	"github.com/lordmitrii/golang-web-gin/internal/usecase"
@@ -166,15 +167,15 @@ func (s *workoutServiceImpl) CompleteWorkoutSet(ctx context.Context, userId, pla
 			wkSkipped = false
 		}
 
-		if err := s.workoutRepo.Update(ctx, userId, planId, cycleId, workoutId, map[string]any{"completed": wkCompleted, "skipped": wkSkipped}); err != nil {
-			return err
-		}
-
 		if wkCompleted {
 			workout.Complete(now, userId)
 			// resKcal, _, _, _ = s.CalculateWorkoutSummary(ctx, userId, workoutId)
 		}
 
+		if err := s.workoutRepo.Update(ctx, userId, planId, cycleId, workoutId, map[string]any{"completed": wkCompleted, "skipped": wkSkipped, "completed_at": workout.CompletedAt}); err != nil {
+			return err
+		}
+
 		ie, err := s.individualExerciseRepo.GetByID(ctx, userId, we.IndividualExerciseID)
 		if err != nil {
 			return err
@@ -345,13 +346,13 @@ func (s *workoutServiceImpl) DeleteWorkoutSet(ctx context.Context, userId, planI
 			wkSkipped = wkCompleted && (skippedExercisesCount == totalExercisesCount)
 		}
 
-		if err := s.workoutRepo.Update(ctx, userId, planId, cycleId, workoutId, map[string]any{"completed": wkCompleted, "skipped": wkSkipped}); err != nil {
-			return err
-		}
 		if wkCompleted {
 			workout.Complete(now, userId)
 			// resKcal, _, _, _ = s.CalculateWorkoutSummary(ctx, userId, workoutId)
 		}
+		if err := s.workoutRepo.Update(ctx, userId, planId, cycleId, workoutId, map[string]any{"completed": wkCompleted, "skipped": wkSkipped, "completed_at": workout.CompletedAt}); err != nil {
+			return err
+		}
 
 		events := workout.PendingEvents()
 		if err := s.dispatcher.Dispatch(ctx, events); err != nil {
@@ -423,5 +424,34 @@ func (s *workoutServiceImpl) GetPreviousSets(ctx context.Context, userId, indivi
 		}
 		prevSets = append(prevSets, pSet)
 	}
+
+	if err := s.roundPreviousWeights(ctx, userId, ie, prevSets); err != nil {
+		return nil, err
+	}
 	return prevSets, nil
 }
+
+// roundPreviousWeights snaps prefilled weights to what the user can actually load,
+// e.g. after switching unit systems or changing their plates.
+func (s *workoutServiceImpl) roundPreviousWeights(ctx context.Context, userId uint, ie *workout.IndividualExercise, sets []*workout.WorkoutSet) error {
+	if ie.Exercise == nil {
+		return nil
+	}
+	eq := ie.Exercise.Equipment
+	if !equipment.PlateLoaded(eq) && !equipment.FixedIncrement(eq) {
+		return nil
+	}
+
+	ls, err := s.loadingService.GetLoadingSettings(ctx, userId)
+	if err != nil {
+		return err
+	}
+	for _, set := range sets {
+		if set.PreviousWeight == nil || *set.PreviousWeight <= 0 {
+			continue
+		}
+		w := ls.Round(*set.PreviousWeight, eq).Weight
+		set.PreviousWeight = &w
+	}
+	return nil
+}
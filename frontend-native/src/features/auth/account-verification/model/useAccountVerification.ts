@@ -105,6 +105,7 @@ export function useAccountVerification(isRegistering: boolean) {
     try {
       const response = await api.post("/email/verify-account", {
         token: codeValue,
+        email,
       });
       if (response.status === 200) {
         await refresh();
@@ -125,7 +126,7 @@ export function useAccountVerification(isRegistering: boolean) {
     } finally {
       setPending(false);
     }
-  }, [codeValue, pending, refresh, setPersisted, isRegistering, t]);
+  }, [codeValue, email, pending, refresh, setPersisted, isRegistering, t]);
 
   const handleEmailChange = useCallback((value: string) => {
     setPersisted((prev) => ({ ...prev, showInputField: false }));
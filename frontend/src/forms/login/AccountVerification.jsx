@@ -125,6 +125,7 @@ const AccountVerification = () => {
     try {
       const response = await api.post("/email/verify-account", {
         token: codeValue,
+        email,
       });
       if (response.status == 200) {
         await refresh();
@@ -16,135 +16,267 @@ INSERT INTO public.muscle_groups (name, slug) VALUES
 ON CONFLICT (slug) DO UPDATE
 SET name = EXCLUDED.name;  
 
-INSERT INTO public.exercises (name, is_bodyweight, muscle_group_id, slug, is_time_based)
-SELECT v.name, v.is_bodyweight, mg.id, v.slug, v.is_time_based
+INSERT INTO public.exercises (name, is_bodyweight, muscle_group_id, slug, is_time_based, equipment, difficulty, movement_pattern)
+SELECT v.name, v.is_bodyweight, mg.id, v.slug, v.is_time_based, v.equipment, v.difficulty, v.movement_pattern
 FROM (
   VALUES
     -- Chest
-    ('Bench Press', false, 'chest', 'bench-press', false),
-    ('Incline Bench Press', false, 'chest', 'incline-bench-press', false),
-    ('Incline Dumbbell Press', false, 'chest', 'incline-dumbbell-press', false),
-    ('Dumbbell Flyes', false, 'chest', 'dumbbell-flyes', false),
-    ('Cable Crossovers', false, 'chest', 'cable-crossovers', false),
-    ('Chest Press Machine', false, 'chest', 'chest-press-machine', false),
-    ('Pec Deck', false, 'chest', 'pec-deck', false),
-    ('Push-Ups', true, 'chest', 'push-ups', false),
-    ('Chest Dips', true, 'chest', 'chest-dips', false),
-    ('Cable Flyes', false, 'chest', 'cable-flyes', false),
-    ('Incline Cable Flyes', false, 'chest', 'incline-cable-flyes', false),
-    ('Dumbbell Bench Press', false, 'chest', 'dumbbell-bench-press', false),
-    ('Smith Machine Bench Press', false, 'chest', 'smith-machine-bench-press', false),
-    ('Incline Smith Machine Press', false, 'chest', 'incline-smith-machine-press', false),
+    ('Bench Press', false, 'chest', 'bench-press', false, 'barbell', 'intermediate', 'push'),
+    ('Incline Bench Press', false, 'chest', 'incline-bench-press', false, 'barbell', 'intermediate', 'push'),
+    ('Incline Dumbbell Press', false, 'chest', 'incline-dumbbell-press', false, 'dumbbell', 'intermediate', 'push'),
+    ('Dumbbell Flyes', false, 'chest', 'dumbbell-flyes', false, 'dumbbell', 'beginner', 'isolation'),
+    ('Cable Crossovers', false, 'chest', 'cable-crossovers', false, 'cable', 'beginner', 'isolation'),
+    ('Chest Press Machine', false, 'chest', 'chest-press-machine', false, 'machine', 'beginner', 'push'),
+    ('Pec Deck', false, 'chest', 'pec-deck', false, 'machine', 'beginner', 'isolation'),
+    ('Push-Ups', true, 'chest', 'push-ups', false, 'bodyweight', 'beginner', 'push'),
+    ('Chest Dips', true, 'chest', 'chest-dips', false, 'bodyweight', 'intermediate', 'push'),
+    ('Cable Flyes', false, 'chest', 'cable-flyes', false, 'cable', 'beginner', 'isolation'),
+    ('Incline Cable Flyes', false, 'chest', 'incline-cable-flyes', false, 'cable', 'beginner', 'isolation'),
+    ('Dumbbell Bench Press', false, 'chest', 'dumbbell-bench-press', false, 'dumbbell', 'beginner', 'push'),
+    ('Smith Machine Bench Press', false, 'chest', 'smith-machine-bench-press', false, 'smith_machine', 'beginner', 'push'),
+    ('Incline Smith Machine Press', false, 'chest', 'incline-smith-machine-press', false, 'smith_machine', 'beginner', 'push'),
 
     -- Back
-    ('Barbell Rows', false, 'back', 'barbell-rows', false),
-    ('Pull-Ups', true, 'back', 'pull-ups', false),
-    ('Chin-Ups', true, 'back', 'chin-ups', false),
-    ('Lat Pulldowns', false, 'back', 'lat-pulldowns', false),
-    ('Pullovers', false, 'back', 'pullovers', false),
-    ('Narrow Grip Seated Cable Rows', false, 'back', 'narrow-grip-seated-cable-rows', false),
-    ('T-Bar Rows', false, 'back', 't-bar-rows', false),
-    ('Single Arm Dumbbell Rows', false, 'back', 'single-arm-dumbbell-rows', false),
-    ('Single Arm Lat Pulldowns', false, 'back', 'single-arm-lat-pulldowns', false),
-    ('Machine Horizontal Rows', false, 'back', 'machine-horizontal-rows', false),
-    ('Single Arm Cable Rows', false, 'back', 'single-arm-cable-rows', false),
-    ('Wide Grip Seated Cable Rows', false, 'back', 'wide-grip-seated-cable-rows', false),
-    ('Seated Machine Rows', false, 'back', 'seated-machine-rows', false),
+    ('Barbell Rows', false, 'back', 'barbell-rows', false, 'barbell', 'intermediate', 'pull'),
+    ('Pull-Ups', true, 'back', 'pull-ups', false, 'bodyweight', 'intermediate', 'pull'),
+    ('Chin-Ups', true, 'back', 'chin-ups', false, 'bodyweight', 'intermediate', 'pull'),
+    ('Lat Pulldowns', false, 'back', 'lat-pulldowns', false, 'cable', 'beginner', 'pull'),
+    ('Pullovers', false, 'back', 'pullovers', false, 'dumbbell', 'intermediate', 'isolation'),
+    ('Narrow Grip Seated Cable Rows', false, 'back', 'narrow-grip-seated-cable-rows', false, 'cable', 'beginner', 'pull'),
+    ('T-Bar Rows', false, 'back', 't-bar-rows', false, 'barbell', 'intermediate', 'pull'),
+    ('Single Arm Dumbbell Rows', false, 'back', 'single-arm-dumbbell-rows', false, 'dumbbell', 'beginner', 'pull'),
+    ('Single Arm Lat Pulldowns', false, 'back', 'single-arm-lat-pulldowns', false, 'cable', 'beginner', 'pull'),
+    ('Machine Horizontal Rows', false, 'back', 'machine-horizontal-rows', false, 'machine', 'beginner', 'pull'),
+    ('Single Arm Cable Rows', false, 'back', 'single-arm-cable-rows', false, 'cable', 'beginner', 'pull'),
+    ('Wide Grip Seated Cable Rows', false, 'back', 'wide-grip-seated-cable-rows', false, 'cable', 'beginner', 'pull'),
+    ('Seated Machine Rows', false, 'back', 'seated-machine-rows', false, 'machine', 'beginner', 'pull'),
 
     -- Shoulders
-    ('Overhead Press', false, 'shoulders', 'overhead-press', false),
-    ('Dumbbell Lateral Raises', false, 'shoulders', 'dumbbell-lateral-raises', false),
-    ('Front Raises', false, 'shoulders', 'front-raises', false),
-    ('Rear Delt Flyes', false, 'shoulders', 'rear-delt-flyes', false),
-    ('Arnold Press', false, 'shoulders', 'arnold-press', false),
-    ('Dumbbell Shoulder Press', false, 'shoulders', 'dumbbell-shoulder-press', false),
-    ('Cable Lateral Raises', false, 'shoulders', 'cable-lateral-raises', false),
-    ('Face Pulls', false, 'shoulders', 'face-pulls', false),
-    ('Upright Rows', false, 'shoulders', 'upright-rows', false),
-    ('Delts Machine', false, 'shoulders', 'delts-machine', false),
+    ('Overhead Press', false, 'shoulders', 'overhead-press', false, 'barbell', 'intermediate', 'push'),
+    ('Dumbbell Lateral Raises', false, 'shoulders', 'dumbbell-lateral-raises', false, 'dumbbell', 'beginner', 'isolation'),
+    ('Front Raises', false, 'shoulders', 'front-raises', false, 'dumbbell', 'beginner', 'isolation'),
+    ('Rear Delt Flyes', false, 'shoulders', 'rear-delt-flyes', false, 'dumbbell', 'beginner', 'isolation'),
+    ('Arnold Press', false, 'shoulders', 'arnold-press', false, 'dumbbell', 'intermediate', 'push'),
+    ('Dumbbell Shoulder Press', false, 'shoulders', 'dumbbell-shoulder-press', false, 'dumbbell', 'beginner', 'push'),
+    ('Cable Lateral Raises', false, 'shoulders', 'cable-lateral-raises', false, 'cable', 'beginner', 'isolation'),
+    ('Face Pulls', false, 'shoulders', 'face-pulls', false, 'cable', 'beginner', 'pull'),
+    ('Upright Rows', false, 'shoulders', 'upright-rows', false, 'barbell', 'intermediate', 'pull'),
+    ('Delts Machine', false, 'shoulders', 'delts-machine', false, 'machine', 'beginner', 'isolation'),
 
     -- Biceps
-    ('Barbell Curls', false, 'biceps', 'barbell-curls', false),
-    ('Hammer Curls', false, 'biceps', 'hammer-curls', false),
-    ('Preacher Curls', false, 'biceps', 'preacher-curls', false),
-    ('Concentration Curls', false, 'biceps', 'concentration-curls', false),
-    ('Cable Curls', false, 'biceps', 'cable-curls', false),
-    ('Incline Dumbbell Curls', false, 'biceps', 'incline-dumbbell-curls', false),
-    ('Zottman Curls', false, 'biceps', 'zottman-curls', false),
-    ('Bayesian Curls', false, 'biceps', 'bayesian-curls', false),
-    ('EZ Bar Curls', false, 'biceps', 'ez-bar-curls', false),
-    ('Dumbbell Curls', false, 'biceps', 'dumbbell-curls', false),
-    ('Machine Preacher Curls', false, 'biceps', 'machine-preacher-curls', false),
+    ('Barbell Curls', false, 'biceps', 'barbell-curls', false, 'barbell', 'beginner', 'isolation'),
+    ('Hammer Curls', false, 'biceps', 'hammer-curls', false, 'dumbbell', 'beginner', 'isolation'),
+    ('Preacher Curls', false, 'biceps', 'preacher-curls', false, 'ez_bar', 'beginner', 'isolation'),
+    ('Concentration Curls', false, 'biceps', 'concentration-curls', false, 'dumbbell', 'beginner', 'isolation'),
+    ('Cable Curls', false, 'biceps', 'cable-curls', false, 'cable', 'beginner', 'isolation'),
+    ('Incline Dumbbell Curls', false, 'biceps', 'incline-dumbbell-curls', false, 'dumbbell', 'beginner', 'isolation'),
+    ('Zottman Curls', false, 'biceps', 'zottman-curls', false, 'dumbbell', 'intermediate', 'isolation'),
+    ('Bayesian Curls', false, 'biceps', 'bayesian-curls', false, 'cable', 'intermediate', 'isolation'),
+    ('EZ Bar Curls', false, 'biceps', 'ez-bar-curls', false, 'ez_bar', 'beginner', 'isolation'),
+    ('Dumbbell Curls', false, 'biceps', 'dumbbell-curls', false, 'dumbbell', 'beginner', 'isolation'),
+    ('Machine Preacher Curls', false, 'biceps', 'machine-preacher-curls', false, 'machine', 'beginner', 'isolation'),
 
     -- Triceps
-    ('Pushdowns', false, 'triceps', 'tricep-pushdowns', false),
-    ('Overhead Tricep Extensions', false, 'triceps', 'overhead-tricep-extensions', false),
-    ('Skullcrusher', false, 'triceps', 'skullcrusher', false),
-    ('Dips', true, 'triceps', 'dips', false),
-    ('Close-Grip Bench Press', false, 'triceps', 'close-grip-bench-press', false),
-    ('Tricep Kickbacks', false, 'triceps', 'tricep-kickbacks', false),
-    ('Diamond Push-Ups', true, 'triceps', 'diamond-push-ups', false),
-    ('Cable Rope Pushdowns', false, 'triceps', 'cable-rope-pushdowns', false),
+    ('Pushdowns', false, 'triceps', 'tricep-pushdowns', false, 'cable', 'beginner', 'isolation'),
+    ('Overhead Tricep Extensions', false, 'triceps', 'overhead-tricep-extensions', false, 'dumbbell', 'beginner', 'isolation'),
+    ('Skullcrusher', false, 'triceps', 'skullcrusher', false, 'ez_bar', 'intermediate', 'isolation'),
+    ('Dips', true, 'triceps', 'dips', false, 'bodyweight', 'intermediate', 'push'),
+    ('Close-Grip Bench Press', false, 'triceps', 'close-grip-bench-press', false, 'barbell', 'intermediate', 'push'),
+    ('Tricep Kickbacks', false, 'triceps', 'tricep-kickbacks', false, 'dumbbell', 'beginner', 'isolation'),
+    ('Diamond Push-Ups', true, 'triceps', 'diamond-push-ups', false, 'bodyweight', 'intermediate', 'push'),
+    ('Cable Rope Pushdowns', false, 'triceps', 'cable-rope-pushdowns', false, 'cable', 'beginner', 'isolation'),
 
     -- Quads
-    ('Squats', false, 'quads', 'squats', false),
-    ('Leg Press', false, 'quads', 'leg-press', false),
-    ('Bulgarian Split Squats', false, 'quads', 'bulgarian-split-squats', false),
-    ('Front Squats', false, 'quads', 'front-squats', false),
-    ('Leg Press Machine', false, 'quads', 'leg-press-machine', false),
-    ('Step-Ups', true, 'quads', 'step-ups', false),
-    ('Leg Extensions', false, 'quads', 'leg-extensions', false),
-    ('Smith Machine Squats', false, 'quads', 'smith-machine-squats', false),
-    ('Goblet Squats', false, 'quads', 'goblet-squats', false),
-    ('Hack Squats', false, 'quads', 'hack-squats', false),
-    ('Sissy Squats', true, 'quads', 'sissy-squats', false),
-    ('Adductor Machine', false, 'quads', 'adductor-machine', false),
+    ('Squats', false, 'quads', 'squats', false, 'barbell', 'intermediate', 'squat'),
+    ('Leg Press', false, 'quads', 'leg-press', false, 'machine', 'beginner', 'squat'),
+    ('Bulgarian Split Squats', false, 'quads', 'bulgarian-split-squats', false, 'dumbbell', 'intermediate', 'lunge'),
+    ('Front Squats', false, 'quads', 'front-squats', false, 'barbell', 'advanced', 'squat'),
+    ('Leg Press Machine', false, 'quads', 'leg-press-machine', false, 'machine', 'beginner', 'squat'),
+    ('Step-Ups', true, 'quads', 'step-ups', false, 'bodyweight', 'beginner', 'lunge'),
+    ('Leg Extensions', false, 'quads', 'leg-extensions', false, 'machine', 'beginner', 'isolation'),
+    ('Smith Machine Squats', false, 'quads', 'smith-machine-squats', false, 'smith_machine', 'beginner', 'squat'),
+    ('Goblet Squats', false, 'quads', 'goblet-squats', false, 'kettlebell', 'beginner', 'squat'),
+    ('Hack Squats', false, 'quads', 'hack-squats', false, 'machine', 'intermediate', 'squat'),
+    ('Sissy Squats', true, 'quads', 'sissy-squats', false, 'bodyweight', 'advanced', 'squat'),
+    ('Adductor Machine', false, 'quads', 'adductor-machine', false, 'machine', 'beginner', 'isolation'),
 
     -- Hamstrings
-    ('Deadlift', false, 'hamstrings', 'deadlift', false),
-    ('Romanian Deadlift', false, 'hamstrings', 'romanian-deadlift', false),
-    ('Seated Leg Curls', false, 'hamstrings', 'seated-leg-curls', false),
-    ('Lying Leg Curls', false, 'hamstrings', 'lying-leg-curls', false),
-    ('Glute-Ham Raises', false, 'hamstrings', 'glute-ham-raises', false),
-    ('Good Mornings', false, 'hamstrings', 'good-mornings', false),
-    ('Kettlebell Swings', false, 'hamstrings', 'kettlebell-swings', false),
-    ('Nordic Curls', false, 'hamstrings', 'nordic-curls', false),
-    ('Stiff-Legged Deadlifts', false, 'hamstrings', 'stiff-legged-deadlifts', false),
+    ('Deadlift', false, 'hamstrings', 'deadlift', false, 'barbell', 'advanced', 'hinge'),
+    ('Romanian Deadlift', false, 'hamstrings', 'romanian-deadlift', false, 'barbell', 'intermediate', 'hinge'),
+    ('Seated Leg Curls', false, 'hamstrings', 'seated-leg-curls', false, 'machine', 'beginner', 'isolation'),
+    ('Lying Leg Curls', false, 'hamstrings', 'lying-leg-curls', false, 'machine', 'beginner', 'isolation'),
+    ('Glute-Ham Raises', false, 'hamstrings', 'glute-ham-raises', false, 'bodyweight', 'advanced', 'hinge'),
+    ('Good Mornings', false, 'hamstrings', 'good-mornings', false, 'barbell', 'intermediate', 'hinge'),
+    ('Kettlebell Swings', false, 'hamstrings', 'kettlebell-swings', false, 'kettlebell', 'intermediate', 'hinge'),
+    ('Nordic Curls', false, 'hamstrings', 'nordic-curls', false, 'bodyweight', 'advanced', 'isolation'),
+    ('Stiff-Legged Deadlifts', false, 'hamstrings', 'stiff-legged-deadlifts', false, 'barbell', 'intermediate', 'hinge'),
 
     -- Glutes
-    ('Hip Thrusts', false, 'glutes', 'hip-thrusts', false),
-    ('Glute Bridges', false, 'glutes', 'glute-bridges', false),
-    ('Cable Kickbacks', false, 'glutes', 'cable-kickbacks', false),
-    ('Lunges', false, 'glutes', 'lunges', false),
-    ('Abductor Machine', false, 'glutes', 'abductor-machine', false),
+    ('Hip Thrusts', false, 'glutes', 'hip-thrusts', false, 'barbell', 'intermediate', 'hinge'),
+    ('Glute Bridges', false, 'glutes', 'glute-bridges', false, 'bodyweight', 'beginner', 'hinge'),
+    ('Cable Kickbacks', false, 'glutes', 'cable-kickbacks', false, 'cable', 'beginner', 'isolation'),
+    ('Lunges', false, 'glutes', 'lunges', false, 'dumbbell', 'beginner', 'lunge'),
+    ('Abductor Machine', false, 'glutes', 'abductor-machine', false, 'machine', 'beginner', 'isolation'),
 
     -- Abs
-    ('Crunches', true, 'abs', 'crunches', false),
-    ('Hanging Leg Raises', true, 'abs', 'hanging-leg-raises', false),
-    ('Plank', true, 'abs', 'plank', true),
-    ('Cable Crunches', false, 'abs', 'cable-crunches', false),
-    ('Russian Twists', true, 'abs', 'russian-twists', false),
-    ('Decline Crunches', true, 'abs', 'decline-crunches', false),
+    ('Crunches', true, 'abs', 'crunches', false, 'bodyweight', 'beginner', 'core'),
+    ('Hanging Leg Raises', true, 'abs', 'hanging-leg-raises', false, 'bodyweight', 'intermediate', 'core'),
+    ('Plank', true, 'abs', 'plank', true, 'bodyweight', 'beginner', 'core'),
+    ('Cable Crunches', false, 'abs', 'cable-crunches', false, 'cable', 'beginner', 'core'),
+    ('Russian Twists', true, 'abs', 'russian-twists', false, 'bodyweight', 'beginner', 'core'),
+    ('Decline Crunches', true, 'abs', 'decline-crunches', false, 'bodyweight', 'intermediate', 'core'),
 
     -- Calves
-    ('Machine Calf Raises', false, 'calves', 'calf-raises', false),
-    ('Standing Calf Raises', true, 'calves', 'standing-calf-raises', false),
-    ('Smith Machine Calf Raises', false, 'calves', 'smith-machine-calf-raises', false),
+    ('Machine Calf Raises', false, 'calves', 'calf-raises', false, 'machine', 'beginner', 'isolation'),
+    ('Standing Calf Raises', true, 'calves', 'standing-calf-raises', false, 'bodyweight', 'beginner', 'isolation'),
+    ('Smith Machine Calf Raises', false, 'calves', 'smith-machine-calf-raises', false, 'smith_machine', 'beginner', 'isolation'),
 
     -- Forearms
-    ('Wrist Curls', false, 'forearms', 'wrist-curls', false),
-    ('Reverse Curls', false, 'forearms', 'reverse-curls', false),
+    ('Wrist Curls', false, 'forearms', 'wrist-curls', false, 'dumbbell', 'beginner', 'isolation'),
+    ('Reverse Curls', false, 'forearms', 'reverse-curls', false, 'ez_bar', 'beginner', 'isolation'),
 
     -- Traps
-    ('Dumbbell Shrugs', false, 'traps', 'dumbbell-shrugs', false),
-    ('Farmer''s Walk', false, 'traps', 'farmers-walk', false)
-) AS v(name, is_bodyweight, mg_slug, slug, is_time_based)
+    ('Dumbbell Shrugs', false, 'traps', 'dumbbell-shrugs', false, 'dumbbell', 'beginner', 'isolation'),
+    ('Farmer''s Walk', false, 'traps', 'farmers-walk', false, 'dumbbell', 'beginner', 'carry')
+) AS v(name, is_bodyweight, mg_slug, slug, is_time_based, equipment, difficulty, movement_pattern)
 JOIN public.muscle_groups mg ON mg.slug = v.mg_slug
 ON CONFLICT (slug) DO UPDATE
 SET
   name = EXCLUDED.name,
   is_bodyweight = EXCLUDED.is_bodyweight,
   muscle_group_id = EXCLUDED.muscle_group_id,
-  is_time_based = EXCLUDED.is_time_based;
+  is_time_based = EXCLUDED.is_time_based,
+  equipment = EXCLUDED.equipment,
+  difficulty = EXCLUDED.difficulty,
+  movement_pattern = EXCLUDED.movement_pattern;
+
+INSERT INTO public.exercise_secondary_muscle_groups (exercise_id, muscle_group_id)
+SELECT e.id, mg.id
+FROM (
+  VALUES
+    ('bench-press', 'triceps'),
+    ('bench-press', 'shoulders'),
+    ('incline-bench-press', 'shoulders'),
+    ('incline-bench-press', 'triceps'),
+    ('incline-dumbbell-press', 'shoulders'),
+    ('incline-dumbbell-press', 'triceps'),
+    ('dumbbell-flyes', 'shoulders'),
+    ('cable-crossovers', 'shoulders'),
+    ('chest-press-machine', 'triceps'),
+    ('chest-press-machine', 'shoulders'),
+    ('pec-deck', 'shoulders'),
+    ('push-ups', 'triceps'),
+    ('push-ups', 'shoulders'),
+    ('push-ups', 'abs'),
+    ('chest-dips', 'triceps'),
+    ('chest-dips', 'shoulders'),
+    ('cable-flyes', 'shoulders'),
+    ('incline-cable-flyes', 'shoulders'),
+    ('dumbbell-bench-press', 'triceps'),
+    ('dumbbell-bench-press', 'shoulders'),
+    ('smith-machine-bench-press', 'triceps'),
+    ('smith-machine-bench-press', 'shoulders'),
+    ('incline-smith-machine-press', 'shoulders'),
+    ('incline-smith-machine-press', 'triceps'),
+    ('barbell-rows', 'biceps'),
+    ('barbell-rows', 'traps'),
+    ('barbell-rows', 'forearms'),
+    ('pull-ups', 'biceps'),
+    ('pull-ups', 'forearms'),
+    ('chin-ups', 'biceps'),
+    ('chin-ups', 'forearms'),
+    ('lat-pulldowns', 'biceps'),
+    ('pullovers', 'chest'),
+    ('pullovers', 'triceps'),
+    ('narrow-grip-seated-cable-rows', 'biceps'),
+    ('narrow-grip-seated-cable-rows', 'traps'),
+    ('t-bar-rows', 'biceps'),
+    ('t-bar-rows', 'traps'),
+    ('single-arm-dumbbell-rows', 'biceps'),
+    ('single-arm-lat-pulldowns', 'biceps'),
+    ('machine-horizontal-rows', 'biceps'),
+    ('machine-horizontal-rows', 'traps'),
+    ('single-arm-cable-rows', 'biceps'),
+    ('wide-grip-seated-cable-rows', 'shoulders'),
+    ('wide-grip-seated-cable-rows', 'traps'),
+    ('seated-machine-rows', 'biceps'),
+    ('seated-machine-rows', 'traps'),
+    ('overhead-press', 'triceps'),
+    ('overhead-press', 'traps'),
+    ('dumbbell-lateral-raises', 'traps'),
+    ('front-raises', 'chest'),
+    ('rear-delt-flyes', 'back'),
+    ('rear-delt-flyes', 'traps'),
+    ('arnold-press', 'triceps'),
+    ('dumbbell-shoulder-press', 'triceps'),
+    ('cable-lateral-raises', 'traps'),
+    ('face-pulls', 'back'),
+    ('face-pulls', 'traps'),
+    ('upright-rows', 'traps'),
+    ('upright-rows', 'biceps'),
+    ('barbell-curls', 'forearms'),
+    ('hammer-curls', 'forearms'),
+    ('cable-curls', 'forearms'),
+    ('zottman-curls', 'forearms'),
+    ('ez-bar-curls', 'forearms'),
+    ('dumbbell-curls', 'forearms'),
+    ('dips', 'chest'),
+    ('dips', 'shoulders'),
+    ('close-grip-bench-press', 'chest'),
+    ('close-grip-bench-press', 'shoulders'),
+    ('diamond-push-ups', 'chest'),
+    ('diamond-push-ups', 'shoulders'),
+    ('squats', 'glutes'),
+    ('squats', 'hamstrings'),
+    ('squats', 'abs'),
+    ('leg-press', 'glutes'),
+    ('leg-press', 'hamstrings'),
+    ('bulgarian-split-squats', 'glutes'),
+    ('bulgarian-split-squats', 'hamstrings'),
+    ('front-squats', 'glutes'),
+    ('front-squats', 'abs'),
+    ('leg-press-machine', 'glutes'),
+    ('leg-press-machine', 'hamstrings'),
+    ('step-ups', 'glutes'),
+    ('step-ups', 'hamstrings'),
+    ('smith-machine-squats', 'glutes'),
+    ('smith-machine-squats', 'hamstrings'),
+    ('goblet-squats', 'glutes'),
+    ('goblet-squats', 'abs'),
+    ('hack-squats', 'glutes'),
+    ('deadlift', 'glutes'),
+    ('deadlift', 'back'),
+    ('deadlift', 'traps'),
+    ('deadlift', 'forearms'),
+    ('romanian-deadlift', 'glutes'),
+    ('romanian-deadlift', 'back'),
+    ('lying-leg-curls', 'calves'),
+    ('glute-ham-raises', 'glutes'),
+    ('glute-ham-raises', 'calves'),
+    ('good-mornings', 'glutes'),
+    ('good-mornings', 'back'),
+    ('kettlebell-swings', 'glutes'),
+    ('kettlebell-swings', 'shoulders'),
+    ('kettlebell-swings', 'abs'),
+    ('nordic-curls', 'glutes'),
+    ('stiff-legged-deadlifts', 'glutes'),
+    ('stiff-legged-deadlifts', 'back'),
+    ('hip-thrusts', 'hamstrings'),
+    ('glute-bridges', 'hamstrings'),
+    ('cable-kickbacks', 'hamstrings'),
+    ('lunges', 'quads'),
+    ('lunges', 'hamstrings'),
+    ('hanging-leg-raises', 'forearms'),
+    ('plank', 'shoulders'),
+    ('plank', 'glutes'),
+    ('reverse-curls', 'biceps'),
+    ('dumbbell-shrugs', 'forearms'),
+    ('farmers-walk', 'forearms'),
+    ('farmers-walk', 'abs'),
+    ('farmers-walk', 'glutes')
+) AS v(ex_slug, mg_slug)
+JOIN public.exercises e ON e.slug = v.ex_slug
+JOIN public.muscle_groups mg ON mg.slug = v.mg_slug
+ON CONFLICT DO NOTHING;
 
 COMMIT;
@@ -0,0 +1,38 @@
+BEGIN;
+
+-- Catalog foods, nutrient values per 100 g. Names are translated under "food.<slug>".
+INSERT INTO public.foods (owner_id, slug, name, brand, kcal, protein, carbs, fat, fiber, serving_size, created_at, updated_at) VALUES
+  (NULL, 'chicken-breast', 'Chicken Breast', '', 165, 31, 0, 3.6, 0, 150, NOW(), NOW()),
+  (NULL, 'egg', 'Egg', '', 143, 12.6, 0.7, 9.5, 0, 50, NOW(), NOW()),
+  (NULL, 'egg-white', 'Egg White', '', 52, 10.9, 0.7, 0.2, 0, 33, NOW(), NOW()),
+  (NULL, 'salmon', 'Salmon', '', 208, 20, 0, 13, 0, 150, NOW(), NOW()),
+  (NULL, 'tuna-canned', 'Canned Tuna', '', 116, 25.5, 0, 0.8, 0, 100, NOW(), NOW()),
+  (NULL, 'beef-lean', 'Lean Beef', '', 176, 26, 0, 8, 0, 150, NOW(), NOW()),
+  (NULL, 'greek-yogurt', 'Greek Yogurt', '', 97, 9, 3.9, 5, 0, 170, NOW(), NOW()),
+  (NULL, 'cottage-cheese', 'Cottage Cheese', '', 98, 11.1, 3.4, 4.3, 0, 150, NOW(), NOW()),
+  (NULL, 'milk', 'Milk', '', 61, 3.2, 4.8, 3.3, 0, 250, NOW(), NOW()),
+  (NULL, 'whey-protein', 'Whey Protein', '', 400, 80, 8, 6, 0, 30, NOW(), NOW()),
+  (NULL, 'oatmeal', 'Oatmeal', '', 379, 13.2, 67.7, 6.5, 10.1, 40, NOW(), NOW()),
+  (NULL, 'white-rice', 'White Rice (cooked)', '', 130, 2.7, 28.2, 0.3, 0.4, 150, NOW(), NOW()),
+  (NULL, 'buckwheat', 'Buckwheat (cooked)', '', 92, 3.4, 19.9, 0.6, 2.7, 150, NOW(), NOW()),
+  (NULL, 'pasta', 'Pasta (cooked)', '', 158, 5.8, 30.9, 0.9, 1.8, 150, NOW(), NOW()),
+  (NULL, 'whole-wheat-bread', 'Whole Wheat Bread', '', 252, 12.5, 42.7, 3.5, 6, 35, NOW(), NOW()),
+  (NULL, 'potato', 'Potato (boiled)', '', 87, 1.9, 20.1, 0.1, 1.8, 200, NOW(), NOW()),
+  (NULL, 'banana', 'Banana', '', 89, 1.1, 22.8, 0.3, 2.6, 120, NOW(), NOW()),
+  (NULL, 'apple', 'Apple', '', 52, 0.3, 13.8, 0.2, 2.4, 180, NOW(), NOW()),
+  (NULL, 'broccoli', 'Broccoli', '', 34, 2.8, 6.6, 0.4, 2.6, 100, NOW(), NOW()),
+  (NULL, 'avocado', 'Avocado', '', 160, 2, 8.5, 14.7, 6.7, 100, NOW(), NOW()),
+  (NULL, 'almonds', 'Almonds', '', 579, 21.2, 21.6, 49.9, 12.5, 30, NOW(), NOW()),
+  (NULL, 'peanut-butter', 'Peanut Butter', '', 588, 25, 20, 50, 6, 32, NOW(), NOW()),
+  (NULL, 'olive-oil', 'Olive Oil', '', 884, 0, 0, 100, 0, 10, NOW(), NOW())
+ON CONFLICT (slug) WHERE owner_id IS NULL DO UPDATE
+SET name = EXCLUDED.name,
+    kcal = EXCLUDED.kcal,
+    protein = EXCLUDED.protein,
+    carbs = EXCLUDED.carbs,
+    fat = EXCLUDED.fat,
+    fiber = EXCLUDED.fiber,
+    serving_size = EXCLUDED.serving_size,
+    updated_at = NOW();
+
+COMMIT;
@@ -566,7 +566,30 @@ VALUES
 ('translation', 'en', 'settings.calculate_calories.hint','Allow the app to calculate estimated calories burned during workouts.', NOW(), NOW()),
 ('translation', 'en', 'copy_toast.structure_success','Structure copied to clipboard.', NOW(), NOW()),
 ('translation', 'en', 'copy_toast.structure_error','Could not copy. Please try again.', NOW(), NOW()),
-('translation', 'en', 'copy_toast.generic_error','Could not copy. Please try again.', NOW(), NOW())
+('translation', 'en', 'copy_toast.generic_error','Could not copy. Please try again.', NOW(), NOW()),
+('translation', 'en', 'food.chicken-breast','Chicken Breast', NOW(), NOW()),
+('translation', 'en', 'food.egg','Egg', NOW(), NOW()),
+('translation', 'en', 'food.egg-white','Egg White', NOW(), NOW()),
+('translation', 'en', 'food.salmon','Salmon', NOW(), NOW()),
+('translation', 'en', 'food.tuna-canned','Canned Tuna', NOW(), NOW()),
+('translation', 'en', 'food.beef-lean','Lean Beef', NOW(), NOW()),
+('translation', 'en', 'food.greek-yogurt','Greek Yogurt', NOW(), NOW()),
+('translation', 'en', 'food.cottage-cheese','Cottage Cheese', NOW(), NOW()),
+('translation', 'en', 'food.milk','Milk', NOW(), NOW()),
+('translation', 'en', 'food.whey-protein','Whey Protein', NOW(), NOW()),
+('translation', 'en', 'food.oatmeal','Oatmeal', NOW(), NOW()),
+('translation', 'en', 'food.white-rice','White Rice (cooked)', NOW(), NOW()),
+('translation', 'en', 'food.buckwheat','Buckwheat (cooked)', NOW(), NOW()),
+('translation', 'en', 'food.pasta','Pasta (cooked)', NOW(), NOW()),
+('translation', 'en', 'food.whole-wheat-bread','Whole Wheat Bread', NOW(), NOW()),
+('translation', 'en', 'food.potato','Potato (boiled)', NOW(), NOW()),
+('translation', 'en', 'food.banana','Banana', NOW(), NOW()),
+('translation', 'en', 'food.apple','Apple', NOW(), NOW()),
+('translation', 'en', 'food.broccoli','Broccoli', NOW(), NOW()),
+('translation', 'en', 'food.avocado','Avocado', NOW(), NOW()),
+('translation', 'en', 'food.almonds','Almonds', NOW(), NOW()),
+('translation', 'en', 'food.peanut-butter','Peanut Butter', NOW(), NOW()),
+('translation', 'en', 'food.olive-oil','Olive Oil', NOW(), NOW())
 ON CONFLICT (namespace, locale, key) DO UPDATE
 SET value = EXCLUDED.value,
     updated_at = NOW();
@@ -566,7 +566,30 @@ VALUES
 ('translation', 'ru', 'settings.calculate_calories.hint','Разрешить приложению рассчитывать примерное количество сожженных калорий во время тренировок.', NOW(), NOW()),
 ('translation', 'ru', 'copy_toast.structure_success','Структура скопирована в буфер обмена.', NOW(), NOW()),
 ('translation', 'ru', 'copy_toast.structure_error','Не удалось скопировать. Попробуйте еще раз.', NOW(), NOW()),
-('translation', 'ru', 'copy_toast.generic_error','Не удалось скопировать. Попробуйте еще раз.', NOW(), NOW())
+('translation', 'ru', 'copy_toast.generic_error','Не удалось скопировать. Попробуйте еще раз.', NOW(), NOW()),
+('translation', 'ru', 'food.chicken-breast','Куриная грудка', NOW(), NOW()),
+('translation', 'ru', 'food.egg','Яйцо', NOW(), NOW()),
+('translation', 'ru', 'food.egg-white','Яичный белок', NOW(), NOW()),
+('translation', 'ru', 'food.salmon','Лосось', NOW(), NOW()),
+('translation', 'ru', 'food.tuna-canned','Тунец консервированный', NOW(), NOW()),
+('translation', 'ru', 'food.beef-lean','Постная говядина', NOW(), NOW()),
+('translation', 'ru', 'food.greek-yogurt','Греческий йогурт', NOW(), NOW()),
+('translation', 'ru', 'food.cottage-cheese','Творог', NOW(), NOW()),
+('translation', 'ru', 'food.milk','Молоко', NOW(), NOW()),
+('translation', 'ru', 'food.whey-protein','Сывороточный протеин', NOW(), NOW()),
+('translation', 'ru', 'food.oatmeal','Овсянка', NOW(), NOW()),
+('translation', 'ru', 'food.white-rice','Белый рис (варёный)', NOW(), NOW()),
+('translation', 'ru', 'food.buckwheat','Гречка (варёная)', NOW(), NOW()),
+('translation', 'ru', 'food.pasta','Макароны (варёные)', NOW(), NOW()),
+('translation', 'ru', 'food.whole-wheat-bread','Цельнозерновой хлеб', NOW(), NOW()),
+('translation', 'ru', 'food.potato','Картофель (варёный)', NOW(), NOW()),
+('translation', 'ru', 'food.banana','Банан', NOW(), NOW()),
+('translation', 'ru', 'food.apple','Яблоко', NOW(), NOW()),
+('translation', 'ru', 'food.broccoli','Брокколи', NOW(), NOW()),
+('translation', 'ru', 'food.avocado','Авокадо', NOW(), NOW()),
+('translation', 'ru', 'food.almonds','Миндаль', NOW(), NOW()),
+('translation', 'ru', 'food.peanut-butter','Арахисовая паста', NOW(), NOW()),
+('translation', 'ru', 'food.olive-oil','Оливковое масло', NOW(), NOW())
 ON CONFLICT (namespace, locale, key) DO UPDATE
 SET value = EXCLUDED.value,
     updated_at = NOW();
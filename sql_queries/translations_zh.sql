@@ -566,7 +566,30 @@ VALUES
 ('translation', 'zh', 'settings.calculate_calories.hint','允许应用程序计算锻炼期间估计的卡路里消耗。', NOW(), NOW()),
 ('translation', 'zh', 'copy_toast.structure_success','结构已复制到剪贴板。', NOW(), NOW()),
 ('translation', 'zh', 'copy_toast.structure_error','无法复制。请重试。', NOW(), NOW()),
-('translation', 'zh', 'copy_toast.generic_error','无法复制。请重试。', NOW(), NOW())
+('translation', 'zh', 'copy_toast.generic_error','无法复制。请重试。', NOW(), NOW()),
+('translation', 'zh', 'food.chicken-breast','鸡胸肉', NOW(), NOW()),
+('translation', 'zh', 'food.egg','鸡蛋', NOW(), NOW()),
+('translation', 'zh', 'food.egg-white','蛋白', NOW(), NOW()),
+('translation', 'zh', 'food.salmon','三文鱼', NOW(), NOW()),
+('translation', 'zh', 'food.tuna-canned','金枪鱼罐头', NOW(), NOW()),
+('translation', 'zh', 'food.beef-lean','瘦牛肉', NOW(), NOW()),
+('translation', 'zh', 'food.greek-yogurt','希腊酸奶', NOW(), NOW()),
+('translation', 'zh', 'food.cottage-cheese','茅屋奶酪', NOW(), NOW()),
+('translation', 'zh', 'food.milk','牛奶', NOW(), NOW()),
+('translation', 'zh', 'food.whey-protein','乳清蛋白', NOW(), NOW()),
+('translation', 'zh', 'food.oatmeal','燕麦片', NOW(), NOW()),
+('translation', 'zh', 'food.white-rice','白米饭（熟）', NOW(), NOW()),
+('translation', 'zh', 'food.buckwheat','荞麦（熟）', NOW(), NOW()),
+('translation', 'zh', 'food.pasta','意大利面（熟）', NOW(), NOW()),
+('translation', 'zh', 'food.whole-wheat-bread','全麦面包', NOW(), NOW()),
+('translation', 'zh', 'food.potato','土豆（煮）', NOW(), NOW()),
+('translation', 'zh', 'food.banana','香蕉', NOW(), NOW()),
+('translation', 'zh', 'food.apple','苹果', NOW(), NOW()),
+('translation', 'zh', 'food.broccoli','西兰花', NOW(), NOW()),
+('translation', 'zh', 'food.avocado','牛油果', NOW(), NOW()),
+('translation', 'zh', 'food.almonds','杏仁', NOW(), NOW()),
+('translation', 'zh', 'food.peanut-butter','花生酱', NOW(), NOW()),
+('translation', 'zh', 'food.olive-oil','橄榄油', NOW(), NOW())
 ON CONFLICT (namespace, locale, key) DO UPDATE
 SET value = EXCLUDED.value,
     updated_at = NOW();